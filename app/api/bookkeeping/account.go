@@ -18,6 +18,36 @@ type jsonAccount struct {
 	Status    string `json:"status"`
 	Currency  string `json:"currency"`
 	Balance   string `json:"balance"`
+	Role      string `json:"role,omitempty"`
+
+	EditPolicy  jsonEditPolicy `json:"edit_policy"`
+	WorkspaceID *int32         `json:"workspace_id,omitempty"`
+}
+
+type jsonEditPolicy struct {
+	Mode          string `json:"mode"`
+	WindowMinutes int    `json:"window_minutes"`
+}
+
+func (p *jsonEditPolicy) fromDomain(policy domain.EditPolicy) {
+	p.Mode = policy.Mode.String()
+	p.WindowMinutes = int(policy.Window / time.Minute)
+}
+
+func (p *jsonEditPolicy) toDomain() (*domain.EditPolicy, error) {
+	mode, err := domain.ParseLedgerEditMode(p.Mode)
+	if err != nil {
+		return nil, app.ParamError(err)
+	}
+
+	if mode == domain.LedgerEditModeWindow && p.WindowMinutes <= 0 {
+		return nil, app.ParamError(errors.New("window_minutes must be positive"))
+	}
+
+	return &domain.EditPolicy{
+		Mode:   mode,
+		Window: time.Duration(p.WindowMinutes) * time.Minute,
+	}, nil
 }
 
 func (r *jsonAccount) fromDomain(account *domain.Account) {
@@ -28,6 +58,8 @@ func (r *jsonAccount) fromDomain(account *domain.Account) {
 	r.Status = account.Status.String()
 	r.Currency = account.Currency
 	r.Balance = account.Balance.String()
+	r.EditPolicy.fromDomain(account.EditPolicy)
+	r.WorkspaceID = account.WorkspaceID
 
 }
 
@@ -54,16 +86,28 @@ func (x *Controller) CreateAccount() func(w http.ResponseWriter, r *http.Request
 				return nil, app.ParamError(errors.New("currency is required"))
 			}
 
+			// Accounts created while a workspace is active belong to that workspace
+			var workspaceID *int32
+			if id := ctx.GetWorkspaceID(); id != 0 {
+				if !ctx.GetWorkspaceRole().Allows(domain.WorkspaceRoleEditor) {
+					return nil, app.Forbidden(errors.New("access denied: editor role required in workspace"))
+				}
+				workspaceID = &id
+			}
+
 			return nil, x.service.CreateAccount(domain.CreateAccountRequest{
-				UserID:   userID,
-				Name:     req.Name,
-				Currency: req.Currency,
+				UserID:      userID,
+				Name:        req.Name,
+				Currency:    req.Currency,
+				WorkspaceID: workspaceID,
+				Actor:       auditActor(ctx),
 			})
 		}).BindJSON(&req).Call(req).ResponseJSON()
 	}
 }
 
-// GetAllAccounts handles the retrieval of all accounts for the current authenticated user
+// GetAllAccounts handles the retrieval of all accounts owned by or shared with the current authenticated user.
+// When a workspace is active, the accounts owned by the workspace are returned instead.
 func (x *Controller) GetAllAccounts() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonAccount, error) {
@@ -72,14 +116,43 @@ func (x *Controller) GetAllAccounts() func(w http.ResponseWriter, r *http.Reques
 				return nil, app.Unauthorized(errors.New("user not authenticated"))
 			}
 
+			if workspaceID := ctx.GetWorkspaceID(); workspaceID != 0 {
+				accounts, err := x.service.GetAccountsByWorkspaceID(workspaceID)
+				if err != nil {
+					return nil, err
+				}
+
+				jsonAccounts := make([]jsonAccount, len(accounts))
+				for index, account := range accounts {
+					jsonAccounts[index].fromDomain(account)
+					jsonAccounts[index].Role = ctx.GetWorkspaceRole().AccountRole().String()
+				}
+
+				return jsonAccounts, nil
+			}
+
 			accounts, err := x.service.GetAccountsByUserID(userID)
 			if err != nil {
 				return nil, err
 			}
 
-			jsonAccounts := make([]jsonAccount, len(accounts))
-			for index, account := range accounts {
-				jsonAccounts[index].fromDomain(account)
+			sharedAccounts, err := x.service.GetSharedAccountsByUserID(userID)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonAccounts := make([]jsonAccount, 0, len(accounts)+len(sharedAccounts))
+			for _, account := range accounts {
+				var jsonAccount jsonAccount
+				jsonAccount.fromDomain(account)
+				jsonAccount.Role = domain.AccountMemberRoleOwner.String()
+				jsonAccounts = append(jsonAccounts, jsonAccount)
+			}
+			for _, sharedAccount := range sharedAccounts {
+				var jsonAccount jsonAccount
+				jsonAccount.fromDomain(sharedAccount.Account)
+				jsonAccount.Role = sharedAccount.Role.String()
+				jsonAccounts = append(jsonAccounts, jsonAccount)
 			}
 
 			return jsonAccounts, nil
@@ -92,23 +165,14 @@ func (x *Controller) GetAccountByID() func(w http.ResponseWriter, r *http.Reques
 	return func(w http.ResponseWriter, r *http.Request) {
 		var id int32
 		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*jsonAccount, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
-
-			account, err := x.service.GetAccountByID(id)
+			account, role, err := x.authorizeAccount(ctx, id, domain.AccountMemberRoleViewer)
 			if err != nil {
 				return nil, err
 			}
 
-			// Verify account ownership
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: account does not belong to user"))
-			}
-
 			var jsonAccount jsonAccount
 			jsonAccount.fromDomain(account)
+			jsonAccount.Role = role.String()
 
 			return &jsonAccount, nil
 		}).Param("id", &id).Call(nil).ResponseJSON()
@@ -119,26 +183,17 @@ func (x *Controller) GetAccountByID() func(w http.ResponseWriter, r *http.Reques
 func (x *Controller) UpdateAccount() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		type request struct {
-			id     int32
-			Name   *string `json:"name"`
-			Status *string `json:"status"`
+			id         int32
+			Name       *string         `json:"name"`
+			Status     *string         `json:"status"`
+			EditPolicy *jsonEditPolicy `json:"edit_policy"`
 		}
 
 		var req request
 		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
-
-			// Verify account ownership
-			account, err := x.service.GetAccountByID(req.id)
-			if err != nil {
+			if _, _, err := x.authorizeAccount(ctx, req.id, domain.AccountMemberRoleOwner); err != nil {
 				return nil, err
 			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: account does not belong to user"))
-			}
 
 			var accountStatus *domain.AccountStatus
 			if req.Status != nil {
@@ -149,10 +204,21 @@ func (x *Controller) UpdateAccount() func(w http.ResponseWriter, r *http.Request
 				accountStatus = &status
 			}
 
+			var editPolicy *domain.EditPolicy
+			if req.EditPolicy != nil {
+				policy, err := req.EditPolicy.toDomain()
+				if err != nil {
+					return nil, err
+				}
+				editPolicy = policy
+			}
+
 			return nil, x.service.UpdateAccount(domain.UpdateAccountRequest{
-				ID:     req.id,
-				Name:   req.Name,
-				Status: accountStatus,
+				ID:         req.id,
+				Name:       req.Name,
+				Status:     accountStatus,
+				EditPolicy: editPolicy,
+				Actor:      auditActor(ctx),
 			})
 		}).Param("id", &req.id).BindJSON(&req).Call(req).ResponseJSON()
 	}
@@ -163,21 +229,11 @@ func (x *Controller) DeactivateAccountByID() func(w http.ResponseWriter, r *http
 	return func(w http.ResponseWriter, r *http.Request) {
 		var id int32
 		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
-
-			// Verify account ownership
-			account, err := x.service.GetAccountByID(id)
-			if err != nil {
+			if _, _, err := x.authorizeAccount(ctx, id, domain.AccountMemberRoleOwner); err != nil {
 				return nil, err
 			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: account does not belong to user"))
-			}
 
-			return nil, x.service.DeactivateAccountByID(id)
+			return nil, x.service.DeactivateAccountByID(id, auditActor(ctx))
 		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
 	}
 }
@@ -186,7 +242,17 @@ func (x *Controller) DeactivateAccountByID() func(w http.ResponseWriter, r *http
 func (x *Controller) GetUserAccounts() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var userID int32
-		engine.Chain(r, w, func(_ *engine.Context, _ *engine.Empty) ([]jsonAccount, error) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonAccount, error) {
+			authenticatedUserID := ctx.GetUserID()
+			if authenticatedUserID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			// Users can only list their own accounts unless they're an admin
+			if userID != authenticatedUserID && !ctx.IsAdmin() {
+				return nil, app.Forbidden(errors.New("access denied: cannot view other user's accounts"))
+			}
+
 			accounts, err := x.service.GetAccountsByUserID(userID)
 			if err != nil {
 				return nil, err
@@ -213,8 +279,6 @@ func (x *Controller) CreateUserAccount() func(w http.ResponseWriter, r *http.Req
 
 		var req request
 		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
-			// Admin validation would go here
-			// For now we'll just use the authenticated user's ID
 			authenticatedUserID := ctx.GetUserID()
 			if authenticatedUserID == 0 {
 				return nil, app.Unauthorized(errors.New("user not authenticated"))
@@ -228,8 +292,8 @@ func (x *Controller) CreateUserAccount() func(w http.ResponseWriter, r *http.Req
 				return nil, app.ParamError(errors.New("currency is required"))
 			}
 
-			// In a real admin scenario, we'd use req.UserID, but for now validate that it matches authenticated user
-			if req.userID != authenticatedUserID {
+			// Only admins may create accounts for other users
+			if req.userID != authenticatedUserID && !ctx.IsAdmin() {
 				return nil, app.Forbidden(errors.New("can only create accounts for yourself"))
 			}
 
@@ -237,6 +301,7 @@ func (x *Controller) CreateUserAccount() func(w http.ResponseWriter, r *http.Req
 				UserID:   req.userID,
 				Name:     req.Name,
 				Currency: req.Currency,
+				Actor:    auditActor(ctx),
 			})
 		}).Param("user_id", &req.userID).BindJSON(&req).Call(req).ResponseJSON()
 	}
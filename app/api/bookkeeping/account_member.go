@@ -0,0 +1,279 @@
+package bookkeeping
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/bookkeeping"
+)
+
+type jsonAccountMember struct {
+	AccountID int32  `json:"account_id"`
+	UserID    int32  `json:"user_id"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at"`
+}
+
+func (m *jsonAccountMember) fromDomain(member *domain.AccountMember) {
+	m.AccountID = member.AccountID
+	m.UserID = member.UserID
+	m.Role = member.Role.String()
+	m.CreatedAt = member.CreatedAt.Format(time.RFC3339)
+}
+
+type jsonAccountInvitation struct {
+	ID          int32      `json:"id"`
+	CreatedAt   string     `json:"created_at"`
+	AccountID   int32      `json:"account_id"`
+	InviterID   int32      `json:"inviter_id"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	Status      string     `json:"status"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+}
+
+func (i *jsonAccountInvitation) fromDomain(invitation *domain.AccountInvitation) {
+	i.ID = invitation.ID
+	i.CreatedAt = invitation.CreatedAt.Format(time.RFC3339)
+	i.AccountID = invitation.AccountID
+	i.InviterID = invitation.InviterID
+	i.Email = invitation.Email
+	i.Role = invitation.Role.String()
+	i.Status = invitation.Status.String()
+	i.ExpiresAt = invitation.ExpiresAt
+	i.RespondedAt = invitation.RespondedAt
+}
+
+func toAccountMemberParamError(err error) error {
+	if errors.Is(err, bookkeeping.ErrInvalidAccountMember) {
+		return app.ParamError(err)
+	}
+
+	return err
+}
+
+// GetAccountMembers handles the retrieval of the owner and members of an account
+func (x *Controller) GetAccountMembers() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var accountID int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonAccountMember, error) {
+			account, _, err := x.authorizeAccount(ctx, accountID, domain.AccountMemberRoleViewer)
+			if err != nil {
+				return nil, err
+			}
+
+			members, err := x.service.GetAccountMembers(accountID)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonMembers := make([]jsonAccountMember, 0, len(members)+1)
+			jsonMembers = append(jsonMembers, jsonAccountMember{
+				AccountID: account.ID,
+				UserID:    account.UserID,
+				Role:      domain.AccountMemberRoleOwner.String(),
+				CreatedAt: account.CreatedAt.Format(time.RFC3339),
+			})
+			for _, member := range members {
+				var jsonMember jsonAccountMember
+				jsonMember.fromDomain(member)
+				jsonMembers = append(jsonMembers, jsonMember)
+			}
+
+			return jsonMembers, nil
+		}).Param("id", &accountID).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// UpdateAccountMember handles changing the role of an account member
+func (x *Controller) UpdateAccountMember() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			accountID int32
+			userID    int32
+			Role      string `json:"role"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
+			if _, _, err := x.authorizeAccount(ctx, req.accountID, domain.AccountMemberRoleOwner); err != nil {
+				return nil, err
+			}
+
+			role, err := domain.ParseAccountMemberRole(req.Role)
+			if err != nil {
+				return nil, app.ParamError(err)
+			}
+
+			return nil, toAccountMemberParamError(x.service.UpdateAccountMemberRole(req.accountID, req.userID, role))
+		}).Param("id", &req.accountID).Param("user_id", &req.userID).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// RemoveAccountMember handles revoking a member's access to an account.
+// Members may also remove themselves to leave a shared account.
+func (x *Controller) RemoveAccountMember() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var accountID, userID int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			required := domain.AccountMemberRoleOwner
+			if userID == ctx.GetUserID() {
+				required = domain.AccountMemberRoleViewer
+			}
+
+			if _, _, err := x.authorizeAccount(ctx, accountID, required); err != nil {
+				return nil, err
+			}
+
+			return nil, x.service.RemoveAccountMember(accountID, userID)
+		}).Param("id", &accountID).Param("user_id", &userID).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// CreateAccountInvitation handles inviting a user by email to an account
+func (x *Controller) CreateAccountInvitation() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			accountID int32
+			Email     string `json:"email"`
+			Role      string `json:"role"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*jsonAccountInvitation, error) {
+			if _, _, err := x.authorizeAccount(ctx, req.accountID, domain.AccountMemberRoleOwner); err != nil {
+				return nil, err
+			}
+
+			if req.Email == "" {
+				return nil, app.ParamError(errors.New("email is required"))
+			}
+
+			role, err := domain.ParseAccountMemberRole(req.Role)
+			if err != nil {
+				return nil, app.ParamError(err)
+			}
+
+			invitation, err := x.service.InviteAccountMember(domain.CreateAccountInvitationRequest{
+				AccountID: req.accountID,
+				InviterID: ctx.GetUserID(),
+				Email:     req.Email,
+				Role:      role,
+			})
+			if err != nil {
+				return nil, toAccountMemberParamError(err)
+			}
+
+			var jsonInvitation jsonAccountInvitation
+			jsonInvitation.fromDomain(invitation)
+
+			return &jsonInvitation, nil
+		}).Param("id", &req.accountID).BindJSON(&req).Call(req).ResponseCreated()
+	}
+}
+
+// GetAccountInvitations handles the retrieval of all invitations of an account
+func (x *Controller) GetAccountInvitations() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var accountID int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonAccountInvitation, error) {
+			if _, _, err := x.authorizeAccount(ctx, accountID, domain.AccountMemberRoleOwner); err != nil {
+				return nil, err
+			}
+
+			invitations, err := x.service.GetAccountInvitations(accountID)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonInvitations := make([]jsonAccountInvitation, len(invitations))
+			for index, invitation := range invitations {
+				jsonInvitations[index].fromDomain(invitation)
+			}
+
+			return jsonInvitations, nil
+		}).Param("id", &accountID).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// RevokeAccountInvitation handles revoking a pending invitation of an account
+func (x *Controller) RevokeAccountInvitation() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			if ctx.GetUserID() == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			invitation, err := x.service.GetAccountInvitationByID(id)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, _, err := x.authorizeAccount(ctx, invitation.AccountID, domain.AccountMemberRoleOwner); err != nil {
+				return nil, err
+			}
+
+			return nil, toAccountMemberParamError(x.service.RevokeAccountInvitation(id))
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// GetPendingAccountInvitations handles the retrieval of pending invitations addressed to the current user
+func (x *Controller) GetPendingAccountInvitations() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonAccountInvitation, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			invitations, err := x.service.GetPendingAccountInvitations(userID)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonInvitations := make([]jsonAccountInvitation, len(invitations))
+			for index, invitation := range invitations {
+				jsonInvitations[index].fromDomain(invitation)
+			}
+
+			return jsonInvitations, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// AcceptAccountInvitation handles accepting an invitation addressed to the current user
+func (x *Controller) AcceptAccountInvitation() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			return nil, toAccountMemberParamError(x.service.AcceptAccountInvitation(id, userID))
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// DeclineAccountInvitation handles declining an invitation addressed to the current user
+func (x *Controller) DeclineAccountInvitation() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			return nil, x.service.DeclineAccountInvitation(id, userID)
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
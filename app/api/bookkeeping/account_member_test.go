@@ -0,0 +1,261 @@
+package bookkeeping_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/omegaatt36/bookly/app/api/bookkeeping"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/database"
+	"github.com/omegaatt36/bookly/persistence/repository"
+	"github.com/omegaatt36/bookly/persistence/sqlc"
+	"github.com/omegaatt36/bookly/service/notify"
+)
+
+const inviteeEmail = "invitee@example.com"
+
+type testAccountMemberSuite struct {
+	suite.Suite
+
+	router *http.ServeMux
+
+	repo      *repository.SQLCRepository
+	finalize  func()
+	mailPath  string
+	userID    int32
+	ownerID   int32
+	inviteeID int32
+	accountID int32
+}
+
+func (s *testAccountMemberSuite) SetupTest() {
+	s.finalize = database.TestingInitialize(database.PostgresOpt)
+	db := database.GetDB()
+	s.repo = repository.NewSQLCRepository(db)
+	s.router = http.NewServeMux()
+	s.mailPath = filepath.Join(s.T().TempDir(), "invitations.log")
+	controller := bookkeeping.NewController(bookkeeping.NewControllerRequest{
+		AccountRepository:       s.repo,
+		LedgerRepository:        s.repo,
+		AccountMemberRepository: s.repo,
+		UserRepository:          s.repo,
+		Notifier:                notify.NewFileNotifier(s.mailPath),
+		WebURL:                  "https://bookly.example.com",
+	})
+
+	authMiddleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := engine.WithUserID(r.Context(), s.userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	registerWithAuth := func(pattern string, handler http.Handler) {
+		s.router.Handle(pattern, authMiddleware(handler))
+	}
+
+	registerWithAuth("GET /accounts", http.HandlerFunc(controller.GetAllAccounts()))
+	registerWithAuth("GET /accounts/{id}", http.HandlerFunc(controller.GetAccountByID()))
+	registerWithAuth("POST /accounts/{account_id}/ledgers", http.HandlerFunc(controller.CreateLedger()))
+	registerWithAuth("GET /accounts/{account_id}/ledgers", http.HandlerFunc(controller.GetLedgersByAccount()))
+	registerWithAuth("GET /accounts/{id}/members", http.HandlerFunc(controller.GetAccountMembers()))
+	registerWithAuth("PATCH /accounts/{id}/members/{user_id}", http.HandlerFunc(controller.UpdateAccountMember()))
+	registerWithAuth("POST /accounts/{id}/invitations", http.HandlerFunc(controller.CreateAccountInvitation()))
+	registerWithAuth("GET /account-invitations", http.HandlerFunc(controller.GetPendingAccountInvitations()))
+	registerWithAuth("POST /account-invitations/{id}/accept", http.HandlerFunc(controller.AcceptAccountInvitation()))
+
+	s.NoError(sqlc.MigrateForTest(context.Background(), db))
+
+	ownerID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: "Owner"})
+	s.NoError(err)
+	s.ownerID = ownerID
+
+	inviteeID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: "Invitee"})
+	s.NoError(err)
+	s.inviteeID = inviteeID
+	verifiedAt := time.Now()
+	s.NoError(s.repo.AddIdentity(inviteeID, domain.Identity{
+		Provider:   domain.IdentityProviderPassword,
+		Identifier: inviteeEmail,
+		Credential: "hash",
+		VerifiedAt: &verifiedAt,
+	}))
+
+	s.NoError(s.repo.CreateAccount(domain.CreateAccountRequest{
+		UserID:   ownerID,
+		Name:     seedAccount.Name,
+		Currency: seedAccount.Currency,
+	}))
+	accounts, err := s.repo.GetAccountsByUserID(ownerID)
+	s.NoError(err)
+	s.accountID = accounts[0].ID
+}
+
+func (s *testAccountMemberSuite) TearDownTest() {
+	s.finalize()
+	s.router = nil
+	s.repo = nil
+}
+
+func TestAccountMemberSuite(t *testing.T) {
+	suite.Run(t, new(testAccountMemberSuite))
+}
+
+func (s *testAccountMemberSuite) invite(role string) {
+	s.userID = s.ownerID
+	reqBody := fmt.Sprintf(`{"email": %q, "role": %q}`, inviteeEmail, role)
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%d/invitations", s.accountID), bytes.NewBufferString(reqBody))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusCreated, w.Code)
+
+	s.userID = s.inviteeID
+	invitations, err := s.repo.GetPendingAccountInvitationsByUserID(s.inviteeID)
+	s.NoError(err)
+	s.Len(invitations, 1)
+
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/account-invitations/%d/accept", invitations[0].ID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *testAccountMemberSuite) TestInvitationIsEmailed() {
+	s.invite("viewer")
+
+	content, err := os.ReadFile(s.mailPath)
+	s.Require().NoError(err)
+	s.Contains(string(content), "To: "+inviteeEmail)
+	s.Contains(string(content), fmt.Sprintf("Owner invited you to join the account %q on Bookly as viewer", seedAccount.Name))
+	s.Contains(string(content), "https://bookly.example.com")
+}
+
+func (s *testAccountMemberSuite) TestInvitationNeedsVerifiedEmail() {
+	// Someone signed up with the invited address without verifying it
+	squatterID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: "Squatter"})
+	s.NoError(err)
+	s.NoError(s.repo.AddIdentity(squatterID, domain.Identity{
+		Provider:   domain.IdentityProviderPassword,
+		Identifier: "unverified@example.com",
+		Credential: "hash",
+	}))
+
+	s.userID = s.ownerID
+	reqBody := `{"email": "unverified@example.com", "role": "editor"}`
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%d/invitations", s.accountID), bytes.NewBufferString(reqBody))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusCreated, w.Code)
+
+	var created struct {
+		Data struct {
+			ID int32 `json:"id"`
+		} `json:"data"`
+	}
+	s.NoError(json.NewDecoder(w.Body).Decode(&created))
+
+	invitations, err := s.repo.GetPendingAccountInvitationsByUserID(squatterID)
+	s.NoError(err)
+	s.Empty(invitations)
+
+	s.userID = squatterID
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/account-invitations/%d/accept", created.Data.ID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusNotFound, w.Code)
+
+	_, err = s.repo.GetAccountMember(s.accountID, squatterID)
+	s.ErrorIs(err, domain.ErrNotFound)
+}
+
+func (s *testAccountMemberSuite) TestNonMemberIsForbidden() {
+	s.userID = s.inviteeID
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/accounts/%d", s.accountID), nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
+func (s *testAccountMemberSuite) TestInviteOwnerRoleIsRejected() {
+	s.userID = s.ownerID
+
+	reqBody := fmt.Sprintf(`{"email": %q, "role": "owner"}`, inviteeEmail)
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%d/invitations", s.accountID), bytes.NewBufferString(reqBody))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *testAccountMemberSuite) TestSharedAccountAppearsInAccounts() {
+	s.invite("viewer")
+
+	type getAccountsResponse struct {
+		Code int `json:"code"`
+		Data []struct {
+			ID   int32  `json:"id"`
+			Role string `json:"role"`
+		} `json:"data"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp getAccountsResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Len(resp.Data, 1)
+	s.Equal(s.accountID, resp.Data[0].ID)
+	s.Equal("viewer", resp.Data[0].Role)
+}
+
+func (s *testAccountMemberSuite) TestViewerCannotCreateLedger() {
+	s.invite("viewer")
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/accounts/%d/ledgers", s.accountID), nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	reqBody := `{"type": "expense", "amount": "-100", "note": "lunch"}`
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%d/ledgers", s.accountID), bytes.NewBufferString(reqBody))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
+func (s *testAccountMemberSuite) TestEditorCanCreateLedger() {
+	s.invite("viewer")
+
+	// Promote the invitee to editor
+	s.userID = s.ownerID
+	req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/accounts/%d/members/%d", s.accountID, s.inviteeID), bytes.NewBufferString(`{"role": "editor"}`))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	s.userID = s.inviteeID
+	reqBody := `{"type": "expense", "amount": "-100", "note": "lunch"}`
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%d/ledgers", s.accountID), bytes.NewBufferString(reqBody))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	ledgers, err := s.repo.GetLedgersByAccountID(s.accountID)
+	s.NoError(err)
+	s.Len(ledgers, 1)
+}
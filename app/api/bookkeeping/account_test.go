@@ -44,7 +44,8 @@ func (s *testAccountSuite) SetupTest() {
 	s.repo = repository.NewSQLCRepository(db)
 	s.router = http.NewServeMux()
 	controller := bookkeeping.NewController(bookkeeping.NewControllerRequest{
-		s.repo, s.repo, nil, nil, nil,
+		AccountRepository: s.repo,
+		LedgerRepository:  s.repo,
 	})
 
 	authMiddleware := func(next http.Handler) http.Handler {
@@ -0,0 +1,121 @@
+package bookkeeping
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+)
+
+type jsonAuditLog struct {
+	ID                int32           `json:"id"`
+	CreatedAt         time.Time       `json:"created_at"`
+	ActorUserID       *int32          `json:"actor_user_id"`
+	Action            string          `json:"action"`
+	EntityType        string          `json:"entity_type"`
+	EntityID          int32           `json:"entity_id"`
+	Before            json.RawMessage `json:"before"`
+	After             json.RawMessage `json:"after"`
+	IPAddress         string          `json:"ip_address"`
+	BooksLockOverride bool            `json:"books_lock_override"`
+}
+
+func (l *jsonAuditLog) fromDomain(log *domain.AuditLog) {
+	l.ID = log.ID
+	l.CreatedAt = log.CreatedAt
+	l.ActorUserID = log.ActorUserID
+	l.Action = log.Action.String()
+	l.EntityType = log.EntityType.String()
+	l.EntityID = log.EntityID
+	l.Before = log.Before
+	l.After = log.After
+	l.IPAddress = log.IPAddress
+	l.BooksLockOverride = log.BooksLockOverride
+}
+
+// auditActor describes the authenticated user and the address the request comes from.
+func auditActor(ctx *engine.Context) domain.AuditActor {
+	ip, _, err := net.SplitHostPort(ctx.Request.RemoteAddr)
+	if err != nil {
+		ip = ctx.Request.RemoteAddr
+	}
+
+	return domain.AuditActor{
+		UserID:    ctx.GetUserID(),
+		IPAddress: ip,
+	}
+}
+
+// GetAuditLogs retrieves the audit history of a ledger, account, recurring transaction or books
+// lock the authenticated user can view. The IP address of an entry is only shown to its actor
+// and to admins.
+func (x *Controller) GetAuditLogs() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			entity string
+			id     int32
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) ([]jsonAuditLog, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			entityType, err := domain.ParseAuditEntityType(req.entity)
+			if err != nil {
+				return nil, app.ParamError(err)
+			}
+
+			if req.id == 0 {
+				return nil, app.ParamError(errors.New("id is required"))
+			}
+
+			switch entityType {
+			case domain.AuditEntityTypeAccount:
+				_, _, err = x.authorizeAccount(ctx, req.id, domain.AccountMemberRoleViewer)
+			case domain.AuditEntityTypeLedger:
+				_, err = x.authorizeLedger(ctx, req.id, domain.AccountMemberRoleViewer)
+			case domain.AuditEntityTypeRecurringTransaction:
+				// Deleted transactions keep their audit history
+				var transaction *domain.RecurringTransaction
+				transaction, err = x.service.GetRecurringTransactionIncludingDeleted(r.Context(), req.id)
+				if err == nil {
+					err = x.authorizeRecurringTransactionAccess(ctx, transaction, domain.WorkspaceRoleViewer)
+				}
+			case domain.AuditEntityTypeUserBooksLock:
+				if req.id != userID && !ctx.IsAdmin() {
+					err = app.NotFoundError()
+				}
+			case domain.AuditEntityTypeAccountBooksLock:
+				_, _, err = x.authorizeAccount(ctx, req.id, domain.AccountMemberRoleViewer)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			logs, err := x.service.GetAuditLogs(entityType, req.id)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonLogs := make([]jsonAuditLog, len(logs))
+			for i, log := range logs {
+				jsonLogs[i].fromDomain(log)
+
+				// Other members only see what was done, not where it was done from
+				if !ctx.IsAdmin() && (log.ActorUserID == nil || *log.ActorUserID != userID) {
+					jsonLogs[i].IPAddress = ""
+				}
+			}
+
+			return jsonLogs, nil
+		}).Query("entity", &req.entity).Query("id", &req.id).Call(req).ResponseJSON()
+	}
+}
@@ -0,0 +1,279 @@
+package bookkeeping_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/omegaatt36/bookly/app/api/bookkeeping"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/database"
+	"github.com/omegaatt36/bookly/persistence/repository"
+	"github.com/omegaatt36/bookly/persistence/sqlc"
+)
+
+type testAuditLogSuite struct {
+	suite.Suite
+
+	router *http.ServeMux
+
+	repo      *repository.SQLCRepository
+	finalize  func()
+	userID    int32
+	userRole  domain.UserRole
+	ownerID   int32
+	accountID int32
+}
+
+type auditLogsResponse struct {
+	Code int `json:"code"`
+	Data []struct {
+		ActorUserID *int32          `json:"actor_user_id"`
+		Action      string          `json:"action"`
+		EntityType  string          `json:"entity_type"`
+		EntityID    int32           `json:"entity_id"`
+		Before      json.RawMessage `json:"before"`
+		After       json.RawMessage `json:"after"`
+		IPAddress   string          `json:"ip_address"`
+	} `json:"data"`
+}
+
+func (s *testAuditLogSuite) SetupTest() {
+	s.finalize = database.TestingInitialize(database.PostgresOpt)
+	db := database.GetDB()
+	s.repo = repository.NewSQLCRepository(db)
+	s.router = http.NewServeMux()
+	controller := bookkeeping.NewController(bookkeeping.NewControllerRequest{
+		AccountRepository:              s.repo,
+		LedgerRepository:               s.repo,
+		RecurringTransactionRepository: s.repo,
+		AuditLogRepository:             s.repo,
+	})
+	authMiddleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := engine.WithUserID(r.Context(), s.userID)
+			ctx = engine.WithUserRole(ctx, s.userRole)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	registerWithAuth := func(pattern string, handler http.Handler) {
+		s.router.Handle(pattern, authMiddleware(handler))
+	}
+
+	registerWithAuth("PATCH /accounts/{id}", http.HandlerFunc(controller.UpdateAccount()))
+	registerWithAuth("POST /accounts/{account_id}/ledgers", http.HandlerFunc(controller.CreateLedger()))
+	registerWithAuth("PATCH /ledgers/{id}", http.HandlerFunc(controller.UpdateLedger()))
+	registerWithAuth("DELETE /ledgers/{id}", http.HandlerFunc(controller.VoidLedger()))
+	registerWithAuth("POST /ledgers/{id}/adjust", http.HandlerFunc(controller.AdjustLedger()))
+	registerWithAuth("GET /audit", http.HandlerFunc(controller.GetAuditLogs()))
+
+	s.NoError(sqlc.MigrateForTest(context.Background(), db))
+
+	ownerID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: seedUser.Name})
+	s.NoError(err)
+	s.ownerID = ownerID
+	s.userID = ownerID
+	s.userRole = domain.UserRoleUser
+
+	s.NoError(s.repo.CreateAccount(domain.CreateAccountRequest{
+		UserID:   ownerID,
+		Name:     seedAccount.Name,
+		Currency: seedAccount.Currency,
+		Actor:    domain.AuditActor{UserID: ownerID},
+	}))
+	accounts, err := s.repo.GetAccountsByUserID(ownerID)
+	s.NoError(err)
+	s.accountID = accounts[0].ID
+}
+
+func (s *testAuditLogSuite) TearDownTest() {
+	s.finalize()
+	s.router = nil
+	s.repo = nil
+}
+
+func TestAuditLogSuite(t *testing.T) {
+	suite.Run(t, new(testAuditLogSuite))
+}
+
+func (s *testAuditLogSuite) do(method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *testAuditLogSuite) createLedger() int32 {
+	w := s.do(http.MethodPost, fmt.Sprintf("/accounts/%d/ledgers", s.accountID),
+		`{"date": "2023-05-01T00:00:00Z", "type": "expense", "amount": "-100", "note": "lunch"}`)
+	s.Equal(http.StatusOK, w.Code)
+
+	ledgers, err := s.repo.GetLedgersByAccountID(s.accountID)
+	s.NoError(err)
+	s.Len(ledgers, 1)
+
+	return ledgers[0].ID
+}
+
+func (s *testAuditLogSuite) getAuditLogs(entity string, id int32) auditLogsResponse {
+	w := s.do(http.MethodGet, fmt.Sprintf("/audit?entity=%s&id=%d", entity, id), "")
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp auditLogsResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	return resp
+}
+
+func (s *testAuditLogSuite) TestLedgerHistory() {
+	ledgerID := s.createLedger()
+
+	w := s.do(http.MethodPatch, fmt.Sprintf("/ledgers/%d", ledgerID), `{"note": "dinner"}`)
+	s.Equal(http.StatusOK, w.Code)
+
+	w = s.do(http.MethodPost, fmt.Sprintf("/ledgers/%d/adjust", ledgerID),
+		fmt.Sprintf(`{"account_id": %d, "date": "2023-05-02T00:00:00Z", "type": "expense", "amount": "-20", "note": "tip"}`, s.accountID))
+	s.Equal(http.StatusOK, w.Code)
+
+	resp := s.getAuditLogs("ledger", ledgerID)
+	s.Require().Len(resp.Data, 3)
+
+	s.Equal("adjust", resp.Data[0].Action)
+	s.Equal("update", resp.Data[1].Action)
+	s.Equal("create", resp.Data[2].Action)
+
+	for _, log := range resp.Data {
+		s.Equal("ledger", log.EntityType)
+		s.Equal(ledgerID, log.EntityID)
+		s.Require().NotNil(log.ActorUserID)
+		s.Equal(s.ownerID, *log.ActorUserID)
+		s.NotEmpty(log.IPAddress)
+	}
+
+	var before, after struct {
+		Note         string `json:"note"`
+		AdjustedFrom *int32 `json:"adjusted_from"`
+	}
+	s.NoError(json.Unmarshal(resp.Data[1].Before, &before))
+	s.NoError(json.Unmarshal(resp.Data[1].After, &after))
+	s.Equal("lunch", before.Note)
+	s.Equal("dinner", after.Note)
+
+	s.Equal("null", string(resp.Data[2].Before))
+
+	s.NoError(json.Unmarshal(resp.Data[0].After, &after))
+	s.Equal("tip", after.Note)
+	s.Require().NotNil(after.AdjustedFrom)
+	s.Equal(ledgerID, *after.AdjustedFrom)
+}
+
+func (s *testAuditLogSuite) TestVoidLedgerIsAudited() {
+	ledgerID := s.createLedger()
+
+	w := s.do(http.MethodDelete, fmt.Sprintf("/ledgers/%d", ledgerID), "")
+	s.Equal(http.StatusOK, w.Code)
+
+	logs, err := s.repo.GetAuditLogs(domain.AuditEntityTypeLedger, ledgerID)
+	s.NoError(err)
+	s.Require().Len(logs, 2)
+	s.Equal(domain.AuditActionVoid, logs[0].Action)
+
+	var after struct {
+		IsVoided bool `json:"is_voided"`
+	}
+	s.NoError(json.Unmarshal(logs[0].After, &after))
+	s.True(after.IsVoided)
+}
+
+func (s *testAuditLogSuite) TestAccountHistory() {
+	w := s.do(http.MethodPatch, fmt.Sprintf("/accounts/%d", s.accountID), `{"name": "Renamed"}`)
+	s.Equal(http.StatusOK, w.Code)
+
+	resp := s.getAuditLogs("account", s.accountID)
+	s.Require().Len(resp.Data, 2)
+	s.Equal("update", resp.Data[0].Action)
+	s.Equal("create", resp.Data[1].Action)
+}
+
+func (s *testAuditLogSuite) TestIPAddressIsShownToItsActorAndAdmins() {
+	ledgerID := s.createLedger()
+
+	otherID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: "Other"})
+	s.NoError(err)
+	note := "dinner"
+	s.NoError(s.repo.UpdateLedger(domain.UpdateLedgerRequest{
+		ID:    ledgerID,
+		Note:  &note,
+		Actor: domain.AuditActor{UserID: otherID, IPAddress: "203.0.113.7"},
+	}))
+
+	resp := s.getAuditLogs("ledger", ledgerID)
+	s.Require().Len(resp.Data, 2)
+	s.Empty(resp.Data[0].IPAddress)
+	s.NotEmpty(resp.Data[1].IPAddress)
+
+	s.userRole = domain.UserRoleAdmin
+	resp = s.getAuditLogs("ledger", ledgerID)
+	s.Require().Len(resp.Data, 2)
+	s.Equal("203.0.113.7", resp.Data[0].IPAddress)
+	s.NotEmpty(resp.Data[1].IPAddress)
+}
+
+func (s *testAuditLogSuite) TestDeletedRecurringTransactionHistory() {
+	actor := domain.AuditActor{UserID: s.ownerID, IPAddress: "192.0.2.1"}
+	transaction, err := s.repo.CreateRecurringTransaction(s.T().Context(), domain.CreateRecurringTransactionRequest{
+		UserID:        s.ownerID,
+		AccountID:     s.accountID,
+		Name:          "Rent",
+		Type:          domain.LedgerTypeExpense,
+		Amount:        decimal.NewFromInt(-800),
+		StartDate:     time.Now(),
+		RecurType:     domain.RecurrenceTypeMonthly,
+		Frequency:     1,
+		CatchUpPolicy: domain.CatchUpPolicyBookAll,
+		PostMode:      domain.PostModeAuto,
+		NextDue:       time.Now().AddDate(0, 1, 0),
+		Actor:         actor,
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.DeleteRecurringTransaction(s.T().Context(), transaction.ID, actor))
+
+	resp := s.getAuditLogs("recurring_transaction", transaction.ID)
+	s.Require().NotEmpty(resp.Data)
+	s.Equal("delete", resp.Data[0].Action)
+
+	otherID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: "Other"})
+	s.NoError(err)
+	s.userID = otherID
+
+	w := s.do(http.MethodGet, fmt.Sprintf("/audit?entity=recurring_transaction&id=%d", transaction.ID), "")
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *testAuditLogSuite) TestOtherUserIsForbidden() {
+	ledgerID := s.createLedger()
+
+	otherID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: "Other"})
+	s.NoError(err)
+	s.userID = otherID
+
+	w := s.do(http.MethodGet, fmt.Sprintf("/audit?entity=ledger&id=%d", ledgerID), "")
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
+func (s *testAuditLogSuite) TestInvalidEntity() {
+	w := s.do(http.MethodGet, fmt.Sprintf("/audit?entity=user&id=%d", s.ownerID), "")
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	w = s.do(http.MethodGet, "/audit?entity=ledger", "")
+	s.Equal(http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,112 @@
+package bookkeeping
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// authorizeAccount loads an account and verifies that the authenticated user holds
+// at least the required role on it, either as its owner or as a member.
+func (x *Controller) authorizeAccount(ctx *engine.Context, accountID int32, required domain.AccountMemberRole) (*domain.Account, domain.AccountMemberRole, error) {
+	userID := ctx.GetUserID()
+	if userID == 0 {
+		return nil, "", app.Unauthorized(errors.New("user not authenticated"))
+	}
+
+	account, role, err := x.service.GetAccountAccess(accountID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if role == "" {
+		return nil, "", app.Forbidden(errors.New("access denied: account does not belong to user"))
+	}
+
+	if !role.Allows(required) {
+		return nil, "", app.Forbidden(fmt.Errorf("access denied: %s role required on account", required))
+	}
+
+	return account, role, nil
+}
+
+// authorizeLedger loads a ledger and verifies that the authenticated user holds
+// at least the required role on the account it belongs to.
+func (x *Controller) authorizeLedger(ctx *engine.Context, ledgerID int32, required domain.AccountMemberRole) (*domain.Ledger, error) {
+	if ctx.GetUserID() == 0 {
+		return nil, app.Unauthorized(errors.New("user not authenticated"))
+	}
+
+	ledger, err := x.service.GetLedgerByID(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := x.authorizeAccount(ctx, ledger.AccountID, required); err != nil {
+		return nil, err
+	}
+
+	return ledger, nil
+}
+
+// authorizeWorkspace loads a workspace and verifies that the authenticated user holds
+// at least the required role in it.
+func (x *Controller) authorizeWorkspace(ctx *engine.Context, workspaceID int32, required domain.WorkspaceRole) (*domain.Workspace, domain.WorkspaceRole, error) {
+	userID := ctx.GetUserID()
+	if userID == 0 {
+		return nil, "", app.Unauthorized(errors.New("user not authenticated"))
+	}
+
+	workspace, role, err := x.service.GetWorkspaceAccess(workspaceID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if role == "" {
+		return nil, "", app.Forbidden(errors.New("access denied: not a member of the workspace"))
+	}
+
+	if !role.Allows(required) {
+		return nil, "", app.Forbidden(fmt.Errorf("access denied: %s role required in workspace", required))
+	}
+
+	return workspace, role, nil
+}
+
+// authorizeRecurringTransaction loads a recurring transaction and verifies that the
+// authenticated user owns it or, when a workspace owns it, holds at least the required role in
+// that workspace. Creating a workspace transaction grants nothing on its own.
+func (x *Controller) authorizeRecurringTransaction(ctx *engine.Context, id int32, required domain.WorkspaceRole) (*domain.RecurringTransaction, error) {
+	userID := ctx.GetUserID()
+	if userID == 0 {
+		return nil, app.Unauthorized(errors.New("user not authenticated"))
+	}
+
+	transaction, err := x.service.GetRecurringTransaction(ctx.Request.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := x.authorizeRecurringTransactionAccess(ctx, transaction, required); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// authorizeRecurringTransactionAccess verifies that the authenticated user owns a loaded
+// recurring transaction or holds at least the required role in the workspace owning it.
+func (x *Controller) authorizeRecurringTransactionAccess(ctx *engine.Context, transaction *domain.RecurringTransaction, required domain.WorkspaceRole) error {
+	if transaction.WorkspaceID == nil {
+		if transaction.UserID != ctx.GetUserID() {
+			return app.NotFoundError()
+		}
+		return nil
+	}
+
+	_, _, err := x.authorizeWorkspace(ctx, *transaction.WorkspaceID, required)
+	return err
+}
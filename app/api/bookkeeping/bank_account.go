@@ -45,22 +45,12 @@ func (x *Controller) CreateBankAccount() func(w http.ResponseWriter, r *http.Req
 
 		var req request
 		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
-
-			// Verify account ownership
-			account, err := x.service.GetAccountByID(req.AccountID)
-			if err != nil {
+			if _, _, err := x.authorizeAccount(ctx, req.AccountID, domain.AccountMemberRoleOwner); err != nil {
 				if errors.Is(err, domain.ErrNotFound) {
 					return nil, app.NotFoundError()
 				}
 				return nil, err
 			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: account does not belong to user"))
-			}
 
 			if req.AccountNumber == "" {
 				return nil, app.ParamError(errors.New("account number is required"))
@@ -86,8 +76,7 @@ func (x *Controller) GetBankAccountByID() func(w http.ResponseWriter, r *http.Re
 	return func(w http.ResponseWriter, r *http.Request) {
 		var id int32
 		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*jsonBankAccount, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
+			if ctx.GetUserID() == 0 {
 				return nil, app.Unauthorized(errors.New("user not authenticated"))
 			}
 
@@ -99,14 +88,10 @@ func (x *Controller) GetBankAccountByID() func(w http.ResponseWriter, r *http.Re
 				return nil, err
 			}
 
-			// Verify ownership by checking the associated account
-			account, err := x.service.GetAccountByID(bankAccount.AccountID)
-			if err != nil {
+			// Verify access by checking the associated account
+			if _, _, err := x.authorizeAccount(ctx, bankAccount.AccountID, domain.AccountMemberRoleViewer); err != nil {
 				return nil, err
 			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: account does not belong to user"))
-			}
 
 			var jsonBankAccount jsonBankAccount
 			jsonBankAccount.fromDomain(bankAccount)
@@ -121,22 +106,12 @@ func (x *Controller) GetBankAccountByAccountID() func(w http.ResponseWriter, r *
 	return func(w http.ResponseWriter, r *http.Request) {
 		var accountID int32
 		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*jsonBankAccount, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
-
-			// Verify account ownership
-			account, err := x.service.GetAccountByID(accountID)
-			if err != nil {
+			if _, _, err := x.authorizeAccount(ctx, accountID, domain.AccountMemberRoleViewer); err != nil {
 				if errors.Is(err, domain.ErrNotFound) {
 					return nil, app.NotFoundError()
 				}
 				return nil, err
 			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: account does not belong to user"))
-			}
 
 			bankAccount, err := x.service.GetBankAccountByAccountID(accountID)
 			if err != nil {
@@ -167,8 +142,7 @@ func (x *Controller) UpdateBankAccount() func(w http.ResponseWriter, r *http.Req
 
 		var req request
 		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
+			if ctx.GetUserID() == 0 {
 				return nil, app.Unauthorized(errors.New("user not authenticated"))
 			}
 
@@ -181,14 +155,10 @@ func (x *Controller) UpdateBankAccount() func(w http.ResponseWriter, r *http.Req
 				return nil, err
 			}
 
-			// Verify ownership by checking the associated account
-			account, err := x.service.GetAccountByID(bankAccount.AccountID)
-			if err != nil {
+			// Verify access by checking the associated account
+			if _, _, err := x.authorizeAccount(ctx, bankAccount.AccountID, domain.AccountMemberRoleOwner); err != nil {
 				return nil, err
 			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: account does not belong to user"))
-			}
 
 			return nil, x.service.UpdateBankAccount(domain.UpdateBankAccountRequest{
 				ID:            req.id,
@@ -206,8 +176,7 @@ func (x *Controller) DeleteBankAccount() func(w http.ResponseWriter, r *http.Req
 	return func(w http.ResponseWriter, r *http.Request) {
 		var id int32
 		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
+			if ctx.GetUserID() == 0 {
 				return nil, app.Unauthorized(errors.New("user not authenticated"))
 			}
 
@@ -220,14 +189,10 @@ func (x *Controller) DeleteBankAccount() func(w http.ResponseWriter, r *http.Req
 				return nil, err
 			}
 
-			// Verify ownership by checking the associated account
-			account, err := x.service.GetAccountByID(bankAccount.AccountID)
-			if err != nil {
+			// Verify access by checking the associated account
+			if _, _, err := x.authorizeAccount(ctx, bankAccount.AccountID, domain.AccountMemberRoleOwner); err != nil {
 				return nil, err
 			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: account does not belong to user"))
-			}
 
 			return nil, x.service.DeleteBankAccount(id)
 		}).Param("id", &id).Call(nil).ResponseJSON()
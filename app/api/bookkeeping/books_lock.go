@@ -0,0 +1,153 @@
+package bookkeeping
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/bookkeeping"
+)
+
+type jsonBooksLock struct {
+	ClosedThrough *string `json:"closed_through"`
+}
+
+func (l *jsonBooksLock) fromDomain(lock *domain.BooksLock) {
+	if lock == nil {
+		return
+	}
+
+	closedThrough := lock.ClosedThrough.Format(time.DateOnly)
+	l.ClosedThrough = &closedThrough
+}
+
+func toBooksLockError(err error) error {
+	if errors.Is(err, bookkeeping.ErrBooksClosed) {
+		return app.Forbidden(err)
+	}
+
+	return err
+}
+
+// writeActor describes the actor of a ledger write. Admins may write into a closed period
+// by passing override_books_lock=true, which is then recorded in the audit trail.
+func writeActor(ctx *engine.Context) (domain.AuditActor, error) {
+	actor := auditActor(ctx)
+
+	override, _ := strconv.ParseBool(ctx.Request.URL.Query().Get("override_books_lock"))
+	if override {
+		if !ctx.IsAdmin() {
+			return actor, app.Forbidden(errors.New("only admins can override the books lock"))
+		}
+		actor.OverrideBooksLock = true
+	}
+
+	return actor, nil
+}
+
+// parseClosedThrough parses the date books are closed through. An empty date reopens the books.
+func parseClosedThrough(closedThrough *string) (*time.Time, error) {
+	if closedThrough == nil || *closedThrough == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, *closedThrough)
+	if err != nil {
+		return nil, app.ParamError(fmt.Errorf("closed_through must be a date like 2006-01-02: %w", err))
+	}
+
+	return &date, nil
+}
+
+// GetBooksLock retrieves the date the books of the authenticated user are closed through
+func (x *Controller) GetBooksLock() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*jsonBooksLock, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			lock, err := x.service.GetUserBooksLock(userID)
+			if err != nil {
+				return nil, err
+			}
+
+			var jsonLock jsonBooksLock
+			jsonLock.fromDomain(lock)
+
+			return &jsonLock, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// SetBooksLock closes, or reopens, the books of all accounts owned by the authenticated user
+func (x *Controller) SetBooksLock() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req jsonBooksLock
+		engine.Chain(r, w, func(ctx *engine.Context, req jsonBooksLock) (*engine.Empty, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			closedThrough, err := parseClosedThrough(req.ClosedThrough)
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, x.service.SetUserBooksLock(userID, closedThrough, auditActor(ctx))
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// GetAccountBooksLock retrieves the date the books of an account are closed through
+func (x *Controller) GetAccountBooksLock() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var accountID int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*jsonBooksLock, error) {
+			if _, _, err := x.authorizeAccount(ctx, accountID, domain.AccountMemberRoleViewer); err != nil {
+				return nil, err
+			}
+
+			lock, err := x.service.GetAccountBooksLock(accountID)
+			if err != nil {
+				return nil, err
+			}
+
+			var jsonLock jsonBooksLock
+			jsonLock.fromDomain(lock)
+
+			return &jsonLock, nil
+		}).Param("id", &accountID).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// SetAccountBooksLock closes, or reopens, the books of an account
+func (x *Controller) SetAccountBooksLock() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			accountID     int32
+			ClosedThrough *string `json:"closed_through"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
+			if _, _, err := x.authorizeAccount(ctx, req.accountID, domain.AccountMemberRoleOwner); err != nil {
+				return nil, err
+			}
+
+			closedThrough, err := parseClosedThrough(req.ClosedThrough)
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, x.service.SetAccountBooksLock(req.accountID, closedThrough, auditActor(ctx))
+		}).Param("id", &req.accountID).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
@@ -0,0 +1,223 @@
+package bookkeeping_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/omegaatt36/bookly/app/api/bookkeeping"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/database"
+	"github.com/omegaatt36/bookly/persistence/repository"
+	"github.com/omegaatt36/bookly/persistence/sqlc"
+)
+
+type testBooksLockSuite struct {
+	suite.Suite
+
+	router *http.ServeMux
+
+	repo      *repository.SQLCRepository
+	finalize  func()
+	userID    int32
+	userRole  domain.UserRole
+	accountID int32
+}
+
+func (s *testBooksLockSuite) SetupTest() {
+	s.finalize = database.TestingInitialize(database.PostgresOpt)
+	db := database.GetDB()
+	s.repo = repository.NewSQLCRepository(db)
+	s.router = http.NewServeMux()
+	controller := bookkeeping.NewController(bookkeeping.NewControllerRequest{
+		AccountRepository:   s.repo,
+		LedgerRepository:    s.repo,
+		AuditLogRepository:  s.repo,
+		BooksLockRepository: s.repo,
+	})
+	authMiddleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := engine.WithUserID(r.Context(), s.userID)
+			ctx = engine.WithUserRole(ctx, s.userRole)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	registerWithAuth := func(pattern string, handler http.Handler) {
+		s.router.Handle(pattern, authMiddleware(handler))
+	}
+
+	registerWithAuth("POST /accounts/{account_id}/ledgers", http.HandlerFunc(controller.CreateLedger()))
+	registerWithAuth("DELETE /ledgers/{id}", http.HandlerFunc(controller.VoidLedger()))
+	registerWithAuth("POST /ledgers/{id}/adjust", http.HandlerFunc(controller.AdjustLedger()))
+	registerWithAuth("GET /books-lock", http.HandlerFunc(controller.GetBooksLock()))
+	registerWithAuth("PUT /books-lock", http.HandlerFunc(controller.SetBooksLock()))
+	registerWithAuth("GET /accounts/{id}/books-lock", http.HandlerFunc(controller.GetAccountBooksLock()))
+	registerWithAuth("PUT /accounts/{id}/books-lock", http.HandlerFunc(controller.SetAccountBooksLock()))
+
+	s.NoError(sqlc.MigrateForTest(context.Background(), db))
+
+	userID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: seedUser.Name})
+	s.NoError(err)
+	s.userID = userID
+	s.userRole = domain.UserRoleUser
+
+	s.NoError(s.repo.CreateAccount(domain.CreateAccountRequest{
+		UserID:   userID,
+		Name:     seedAccount.Name,
+		Currency: seedAccount.Currency,
+	}))
+	accounts, err := s.repo.GetAccountsByUserID(userID)
+	s.NoError(err)
+	s.accountID = accounts[0].ID
+}
+
+func (s *testBooksLockSuite) TearDownTest() {
+	s.finalize()
+	s.router = nil
+	s.repo = nil
+}
+
+func TestBooksLockSuite(t *testing.T) {
+	suite.Run(t, new(testBooksLockSuite))
+}
+
+func (s *testBooksLockSuite) do(method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *testBooksLockSuite) createLedger(date time.Time) int32 {
+	ledgerID, err := s.repo.CreateLedger(domain.CreateLedgerRequest{
+		AccountID: s.accountID,
+		Date:      date,
+		Type:      domain.LedgerTypeExpense,
+		Amount:    decimal.NewFromInt(-100),
+		Note:      "lunch",
+	})
+	s.NoError(err)
+	return ledgerID
+}
+
+func (s *testBooksLockSuite) TestUserLockRejectsCreate() {
+	w := s.do(http.MethodPut, "/books-lock", `{"closed_through": "2023-12-31"}`)
+	s.Equal(http.StatusOK, w.Code)
+
+	w = s.do(http.MethodGet, "/books-lock", "")
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `"closed_through":"2023-12-31"`)
+
+	target := fmt.Sprintf("/accounts/%d/ledgers", s.accountID)
+
+	w = s.do(http.MethodPost, target, `{"date": "2023-12-31T23:00:00Z", "type": "expense", "amount": "-10"}`)
+	s.Equal(http.StatusForbidden, w.Code)
+
+	w = s.do(http.MethodPost, target, `{"date": "2024-01-01T00:00:00Z", "type": "expense", "amount": "-10"}`)
+	s.Equal(http.StatusOK, w.Code)
+
+	w = s.do(http.MethodPut, "/books-lock", `{"closed_through": null}`)
+	s.Equal(http.StatusOK, w.Code)
+
+	w = s.do(http.MethodPost, target, `{"date": "2023-12-31T23:00:00Z", "type": "expense", "amount": "-10"}`)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *testBooksLockSuite) TestAccountLockRejectsVoidAndAdjust() {
+	ledgerID := s.createLedger(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	w := s.do(http.MethodPut, fmt.Sprintf("/accounts/%d/books-lock", s.accountID), `{"closed_through": "2023-12-31"}`)
+	s.Equal(http.StatusOK, w.Code)
+
+	w = s.do(http.MethodDelete, fmt.Sprintf("/ledgers/%d", ledgerID), "")
+	s.Equal(http.StatusForbidden, w.Code)
+
+	adjust := func(date string) int {
+		body := fmt.Sprintf(`{"account_id": %d, "date": %q, "type": "expense", "amount": "10", "note": "refund"}`, s.accountID, date)
+		return s.do(http.MethodPost, fmt.Sprintf("/ledgers/%d/adjust", ledgerID), body).Code
+	}
+
+	// The original ledger is in the closed period, wherever the adjusting entry falls
+	s.Equal(http.StatusForbidden, adjust("2023-06-02T00:00:00Z"))
+	s.Equal(http.StatusForbidden, adjust("2024-01-02T00:00:00Z"))
+
+	openLedgerID := s.createLedger(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	body := fmt.Sprintf(`{"account_id": %d, "date": "2023-06-02T00:00:00Z", "type": "expense", "amount": "10", "note": "refund"}`, s.accountID)
+	w = s.do(http.MethodPost, fmt.Sprintf("/ledgers/%d/adjust", openLedgerID), body)
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
+func (s *testBooksLockSuite) TestAdminOverrideOfAdjustmentIsAudited() {
+	ledgerID := s.createLedger(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	closedThrough := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	s.NoError(s.repo.SetAccountBooksLock(s.accountID, &closedThrough, domain.AuditActor{}))
+
+	s.userRole = domain.UserRoleAdmin
+	body := fmt.Sprintf(`{"account_id": %d, "date": "2024-01-02T00:00:00Z", "type": "expense", "amount": "10", "note": "refund"}`, s.accountID)
+	w := s.do(http.MethodPost, fmt.Sprintf("/ledgers/%d/adjust?override_books_lock=true", ledgerID), body)
+	s.Equal(http.StatusOK, w.Code)
+
+	logs, err := s.repo.GetAuditLogs(domain.AuditEntityTypeLedger, ledgerID)
+	s.NoError(err)
+	s.Require().NotEmpty(logs)
+	s.Equal(domain.AuditActionAdjust, logs[0].Action)
+	s.True(logs[0].BooksLockOverride)
+}
+
+func (s *testBooksLockSuite) TestLockChangesAreAudited() {
+	target := fmt.Sprintf("/accounts/%d/books-lock", s.accountID)
+	s.Equal(http.StatusOK, s.do(http.MethodPut, target, `{"closed_through": "2023-12-31"}`).Code)
+	s.Equal(http.StatusOK, s.do(http.MethodPut, target, `{"closed_through": "2023-06-30"}`).Code)
+	s.Equal(http.StatusOK, s.do(http.MethodPut, target, `{"closed_through": null}`).Code)
+
+	logs, err := s.repo.GetAuditLogs(domain.AuditEntityTypeAccountBooksLock, s.accountID)
+	s.NoError(err)
+	s.Require().Len(logs, 3)
+
+	actions := make([]domain.AuditAction, len(logs))
+	for i, log := range logs {
+		s.Require().NotNil(log.ActorUserID)
+		s.Equal(s.userID, *log.ActorUserID)
+		actions[i] = log.Action
+	}
+	s.ElementsMatch([]domain.AuditAction{domain.AuditActionCreate, domain.AuditActionUpdate, domain.AuditActionDelete}, actions)
+
+	s.Equal(http.StatusOK, s.do(http.MethodPut, "/books-lock", `{"closed_through": "2023-12-31"}`).Code)
+	logs, err = s.repo.GetAuditLogs(domain.AuditEntityTypeUserBooksLock, s.userID)
+	s.NoError(err)
+	s.Require().Len(logs, 1)
+	s.JSONEq(`{"closed_through": "2023-12-31"}`, string(logs[0].After))
+}
+
+func (s *testBooksLockSuite) TestAdminOverrideIsAudited() {
+	ledgerID := s.createLedger(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+	closedThrough := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	s.NoError(s.repo.SetAccountBooksLock(s.accountID, &closedThrough, domain.AuditActor{}))
+
+	w := s.do(http.MethodDelete, fmt.Sprintf("/ledgers/%d?override_books_lock=true", ledgerID), "")
+	s.Equal(http.StatusForbidden, w.Code)
+
+	s.userRole = domain.UserRoleAdmin
+	w = s.do(http.MethodDelete, fmt.Sprintf("/ledgers/%d?override_books_lock=true", ledgerID), "")
+	s.Equal(http.StatusOK, w.Code)
+
+	logs, err := s.repo.GetAuditLogs(domain.AuditEntityTypeLedger, ledgerID)
+	s.NoError(err)
+	s.Require().NotEmpty(logs)
+	s.Equal(domain.AuditActionVoid, logs[0].Action)
+	s.True(logs[0].BooksLockOverride)
+}
+
+func (s *testBooksLockSuite) TestInvalidClosedThrough() {
+	w := s.do(http.MethodPut, "/books-lock", `{"closed_through": "31/12/2023"}`)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
@@ -17,6 +17,16 @@ type NewControllerRequest struct {
 	RecurringTransactionRepository domain.RecurringTransactionRepository
 	ReminderRepository             domain.ReminderRepository
 	BankAccountRepository          domain.BankAccountRepository
+	PayeeRepository                domain.PayeeRepository
+	AccountMemberRepository        domain.AccountMemberRepository
+	WorkspaceRepository            domain.WorkspaceRepository
+	AuditLogRepository             domain.AuditLogRepository
+	BooksLockRepository            domain.BooksLockRepository
+	NotificationRepository         domain.NotificationRepository
+	DigestRepository               domain.DigestRepository
+	UserRepository                 domain.UserRepository
+	Notifier                       domain.Notifier // Sends account invitations
+	WebURL                         string          // Base URL of the web app, used in links sent to users
 }
 
 // NewController creates a new controller
@@ -28,6 +38,16 @@ func NewController(req NewControllerRequest) *Controller {
 			RecurringTransactionRepo: req.RecurringTransactionRepository,
 			ReminderRepo:             req.ReminderRepository,
 			BankAccountRepo:          req.BankAccountRepository,
+			PayeeRepo:                req.PayeeRepository,
+			AccountMemberRepo:        req.AccountMemberRepository,
+			WorkspaceRepo:            req.WorkspaceRepository,
+			AuditLogRepo:             req.AuditLogRepository,
+			BooksLockRepo:            req.BooksLockRepository,
+			NotificationRepo:         req.NotificationRepository,
+			DigestRepo:               req.DigestRepository,
+			UserRepo:                 req.UserRepository,
+			Notifier:                 req.Notifier,
+			WebURL:                   req.WebURL,
 		}),
 	}
 }
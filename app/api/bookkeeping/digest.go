@@ -0,0 +1,105 @@
+package bookkeeping
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/bookkeeping"
+)
+
+// DigestSettingsResponse is the response for the digest email preferences of a user
+type DigestSettingsResponse struct {
+	Enabled       bool       `json:"enabled"`
+	Frequency     string     `json:"frequency"`
+	Weekday       int        `json:"weekday"`
+	DayOfMonth    int        `json:"day_of_month"`
+	LastPeriodEnd *time.Time `json:"last_period_end,omitempty"`
+}
+
+// GetDigestSettings gets the digest email preferences of the current user
+func (x *Controller) GetDigestSettings() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*DigestSettingsResponse, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			settings, err := x.service.GetDigestSettings(r.Context(), userID)
+			if err != nil {
+				slog.Error("Failed to get digest settings", "error", err)
+				return nil, err
+			}
+
+			response := mapToDigestSettingsResponse(settings)
+			return &response, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// UpdateDigestSettings sets the digest email preferences of the current user. Omitted fields keep
+// their current value.
+func (x *Controller) UpdateDigestSettings() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Enabled    *bool   `json:"enabled"`
+			Frequency  *string `json:"frequency"`
+			Weekday    *int    `json:"weekday"`
+			DayOfMonth *int    `json:"day_of_month"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*DigestSettingsResponse, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			settings, err := x.service.GetDigestSettings(r.Context(), userID)
+			if err != nil {
+				slog.Error("Failed to get digest settings", "error", err)
+				return nil, err
+			}
+
+			if req.Enabled != nil {
+				settings.Enabled = *req.Enabled
+			}
+			if req.Frequency != nil {
+				settings.Frequency = domain.DigestFrequency(*req.Frequency)
+			}
+			if req.Weekday != nil {
+				settings.Weekday = time.Weekday(*req.Weekday)
+			}
+			if req.DayOfMonth != nil {
+				settings.DayOfMonth = *req.DayOfMonth
+			}
+
+			settings, err = x.service.UpdateDigestSettings(r.Context(), *settings)
+			if errors.Is(err, bookkeeping.ErrInvalidDigestSettings) {
+				return nil, app.ParamError(err)
+			}
+			if err != nil {
+				slog.Error("Failed to update digest settings", "error", err)
+				return nil, err
+			}
+
+			response := mapToDigestSettingsResponse(settings)
+			return &response, nil
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+func mapToDigestSettingsResponse(s *domain.DigestSettings) DigestSettingsResponse {
+	return DigestSettingsResponse{
+		Enabled:       s.Enabled,
+		Frequency:     s.Frequency.String(),
+		Weekday:       int(s.Weekday),
+		DayOfMonth:    s.DayOfMonth,
+		LastPeriodEnd: s.LastPeriodEnd,
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/omegaatt36/bookly/app"
 	"github.com/omegaatt36/bookly/app/api/engine"
 	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/bookkeeping"
 )
 
 type jsonLedger struct {
@@ -25,9 +26,14 @@ type jsonLedger struct {
 	AdjustedFrom *int32          `json:"adjusted_from"`
 	IsVoided     bool            `json:"is_voided"`
 	VoidedAt     *time.Time      `json:"voided_at"`
+	IsPending    bool            `json:"is_pending"`
+	Recurring    *int32          `json:"recurring_transaction_id"`
+	PayeeID      *int32          `json:"payee_id"`
+	Category     string          `json:"category"`
+	Tags         []string        `json:"tags"`
 }
 
-func (l *jsonLedger) fromDomain(ledger *domain.Ledger) {
+func (l *jsonLedger) fromDomain(ledger *domain.Ledger, policy domain.EditPolicy) {
 	l.ID = ledger.ID
 	l.AccountID = ledger.AccountID
 	l.Date = ledger.Date
@@ -35,11 +41,16 @@ func (l *jsonLedger) fromDomain(ledger *domain.Ledger) {
 	l.Currency = ledger.Currency
 	l.Amount = ledger.Amount
 	l.Note = ledger.Note
-	l.Adjustable = time.Since(ledger.CreatedAt) <= domain.EditableDuration
+	l.Adjustable = !ledger.IsPending && policy.Editable(ledger, time.Now())
 	l.IsAdjustment = ledger.IsAdjustment
 	l.AdjustedFrom = ledger.AdjustedFrom
 	l.IsVoided = ledger.IsVoided
 	l.VoidedAt = ledger.VoidedAt
+	l.IsPending = ledger.IsPending
+	l.Recurring = ledger.RecurringTransactionID
+	l.PayeeID = ledger.PayeeID
+	l.Category = ledger.Category
+	l.Tags = ledger.Tags
 }
 
 // CreateLedger handles the creation of a new ledger entry
@@ -51,23 +62,16 @@ func (x *Controller) CreateLedger() func(w http.ResponseWriter, r *http.Request)
 			Type      string          `json:"type"`
 			Amount    decimal.Decimal `json:"amount"`
 			Note      string          `json:"note"`
+			PayeeID   *int32          `json:"payee_id"`
+			Category  string          `json:"category"`
+			Tags      []string        `json:"tags"`
 		}
 
 		var req request
 		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
-
-			// Verify account ownership
-			account, err := x.service.GetAccountByID(req.accountID)
-			if err != nil {
+			if _, _, err := x.authorizeAccount(ctx, req.accountID, domain.AccountMemberRoleEditor); err != nil {
 				return nil, err
 			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: account does not belong to user"))
-			}
 
 			ledgerType, err := domain.ParseLedgerType(req.Type)
 			if err != nil {
@@ -82,15 +86,30 @@ func (x *Controller) CreateLedger() func(w http.ResponseWriter, r *http.Request)
 				return nil, app.ParamError(errors.New("amount is required"))
 			}
 
+			if req.PayeeID != nil {
+				if _, err := x.getOwnedPayee(ctx, *req.PayeeID); err != nil {
+					return nil, err
+				}
+			}
+
+			actor, err := writeActor(ctx)
+			if err != nil {
+				return nil, err
+			}
+
 			_, err = x.service.CreateLedger(domain.CreateLedgerRequest{
 				AccountID: req.accountID,
 				Date:      req.Date,
 				Type:      ledgerType,
 				Amount:    req.Amount,
 				Note:      req.Note,
+				PayeeID:   req.PayeeID,
+				Category:  req.Category,
+				Tags:      req.Tags,
+				Actor:     actor,
 			})
 
-			return nil, err
+			return nil, toBooksLockError(err)
 		}).Param("account_id", &req.accountID).BindJSON(&req).Call(req).ResponseJSON()
 	}
 }
@@ -100,19 +119,10 @@ func (x *Controller) GetLedgersByAccount() func(w http.ResponseWriter, r *http.R
 	return func(w http.ResponseWriter, r *http.Request) {
 		var accountID int32
 		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonLedger, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
-
-			// Verify account ownership
-			account, err := x.service.GetAccountByID(accountID)
+			account, _, err := x.authorizeAccount(ctx, accountID, domain.AccountMemberRoleViewer)
 			if err != nil {
 				return nil, err
 			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: account does not belong to user"))
-			}
 
 			ledgers, err := x.service.GetLedgersByAccountID(accountID)
 			if err != nil {
@@ -121,7 +131,7 @@ func (x *Controller) GetLedgersByAccount() func(w http.ResponseWriter, r *http.R
 
 			jsonLedgers := make([]jsonLedger, len(ledgers))
 			for index, ledger := range ledgers {
-				jsonLedgers[index].fromDomain(ledger)
+				jsonLedgers[index].fromDomain(ledger, account.EditPolicy)
 			}
 
 			return jsonLedgers, nil
@@ -134,27 +144,18 @@ func (x *Controller) GetLedgerByID() func(w http.ResponseWriter, r *http.Request
 	return func(w http.ResponseWriter, r *http.Request) {
 		var id int32
 		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*jsonLedger, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
-
-			ledger, err := x.service.GetLedgerByID(id)
+			ledger, err := x.authorizeLedger(ctx, id, domain.AccountMemberRoleViewer)
 			if err != nil {
 				return nil, err
 			}
 
-			// Verify account ownership
 			account, err := x.service.GetAccountByID(ledger.AccountID)
 			if err != nil {
 				return nil, err
 			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: ledger does not belong to user"))
-			}
 
 			var jsonLedger jsonLedger
-			jsonLedger.fromDomain(ledger)
+			jsonLedger.fromDomain(ledger, account.EditPolicy)
 
 			return &jsonLedger, nil
 		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
@@ -165,34 +166,21 @@ func (x *Controller) GetLedgerByID() func(w http.ResponseWriter, r *http.Request
 func (x *Controller) UpdateLedger() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		type request struct {
-			id     int32
-			Date   *time.Time       `json:"date"`
-			Type   *string          `json:"type"`
-			Amount *decimal.Decimal `json:"amount"`
-			Note   *string          `json:"note"`
+			id       int32
+			Date     *time.Time       `json:"date"`
+			Type     *string          `json:"type"`
+			Amount   *decimal.Decimal `json:"amount"`
+			Note     *string          `json:"note"`
+			PayeeID  *int32           `json:"payee_id"`
+			Category *string          `json:"category"`
+			Tags     *[]string        `json:"tags"`
 		}
 
 		var req request
 		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
-
-			// Verify ledger ownership
-			ledger, err := x.service.GetLedgerByID(req.id)
-			if err != nil {
-				return nil, err
-			}
-
-			// Verify account ownership
-			account, err := x.service.GetAccountByID(ledger.AccountID)
-			if err != nil {
+			if _, err := x.authorizeLedger(ctx, req.id, domain.AccountMemberRoleEditor); err != nil {
 				return nil, err
 			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: ledger does not belong to user"))
-			}
 
 			var ledgerType *domain.LedgerType
 			if req.Type != nil {
@@ -203,13 +191,33 @@ func (x *Controller) UpdateLedger() func(w http.ResponseWriter, r *http.Request)
 				ledgerType = &t
 			}
 
-			return nil, x.service.UpdateLedger(domain.UpdateLedgerRequest{
-				ID:     req.id,
-				Date:   req.Date,
-				Type:   ledgerType,
-				Amount: req.Amount,
-				Note:   req.Note,
+			if req.PayeeID != nil {
+				if _, err := x.getOwnedPayee(ctx, *req.PayeeID); err != nil {
+					return nil, err
+				}
+			}
+
+			actor, err := writeActor(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			err = x.service.UpdateLedger(domain.UpdateLedgerRequest{
+				ID:       req.id,
+				Date:     req.Date,
+				Type:     ledgerType,
+				Amount:   req.Amount,
+				Note:     req.Note,
+				PayeeID:  req.PayeeID,
+				Category: req.Category,
+				Tags:     req.Tags,
+				Actor:    actor,
 			})
+			if errors.Is(err, bookkeeping.ErrLedgerNotEditable) || errors.Is(err, bookkeeping.ErrLedgerPending) {
+				return nil, app.Forbidden(err)
+			}
+
+			return nil, toBooksLockError(err)
 		}).Param("id", &req.id).BindJSON(&req).Call(req).ResponseJSON()
 	}
 }
@@ -219,27 +227,21 @@ func (x *Controller) VoidLedger() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var id int32
 		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
-
-			// Verify ledger ownership
-			ledger, err := x.service.GetLedgerByID(id)
-			if err != nil {
+			if _, err := x.authorizeLedger(ctx, id, domain.AccountMemberRoleEditor); err != nil {
 				return nil, err
 			}
 
-			// Verify account ownership
-			account, err := x.service.GetAccountByID(ledger.AccountID)
+			actor, err := writeActor(ctx)
 			if err != nil {
 				return nil, err
 			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: ledger does not belong to user"))
+
+			err = x.service.VoidLedger(id, actor)
+			if errors.Is(err, bookkeeping.ErrLedgerPending) {
+				return nil, app.Forbidden(err)
 			}
 
-			return nil, x.service.VoidLedger(id)
+			return nil, toBooksLockError(err)
 		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
 	}
 }
@@ -258,35 +260,16 @@ func (x *Controller) AdjustLedger() func(w http.ResponseWriter, r *http.Request)
 
 		var req request
 		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
-
-			// Verify ledger ownership
-			ledger, err := x.service.GetLedgerByID(req.id)
+			ledger, err := x.authorizeLedger(ctx, req.id, domain.AccountMemberRoleEditor)
 			if err != nil {
 				return nil, err
 			}
 
-			// Verify original account ownership
-			account, err := x.service.GetAccountByID(ledger.AccountID)
-			if err != nil {
-				return nil, err
-			}
-			if account.UserID != userID {
-				return nil, app.Forbidden(errors.New("access denied: ledger does not belong to user"))
-			}
-
-			// Verify new account ownership if changing account
+			// Verify access to the new account if changing account
 			if req.AccountID != ledger.AccountID {
-				newAccount, err := x.service.GetAccountByID(req.AccountID)
-				if err != nil {
+				if _, _, err := x.authorizeAccount(ctx, req.AccountID, domain.AccountMemberRoleEditor); err != nil {
 					return nil, err
 				}
-				if newAccount.UserID != userID {
-					return nil, app.Forbidden(errors.New("access denied: new account does not belong to user"))
-				}
 			}
 
 			ledgerType, err := domain.ParseLedgerType(req.Type)
@@ -298,13 +281,24 @@ func (x *Controller) AdjustLedger() func(w http.ResponseWriter, r *http.Request)
 				return nil, app.ParamError(errors.New("amount is required"))
 			}
 
-			return nil, x.service.AdjustLedger(req.id, domain.CreateLedgerRequest{
+			actor, err := writeActor(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			err = x.service.AdjustLedger(req.id, domain.CreateLedgerRequest{
 				AccountID: req.AccountID,
 				Date:      req.Date,
 				Type:      ledgerType,
 				Amount:    req.Amount,
 				Note:      req.Note,
+				Actor:     actor,
 			})
+			if errors.Is(err, bookkeeping.ErrLedgerPending) {
+				return nil, app.Forbidden(err)
+			}
+
+			return nil, toBooksLockError(err)
 		}).Param("id", &req.id).BindJSON(&req).Call(req).ResponseJSON()
 	}
 }
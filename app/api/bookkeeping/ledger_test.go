@@ -37,7 +37,8 @@ func (s *testLedgerSuite) SetupTest() {
 	s.repo = repository.NewSQLCRepository(db)
 	s.router = http.NewServeMux()
 	controller := bookkeeping.NewController(bookkeeping.NewControllerRequest{
-		s.repo, s.repo, nil, nil, nil,
+		AccountRepository: s.repo,
+		LedgerRepository:  s.repo,
 	})
 	authMiddleware := func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -245,6 +246,40 @@ func (s *testLedgerSuite) TestUpdateLedger() {
 	s.Equal(decimal.NewFromFloat(120.00).String(), account.Balance.String())
 }
 
+func (s *testLedgerSuite) TestUpdateLedgerAdjustOnly() {
+	accountID, err := s.createSeedAccount()
+	s.NoError(err)
+
+	s.NoError(s.repo.UpdateAccount(domain.UpdateAccountRequest{
+		ID:         accountID,
+		EditPolicy: &domain.EditPolicy{Mode: domain.LedgerEditModeAdjustOnly},
+	}))
+
+	ledgerID, err := s.repo.CreateLedger(domain.CreateLedgerRequest{
+		AccountID: accountID,
+		Date:      time.Now(),
+		Type:      domain.LedgerTypeExpense,
+		Amount:    decimal.NewFromFloat(100.00),
+		Note:      "Original Expense",
+	})
+	s.NoError(err)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/ledgers/%d", ledgerID), nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `"adjustable":false`)
+
+	req = httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/ledgers/%d", ledgerID), bytes.NewBufferString(`{"amount": "120.00"}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+
+	ledger, err := s.repo.GetLedgerByID(ledgerID)
+	s.NoError(err)
+	s.Equal(decimal.NewFromFloat(100.00).String(), ledger.Amount.String())
+}
+
 func (s *testLedgerSuite) TestVoidLedger() {
 	accountID, err := s.createSeedAccount()
 	s.NoError(err)
@@ -0,0 +1,207 @@
+package bookkeeping
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/bookkeeping"
+)
+
+// NotificationChannelResponse is the response for a channel reminders are delivered through
+type NotificationChannelResponse struct {
+	Channel   string    `json:"channel"`
+	Target    string    `json:"target"`
+	Enabled   bool      `json:"enabled"`
+	Confirmed bool      `json:"confirmed"` // Reminders are only delivered to confirmed targets
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReminderDeliveryResponse is the response for the delivery of a reminder through a channel
+type ReminderDeliveryResponse struct {
+	ID            int32      `json:"id"`
+	ReminderDate  time.Time  `json:"reminder_date"`
+	Channel       string     `json:"channel"`
+	Target        string     `json:"target"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error,omitempty"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+}
+
+// GetNotificationChannels gets the channels the current user wants their reminders delivered through
+func (x *Controller) GetNotificationChannels() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]NotificationChannelResponse, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			preferences, err := x.service.GetNotificationPreferences(r.Context(), userID)
+			if err != nil {
+				slog.Error("Failed to get notification channels", "error", err)
+				return nil, err
+			}
+
+			response := make([]NotificationChannelResponse, len(preferences))
+			for i, preference := range preferences {
+				response[i] = mapToNotificationChannelResponse(preference)
+			}
+
+			return response, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// UpdateNotificationChannel sets where the current user receives their reminders through a channel
+func (x *Controller) UpdateNotificationChannel() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			channel domain.NotificationChannel
+			Target  string `json:"target"`
+			Enabled *bool  `json:"enabled"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*NotificationChannelResponse, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			if !req.channel.IsValid() {
+				return nil, app.ParamError(fmt.Errorf("unknown channel %q", req.channel))
+			}
+
+			enabled := true
+			if req.Enabled != nil {
+				enabled = *req.Enabled
+			}
+
+			preference, err := x.service.UpdateNotificationPreference(r.Context(), domain.NotificationPreference{
+				UserID:  userID,
+				Channel: req.channel,
+				Target:  req.Target,
+				Enabled: enabled,
+			})
+			if errors.Is(err, bookkeeping.ErrInvalidNotificationTarget) {
+				return nil, app.ParamError(err)
+			}
+			if err != nil {
+				slog.Error("Failed to update notification channel", "channel", req.channel, "error", err)
+				return nil, err
+			}
+
+			response := mapToNotificationChannelResponse(preference)
+			return &response, nil
+		}).Param("channel", &req.channel).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// ConfirmNotificationChannel confirms an email target using the token of the link sent to it
+func (x *Controller) ConfirmNotificationChannel() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Token string `json:"token"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(_ *engine.Context, req request) (*engine.Empty, error) {
+			if req.Token == "" {
+				return nil, app.ParamError(errors.New("token is required"))
+			}
+
+			_, err := x.service.ConfirmNotificationTarget(r.Context(), req.Token)
+			if errors.Is(err, bookkeeping.ErrInvalidConfirmationToken) {
+				return nil, app.ParamError(err)
+			}
+			if err != nil {
+				slog.Error("Failed to confirm notification channel", "error", err)
+				return nil, err
+			}
+
+			return nil, nil
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// DeleteNotificationChannel stops delivering the reminders of the current user through a channel
+func (x *Controller) DeleteNotificationChannel() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var channel domain.NotificationChannel
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			if !channel.IsValid() {
+				return nil, app.ParamError(fmt.Errorf("unknown channel %q", channel))
+			}
+
+			if err := x.service.DeleteNotificationPreference(r.Context(), userID, channel); err != nil {
+				slog.Error("Failed to delete notification channel", "channel", channel, "error", err)
+				return nil, err
+			}
+
+			return nil, nil
+		}).Param("channel", &channel).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// GetReminderDeliveries gets the deliveries of a reminder through the channels of its user
+func (x *Controller) GetReminderDeliveries() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]ReminderDeliveryResponse, error) {
+			reminder, err := x.service.GetReminderByID(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, err := x.authorizeRecurringTransaction(ctx, reminder.RecurringTransactionID, domain.WorkspaceRoleViewer); err != nil {
+				return nil, err
+			}
+
+			deliveries, err := x.service.GetReminderDeliveries(r.Context(), id)
+			if err != nil {
+				slog.Error("Failed to get reminder deliveries", "id", id, "error", err)
+				return nil, err
+			}
+
+			response := make([]ReminderDeliveryResponse, len(deliveries))
+			for i, delivery := range deliveries {
+				response[i] = ReminderDeliveryResponse{
+					ID:            delivery.ID,
+					ReminderDate:  delivery.ReminderDate,
+					Channel:       delivery.Channel.String(),
+					Target:        delivery.Target,
+					Status:        delivery.Status.String(),
+					Attempts:      delivery.Attempts,
+					NextAttemptAt: delivery.NextAttemptAt,
+					LastError:     delivery.LastError,
+					DeliveredAt:   delivery.DeliveredAt,
+				}
+			}
+
+			return response, nil
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+func mapToNotificationChannelResponse(p *domain.NotificationPreference) NotificationChannelResponse {
+	return NotificationChannelResponse{
+		Channel:   p.Channel.String(),
+		Target:    p.Target,
+		Enabled:   p.Enabled,
+		Confirmed: p.ConfirmedAt != nil,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
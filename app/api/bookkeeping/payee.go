@@ -0,0 +1,430 @@
+package bookkeeping
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/bookkeeping"
+)
+
+type jsonPayee struct {
+	ID        int32  `json:"id"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	Name      string `json:"name"`
+}
+
+func (p *jsonPayee) fromDomain(payee *domain.Payee) {
+	p.ID = payee.ID
+	p.CreatedAt = payee.CreatedAt.Format(time.RFC3339)
+	p.UpdatedAt = payee.UpdatedAt.Format(time.RFC3339)
+	p.Name = payee.Name
+}
+
+type jsonPayeeRule struct {
+	ID        int32    `json:"id"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+	PayeeID   *int32   `json:"payee_id"`
+	MatchType string   `json:"match_type"`
+	Pattern   string   `json:"pattern"`
+	Category  string   `json:"category"`
+	Tags      []string `json:"tags"`
+	Priority  int32    `json:"priority"`
+}
+
+func (p *jsonPayeeRule) fromDomain(rule *domain.PayeeRule) {
+	p.ID = rule.ID
+	p.CreatedAt = rule.CreatedAt.Format(time.RFC3339)
+	p.UpdatedAt = rule.UpdatedAt.Format(time.RFC3339)
+	p.PayeeID = rule.PayeeID
+	p.MatchType = rule.MatchType.String()
+	p.Pattern = rule.Pattern
+	p.Category = rule.Category
+	p.Tags = rule.Tags
+	p.Priority = rule.Priority
+}
+
+type jsonLedgerClassification struct {
+	PayeeID  *int32   `json:"payee_id"`
+	Category string   `json:"category"`
+	Tags     []string `json:"tags"`
+}
+
+type jsonPayeeRuleMatch struct {
+	LedgerID int32                    `json:"ledger_id"`
+	RuleID   int32                    `json:"rule_id"`
+	Note     string                   `json:"note"`
+	Before   jsonLedgerClassification `json:"before"`
+	After    jsonLedgerClassification `json:"after"`
+}
+
+func (m *jsonPayeeRuleMatch) fromDomain(match domain.PayeeRuleMatch) {
+	m.LedgerID = match.LedgerID
+	m.RuleID = match.RuleID
+	m.Note = match.Note
+	m.Before = jsonLedgerClassification(match.Before)
+	m.After = jsonLedgerClassification(match.After)
+}
+
+// CreatePayee handles the creation of a new payee
+func (x *Controller) CreatePayee() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Name string `json:"name"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*jsonPayee, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			if req.Name == "" {
+				return nil, app.ParamError(errors.New("name is required"))
+			}
+
+			id, err := x.service.CreatePayee(domain.CreatePayeeRequest{
+				UserID: userID,
+				Name:   req.Name,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			payee, err := x.service.GetPayeeByID(id)
+			if err != nil {
+				return nil, err
+			}
+
+			var jsonPayee jsonPayee
+			jsonPayee.fromDomain(payee)
+
+			return &jsonPayee, nil
+		}).BindJSON(&req).Call(req).ResponseCreated()
+	}
+}
+
+// GetPayees handles the retrieval of all payees for the current authenticated user
+func (x *Controller) GetPayees() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonPayee, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			payees, err := x.service.GetPayeesByUserID(userID)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonPayees := make([]jsonPayee, len(payees))
+			for index, payee := range payees {
+				jsonPayees[index].fromDomain(payee)
+			}
+
+			return jsonPayees, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// GetPayeeByID handles the retrieval of a payee by its ID
+func (x *Controller) GetPayeeByID() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*jsonPayee, error) {
+			payee, err := x.getOwnedPayee(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+
+			var jsonPayee jsonPayee
+			jsonPayee.fromDomain(payee)
+
+			return &jsonPayee, nil
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// UpdatePayee handles the update of a payee
+func (x *Controller) UpdatePayee() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			id   int32
+			Name *string `json:"name"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
+			if _, err := x.getOwnedPayee(ctx, req.id); err != nil {
+				return nil, err
+			}
+
+			if req.Name != nil && *req.Name == "" {
+				return nil, app.ParamError(errors.New("name cannot be empty"))
+			}
+
+			return nil, x.service.UpdatePayee(domain.UpdatePayeeRequest{
+				ID:   req.id,
+				Name: req.Name,
+			})
+		}).Param("id", &req.id).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// DeletePayee handles the deletion of a payee
+func (x *Controller) DeletePayee() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			if _, err := x.getOwnedPayee(ctx, id); err != nil {
+				return nil, err
+			}
+
+			return nil, x.service.DeletePayee(id)
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// CreatePayeeRule handles the creation of a new payee rule
+func (x *Controller) CreatePayeeRule() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			PayeeID   *int32   `json:"payee_id"`
+			MatchType string   `json:"match_type"`
+			Pattern   string   `json:"pattern"`
+			Category  string   `json:"category"`
+			Tags      []string `json:"tags"`
+			Priority  int32    `json:"priority"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*jsonPayeeRule, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			if req.PayeeID != nil {
+				if _, err := x.getOwnedPayee(ctx, *req.PayeeID); err != nil {
+					return nil, err
+				}
+			}
+
+			matchType, err := domain.ParsePayeeRuleMatchType(req.MatchType)
+			if err != nil {
+				return nil, app.ParamError(err)
+			}
+
+			id, err := x.service.CreatePayeeRule(domain.CreatePayeeRuleRequest{
+				UserID:    userID,
+				PayeeID:   req.PayeeID,
+				MatchType: matchType,
+				Pattern:   req.Pattern,
+				Category:  req.Category,
+				Tags:      req.Tags,
+				Priority:  req.Priority,
+			})
+			if err != nil {
+				if errors.Is(err, bookkeeping.ErrInvalidPayeeRule) {
+					return nil, app.ParamError(err)
+				}
+				return nil, err
+			}
+
+			rule, err := x.service.GetPayeeRuleByID(id)
+			if err != nil {
+				return nil, err
+			}
+
+			var jsonRule jsonPayeeRule
+			jsonRule.fromDomain(rule)
+
+			return &jsonRule, nil
+		}).BindJSON(&req).Call(req).ResponseCreated()
+	}
+}
+
+// GetPayeeRules handles the retrieval of all payee rules for the current authenticated user
+func (x *Controller) GetPayeeRules() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonPayeeRule, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			rules, err := x.service.GetPayeeRulesByUserID(userID)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonRules := make([]jsonPayeeRule, len(rules))
+			for index, rule := range rules {
+				jsonRules[index].fromDomain(rule)
+			}
+
+			return jsonRules, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// GetPayeeRuleByID handles the retrieval of a payee rule by its ID
+func (x *Controller) GetPayeeRuleByID() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*jsonPayeeRule, error) {
+			rule, err := x.getOwnedPayeeRule(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+
+			var jsonRule jsonPayeeRule
+			jsonRule.fromDomain(rule)
+
+			return &jsonRule, nil
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// UpdatePayeeRule handles the update of a payee rule
+func (x *Controller) UpdatePayeeRule() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			id        int32
+			PayeeID   *int32    `json:"payee_id"`
+			MatchType *string   `json:"match_type"`
+			Pattern   *string   `json:"pattern"`
+			Category  *string   `json:"category"`
+			Tags      *[]string `json:"tags"`
+			Priority  *int32    `json:"priority"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
+			if _, err := x.getOwnedPayeeRule(ctx, req.id); err != nil {
+				return nil, err
+			}
+
+			if req.PayeeID != nil {
+				if _, err := x.getOwnedPayee(ctx, *req.PayeeID); err != nil {
+					return nil, err
+				}
+			}
+
+			var matchType *domain.PayeeRuleMatchType
+			if req.MatchType != nil {
+				t, err := domain.ParsePayeeRuleMatchType(*req.MatchType)
+				if err != nil {
+					return nil, app.ParamError(err)
+				}
+				matchType = &t
+			}
+
+			err := x.service.UpdatePayeeRule(domain.UpdatePayeeRuleRequest{
+				ID:        req.id,
+				PayeeID:   req.PayeeID,
+				MatchType: matchType,
+				Pattern:   req.Pattern,
+				Category:  req.Category,
+				Tags:      req.Tags,
+				Priority:  req.Priority,
+			})
+			if errors.Is(err, bookkeeping.ErrInvalidPayeeRule) {
+				return nil, app.ParamError(err)
+			}
+
+			return nil, err
+		}).Param("id", &req.id).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// DeletePayeeRule handles the deletion of a payee rule
+func (x *Controller) DeletePayeeRule() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			if _, err := x.getOwnedPayeeRule(ctx, id); err != nil {
+				return nil, err
+			}
+
+			return nil, x.service.DeletePayeeRule(id)
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// ApplyPayeeRules re-applies the payee rules to the ledger history of the current user.
+// With dry_run the matches are returned without changing any ledger.
+func (x *Controller) ApplyPayeeRules() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			DryRun bool `json:"dry_run"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) ([]jsonPayeeRuleMatch, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			matches, err := x.service.ApplyPayeeRules(userID, req.DryRun)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonMatches := make([]jsonPayeeRuleMatch, len(matches))
+			for index, match := range matches {
+				jsonMatches[index].fromDomain(match)
+			}
+
+			return jsonMatches, nil
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+func (x *Controller) getOwnedPayee(ctx *engine.Context, id int32) (*domain.Payee, error) {
+	userID := ctx.GetUserID()
+	if userID == 0 {
+		return nil, app.Unauthorized(errors.New("user not authenticated"))
+	}
+
+	payee, err := x.service.GetPayeeByID(id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, app.NotFoundError()
+		}
+		return nil, err
+	}
+	if payee.UserID != userID {
+		return nil, app.Forbidden(errors.New("access denied: payee does not belong to user"))
+	}
+
+	return payee, nil
+}
+
+func (x *Controller) getOwnedPayeeRule(ctx *engine.Context, id int32) (*domain.PayeeRule, error) {
+	userID := ctx.GetUserID()
+	if userID == 0 {
+		return nil, app.Unauthorized(errors.New("user not authenticated"))
+	}
+
+	rule, err := x.service.GetPayeeRuleByID(id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, app.NotFoundError()
+		}
+		return nil, err
+	}
+	if rule.UserID != userID {
+		return nil, app.Forbidden(errors.New("access denied: payee rule does not belong to user"))
+	}
+
+	return rule, nil
+}
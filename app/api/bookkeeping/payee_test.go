@@ -0,0 +1,206 @@
+package bookkeeping_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/omegaatt36/bookly/app/api/bookkeeping"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/database"
+	"github.com/omegaatt36/bookly/persistence/repository"
+	"github.com/omegaatt36/bookly/persistence/sqlc"
+)
+
+type testPayeeSuite struct {
+	suite.Suite
+
+	router *http.ServeMux
+
+	repo      *repository.SQLCRepository
+	finalize  func()
+	userID    int32
+	accountID int32
+}
+
+func (s *testPayeeSuite) SetupTest() {
+	s.finalize = database.TestingInitialize(database.PostgresOpt)
+	db := database.GetDB()
+	s.repo = repository.NewSQLCRepository(db)
+	s.router = http.NewServeMux()
+	controller := bookkeeping.NewController(bookkeeping.NewControllerRequest{
+		AccountRepository: s.repo,
+		LedgerRepository:  s.repo,
+		PayeeRepository:   s.repo,
+	})
+
+	authMiddleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := engine.WithUserID(r.Context(), s.userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	registerWithAuth := func(pattern string, handler http.Handler) {
+		s.router.Handle(pattern, authMiddleware(handler))
+	}
+
+	registerWithAuth("POST /accounts/{account_id}/ledgers", http.HandlerFunc(controller.CreateLedger()))
+	registerWithAuth("POST /payees", http.HandlerFunc(controller.CreatePayee()))
+	registerWithAuth("GET /payees", http.HandlerFunc(controller.GetPayees()))
+	registerWithAuth("POST /payee-rules", http.HandlerFunc(controller.CreatePayeeRule()))
+	registerWithAuth("GET /payee-rules", http.HandlerFunc(controller.GetPayeeRules()))
+	registerWithAuth("POST /payee-rules/apply", http.HandlerFunc(controller.ApplyPayeeRules()))
+
+	s.NoError(sqlc.MigrateForTest(context.Background(), db))
+
+	userID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: seedUser.Name})
+	s.NoError(err)
+	s.userID = userID
+
+	s.NoError(s.repo.CreateAccount(domain.CreateAccountRequest{
+		UserID:   userID,
+		Name:     seedAccount.Name,
+		Currency: seedAccount.Currency,
+	}))
+	accounts, err := s.repo.GetAccountsByUserID(userID)
+	s.NoError(err)
+	s.accountID = accounts[0].ID
+}
+
+func (s *testPayeeSuite) TearDownTest() {
+	s.finalize()
+	s.router = nil
+	s.repo = nil
+}
+
+func TestPayeeSuite(t *testing.T) {
+	suite.Run(t, new(testPayeeSuite))
+}
+
+func (s *testPayeeSuite) createPayee(name string) int32 {
+	id, err := s.repo.CreatePayee(domain.CreatePayeeRequest{UserID: s.userID, Name: name})
+	s.NoError(err)
+	return id
+}
+
+func (s *testPayeeSuite) TestCreatePayee() {
+	req := httptest.NewRequest(http.MethodPost, "/payees", bytes.NewBufferString(`{"name": "Starbucks"}`))
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusCreated, w.Code)
+
+	payees, err := s.repo.GetPayeesByUserID(s.userID)
+	s.NoError(err)
+	s.Len(payees, 1)
+	s.Equal("Starbucks", payees[0].Name)
+}
+
+func (s *testPayeeSuite) TestCreatePayeeRuleRejectsInvalidRegex() {
+	payeeID := s.createPayee("Starbucks")
+
+	reqBody := fmt.Sprintf(`{"payee_id": %d, "match_type": "regex", "pattern": "STARBUCKS("}`, payeeID)
+	req := httptest.NewRequest(http.MethodPost, "/payee-rules", bytes.NewBufferString(reqBody))
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *testPayeeSuite) TestCreateLedgerAppliesRules() {
+	payeeID := s.createPayee("Starbucks")
+
+	reqBody := fmt.Sprintf(`{
+		"payee_id": %d,
+		"match_type": "contains",
+		"pattern": "starbucks",
+		"category": "coffee",
+		"tags": ["food", "daily"]
+	}`, payeeID)
+	req := httptest.NewRequest(http.MethodPost, "/payee-rules", bytes.NewBufferString(reqBody))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusCreated, w.Code)
+
+	reqBody = `{"type": "expense", "amount": "-120", "note": "STARBUCKS 1234 TAIPEI"}`
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%d/ledgers", s.accountID), bytes.NewBufferString(reqBody))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	ledgers, err := s.repo.GetLedgersByAccountID(s.accountID)
+	s.NoError(err)
+	s.Len(ledgers, 1)
+	s.NotNil(ledgers[0].PayeeID)
+	s.Equal(payeeID, *ledgers[0].PayeeID)
+	s.Equal("coffee", ledgers[0].Category)
+	s.Equal([]string{"food", "daily"}, ledgers[0].Tags)
+}
+
+func (s *testPayeeSuite) TestApplyPayeeRulesDryRun() {
+	payeeID := s.createPayee("Starbucks")
+
+	ledgerID, err := s.repo.CreateLedger(domain.CreateLedgerRequest{
+		AccountID: s.accountID,
+		Date:      time.Now(),
+		Type:      domain.LedgerTypeExpense,
+		Amount:    decimal.NewFromInt(-120),
+		Note:      "STARBUCKS 1234 TAIPEI",
+	})
+	s.NoError(err)
+
+	_, err = s.repo.CreatePayeeRule(domain.CreatePayeeRuleRequest{
+		UserID:    s.userID,
+		PayeeID:   &payeeID,
+		MatchType: domain.PayeeRuleMatchTypeRegex,
+		Pattern:   `^STARBUCKS \d+`,
+	})
+	s.NoError(err)
+
+	type applyResponse struct {
+		Code int `json:"code"`
+		Data []struct {
+			LedgerID int32 `json:"ledger_id"`
+			After    struct {
+				PayeeID *int32 `json:"payee_id"`
+			} `json:"after"`
+		} `json:"data"`
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/payee-rules/apply", bytes.NewBufferString(`{"dry_run": true}`))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp applyResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Len(resp.Data, 1)
+	s.Equal(ledgerID, resp.Data[0].LedgerID)
+	s.Equal(payeeID, *resp.Data[0].After.PayeeID)
+
+	ledger, err := s.repo.GetLedgerByID(ledgerID)
+	s.NoError(err)
+	s.Nil(ledger.PayeeID)
+
+	req = httptest.NewRequest(http.MethodPost, "/payee-rules/apply", bytes.NewBufferString(`{"dry_run": false}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	ledger, err = s.repo.GetLedgerByID(ledgerID)
+	s.NoError(err)
+	s.NotNil(ledger.PayeeID)
+	s.Equal(payeeID, *ledger.PayeeID)
+}
@@ -2,6 +2,7 @@ package bookkeeping
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
@@ -11,27 +12,33 @@ import (
 	"github.com/omegaatt36/bookly/app"
 	"github.com/omegaatt36/bookly/app/api/engine"
 	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/bookkeeping"
 )
 
 // RecurringTransactionResponse is the response for a recurring transaction
 type RecurringTransactionResponse struct {
-	ID           int32           `json:"id"`
-	CreatedAt    time.Time       `json:"created_at"`
-	UpdatedAt    time.Time       `json:"updated_at"`
-	Name         string          `json:"name"`
-	Type         string          `json:"type"`
-	Amount       decimal.Decimal `json:"amount"`
-	Note         string          `json:"note"`
-	StartDate    time.Time       `json:"start_date"`
-	EndDate      *time.Time      `json:"end_date,omitempty"`
-	RecurType    string          `json:"recur_type"`
-	Status       string          `json:"status"`
-	Frequency    int             `json:"frequency"`
-	DayOfWeek    *int            `json:"day_of_week,omitempty"`
-	DayOfMonth   *int            `json:"day_of_month,omitempty"`
-	MonthOfYear  *int            `json:"month_of_year,omitempty"`
-	LastExecuted *time.Time      `json:"last_executed,omitempty"`
-	NextDue      time.Time       `json:"next_due"`
+	ID              int32           `json:"id"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	Name            string          `json:"name"`
+	Type            string          `json:"type"`
+	Amount          decimal.Decimal `json:"amount"`
+	Note            string          `json:"note"`
+	StartDate       time.Time       `json:"start_date"`
+	EndDate         *time.Time      `json:"end_date,omitempty"`
+	RecurType       string          `json:"recur_type"`
+	Status          string          `json:"status"`
+	Frequency       int             `json:"frequency"`
+	DayOfWeek       *int            `json:"day_of_week,omitempty"`
+	DayOfMonth      *int            `json:"day_of_month,omitempty"`
+	MonthOfYear     *int            `json:"month_of_year,omitempty"`
+	RRule           string          `json:"rrule,omitempty"`
+	CatchUpPolicy   string          `json:"catch_up_policy"`
+	PostMode        string          `json:"post_mode"`
+	ReminderOffsets []int           `json:"reminder_offsets"`
+	LastExecuted    *time.Time      `json:"last_executed,omitempty"`
+	NextDue         time.Time       `json:"next_due"`
+	WorkspaceID     *int32          `json:"workspace_id,omitempty"`
 }
 
 // ReminderResponse is the response for a reminder
@@ -42,24 +49,47 @@ type ReminderResponse struct {
 	ReminderDate           time.Time  `json:"reminder_date"`
 	IsRead                 bool       `json:"is_read"`
 	ReadAt                 *time.Time `json:"read_at,omitempty"`
+	LedgerID               *int32     `json:"ledger_id,omitempty"`
+}
+
+// RecurringExecutionResponse is the response for a processed occurrence of a recurring transaction
+type RecurringExecutionResponse struct {
+	ScheduledFor time.Time                `json:"scheduled_for"`
+	Status       string                   `json:"status"`
+	ExecutedAt   time.Time                `json:"executed_at"`
+	Ledger       *RecurringLedgerResponse `json:"ledger,omitempty"`
+}
+
+// RecurringLedgerResponse is the response for a ledger booked for a recurring transaction
+type RecurringLedgerResponse struct {
+	ID        int32           `json:"id"`
+	Date      time.Time       `json:"date"`
+	Amount    decimal.Decimal `json:"amount"`
+	Currency  string          `json:"currency"`
+	IsPending bool            `json:"is_pending"`
+	IsVoided  bool            `json:"is_voided"`
 }
 
 // CreateRecurringTransaction creates a new recurring transaction
 func (x *Controller) CreateRecurringTransaction() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		type request struct {
-			AccountID   int32           `json:"account_id"`
-			Name        string          `json:"name"`
-			Type        string          `json:"type"`
-			Amount      decimal.Decimal `json:"amount"`
-			Note        string          `json:"note"`
-			StartDate   time.Time       `json:"start_date"`
-			EndDate     *time.Time      `json:"end_date,omitempty"`
-			RecurType   string          `json:"recur_type"`
-			Frequency   int             `json:"frequency"`
-			DayOfWeek   *int            `json:"day_of_week,omitempty"`
-			DayOfMonth  *int            `json:"day_of_month,omitempty"`
-			MonthOfYear *int            `json:"month_of_year,omitempty"`
+			AccountID       int32           `json:"account_id"`
+			Name            string          `json:"name"`
+			Type            string          `json:"type"`
+			Amount          decimal.Decimal `json:"amount"`
+			Note            string          `json:"note"`
+			StartDate       time.Time       `json:"start_date"`
+			EndDate         *time.Time      `json:"end_date,omitempty"`
+			RecurType       string          `json:"recur_type"`
+			Frequency       int             `json:"frequency"`
+			DayOfWeek       *int            `json:"day_of_week,omitempty"`
+			DayOfMonth      *int            `json:"day_of_month,omitempty"`
+			MonthOfYear     *int            `json:"month_of_year,omitempty"`
+			RRule           string          `json:"rrule,omitempty"`
+			CatchUpPolicy   string          `json:"catch_up_policy,omitempty"`
+			PostMode        string          `json:"post_mode,omitempty"`
+			ReminderOffsets []int           `json:"reminder_offsets,omitempty"`
 		}
 
 		var req request
@@ -73,6 +103,11 @@ func (x *Controller) CreateRecurringTransaction() func(w http.ResponseWriter, r
 				return nil, app.ParamError(errors.New("name and account_id are required"))
 			}
 
+			account, _, err := x.authorizeAccount(ctx, req.AccountID, domain.AccountMemberRoleEditor)
+			if err != nil {
+				return nil, err
+			}
+
 			if req.Amount.LessThanOrEqual(decimal.Zero) {
 				return nil, app.ParamError(errors.New("amount must be greater than zero"))
 			}
@@ -87,23 +122,46 @@ func (x *Controller) CreateRecurringTransaction() func(w http.ResponseWriter, r
 				return nil, app.ParamError(err)
 			}
 
+			var catchUpPolicy domain.CatchUpPolicy
+			if req.CatchUpPolicy != "" {
+				if catchUpPolicy, err = domain.ParseCatchUpPolicy(req.CatchUpPolicy); err != nil {
+					return nil, app.ParamError(err)
+				}
+			}
+
+			var postMode domain.PostMode
+			if req.PostMode != "" {
+				if postMode, err = domain.ParsePostMode(req.PostMode); err != nil {
+					return nil, app.ParamError(err)
+				}
+			}
+
 			serviceReq := domain.CreateRecurringTransactionRequest{
-				UserID:      userID,
-				AccountID:   req.AccountID,
-				Name:        req.Name,
-				Type:        ledgerType,
-				Amount:      req.Amount,
-				Note:        req.Note,
-				StartDate:   req.StartDate,
-				EndDate:     req.EndDate,
-				RecurType:   recurType,
-				Frequency:   req.Frequency,
-				DayOfWeek:   req.DayOfWeek,
-				DayOfMonth:  req.DayOfMonth,
-				MonthOfYear: req.MonthOfYear,
+				UserID:          userID,
+				AccountID:       req.AccountID,
+				Name:            req.Name,
+				Type:            ledgerType,
+				Amount:          req.Amount,
+				Note:            req.Note,
+				StartDate:       req.StartDate,
+				EndDate:         req.EndDate,
+				RecurType:       recurType,
+				Frequency:       req.Frequency,
+				DayOfWeek:       req.DayOfWeek,
+				DayOfMonth:      req.DayOfMonth,
+				MonthOfYear:     req.MonthOfYear,
+				RRule:           req.RRule,
+				CatchUpPolicy:   catchUpPolicy,
+				PostMode:        postMode,
+				ReminderOffsets: req.ReminderOffsets,
+				WorkspaceID:     account.WorkspaceID,
+				Actor:           auditActor(ctx),
 			}
 
 			transaction, err := x.service.CreateRecurringTransaction(r.Context(), serviceReq)
+			if errors.Is(err, bookkeeping.ErrInvalidRecurrenceRule) || errors.Is(err, bookkeeping.ErrInvalidReminderOffsets) {
+				return nil, app.ParamError(err)
+			}
 			if err != nil {
 				slog.Error("Failed to create recurring transaction", "error", err)
 				return nil, err
@@ -115,7 +173,8 @@ func (x *Controller) CreateRecurringTransaction() func(w http.ResponseWriter, r
 	}
 }
 
-// GetRecurringTransactions gets all recurring transactions for the current user
+// GetRecurringTransactions gets all recurring transactions for the current user,
+// or those of the active workspace when one is selected
 func (x *Controller) GetRecurringTransactions() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]RecurringTransactionResponse, error) {
@@ -124,7 +183,15 @@ func (x *Controller) GetRecurringTransactions() func(w http.ResponseWriter, r *h
 				return nil, app.Unauthorized(errors.New("user not authenticated"))
 			}
 
-			transactions, err := x.service.GetRecurringTransactionsByUserID(r.Context(), userID)
+			var (
+				transactions []*domain.RecurringTransaction
+				err          error
+			)
+			if workspaceID := ctx.GetWorkspaceID(); workspaceID != 0 {
+				transactions, err = x.service.GetRecurringTransactionsByWorkspaceID(r.Context(), workspaceID)
+			} else {
+				transactions, err = x.service.GetRecurringTransactionsByUserID(r.Context(), userID)
+			}
 			if err != nil {
 				slog.Error("Failed to get recurring transactions", "error", err)
 				return nil, err
@@ -146,60 +213,96 @@ func (x *Controller) GetRecurringTransaction() func(w http.ResponseWriter, r *ht
 		var id int32
 
 		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*RecurringTransactionResponse, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
-
-			transaction, err := x.service.GetRecurringTransaction(r.Context(), id)
+			transaction, err := x.authorizeRecurringTransaction(ctx, id, domain.WorkspaceRoleViewer)
 			if err != nil {
 				slog.Error("Failed to get recurring transaction", "id", id, "error", err)
 				return nil, err
 			}
 
-			if transaction.UserID != userID {
-				return nil, app.NotFoundError()
-			}
-
 			response := mapToRecurringTransactionResponse(transaction)
 			return &response, nil
 		}).Param("id", &id).Call(nil).ResponseJSON()
 	}
 }
 
-// UpdateRecurringTransaction updates a recurring transaction
-func (x *Controller) UpdateRecurringTransaction() func(w http.ResponseWriter, r *http.Request) {
+// defaultOccurrencePreview is how far ahead occurrences are previewed without an explicit range
+const defaultOccurrencePreview = 90 * 24 * time.Hour
+
+// GetRecurringOccurrences previews the due dates of a recurring transaction between two dates
+func (x *Controller) GetRecurringOccurrences() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		type request struct {
-			id          int32
-			Name        *string          `json:"name,omitempty"`
-			Type        *string          `json:"type,omitempty"`
-			Amount      *decimal.Decimal `json:"amount,omitempty"`
-			Note        *string          `json:"note,omitempty"`
-			EndDate     *time.Time       `json:"end_date,omitempty"`
-			RecurType   *string          `json:"recur_type,omitempty"`
-			Status      *string          `json:"status,omitempty"`
-			Frequency   *int             `json:"frequency,omitempty"`
-			DayOfWeek   *int             `json:"day_of_week,omitempty"`
-			DayOfMonth  *int             `json:"day_of_month,omitempty"`
-			MonthOfYear *int             `json:"month_of_year,omitempty"`
+			id   int32
+			from string
+			to   string
 		}
 
 		var req request
-		engine.Chain(r, w, func(ctx *engine.Context, req request) (*RecurringTransactionResponse, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
+		engine.Chain(r, w, func(ctx *engine.Context, req request) ([]time.Time, error) {
+			transaction, err := x.authorizeRecurringTransaction(ctx, req.id, domain.WorkspaceRoleViewer)
+			if err != nil {
+				return nil, err
+			}
+
+			from := time.Now().UTC().Truncate(24 * time.Hour)
+			if req.from != "" {
+				if from, err = time.Parse(time.DateOnly, req.from); err != nil {
+					return nil, app.ParamError(fmt.Errorf("from must be a date like 2006-01-02: %w", err))
+				}
 			}
 
-			existingTransaction, err := x.service.GetRecurringTransaction(r.Context(), req.id)
+			to := from.Add(defaultOccurrencePreview)
+			if req.to != "" {
+				if to, err = time.Parse(time.DateOnly, req.to); err != nil {
+					return nil, app.ParamError(fmt.Errorf("to must be a date like 2006-01-02: %w", err))
+				}
+			}
+
+			if to.Before(from) {
+				return nil, app.ParamError(errors.New("to must not be before from"))
+			}
+
+			// The whole last day is included
+			occurrences, err := x.service.PreviewOccurrences(transaction, from, to.AddDate(0, 0, 1).Add(-time.Nanosecond))
+			if errors.Is(err, bookkeeping.ErrInvalidRecurrenceRule) {
+				return nil, app.ParamError(err)
+			}
 			if err != nil {
-				slog.Error("Failed to get recurring transaction", "id", req.id, "error", err)
 				return nil, err
 			}
 
-			if existingTransaction.UserID != userID {
-				return nil, app.NotFoundError()
+			return occurrences, nil
+		}).Param("id", &req.id).Query("from", &req.from).Query("to", &req.to).Call(req).ResponseJSON()
+	}
+}
+
+// UpdateRecurringTransaction updates a recurring transaction
+func (x *Controller) UpdateRecurringTransaction() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			id              int32
+			Name            *string          `json:"name,omitempty"`
+			Type            *string          `json:"type,omitempty"`
+			Amount          *decimal.Decimal `json:"amount,omitempty"`
+			Note            *string          `json:"note,omitempty"`
+			EndDate         *time.Time       `json:"end_date,omitempty"`
+			RecurType       *string          `json:"recur_type,omitempty"`
+			Status          *string          `json:"status,omitempty"`
+			Frequency       *int             `json:"frequency,omitempty"`
+			DayOfWeek       *int             `json:"day_of_week,omitempty"`
+			DayOfMonth      *int             `json:"day_of_month,omitempty"`
+			MonthOfYear     *int             `json:"month_of_year,omitempty"`
+			RRule           *string          `json:"rrule,omitempty"`
+			CatchUpPolicy   *string          `json:"catch_up_policy,omitempty"`
+			PostMode        *string          `json:"post_mode,omitempty"`
+			ReminderOffsets []int            `json:"reminder_offsets,omitempty"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*RecurringTransactionResponse, error) {
+			if _, err := x.authorizeRecurringTransaction(ctx, req.id, domain.WorkspaceRoleEditor); err != nil {
+				slog.Error("Failed to get recurring transaction", "id", req.id, "error", err)
+				return nil, err
 			}
 
 			var transactionType *domain.LedgerType
@@ -229,22 +332,48 @@ func (x *Controller) UpdateRecurringTransaction() func(w http.ResponseWriter, r
 				status = &s
 			}
 
+			var catchUpPolicy *domain.CatchUpPolicy
+			if req.CatchUpPolicy != nil {
+				p, err := domain.ParseCatchUpPolicy(*req.CatchUpPolicy)
+				if err != nil {
+					return nil, app.ParamError(err)
+				}
+				catchUpPolicy = &p
+			}
+
+			var postMode *domain.PostMode
+			if req.PostMode != nil {
+				m, err := domain.ParsePostMode(*req.PostMode)
+				if err != nil {
+					return nil, app.ParamError(err)
+				}
+				postMode = &m
+			}
+
 			serviceReq := domain.UpdateRecurringTransactionRequest{
-				ID:          req.id,
-				Name:        req.Name,
-				Type:        transactionType,
-				Amount:      req.Amount,
-				Note:        req.Note,
-				EndDate:     req.EndDate,
-				RecurType:   recurType,
-				Status:      status,
-				Frequency:   req.Frequency,
-				DayOfWeek:   req.DayOfWeek,
-				DayOfMonth:  req.DayOfMonth,
-				MonthOfYear: req.MonthOfYear,
+				ID:              req.id,
+				Name:            req.Name,
+				Type:            transactionType,
+				Amount:          req.Amount,
+				Note:            req.Note,
+				EndDate:         req.EndDate,
+				RecurType:       recurType,
+				Status:          status,
+				Frequency:       req.Frequency,
+				DayOfWeek:       req.DayOfWeek,
+				DayOfMonth:      req.DayOfMonth,
+				MonthOfYear:     req.MonthOfYear,
+				RRule:           req.RRule,
+				CatchUpPolicy:   catchUpPolicy,
+				PostMode:        postMode,
+				ReminderOffsets: req.ReminderOffsets,
+				Actor:           auditActor(ctx),
 			}
 
 			transaction, err := x.service.UpdateRecurringTransaction(r.Context(), serviceReq)
+			if errors.Is(err, bookkeeping.ErrInvalidRecurrenceRule) || errors.Is(err, bookkeeping.ErrInvalidReminderOffsets) {
+				return nil, app.ParamError(err)
+			}
 			if err != nil {
 				slog.Error("Failed to update recurring transaction", "id", req.id, "error", err)
 				return nil, err
@@ -256,27 +385,104 @@ func (x *Controller) UpdateRecurringTransaction() func(w http.ResponseWriter, r
 	}
 }
 
-// DeleteRecurringTransaction deletes a recurring transaction
-func (x *Controller) DeleteRecurringTransaction() func(w http.ResponseWriter, r *http.Request) {
+// GetRecurringTransactionHistory lists the processed occurrences of a recurring transaction with
+// the ledgers booked for them
+func (x *Controller) GetRecurringTransactionHistory() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var id int32
-		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
-			userID := ctx.GetUserID()
-			if userID == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]RecurringExecutionResponse, error) {
+			if _, err := x.authorizeRecurringTransaction(ctx, id, domain.WorkspaceRoleViewer); err != nil {
+				return nil, err
 			}
 
-			existingTransaction, err := x.service.GetRecurringTransaction(r.Context(), id)
+			executions, err := x.service.GetRecurringTransactionHistory(r.Context(), id)
 			if err != nil {
-				slog.Error("Failed to get recurring transaction", "id", id, "error", err)
+				slog.Error("Failed to get recurring transaction history", "id", id, "error", err)
+				return nil, err
+			}
+
+			response := make([]RecurringExecutionResponse, len(executions))
+			for i, execution := range executions {
+				response[i] = mapToRecurringExecutionResponse(execution)
+			}
+
+			return response, nil
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// toNextOccurrenceError maps errors of skipping or postponing the next occurrence
+func toNextOccurrenceError(err error) error {
+	if errors.Is(err, bookkeeping.ErrRecurringTransactionNotActive) || errors.Is(err, bookkeeping.ErrInvalidPostponement) {
+		return app.ParamError(err)
+	}
+
+	return err
+}
+
+// SkipNextOccurrence skips the next occurrence of a recurring transaction
+func (x *Controller) SkipNextOccurrence() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*RecurringTransactionResponse, error) {
+			if _, err := x.authorizeRecurringTransaction(ctx, id, domain.WorkspaceRoleEditor); err != nil {
+				return nil, err
+			}
+
+			transaction, err := x.service.SkipNextOccurrence(r.Context(), id, auditActor(ctx))
+			if err != nil {
+				slog.Error("Failed to skip next occurrence", "id", id, "error", err)
+				return nil, toNextOccurrenceError(err)
+			}
+
+			response := mapToRecurringTransactionResponse(transaction)
+			return &response, nil
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// PostponeNextOccurrence moves the next occurrence of a recurring transaction to a later date
+func (x *Controller) PostponeNextOccurrence() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			id    int32
+			Until string `json:"until"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*RecurringTransactionResponse, error) {
+			if _, err := x.authorizeRecurringTransaction(ctx, req.id, domain.WorkspaceRoleEditor); err != nil {
 				return nil, err
 			}
 
-			if existingTransaction.UserID != userID {
-				return nil, app.NotFoundError()
+			until, err := time.Parse(time.DateOnly, req.Until)
+			if err != nil {
+				return nil, app.ParamError(fmt.Errorf("until must be a date like 2006-01-02: %w", err))
+			}
+
+			transaction, err := x.service.PostponeNextOccurrence(r.Context(), req.id, until, auditActor(ctx))
+			if err != nil {
+				slog.Error("Failed to postpone next occurrence", "id", req.id, "error", err)
+				return nil, toNextOccurrenceError(err)
+			}
+
+			response := mapToRecurringTransactionResponse(transaction)
+			return &response, nil
+		}).Param("id", &req.id).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// DeleteRecurringTransaction deletes a recurring transaction
+func (x *Controller) DeleteRecurringTransaction() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			if _, err := x.authorizeRecurringTransaction(ctx, id, domain.WorkspaceRoleEditor); err != nil {
+				slog.Error("Failed to get recurring transaction", "id", id, "error", err)
+				return nil, err
 			}
 
-			if err := x.service.DeleteRecurringTransaction(r.Context(), id); err != nil {
+			if err := x.service.DeleteRecurringTransaction(r.Context(), id, auditActor(ctx)); err != nil {
 				slog.Error("Failed to delete recurring transaction", "id", id, "error", err)
 				return nil, err
 			}
@@ -350,29 +556,253 @@ func (x *Controller) MarkReminderAsRead() func(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// GetUpcomingReminders gets the unread reminders of the current user due within the next week
+func (x *Controller) GetUpcomingReminders() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]ReminderResponse, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			reminders, err := x.service.GetUpcomingReminders(r.Context(), userID)
+			if err != nil {
+				slog.Error("Failed to get upcoming reminders", "error", err)
+				return nil, err
+			}
+
+			response := make([]ReminderResponse, len(reminders))
+			for i, reminder := range reminders {
+				response[i] = mapToReminderResponse(reminder)
+			}
+
+			return response, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// maxSnoozeDays bounds how long a reminder is snoozed at once
+const maxSnoozeDays = 30
+
+// SnoozeReminder moves an unread reminder a number of days later
+func (x *Controller) SnoozeReminder() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			id   int32
+			Days int `json:"days"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*ReminderResponse, error) {
+			reminder, err := x.service.GetReminderByID(r.Context(), req.id)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, err := x.authorizeRecurringTransaction(ctx, reminder.RecurringTransactionID, domain.WorkspaceRoleViewer); err != nil {
+				return nil, err
+			}
+
+			if req.Days < 1 || req.Days > maxSnoozeDays {
+				return nil, app.ParamError(fmt.Errorf("days must be between 1 and %d", maxSnoozeDays))
+			}
+
+			reminder, err = x.service.SnoozeReminder(r.Context(), req.id, time.Now().AddDate(0, 0, req.Days))
+			if errors.Is(err, bookkeeping.ErrReminderAlreadyRead) || errors.Is(err, bookkeeping.ErrInvalidSnooze) {
+				return nil, app.ParamError(err)
+			}
+			if err != nil {
+				slog.Error("Failed to snooze reminder", "id", req.id, "error", err)
+				return nil, err
+			}
+
+			response := mapToReminderResponse(reminder)
+			return &response, nil
+		}).Param("id", &req.id).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// ReminderSettingsResponse is the response for the reminder preferences of a user
+type ReminderSettingsResponse struct {
+	DefaultOffsets []int `json:"default_offsets"`
+}
+
+// GetReminderSettings gets the reminder preferences of the current user
+func (x *Controller) GetReminderSettings() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*ReminderSettingsResponse, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			settings, err := x.service.GetReminderSettings(r.Context(), userID)
+			if err != nil {
+				slog.Error("Failed to get reminder settings", "error", err)
+				return nil, err
+			}
+
+			return &ReminderSettingsResponse{DefaultOffsets: settings.DefaultOffsets}, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// UpdateReminderSettings sets the reminder preferences of the current user
+func (x *Controller) UpdateReminderSettings() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ReminderSettingsResponse
+		engine.Chain(r, w, func(ctx *engine.Context, req ReminderSettingsResponse) (*ReminderSettingsResponse, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			settings, err := x.service.UpdateReminderSettings(r.Context(), domain.ReminderSettings{
+				UserID:         userID,
+				DefaultOffsets: req.DefaultOffsets,
+			})
+			if errors.Is(err, bookkeeping.ErrInvalidReminderOffsets) {
+				return nil, app.ParamError(err)
+			}
+			if err != nil {
+				slog.Error("Failed to update reminder settings", "error", err)
+				return nil, err
+			}
+
+			return &ReminderSettingsResponse{DefaultOffsets: settings.DefaultOffsets}, nil
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// authorizePendingReminder returns a reminder the authenticated user may confirm or skip
+func (x *Controller) authorizePendingReminder(ctx *engine.Context, id int32) (*domain.Reminder, error) {
+	reminder, err := x.service.GetReminderByID(ctx.Request.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := x.authorizeRecurringTransaction(ctx, reminder.RecurringTransactionID, domain.WorkspaceRoleEditor); err != nil {
+		return nil, err
+	}
+
+	return reminder, nil
+}
+
+// toPendingReminderError maps errors of confirming or skipping a reminder
+func toPendingReminderError(err error) error {
+	if errors.Is(err, bookkeeping.ErrNoPendingLedger) {
+		return app.ParamError(err)
+	}
+
+	return toBooksLockError(err)
+}
+
+// ConfirmReminder posts the pending ledger of a reminder, optionally with an edited amount
+func (x *Controller) ConfirmReminder() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			id     int32
+			Amount *decimal.Decimal `json:"amount,omitempty"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*ReminderResponse, error) {
+			if _, err := x.authorizePendingReminder(ctx, req.id); err != nil {
+				return nil, err
+			}
+
+			if req.Amount != nil && req.Amount.LessThanOrEqual(decimal.Zero) {
+				return nil, app.ParamError(errors.New("amount must be greater than zero"))
+			}
+
+			actor, err := writeActor(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			reminder, err := x.service.ConfirmReminder(r.Context(), req.id, req.Amount, actor)
+			if err != nil {
+				slog.Error("Failed to confirm reminder", "id", req.id, "error", err)
+				return nil, toPendingReminderError(err)
+			}
+
+			response := mapToReminderResponse(reminder)
+			return &response, nil
+		}).Param("id", &req.id).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// SkipReminder discards the pending ledger of a reminder
+func (x *Controller) SkipReminder() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*ReminderResponse, error) {
+			if _, err := x.authorizePendingReminder(ctx, id); err != nil {
+				return nil, err
+			}
+
+			reminder, err := x.service.SkipReminder(r.Context(), id, auditActor(ctx))
+			if err != nil {
+				slog.Error("Failed to skip reminder", "id", id, "error", err)
+				return nil, toPendingReminderError(err)
+			}
+
+			response := mapToReminderResponse(reminder)
+			return &response, nil
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
 // DTO
 func mapToRecurringTransactionResponse(t *domain.RecurringTransaction) RecurringTransactionResponse {
 	return RecurringTransactionResponse{
-		ID:           t.ID,
-		CreatedAt:    t.CreatedAt,
-		UpdatedAt:    t.UpdatedAt,
-		Name:         t.Name,
-		Type:         string(t.Type),
-		Amount:       t.Amount,
-		Note:         t.Note,
-		StartDate:    t.StartDate,
-		EndDate:      t.EndDate,
-		RecurType:    string(t.RecurType),
-		Status:       string(t.Status),
-		Frequency:    t.Frequency,
-		DayOfWeek:    t.DayOfWeek,
-		DayOfMonth:   t.DayOfMonth,
-		MonthOfYear:  t.MonthOfYear,
-		LastExecuted: t.LastExecuted,
-		NextDue:      t.NextDue,
+		ID:              t.ID,
+		CreatedAt:       t.CreatedAt,
+		UpdatedAt:       t.UpdatedAt,
+		Name:            t.Name,
+		Type:            string(t.Type),
+		Amount:          t.Amount,
+		Note:            t.Note,
+		StartDate:       t.StartDate,
+		EndDate:         t.EndDate,
+		RecurType:       string(t.RecurType),
+		Status:          string(t.Status),
+		Frequency:       t.Frequency,
+		DayOfWeek:       t.DayOfWeek,
+		DayOfMonth:      t.DayOfMonth,
+		MonthOfYear:     t.MonthOfYear,
+		RRule:           t.RRule,
+		CatchUpPolicy:   string(t.CatchUpPolicy),
+		PostMode:        string(t.PostMode),
+		ReminderOffsets: t.ReminderOffsets,
+		LastExecuted:    t.LastExecuted,
+		NextDue:         t.NextDue,
+		WorkspaceID:     t.WorkspaceID,
 	}
 }
 
+// DTO
+func mapToRecurringExecutionResponse(e *domain.RecurringTransactionExecution) RecurringExecutionResponse {
+	response := RecurringExecutionResponse{
+		ScheduledFor: e.ScheduledFor,
+		Status:       e.Status.String(),
+		ExecutedAt:   e.CreatedAt,
+	}
+
+	if e.Ledger != nil {
+		response.Ledger = &RecurringLedgerResponse{
+			ID:        e.Ledger.ID,
+			Date:      e.Ledger.Date,
+			Amount:    e.Ledger.Amount,
+			Currency:  e.Ledger.Currency,
+			IsPending: e.Ledger.IsPending,
+			IsVoided:  e.Ledger.IsVoided,
+		}
+	}
+
+	return response
+}
+
 // DTO
 func mapToReminderResponse(r *domain.Reminder) ReminderResponse {
 	return ReminderResponse{
@@ -382,5 +812,6 @@ func mapToReminderResponse(r *domain.Reminder) ReminderResponse {
 		ReminderDate:           r.ReminderDate,
 		IsRead:                 r.IsRead,
 		ReadAt:                 r.ReadAt,
+		LedgerID:               r.LedgerID,
 	}
 }
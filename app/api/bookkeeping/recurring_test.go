@@ -7,6 +7,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -19,6 +24,8 @@ import (
 	"github.com/omegaatt36/bookly/persistence/database"
 	"github.com/omegaatt36/bookly/persistence/repository" // Assuming SQLCRepository is here
 	"github.com/omegaatt36/bookly/persistence/sqlc"
+	service "github.com/omegaatt36/bookly/service/bookkeeping"
+	"github.com/omegaatt36/bookly/service/notify"
 )
 
 type testRecurringSuite struct {
@@ -28,6 +35,7 @@ type testRecurringSuite struct {
 
 	repo      *repository.SQLCRepository
 	finalize  func()
+	mailPath  string
 	userID    int32
 	accountID int32
 }
@@ -37,6 +45,7 @@ func (s *testRecurringSuite) SetupTest() {
 	db := database.GetDB()
 	s.repo = repository.NewSQLCRepository(db)
 	s.router = http.NewServeMux()
+	s.mailPath = filepath.Join(s.T().TempDir(), "notifications.log")
 
 	// Pass all repositories to the controller
 	controller := bookkeeping.NewController(bookkeeping.NewControllerRequest{
@@ -44,6 +53,11 @@ func (s *testRecurringSuite) SetupTest() {
 		LedgerRepository:               s.repo,
 		RecurringTransactionRepository: s.repo,
 		ReminderRepository:             s.repo,
+		NotificationRepository:         s.repo,
+		DigestRepository:               s.repo,
+		UserRepository:                 s.repo,
+		Notifier:                       notify.NewFileNotifier(s.mailPath),
+		WebURL:                         "https://bookly.example.com",
 	})
 
 	// Authentication middleware
@@ -65,10 +79,24 @@ func (s *testRecurringSuite) SetupTest() {
 	registerWithAuth("POST /recurring", http.HandlerFunc(controller.CreateRecurringTransaction()))
 	registerWithAuth("GET /recurring", http.HandlerFunc(controller.GetRecurringTransactions()))
 	registerWithAuth("GET /recurring/{id}", http.HandlerFunc(controller.GetRecurringTransaction()))
+	registerWithAuth("GET /recurring/{id}/occurrences", http.HandlerFunc(controller.GetRecurringOccurrences()))
+	registerWithAuth("GET /recurring/{id}/history", http.HandlerFunc(controller.GetRecurringTransactionHistory()))
+	registerWithAuth("POST /recurring/{id}/skip", http.HandlerFunc(controller.SkipNextOccurrence()))
+	registerWithAuth("POST /recurring/{id}/postpone", http.HandlerFunc(controller.PostponeNextOccurrence()))
 	registerWithAuth("PUT /recurring/{id}", http.HandlerFunc(controller.UpdateRecurringTransaction()))
 	registerWithAuth("DELETE /recurring/{id}", http.HandlerFunc(controller.DeleteRecurringTransaction()))
 	registerWithAuth("GET /recurring/reminders", http.HandlerFunc(controller.GetReminders()))
 	registerWithAuth("POST /recurring/reminders/{id}/read", http.HandlerFunc(controller.MarkReminderAsRead()))
+	registerWithAuth("POST /recurring/reminders/{id}/confirm", http.HandlerFunc(controller.ConfirmReminder()))
+	registerWithAuth("POST /recurring/reminders/{id}/skip", http.HandlerFunc(controller.SkipReminder()))
+	registerWithAuth("POST /recurring/reminders/{id}/snooze", http.HandlerFunc(controller.SnoozeReminder()))
+	registerWithAuth("PUT /reminder-settings", http.HandlerFunc(controller.UpdateReminderSettings()))
+	registerWithAuth("GET /recurring/reminders/{id}/deliveries", http.HandlerFunc(controller.GetReminderDeliveries()))
+	registerWithAuth("GET /notification-channels", http.HandlerFunc(controller.GetNotificationChannels()))
+	registerWithAuth("PUT /notification-channels/{channel}", http.HandlerFunc(controller.UpdateNotificationChannel()))
+	s.router.HandleFunc("POST /notification-channels/email/confirm", controller.ConfirmNotificationChannel())
+	registerWithAuth("GET /digest-settings", http.HandlerFunc(controller.GetDigestSettings()))
+	registerWithAuth("PUT /digest-settings", http.HandlerFunc(controller.UpdateDigestSettings()))
 
 	s.NoError(sqlc.MigrateForTest(context.Background(), db))
 
@@ -119,7 +147,7 @@ func (s *testRecurringSuite) createSeedAccount(userID int32) (int32, error) {
 
 func (s *testRecurringSuite) createSeedRecurringTransaction(accountID int32,
 	recurType domain.RecurrenceType, amount decimal.Decimal) (*domain.RecurringTransaction, error) {
-	// Set start date in the past to ensure NextDue calculation
+	// Set start date in the past so that the transaction is due
 	startDate := time.Now().Add(-24 * time.Hour).Truncate(24 * time.Hour)
 
 	return s.repo.CreateRecurringTransaction(s.T().Context(), domain.CreateRecurringTransactionRequest{
@@ -135,6 +163,7 @@ func (s *testRecurringSuite) createSeedRecurringTransaction(accountID int32,
 		DayOfWeek:   nil,
 		DayOfMonth:  nil,
 		MonthOfYear: nil,
+		NextDue:     startDate,
 	})
 }
 
@@ -159,6 +188,26 @@ type reminderSingleResponse struct {
 	Data bookkeeping.ReminderResponse `json:"data"`
 }
 
+type recurringHistoryResponse struct {
+	Code int                                      `json:"code"`
+	Data []bookkeeping.RecurringExecutionResponse `json:"data"`
+}
+
+type reminderDeliveriesResponse struct {
+	Code int                                    `json:"code"`
+	Data []bookkeeping.ReminderDeliveryResponse `json:"data"`
+}
+
+type digestSettingsResponse struct {
+	Code int                                `json:"code"`
+	Data bookkeeping.DigestSettingsResponse `json:"data"`
+}
+
+type notificationChannelsResponse struct {
+	Code int                                       `json:"code"`
+	Data []bookkeeping.NotificationChannelResponse `json:"data"`
+}
+
 type emptyResponse struct {
 	Code int `json:"code"`
 	Data any `json:"data"`
@@ -200,6 +249,40 @@ func (s *testRecurringSuite) TestCreateRecurringTransaction() {
 	s.Equal(1, *resp.Data.DayOfMonth)
 }
 
+func (s *testRecurringSuite) TestCreateRecurringTransactionWithRule() {
+	startDate := time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC)
+	create := func(recurType, rule string) *httptest.ResponseRecorder {
+		reqBody := fmt.Appendf(nil, `{
+			"account_id": %d,
+			"name": "Rent",
+			"type": "expense",
+			"amount": "800.00",
+			"start_date": "%s",
+			"recur_type": %q,
+			"frequency": 1,
+			"rrule": %q
+		}`, s.accountID, startDate.Format(time.RFC3339), recurType, rule)
+
+		req := httptest.NewRequest(http.MethodPost, "/recurring", bytes.NewBuffer(reqBody))
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := create("custom", "FREQ=MONTHLY;BYDAY=-1FR")
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp recurringSingleResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Equal("FREQ=MONTHLY;BYDAY=-1FR", resp.Data.RRule)
+	// The first occurrence is the last Friday of January, not the start date
+	s.Equal(time.Date(2030, time.January, 25, 9, 0, 0, 0, time.UTC), resp.Data.NextDue.UTC())
+
+	s.Equal(http.StatusBadRequest, create("custom", "FREQ=MONTHLY;BYDAY=XX").Code)
+	s.Equal(http.StatusBadRequest, create("custom", "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30").Code)
+	s.Equal(http.StatusBadRequest, create("monthly", "FREQ=MONTHLY;BYDAY=-1FR").Code)
+}
+
 func (s *testRecurringSuite) TestGetRecurringTransactions() {
 	// Create a recurring transaction first
 	_, err := s.createSeedRecurringTransaction(s.accountID, domain.RecurrenceTypeMonthly, decimal.NewFromFloat(500.00))
@@ -243,6 +326,534 @@ func (s *testRecurringSuite) TestGetRecurringTransaction() {
 	s.Equal("Test Recurring Transaction", resp.Data.Name)
 }
 
+func (s *testRecurringSuite) TestGetRecurringOccurrences() {
+	dayOfMonth := 31
+	startDate := time.Date(2030, time.January, 31, 9, 0, 0, 0, time.UTC)
+	transaction, err := s.repo.CreateRecurringTransaction(s.T().Context(), domain.CreateRecurringTransactionRequest{
+		UserID:     s.userID,
+		AccountID:  s.accountID,
+		Name:       "Month End Transfer",
+		Type:       domain.LedgerTypeExpense,
+		Amount:     decimal.NewFromFloat(200.00),
+		StartDate:  startDate,
+		RecurType:  domain.RecurrenceTypeMonthly,
+		Frequency:  1,
+		DayOfMonth: &dayOfMonth,
+		NextDue:    startDate,
+	})
+	s.NoError(err)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/recurring/%d/occurrences?from=2030-01-01&to=2030-04-30", transaction.ID), nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp struct {
+		Code int         `json:"code"`
+		Data []time.Time `json:"data"`
+	}
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+
+	occurrences := make([]string, len(resp.Data))
+	for i, occurrence := range resp.Data {
+		occurrences[i] = occurrence.UTC().Format(time.DateTime)
+	}
+
+	// Short months fall on their last day without drifting the following months
+	s.Equal([]string{
+		"2030-01-31 09:00:00",
+		"2030-02-28 09:00:00",
+		"2030-03-31 09:00:00",
+		"2030-04-30 09:00:00",
+	}, occurrences)
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/recurring/%d/occurrences?from=2030-04-30&to=2030-01-01", transaction.ID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *testRecurringSuite) TestProcessDueTransactionsCatchUp() {
+	svc := service.NewService(service.NewServiceRequest{
+		AccountRepo:              s.repo,
+		LedgerRepo:               s.repo,
+		RecurringTransactionRepo: s.repo,
+		ReminderRepo:             s.repo,
+	})
+
+	// Three monthly occurrences were missed while crond was down
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), now.Month()-2, 1, 0, 0, 0, 0, time.UTC)
+	create := func(policy domain.CatchUpPolicy) int32 {
+		transaction, err := s.repo.CreateRecurringTransaction(s.T().Context(), domain.CreateRecurringTransactionRequest{
+			UserID:        s.userID,
+			AccountID:     s.accountID,
+			Name:          "Rent " + policy.String(),
+			Type:          domain.LedgerTypeExpense,
+			Amount:        decimal.NewFromFloat(-800.00),
+			StartDate:     startDate,
+			RecurType:     domain.RecurrenceTypeMonthly,
+			Frequency:     1,
+			CatchUpPolicy: policy,
+			NextDue:       startDate,
+		})
+		s.NoError(err)
+		return transaction.ID
+	}
+
+	bookAllID := create(domain.CatchUpPolicyBookAll)
+	latestOnlyID := create(domain.CatchUpPolicyLatestOnly)
+	skipID := create(domain.CatchUpPolicySkip)
+
+	processed, err := svc.ProcessDueTransactions(s.T().Context())
+	s.NoError(err)
+	s.Equal(3, processed)
+	// Processing again books nothing twice
+	processed, err = svc.ProcessDueTransactions(s.T().Context())
+	s.NoError(err)
+	s.Zero(processed)
+
+	ledgers, err := s.repo.GetLedgersByAccountID(s.accountID)
+	s.NoError(err)
+
+	booked := make(map[string][]string)
+	for _, ledger := range ledgers {
+		booked[ledger.Note] = append(booked[ledger.Note], ledger.Date.UTC().Format(time.DateOnly))
+	}
+
+	s.ElementsMatch([]string{
+		startDate.Format(time.DateOnly),
+		startDate.AddDate(0, 1, 0).Format(time.DateOnly),
+		startDate.AddDate(0, 2, 0).Format(time.DateOnly),
+	}, booked[" (Recurring: Rent book_all)"])
+	s.Equal([]string{startDate.AddDate(0, 2, 0).Format(time.DateOnly)}, booked[" (Recurring: Rent latest_only)"])
+	s.Empty(booked[" (Recurring: Rent skip)"])
+
+	for _, id := range []int32{bookAllID, latestOnlyID, skipID} {
+		transaction, err := s.repo.GetRecurringTransactionByID(s.T().Context(), id)
+		s.NoError(err)
+		s.True(transaction.NextDue.After(time.Now()))
+	}
+}
+
+func (s *testRecurringSuite) TestProcessDueTransactionsCatchUpLongBacklog() {
+	svc := service.NewService(service.NewServiceRequest{
+		AccountRepo:              s.repo,
+		LedgerRepo:               s.repo,
+		RecurringTransactionRepo: s.repo,
+		ReminderRepo:             s.repo,
+	})
+
+	// More daily occurrences were missed than are booked in one run
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	startDate := today.AddDate(0, 0, -249)
+	create := func(policy domain.CatchUpPolicy) int32 {
+		transaction, err := s.repo.CreateRecurringTransaction(s.T().Context(), domain.CreateRecurringTransactionRequest{
+			UserID:        s.userID,
+			AccountID:     s.accountID,
+			Name:          "Coffee " + policy.String(),
+			Type:          domain.LedgerTypeExpense,
+			Amount:        decimal.NewFromFloat(-3.00),
+			StartDate:     startDate,
+			RecurType:     domain.RecurrenceTypeDaily,
+			Frequency:     1,
+			CatchUpPolicy: policy,
+			NextDue:       startDate,
+		})
+		s.NoError(err)
+		return transaction.ID
+	}
+
+	bookAllID := create(domain.CatchUpPolicyBookAll)
+	latestOnlyID := create(domain.CatchUpPolicyLatestOnly)
+	skipID := create(domain.CatchUpPolicySkip)
+
+	_, err := svc.ProcessDueTransactions(s.T().Context())
+	s.NoError(err)
+
+	ledgers, err := s.repo.GetLedgersByAccountID(s.accountID)
+	s.NoError(err)
+
+	booked := make(map[string][]string)
+	for _, ledger := range ledgers {
+		booked[ledger.Note] = append(booked[ledger.Note], ledger.Date.UTC().Format(time.DateOnly))
+	}
+
+	// The policies apply to the whole backlog, only book_all is spread over several runs
+	s.Len(booked[" (Recurring: Coffee book_all)"], 100)
+	s.Equal([]string{today.Format(time.DateOnly)}, booked[" (Recurring: Coffee latest_only)"])
+	s.Empty(booked[" (Recurring: Coffee skip)"])
+
+	bookAll, err := s.repo.GetRecurringTransactionByID(s.T().Context(), bookAllID)
+	s.NoError(err)
+	s.Equal(startDate.AddDate(0, 0, 100).Format(time.DateOnly), bookAll.NextDue.UTC().Format(time.DateOnly))
+
+	for _, id := range []int32{latestOnlyID, skipID} {
+		transaction, err := s.repo.GetRecurringTransactionByID(s.T().Context(), id)
+		s.NoError(err)
+		s.Equal(today.AddDate(0, 0, 1).Format(time.DateOnly), transaction.NextDue.UTC().Format(time.DateOnly))
+	}
+}
+
+func (s *testRecurringSuite) TestProcessDueTransactionsConcurrently() {
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), now.Month()-2, 1, 0, 0, 0, 0, time.UTC)
+	transaction, err := s.repo.CreateRecurringTransaction(s.T().Context(), domain.CreateRecurringTransactionRequest{
+		UserID:        s.userID,
+		AccountID:     s.accountID,
+		Name:          "Rent",
+		Type:          domain.LedgerTypeExpense,
+		Amount:        decimal.NewFromFloat(-800.00),
+		StartDate:     startDate,
+		RecurType:     domain.RecurrenceTypeMonthly,
+		Frequency:     1,
+		CatchUpPolicy: domain.CatchUpPolicyBookAll,
+		NextDue:       startDate,
+	})
+	s.NoError(err)
+
+	// Several crond replicas process the same due transactions at once
+	var wg sync.WaitGroup
+	for range 4 {
+		svc := service.NewService(service.NewServiceRequest{
+			AccountRepo:              s.repo,
+			LedgerRepo:               s.repo,
+			RecurringTransactionRepo: s.repo,
+			ReminderRepo:             s.repo,
+		})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.ProcessDueTransactions(s.T().Context())
+			s.NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	ledgers, err := s.repo.GetLedgersByAccountID(s.accountID)
+	s.NoError(err)
+	s.Len(ledgers, 3)
+
+	account, err := s.repo.GetAccountByID(s.accountID)
+	s.NoError(err)
+	s.True(decimal.NewFromFloat(-2400.00).Equal(account.Balance))
+
+	// Replaying an occurrence that was already executed books nothing, even when the transaction
+	// is due at it again
+	_, err = s.repo.UpdateRecurringTransaction(s.T().Context(), domain.UpdateRecurringTransactionRequest{
+		ID:      transaction.ID,
+		NextDue: &startDate,
+	})
+	s.NoError(err)
+
+	_, err = s.repo.ExecuteRecurringOccurrence(s.T().Context(), domain.ExecuteRecurringOccurrenceRequest{
+		ID:           transaction.ID,
+		ScheduledFor: startDate,
+		Ledger: &domain.CreateLedgerRequest{
+			AccountID: s.accountID,
+			Date:      startDate,
+			Type:      domain.LedgerTypeExpense,
+			Amount:    decimal.NewFromFloat(-800.00),
+		},
+		NextDue: startDate.AddDate(0, 1, 0),
+		Status:  domain.RecurrenceStatusActive,
+	})
+	s.ErrorIs(err, domain.ErrNotFound)
+
+	ledgers, err = s.repo.GetLedgersByAccountID(s.accountID)
+	s.NoError(err)
+	s.Len(ledgers, 3)
+}
+
+func (s *testRecurringSuite) TestConfirmPendingOccurrences() {
+	svc := service.NewService(service.NewServiceRequest{
+		AccountRepo:              s.repo,
+		LedgerRepo:               s.repo,
+		RecurringTransactionRepo: s.repo,
+		ReminderRepo:             s.repo,
+	})
+
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	create := func(name string) {
+		_, err := s.repo.CreateRecurringTransaction(s.T().Context(), domain.CreateRecurringTransactionRequest{
+			UserID:        s.userID,
+			AccountID:     s.accountID,
+			Name:          name,
+			Type:          domain.LedgerTypeExpense,
+			Amount:        decimal.NewFromFloat(80.00),
+			StartDate:     startDate,
+			RecurType:     domain.RecurrenceTypeMonthly,
+			Frequency:     1,
+			CatchUpPolicy: domain.CatchUpPolicyBookAll,
+			PostMode:      domain.PostModeConfirm,
+			NextDue:       startDate,
+		})
+		s.NoError(err)
+	}
+	create("Electricity")
+	create("Credit Card")
+
+	_, err := svc.ProcessDueTransactions(s.T().Context())
+	s.NoError(err)
+
+	// Occurrences are drafted without touching the balance
+	ledgers, err := s.repo.GetLedgersByAccountID(s.accountID)
+	s.NoError(err)
+	s.Len(ledgers, 2)
+	for _, ledger := range ledgers {
+		s.True(ledger.IsPending)
+	}
+
+	account, err := s.repo.GetAccountByID(s.accountID)
+	s.NoError(err)
+	s.True(account.Balance.IsZero())
+
+	req := httptest.NewRequest(http.MethodGet, "/recurring/reminders", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var reminders reminderListResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&reminders))
+
+	// Reminders of the next due dates carry no ledger
+	var pending []bookkeeping.ReminderResponse
+	for _, reminder := range reminders.Data {
+		if reminder.LedgerID != nil {
+			pending = append(pending, reminder)
+		}
+	}
+	s.Require().Len(pending, 2)
+
+	// The first occurrence is confirmed with the amount of the bill
+	confirmed, skipped := pending[0], pending[1]
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/recurring/reminders/%d/confirm", confirmed.ID),
+		bytes.NewBufferString(`{"amount": "84.20"}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp reminderSingleResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.True(resp.Data.IsRead)
+
+	ledger, err := s.repo.GetLedgerByID(*confirmed.LedgerID)
+	s.NoError(err)
+	s.False(ledger.IsPending)
+	s.True(decimal.NewFromFloat(84.20).Equal(ledger.Amount))
+
+	// The second one is skipped
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/recurring/reminders/%d/skip", skipped.ID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	_, err = s.repo.GetLedgerByID(*skipped.LedgerID)
+	s.ErrorIs(err, domain.ErrNotFound)
+
+	account, err = s.repo.GetAccountByID(s.accountID)
+	s.NoError(err)
+	s.True(decimal.NewFromFloat(84.20).Equal(account.Balance))
+
+	// A reminder is only confirmed once
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/recurring/reminders/%d/confirm", confirmed.ID),
+		bytes.NewBufferString(`{}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *testRecurringSuite) TestSkipAndPostponeNextOccurrence() {
+	svc := service.NewService(service.NewServiceRequest{
+		AccountRepo:              s.repo,
+		LedgerRepo:               s.repo,
+		RecurringTransactionRepo: s.repo,
+		ReminderRepo:             s.repo,
+	})
+
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), now.Month()-2, 1, 0, 0, 0, 0, time.UTC)
+	transaction, err := s.repo.CreateRecurringTransaction(s.T().Context(), domain.CreateRecurringTransactionRequest{
+		UserID:        s.userID,
+		AccountID:     s.accountID,
+		Name:          "Rent",
+		Type:          domain.LedgerTypeExpense,
+		Amount:        decimal.NewFromFloat(-800.00),
+		StartDate:     startDate,
+		RecurType:     domain.RecurrenceTypeMonthly,
+		Frequency:     1,
+		CatchUpPolicy: domain.CatchUpPolicyBookAll,
+		NextDue:       startDate,
+	})
+	s.NoError(err)
+
+	_, err = svc.ProcessDueTransactions(s.T().Context())
+	s.NoError(err)
+
+	// Booked ledgers are linked to the recurring transaction
+	ledgers, err := s.repo.GetLedgersByAccountID(s.accountID)
+	s.NoError(err)
+	s.Len(ledgers, 3)
+	for _, ledger := range ledgers {
+		s.Require().NotNil(ledger.RecurringTransactionID)
+		s.Equal(transaction.ID, *ledger.RecurringTransactionID)
+	}
+
+	nextDue := startDate.AddDate(0, 3, 0)
+
+	// The next occurrence is skipped, leaving the rest of the series untouched
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/recurring/%d/skip", transaction.ID), nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp recurringSingleResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.True(nextDue.AddDate(0, 1, 0).Equal(resp.Data.NextDue))
+	s.Equal(domain.RecurrenceStatusActive.String(), resp.Data.Status)
+
+	// An occurrence is not postponed past the following one
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/recurring/%d/postpone", transaction.ID),
+		bytes.NewBufferString(fmt.Sprintf(`{"until": %q}`, nextDue.AddDate(0, 2, 0).Format(time.DateOnly))))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/recurring/%d/postpone", transaction.ID),
+		bytes.NewBufferString(fmt.Sprintf(`{"until": %q}`, nextDue.AddDate(0, 1, 3).Format(time.DateOnly))))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.True(nextDue.AddDate(0, 1, 3).Equal(resp.Data.NextDue))
+
+	// Updating the schedule keeps the postponed occurrence pending instead of rescheduling from now
+	req = httptest.NewRequest(http.MethodPut, fmt.Sprintf("/recurring/%d", transaction.ID),
+		bytes.NewBufferString(fmt.Sprintf(`{"end_date": %q}`, nextDue.AddDate(1, 0, 0).Format(time.RFC3339))))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.True(nextDue.AddDate(0, 1, 3).Equal(resp.Data.NextDue))
+
+	req = httptest.NewRequest(http.MethodPut, fmt.Sprintf("/recurring/%d", transaction.ID),
+		bytes.NewBufferString(`{"day_of_month": 10}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.True(nextDue.AddDate(0, 1, 9).Equal(resp.Data.NextDue))
+
+	// The history lists the booked occurrences and the skipped one, latest first
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/recurring/%d/history", transaction.ID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var history recurringHistoryResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&history))
+	s.Require().Len(history.Data, 4)
+
+	s.True(nextDue.Equal(history.Data[0].ScheduledFor))
+	s.Equal(domain.RecurringExecutionStatusSkipped.String(), history.Data[0].Status)
+	s.Nil(history.Data[0].Ledger)
+
+	for i, execution := range history.Data[1:] {
+		s.True(startDate.AddDate(0, 2-i, 0).Equal(execution.ScheduledFor))
+		s.Equal(domain.RecurringExecutionStatusBooked.String(), execution.Status)
+		s.Require().NotNil(execution.Ledger)
+		s.True(decimal.NewFromFloat(-800.00).Equal(execution.Ledger.Amount))
+	}
+}
+
+func (s *testRecurringSuite) TestReminderOffsets() {
+	// Reminders default to a week and a day before
+	req := httptest.NewRequest(http.MethodPut, "/reminder-settings", bytes.NewBufferString(`{"default_offsets": [1, 7]}`))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	startDate := time.Now().UTC().AddDate(0, 0, 20).Truncate(time.Second)
+	reqBody := fmt.Appendf(nil, `{
+		"account_id": %d,
+		"name": "Insurance",
+		"type": "expense",
+		"amount": "120.00",
+		"start_date": "%s",
+		"recur_type": "monthly",
+		"frequency": 1
+	}`, s.accountID, startDate.Format(time.RFC3339))
+
+	req = httptest.NewRequest(http.MethodPost, "/recurring", bytes.NewBuffer(reqBody))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	var resp recurringSingleResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Nil(resp.Data.ReminderOffsets)
+	nextDue := resp.Data.NextDue
+
+	reminderDates := func() []time.Time {
+		reminders, err := s.repo.GetRemindersByRecurringTransactionID(s.T().Context(), resp.Data.ID)
+		s.NoError(err)
+
+		dates := make([]time.Time, len(reminders))
+		for i, reminder := range reminders {
+			dates[i] = reminder.ReminderDate.UTC()
+		}
+		return dates
+	}
+	s.Equal([]time.Time{nextDue.AddDate(0, 0, -7).UTC(), nextDue.AddDate(0, 0, -1).UTC()}, reminderDates())
+
+	// The series sets its own lead time, replacing the upcoming reminders
+	req = httptest.NewRequest(http.MethodPut, fmt.Sprintf("/recurring/%d", resp.Data.ID),
+		bytes.NewBufferString(`{"reminder_offsets": [10]}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Equal([]int{10}, resp.Data.ReminderOffsets)
+	s.Equal([]time.Time{nextDue.AddDate(0, 0, -10).UTC()}, reminderDates())
+
+	req = httptest.NewRequest(http.MethodPut, fmt.Sprintf("/recurring/%d", resp.Data.ID),
+		bytes.NewBufferString(`{"reminder_offsets": [3, 3]}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	// A due reminder is snoozed out of the active ones
+	reminder, err := s.repo.CreateReminder(s.T().Context(), resp.Data.ID, time.Now().Add(-time.Minute))
+	s.NoError(err)
+
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/recurring/reminders/%d/snooze", reminder.ID),
+		bytes.NewBufferString(`{"days": 0}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/recurring/reminders/%d/snooze", reminder.ID),
+		bytes.NewBufferString(`{"days": 2}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var snoozed reminderSingleResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&snoozed))
+	s.True(snoozed.Data.ReminderDate.After(time.Now().AddDate(0, 0, 1)))
+
+	active, err := s.repo.GetActiveRemindersByUserID(s.T().Context(), s.userID, time.Now())
+	s.NoError(err)
+	s.Empty(active)
+}
+
 func (s *testRecurringSuite) TestUpdateRecurringTransaction() {
 	// Create a recurring transaction first
 	transaction, err := s.createSeedRecurringTransaction(s.accountID, domain.RecurrenceTypeDaily, decimal.NewFromFloat(10.00))
@@ -388,3 +999,296 @@ func (s *testRecurringSuite) TestMarkReminderAsRead() {
 	s.True(updatedReminder.IsRead)
 	s.NotNil(updatedReminder.ReadAt)
 }
+
+func (s *testRecurringSuite) TestEmailNotificationChannelNeedsConfirmation() {
+	put := func(channel, body string) {
+		req := httptest.NewRequest(http.MethodPut, "/notification-channels/"+channel, bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		s.Require().Equal(http.StatusOK, w.Code, channel)
+	}
+	confirmed := func() map[string]bool {
+		req := httptest.NewRequest(http.MethodGet, "/notification-channels", nil)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		s.Require().Equal(http.StatusOK, w.Code)
+
+		var resp notificationChannelsResponse
+		s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+
+		byChannel := make(map[string]bool)
+		for _, channel := range resp.Data {
+			byChannel[channel.Channel] = channel.Confirmed
+		}
+		return byChannel
+	}
+	confirm := func(token string) int {
+		req := httptest.NewRequest(http.MethodPost, "/notification-channels/email/confirm", bytes.NewBufferString(fmt.Sprintf(`{"token": %q}`, token)))
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+	tokenPattern := regexp.MustCompile(`confirm-notification-email\?token=(\S+)`)
+	tokens := func() []string {
+		content, err := os.ReadFile(s.mailPath)
+		s.Require().NoError(err)
+
+		var tokens []string
+		for _, match := range tokenPattern.FindAllStringSubmatch(string(content), -1) {
+			tokens = append(tokens, match[1])
+		}
+		return tokens
+	}
+
+	put("webhook", `{"target": "https://hooks.example.com/bookly"}`)
+	put("email", `{"target": "someone@example.com"}`)
+	s.Equal(map[string]bool{"webhook": true, "email": false}, confirmed())
+	s.Require().Len(tokens(), 1)
+
+	// Reminders are not delivered to an unconfirmed email target
+	transaction, err := s.createSeedRecurringTransaction(s.accountID, domain.RecurrenceTypeMonthly, decimal.NewFromFloat(45.00))
+	s.Require().NoError(err)
+	_, err = s.repo.CreateReminder(s.T().Context(), transaction.ID, time.Now().Add(-time.Minute))
+	s.Require().NoError(err)
+	queued, err := s.repo.QueueReminderDeliveries(s.T().Context(), time.Now().Add(-time.Hour), time.Now())
+	s.NoError(err)
+	s.Require().Len(queued, 1)
+	s.Equal(domain.NotificationChannelWebhook, queued[0].Channel)
+
+	s.Equal(http.StatusBadRequest, confirm("not-a-token"))
+	s.Equal(http.StatusOK, confirm(tokens()[0]))
+	s.Equal(http.StatusBadRequest, confirm(tokens()[0]))
+	s.Equal(map[string]bool{"webhook": true, "email": true}, confirmed())
+
+	queued, err = s.repo.QueueReminderDeliveries(s.T().Context(), time.Now().Add(-time.Hour), time.Now())
+	s.NoError(err)
+	s.Require().Len(queued, 1)
+	s.Equal(domain.NotificationChannelEmail, queued[0].Channel)
+
+	// Disabling a confirmed target keeps it confirmed, changing it needs a new confirmation
+	put("email", `{"target": "someone@example.com", "enabled": false}`)
+	s.True(confirmed()["email"])
+	s.Len(tokens(), 1)
+
+	put("email", `{"target": "someone.else@example.com"}`)
+	s.False(confirmed()["email"])
+	s.Len(tokens(), 2)
+}
+
+func (s *testRecurringSuite) TestDispatchDueReminders() {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The webhook is down on the first attempt
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var payload map[string]string
+		s.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		s.Contains(payload["subject"], "Gym")
+	}))
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPut, "/notification-channels/webhook", bytes.NewBufferString(`{"target": "ftp://example.com"}`))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodPut, "/notification-channels/pigeon", bytes.NewBufferString(`{"target": "home"}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	for channel, body := range map[string]string{
+		"webhook": fmt.Sprintf(`{"target": %q}`, server.URL),
+		"chat":    `{"target": "42"}`,
+		"email":   `{"target": "me@example.com", "enabled": false}`,
+	} {
+		req = httptest.NewRequest(http.MethodPut, "/notification-channels/"+channel, bytes.NewBufferString(body))
+		w = httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		s.Require().Equal(http.StatusOK, w.Code, channel)
+	}
+
+	transaction, err := s.createSeedRecurringTransaction(s.accountID, domain.RecurrenceTypeMonthly, decimal.NewFromFloat(45.00))
+	s.Require().NoError(err)
+	name := "Gym"
+	_, err = s.repo.UpdateRecurringTransaction(s.T().Context(), domain.UpdateRecurringTransactionRequest{
+		ID:   transaction.ID,
+		Name: &name,
+	})
+	s.Require().NoError(err)
+
+	reminder, err := s.repo.CreateReminder(s.T().Context(), transaction.ID, time.Now().Add(-time.Minute))
+	s.Require().NoError(err)
+
+	// The chat channel is not configured, and disabled channels are not delivered to
+	svc := service.NewService(service.NewServiceRequest{
+		RecurringTransactionRepo: s.repo,
+		ReminderRepo:             s.repo,
+		NotificationRepo:         s.repo,
+		ChannelNotifiers: map[domain.NotificationChannel]domain.Notifier{
+			domain.NotificationChannelWebhook: notify.NewWebhookNotifier(notify.WebhookOption{AllowPrivateNetworks: true}),
+		},
+		DeliveryRetry: service.DeliveryRetry{MaxAttempts: 3, Backoff: time.Millisecond},
+	})
+
+	deliveries := func() map[string]bookkeeping.ReminderDeliveryResponse {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/recurring/reminders/%d/deliveries", reminder.ID), nil)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		s.Require().Equal(http.StatusOK, w.Code)
+
+		var resp reminderDeliveriesResponse
+		s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+
+		byChannel := make(map[string]bookkeeping.ReminderDeliveryResponse)
+		for _, delivery := range resp.Data {
+			byChannel[delivery.Channel] = delivery
+		}
+		return byChannel
+	}
+
+	attempts, err := svc.DispatchDueReminders(s.T().Context())
+	s.NoError(err)
+	s.Equal(2, attempts)
+
+	byChannel := deliveries()
+	s.Len(byChannel, 2)
+	s.Equal(domain.ReminderDeliveryStatusPending.String(), byChannel["webhook"].Status)
+	s.Equal(1, byChannel["webhook"].Attempts)
+	s.Contains(byChannel["webhook"].LastError, "503")
+	s.Equal(domain.ReminderDeliveryStatusFailed.String(), byChannel["chat"].Status)
+
+	// The webhook is retried once its backoff passed, and delivered once only
+	time.Sleep(10 * time.Millisecond)
+	attempts, err = svc.DispatchDueReminders(s.T().Context())
+	s.NoError(err)
+	s.Equal(1, attempts)
+	attempts, err = svc.DispatchDueReminders(s.T().Context())
+	s.NoError(err)
+	s.Zero(attempts)
+
+	byChannel = deliveries()
+	s.Len(byChannel, 2)
+	s.Equal(domain.ReminderDeliveryStatusSent.String(), byChannel["webhook"].Status)
+	s.Equal(2, byChannel["webhook"].Attempts)
+	s.NotNil(byChannel["webhook"].DeliveredAt)
+	s.Equal(int32(2), calls.Load())
+
+	// A snoozed reminder is delivered again once it comes due
+	_, err = s.repo.SnoozeReminder(s.T().Context(), reminder.ID, time.Now().Add(-time.Second))
+	s.NoError(err)
+	attempts, err = svc.DispatchDueReminders(s.T().Context())
+	s.NoError(err)
+	s.Equal(2, attempts)
+	s.Equal(int32(3), calls.Load())
+}
+
+func (s *testRecurringSuite) TestSendDigests() {
+	digestSettings := func() bookkeeping.DigestSettingsResponse {
+		req := httptest.NewRequest(http.MethodGet, "/digest-settings", nil)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		s.Require().Equal(http.StatusOK, w.Code)
+
+		var resp digestSettingsResponse
+		s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+		return resp.Data
+	}
+
+	// Users are opted out until they enable digests
+	settings := digestSettings()
+	s.False(settings.Enabled)
+	s.Equal(domain.DigestFrequencyWeekly.String(), settings.Frequency)
+	s.Equal(int(time.Monday), settings.Weekday)
+
+	for _, body := range []string{
+		`{"frequency": "daily"}`,
+		`{"weekday": 7}`,
+		`{"day_of_month": 31}`,
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/digest-settings", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		s.Equal(http.StatusBadRequest, w.Code, body)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/digest-settings",
+		bytes.NewBufferString(fmt.Sprintf(`{"enabled": true, "weekday": %d}`, time.Now().Weekday())))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodPut, "/notification-channels/email", bytes.NewBufferString(`{"target": "me@example.com"}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	// Expenses of the last week are reported, the ones of today belong to the next digest
+	for _, expense := range []struct {
+		date     time.Time
+		category string
+		amount   int64
+	}{
+		{date: time.Now().AddDate(0, 0, -2), category: "Food", amount: -30},
+		{date: time.Now().AddDate(0, 0, -3), category: "Food", amount: -12},
+		{date: time.Now().AddDate(0, 0, -3), amount: -5},
+		{date: time.Now(), category: "Travel", amount: -100},
+	} {
+		_, err := s.repo.CreateLedger(domain.CreateLedgerRequest{
+			AccountID: s.accountID,
+			Date:      expense.date,
+			Type:      domain.LedgerTypeExpense,
+			Amount:    decimal.NewFromInt(expense.amount),
+			Category:  expense.category,
+		})
+		s.Require().NoError(err)
+	}
+
+	transaction, err := s.createSeedRecurringTransaction(s.accountID, domain.RecurrenceTypeDaily, decimal.NewFromFloat(-45.00))
+	s.Require().NoError(err)
+	name := "Gym"
+	_, err = s.repo.UpdateRecurringTransaction(s.T().Context(), domain.UpdateRecurringTransactionRequest{
+		ID:   transaction.ID,
+		Name: &name,
+	})
+	s.Require().NoError(err)
+
+	path := filepath.Join(s.T().TempDir(), "digests.log")
+	svc := service.NewService(service.NewServiceRequest{
+		AccountRepo:              s.repo,
+		RecurringTransactionRepo: s.repo,
+		UserRepo:                 s.repo,
+		NotificationRepo:         s.repo,
+		DigestRepo:               s.repo,
+		Mailer:                   notify.NewFileNotifier(path),
+	})
+
+	sent, err := svc.SendDigests(s.T().Context())
+	s.NoError(err)
+	s.Equal(1, sent)
+
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Contains(string(content), "To: me@example.com")
+	s.Contains(string(content), "Food: 42.00 USD (2 ledgers)")
+	s.Contains(string(content), "Uncategorized: 5.00 USD (1 ledger)")
+	s.Contains(string(content), "Total: 47.00 USD")
+	s.NotContains(string(content), "Travel")
+	s.Contains(string(content), "Gym: 45.00 USD")
+	s.Contains(string(content), "Recurring Test Account")
+
+	// A digest is sent once per period
+	sent, err = svc.SendDigests(s.T().Context())
+	s.NoError(err)
+	s.Zero(sent)
+	s.NotNil(digestSettings().LastPeriodEnd)
+
+	req = httptest.NewRequest(http.MethodPut, "/digest-settings", bytes.NewBufferString(`{"enabled": false}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.False(digestSettings().Enabled)
+}
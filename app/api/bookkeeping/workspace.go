@@ -0,0 +1,291 @@
+package bookkeeping
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/bookkeeping"
+)
+
+type jsonWorkspace struct {
+	ID        int32  `json:"id"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Role      string `json:"role,omitempty"`
+}
+
+func (w *jsonWorkspace) fromDomain(workspace *domain.Workspace) {
+	w.ID = workspace.ID
+	w.CreatedAt = workspace.CreatedAt.Format(time.RFC3339)
+	w.UpdatedAt = workspace.UpdatedAt.Format(time.RFC3339)
+	w.Name = workspace.Name
+	w.Type = workspace.Type.String()
+}
+
+type jsonWorkspaceMember struct {
+	WorkspaceID int32  `json:"workspace_id"`
+	UserID      int32  `json:"user_id"`
+	Role        string `json:"role"`
+	CreatedAt   string `json:"created_at"`
+}
+
+func (m *jsonWorkspaceMember) fromDomain(member *domain.WorkspaceMember) {
+	m.WorkspaceID = member.WorkspaceID
+	m.UserID = member.UserID
+	m.Role = member.Role.String()
+	m.CreatedAt = member.CreatedAt.Format(time.RFC3339)
+}
+
+func toWorkspaceParamError(err error) error {
+	if errors.Is(err, bookkeeping.ErrInvalidWorkspace) {
+		return app.ParamError(err)
+	}
+
+	return err
+}
+
+// CreateWorkspace handles the creation of a workspace owned by the current user
+func (x *Controller) CreateWorkspace() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*jsonWorkspace, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			workspaceType, err := domain.ParseWorkspaceType(req.Type)
+			if err != nil {
+				return nil, app.ParamError(err)
+			}
+
+			workspace, err := x.service.CreateWorkspace(domain.CreateWorkspaceRequest{
+				OwnerID: userID,
+				Name:    req.Name,
+				Type:    workspaceType,
+			})
+			if err != nil {
+				return nil, toWorkspaceParamError(err)
+			}
+
+			var jsonWorkspace jsonWorkspace
+			jsonWorkspace.fromDomain(workspace)
+			jsonWorkspace.Role = domain.WorkspaceRoleOwner.String()
+
+			return &jsonWorkspace, nil
+		}).BindJSON(&req).Call(req).ResponseCreated()
+	}
+}
+
+// GetWorkspaces handles the retrieval of the workspaces the current user belongs to
+func (x *Controller) GetWorkspaces() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonWorkspace, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			workspaces, err := x.service.GetWorkspacesByUserID(userID)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonWorkspaces := make([]jsonWorkspace, len(workspaces))
+			for index, workspace := range workspaces {
+				jsonWorkspaces[index].fromDomain(workspace.Workspace)
+				jsonWorkspaces[index].Role = workspace.Role.String()
+			}
+
+			return jsonWorkspaces, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// GetWorkspaceByID handles the retrieval of a workspace the current user belongs to
+func (x *Controller) GetWorkspaceByID() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*jsonWorkspace, error) {
+			workspace, role, err := x.authorizeWorkspace(ctx, id, domain.WorkspaceRoleViewer)
+			if err != nil {
+				return nil, err
+			}
+
+			var jsonWorkspace jsonWorkspace
+			jsonWorkspace.fromDomain(workspace)
+			jsonWorkspace.Role = role.String()
+
+			return &jsonWorkspace, nil
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// UpdateWorkspace handles renaming or changing the type of a workspace
+func (x *Controller) UpdateWorkspace() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			id   int32
+			Name *string `json:"name"`
+			Type *string `json:"type"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*jsonWorkspace, error) {
+			if _, _, err := x.authorizeWorkspace(ctx, req.id, domain.WorkspaceRoleOwner); err != nil {
+				return nil, err
+			}
+
+			var workspaceType *domain.WorkspaceType
+			if req.Type != nil {
+				t, err := domain.ParseWorkspaceType(*req.Type)
+				if err != nil {
+					return nil, app.ParamError(err)
+				}
+				workspaceType = &t
+			}
+
+			workspace, err := x.service.UpdateWorkspace(domain.UpdateWorkspaceRequest{
+				ID:   req.id,
+				Name: req.Name,
+				Type: workspaceType,
+			})
+			if err != nil {
+				return nil, toWorkspaceParamError(err)
+			}
+
+			var jsonWorkspace jsonWorkspace
+			jsonWorkspace.fromDomain(workspace)
+			jsonWorkspace.Role = domain.WorkspaceRoleOwner.String()
+
+			return &jsonWorkspace, nil
+		}).Param("id", &req.id).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// DeleteWorkspace handles the deletion of a workspace
+func (x *Controller) DeleteWorkspace() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			if _, _, err := x.authorizeWorkspace(ctx, id, domain.WorkspaceRoleOwner); err != nil {
+				return nil, err
+			}
+
+			return nil, x.service.DeleteWorkspace(id)
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// GetWorkspaceMembers handles the retrieval of the members of a workspace
+func (x *Controller) GetWorkspaceMembers() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonWorkspaceMember, error) {
+			if _, _, err := x.authorizeWorkspace(ctx, id, domain.WorkspaceRoleViewer); err != nil {
+				return nil, err
+			}
+
+			members, err := x.service.GetWorkspaceMembers(id)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonMembers := make([]jsonWorkspaceMember, len(members))
+			for index, member := range members {
+				jsonMembers[index].fromDomain(member)
+			}
+
+			return jsonMembers, nil
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// AddWorkspaceMember handles adding a registered user to a workspace by email
+func (x *Controller) AddWorkspaceMember() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			workspaceID int32
+			Email       string `json:"email"`
+			Role        string `json:"role"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*jsonWorkspaceMember, error) {
+			if _, _, err := x.authorizeWorkspace(ctx, req.workspaceID, domain.WorkspaceRoleOwner); err != nil {
+				return nil, err
+			}
+
+			role, err := domain.ParseWorkspaceRole(req.Role)
+			if err != nil {
+				return nil, app.ParamError(err)
+			}
+
+			member, err := x.service.AddWorkspaceMember(req.workspaceID, req.Email, role)
+			if err != nil {
+				return nil, toWorkspaceParamError(err)
+			}
+
+			var jsonMember jsonWorkspaceMember
+			jsonMember.fromDomain(member)
+
+			return &jsonMember, nil
+		}).Param("id", &req.workspaceID).BindJSON(&req).Call(req).ResponseCreated()
+	}
+}
+
+// UpdateWorkspaceMember handles changing the role of a workspace member
+func (x *Controller) UpdateWorkspaceMember() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			workspaceID int32
+			userID      int32
+			Role        string `json:"role"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
+			if _, _, err := x.authorizeWorkspace(ctx, req.workspaceID, domain.WorkspaceRoleOwner); err != nil {
+				return nil, err
+			}
+
+			role, err := domain.ParseWorkspaceRole(req.Role)
+			if err != nil {
+				return nil, app.ParamError(err)
+			}
+
+			return nil, toWorkspaceParamError(x.service.UpdateWorkspaceMemberRole(req.workspaceID, req.userID, role))
+		}).Param("id", &req.workspaceID).Param("user_id", &req.userID).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// RemoveWorkspaceMember handles removing a member from a workspace.
+// Members may also remove themselves to leave a workspace.
+func (x *Controller) RemoveWorkspaceMember() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var workspaceID, userID int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			required := domain.WorkspaceRoleOwner
+			if userID == ctx.GetUserID() {
+				required = domain.WorkspaceRoleViewer
+			}
+
+			if _, _, err := x.authorizeWorkspace(ctx, workspaceID, required); err != nil {
+				return nil, err
+			}
+
+			return nil, toWorkspaceParamError(x.service.RemoveWorkspaceMember(workspaceID, userID))
+		}).Param("id", &workspaceID).Param("user_id", &userID).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
@@ -0,0 +1,300 @@
+package bookkeeping_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/omegaatt36/bookly/app/api/bookkeeping"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/database"
+	"github.com/omegaatt36/bookly/persistence/repository"
+	"github.com/omegaatt36/bookly/persistence/sqlc"
+)
+
+const workspaceMemberEmail = "member@example.com"
+
+type testWorkspaceSuite struct {
+	suite.Suite
+
+	router *http.ServeMux
+
+	repo        *repository.SQLCRepository
+	finalize    func()
+	userID      int32
+	workspaceID int32
+	ownerID     int32
+	memberID    int32
+}
+
+func (s *testWorkspaceSuite) SetupTest() {
+	s.finalize = database.TestingInitialize(database.PostgresOpt)
+	db := database.GetDB()
+	s.repo = repository.NewSQLCRepository(db)
+	s.router = http.NewServeMux()
+	controller := bookkeeping.NewController(bookkeeping.NewControllerRequest{
+		AccountRepository:              s.repo,
+		LedgerRepository:               s.repo,
+		AccountMemberRepository:        s.repo,
+		WorkspaceRepository:            s.repo,
+		RecurringTransactionRepository: s.repo,
+		ReminderRepository:             s.repo,
+	})
+
+	authMiddleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := engine.WithUserID(r.Context(), s.userID)
+			if s.workspaceID != 0 {
+				member, err := s.repo.GetWorkspaceMember(s.workspaceID, s.userID)
+				s.Require().NoError(err)
+				ctx = engine.WithWorkspace(ctx, member.WorkspaceID, member.Role)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	registerWithAuth := func(pattern string, handler http.Handler) {
+		s.router.Handle(pattern, authMiddleware(handler))
+	}
+
+	registerWithAuth("POST /accounts", http.HandlerFunc(controller.CreateAccount()))
+	registerWithAuth("GET /accounts", http.HandlerFunc(controller.GetAllAccounts()))
+	registerWithAuth("GET /accounts/{id}", http.HandlerFunc(controller.GetAccountByID()))
+	registerWithAuth("POST /accounts/{account_id}/ledgers", http.HandlerFunc(controller.CreateLedger()))
+	registerWithAuth("GET /recurring/{id}", http.HandlerFunc(controller.GetRecurringTransaction()))
+	registerWithAuth("POST /workspaces", http.HandlerFunc(controller.CreateWorkspace()))
+	registerWithAuth("GET /workspaces", http.HandlerFunc(controller.GetWorkspaces()))
+	registerWithAuth("POST /workspaces/{id}/members", http.HandlerFunc(controller.AddWorkspaceMember()))
+	registerWithAuth("DELETE /workspaces/{id}/members/{user_id}", http.HandlerFunc(controller.RemoveWorkspaceMember()))
+
+	s.NoError(sqlc.MigrateForTest(context.Background(), db))
+
+	ownerID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: "Owner"})
+	s.NoError(err)
+	s.ownerID = ownerID
+
+	memberID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: "Member"})
+	s.NoError(err)
+	s.memberID = memberID
+	s.NoError(s.repo.AddIdentity(memberID, domain.Identity{
+		Provider:   domain.IdentityProviderPassword,
+		Identifier: workspaceMemberEmail,
+		Credential: "hash",
+	}))
+}
+
+func (s *testWorkspaceSuite) TearDownTest() {
+	s.finalize()
+	s.router = nil
+	s.repo = nil
+}
+
+func TestWorkspaceSuite(t *testing.T) {
+	suite.Run(t, new(testWorkspaceSuite))
+}
+
+func (s *testWorkspaceSuite) createWorkspace() int32 {
+	s.userID = s.ownerID
+	s.workspaceID = 0
+
+	req := httptest.NewRequest(http.MethodPost, "/workspaces", bytes.NewBufferString(`{"name": "Home", "type": "household"}`))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusCreated, w.Code)
+
+	var resp struct {
+		Data struct {
+			ID   int32  `json:"id"`
+			Role string `json:"role"`
+		} `json:"data"`
+	}
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Equal("owner", resp.Data.Role)
+
+	return resp.Data.ID
+}
+
+func (s *testWorkspaceSuite) TestCreateWorkspaceRejectsInvalidType() {
+	s.userID = s.ownerID
+
+	req := httptest.NewRequest(http.MethodPost, "/workspaces", bytes.NewBufferString(`{"name": "Home", "type": "club"}`))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *testWorkspaceSuite) TestAccountsAreScopedToActiveWorkspace() {
+	workspaceID := s.createWorkspace()
+
+	// Create one personal account and one workspace account
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(`{"name": "Wallet", "currency": "USD"}`))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	s.workspaceID = workspaceID
+	req = httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(`{"name": "Groceries", "currency": "USD"}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	type getAccountsResponse struct {
+		Data []struct {
+			Name        string `json:"name"`
+			WorkspaceID *int32 `json:"workspace_id"`
+		} `json:"data"`
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/accounts", nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp getAccountsResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Len(resp.Data, 1)
+	s.Equal("Groceries", resp.Data[0].Name)
+	s.Require().NotNil(resp.Data[0].WorkspaceID)
+	s.Equal(workspaceID, *resp.Data[0].WorkspaceID)
+
+	s.workspaceID = 0
+	req = httptest.NewRequest(http.MethodGet, "/accounts", nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	resp = getAccountsResponse{}
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Len(resp.Data, 1)
+	s.Equal("Wallet", resp.Data[0].Name)
+}
+
+func (s *testWorkspaceSuite) TestViewerCanReadButNotWriteWorkspaceAccount() {
+	workspaceID := s.createWorkspace()
+
+	s.workspaceID = workspaceID
+	req := httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(`{"name": "Groceries", "currency": "USD"}`))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	accounts, err := s.repo.GetAccountsByWorkspaceID(workspaceID)
+	s.NoError(err)
+	s.Len(accounts, 1)
+	accountID := accounts[0].ID
+
+	reqBody := fmt.Sprintf(`{"email": %q, "role": "viewer"}`, workspaceMemberEmail)
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/workspaces/%d/members", workspaceID), bytes.NewBufferString(reqBody))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusCreated, w.Code)
+
+	s.userID = s.memberID
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/accounts/%d", accountID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	reqBody = `{"type": "expense", "amount": "-100", "note": "lunch"}`
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/accounts/%d/ledgers", accountID), bytes.NewBufferString(reqBody))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(`{"name": "Mine", "currency": "USD"}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
+func (s *testWorkspaceSuite) TestLastOwnerCannotLeave() {
+	workspaceID := s.createWorkspace()
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/workspaces/%d/members/%d", workspaceID, s.ownerID), nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *testWorkspaceSuite) TestRemovedMemberLosesAccessToWhatTheyCreated() {
+	workspaceID := s.createWorkspace()
+
+	reqBody := fmt.Sprintf(`{"email": %q, "role": "editor"}`, workspaceMemberEmail)
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/workspaces/%d/members", workspaceID), bytes.NewBufferString(reqBody))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusCreated, w.Code)
+
+	// The member creates an account and a recurring transaction in the workspace
+	s.userID = s.memberID
+	s.workspaceID = workspaceID
+	req = httptest.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(`{"name": "Groceries", "currency": "USD"}`))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	accounts, err := s.repo.GetAccountsByWorkspaceID(workspaceID)
+	s.NoError(err)
+	s.Require().Len(accounts, 1)
+	accountID := accounts[0].ID
+
+	transaction, err := s.repo.CreateRecurringTransaction(s.T().Context(), domain.CreateRecurringTransactionRequest{
+		UserID:        s.memberID,
+		AccountID:     accountID,
+		Name:          "Rent",
+		Type:          domain.LedgerTypeExpense,
+		Amount:        decimal.NewFromInt(-800),
+		StartDate:     time.Now(),
+		RecurType:     domain.RecurrenceTypeMonthly,
+		Frequency:     1,
+		CatchUpPolicy: domain.CatchUpPolicyBookAll,
+		PostMode:      domain.PostModeAuto,
+		NextDue:       time.Now().AddDate(0, 1, 0),
+		WorkspaceID:   &workspaceID,
+	})
+	s.NoError(err)
+
+	// Creating the account does not make the member its owner
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/accounts/%d", accountID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp struct {
+		Data struct {
+			Role string `json:"role"`
+		} `json:"data"`
+	}
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Equal(domain.AccountMemberRoleEditor.String(), resp.Data.Role)
+
+	// Once removed from the workspace, the member has no access left
+	s.userID = s.ownerID
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/workspaces/%d/members/%d", workspaceID, s.memberID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	s.userID = s.memberID
+	s.workspaceID = 0
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/accounts/%d", accountID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/recurring/%d", transaction.ID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,18 @@
+package cronjob
+
+import (
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/cronjob"
+)
+
+// Controller represents a controller
+type Controller struct {
+	service *cronjob.Service
+}
+
+// NewController creates a new controller
+func NewController(cronJobRepo domain.CronJobRepository) *Controller {
+	return &Controller{
+		service: cronjob.NewService(cronJobRepo),
+	}
+}
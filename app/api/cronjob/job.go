@@ -0,0 +1,135 @@
+package cronjob
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+)
+
+const (
+	// defaultRunsLimit is the number of runs listed when no limit is given
+	defaultRunsLimit = 20
+	// maxRunsLimit bounds the number of runs listed at once
+	maxRunsLimit = 100
+)
+
+// JobResponse is the response for a job of crond
+type JobResponse struct {
+	Name               string       `json:"name"`
+	Schedule           string       `json:"schedule"`
+	TimeoutSeconds     int          `json:"timeout_seconds"`
+	Enabled            bool         `json:"enabled"`
+	TriggerRequestedAt *time.Time   `json:"trigger_requested_at,omitempty"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+	LastRun            *RunResponse `json:"last_run,omitempty"`
+}
+
+// RunResponse is the response for a run of a job
+type RunResponse struct {
+	ID             int32      `json:"id"`
+	Trigger        string     `json:"trigger"`
+	Status         string     `json:"status"`
+	StartedAt      time.Time  `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+	ItemsProcessed int        `json:"items_processed"`
+	Error          string     `json:"error,omitempty"`
+}
+
+// GetJobs lists the jobs registered by crond with their latest run
+func (x *Controller) GetJobs() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(_ *engine.Context, _ *engine.Empty) ([]JobResponse, error) {
+			statuses, err := x.service.GetJobs(r.Context())
+			if err != nil {
+				slog.Error("Failed to get jobs", "error", err)
+				return nil, err
+			}
+
+			response := make([]JobResponse, len(statuses))
+			for i, status := range statuses {
+				response[i] = mapToJobResponse(status.Job)
+				if status.LastRun != nil {
+					run := mapToRunResponse(status.LastRun)
+					response[i].LastRun = &run
+				}
+			}
+
+			return response, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// GetJobRuns lists the latest runs of a job, the latest first
+func (x *Controller) GetJobRuns() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			name  string
+			limit int
+		}
+
+		req := request{limit: defaultRunsLimit}
+		engine.Chain(r, w, func(_ *engine.Context, req request) ([]RunResponse, error) {
+			if req.limit < 1 || req.limit > maxRunsLimit {
+				return nil, app.ParamError(fmt.Errorf("limit must be between 1 and %d", maxRunsLimit))
+			}
+
+			runs, err := x.service.GetJobRuns(r.Context(), req.name, req.limit)
+			if err != nil {
+				return nil, err
+			}
+
+			response := make([]RunResponse, len(runs))
+			for i, run := range runs {
+				response[i] = mapToRunResponse(run)
+			}
+
+			return response, nil
+		}).Param("name", &req.name).Query("limit", &req.limit).Call(req).ResponseJSON()
+	}
+}
+
+// TriggerJob asks crond to run a job, which it does within a few seconds
+func (x *Controller) TriggerJob() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var name string
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*JobResponse, error) {
+			job, err := x.service.TriggerJob(r.Context(), name)
+			if err != nil {
+				return nil, err
+			}
+
+			slog.Info("Job triggered", "job", name, "user_id", ctx.GetUserID())
+
+			response := mapToJobResponse(job)
+			return &response, nil
+		}).Param("name", &name).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+func mapToJobResponse(job *domain.CronJob) JobResponse {
+	return JobResponse{
+		Name:               job.Name,
+		Schedule:           job.Schedule,
+		TimeoutSeconds:     int(job.Timeout / time.Second),
+		Enabled:            job.Enabled,
+		TriggerRequestedAt: job.TriggerRequestedAt,
+		UpdatedAt:          job.UpdatedAt,
+	}
+}
+
+func mapToRunResponse(run *domain.CronJobRun) RunResponse {
+	return RunResponse{
+		ID:             run.ID,
+		Trigger:        run.Trigger.String(),
+		Status:         run.Status.String(),
+		StartedAt:      run.StartedAt,
+		FinishedAt:     run.FinishedAt,
+		ItemsProcessed: run.ItemsProcessed,
+		Error:          run.Error,
+	}
+}
@@ -107,10 +107,13 @@ func (h *Handler[Req, Resp]) Call(req Req) *Handler[Req, Resp] {
 
 	// Get the userID from the request context
 	// This userID was set by the authenticated middleware
-	userID := getUserIDFromContext(h.r.Context())
+	userID := UserIDFromContext(h.r.Context())
 	if userID != 0 {
 		ctx.SetUserID(userID)
 	}
+	ctx.SetUserRole(UserRoleFromContext(h.r.Context()))
+	ctx.SetSessionID(SessionIDFromContext(h.r.Context()))
+	ctx.SetWorkspace(WorkspaceFromContext(h.r.Context()))
 
 	h.resp, h.err = h.call(ctx, req)
 
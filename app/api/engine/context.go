@@ -3,6 +3,8 @@ package engine
 import (
 	"context"
 	"net/http"
+
+	"github.com/omegaatt36/bookly/domain"
 )
 
 // ContextKey is a custom type for context keys
@@ -12,9 +14,22 @@ type ContextKey string
 const (
 	// ContextKeyUserID is the key for the UserID in the context
 	ContextKeyUserID ContextKey = "userID"
+	// ContextKeyUserRole is the key for the UserRole in the context
+	ContextKeyUserRole ContextKey = "userRole"
+	// ContextKeyWorkspace is the key for the active workspace in the context
+	ContextKeyWorkspace ContextKey = "workspace"
+	// ContextKeySessionID is the key for the SessionID in the context
+	ContextKeySessionID ContextKey = "sessionID"
 )
 
-func getUserIDFromContext(ctx context.Context) int32 {
+// activeWorkspace is the workspace selected for the request and the user's role in it
+type activeWorkspace struct {
+	id   int32
+	role domain.WorkspaceRole
+}
+
+// UserIDFromContext returns the UserID stored in the context
+func UserIDFromContext(ctx context.Context) int32 {
 	if userID, ok := ctx.Value(ContextKeyUserID).(int32); ok {
 		return userID
 	}
@@ -27,10 +42,57 @@ func WithUserID(ctx context.Context, userID int32) context.Context {
 	return context.WithValue(ctx, ContextKeyUserID, userID)
 }
 
+// UserRoleFromContext returns the UserRole stored in the context
+func UserRoleFromContext(ctx context.Context) domain.UserRole {
+	if role, ok := ctx.Value(ContextKeyUserRole).(domain.UserRole); ok {
+		return role
+	}
+
+	return ""
+}
+
+// WithUserRole adds the UserRole to the context
+func WithUserRole(ctx context.Context, role domain.UserRole) context.Context {
+	return context.WithValue(ctx, ContextKeyUserRole, role)
+}
+
+// SessionIDFromContext returns the SessionID stored in the context
+func SessionIDFromContext(ctx context.Context) int32 {
+	if sessionID, ok := ctx.Value(ContextKeySessionID).(int32); ok {
+		return sessionID
+	}
+
+	return 0
+}
+
+// WithSessionID adds the SessionID to the context
+func WithSessionID(ctx context.Context, sessionID int32) context.Context {
+	return context.WithValue(ctx, ContextKeySessionID, sessionID)
+}
+
+// WorkspaceFromContext returns the active workspace ID and the user's role in it.
+// A zero ID means no workspace is selected and the request acts on personal data.
+func WorkspaceFromContext(ctx context.Context) (int32, domain.WorkspaceRole) {
+	if workspace, ok := ctx.Value(ContextKeyWorkspace).(activeWorkspace); ok {
+		return workspace.id, workspace.role
+	}
+
+	return 0, ""
+}
+
+// WithWorkspace adds the active workspace and the user's role in it to the context
+func WithWorkspace(ctx context.Context, workspaceID int32, role domain.WorkspaceRole) context.Context {
+	return context.WithValue(ctx, ContextKeyWorkspace, activeWorkspace{id: workspaceID, role: role})
+}
+
 // Context represents a context.
 type Context struct {
-	Request *http.Request
-	userID  int32
+	Request       *http.Request
+	userID        int32
+	userRole      domain.UserRole
+	sessionID     int32
+	workspaceID   int32
+	workspaceRole domain.WorkspaceRole
 }
 
 // GetUserID returns the user ID from the context
@@ -42,3 +104,44 @@ func (c *Context) GetUserID() int32 {
 func (c *Context) SetUserID(userID int32) {
 	c.userID = userID
 }
+
+// GetUserRole returns the user role from the context
+func (c *Context) GetUserRole() domain.UserRole {
+	return c.userRole
+}
+
+// SetUserRole sets the user role in the context
+func (c *Context) SetUserRole(role domain.UserRole) {
+	c.userRole = role
+}
+
+// IsAdmin reports whether the authenticated user has the admin role
+func (c *Context) IsAdmin() bool {
+	return c.userRole == domain.UserRoleAdmin
+}
+
+// GetWorkspaceID returns the active workspace ID, or zero when none is selected
+func (c *Context) GetWorkspaceID() int32 {
+	return c.workspaceID
+}
+
+// GetWorkspaceRole returns the user's role in the active workspace
+func (c *Context) GetWorkspaceRole() domain.WorkspaceRole {
+	return c.workspaceRole
+}
+
+// SetWorkspace sets the active workspace and the user's role in it
+func (c *Context) SetWorkspace(workspaceID int32, role domain.WorkspaceRole) {
+	c.workspaceID = workspaceID
+	c.workspaceRole = role
+}
+
+// GetSessionID returns the ID of the session the request was authenticated with
+func (c *Context) GetSessionID() int32 {
+	return c.sessionID
+}
+
+// SetSessionID sets the session ID in the context
+func (c *Context) SetSessionID(sessionID int32) {
+	c.sessionID = sessionID
+}
@@ -2,10 +2,13 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,6 +16,7 @@ import (
 	"github.com/omegaatt36/bookly/app"
 	"github.com/omegaatt36/bookly/app/api/engine"
 	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/auth"
 )
 
 type middleware func(http.Handler) http.Handler
@@ -83,19 +87,66 @@ func rateLimiter(rate float64, capacity int) middleware {
 	}
 }
 
-func authenticated(authenticator domain.Authenticator) middleware {
+// apiKeyLastUsedInterval is how often the last use of an API key is recorded
+const apiKeyLastUsedInterval = time.Minute
+
+// requiredAPIKeyScope returns the scope an API key needs for a request. Requests managing
+// the user's credentials, sessions, memberships or other users are never allowed with API keys.
+func requiredAPIKeyScope(r *http.Request) (domain.APIKeyScope, bool) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch segments[0] {
+	case "auth", "api-keys", "sessions", "admin":
+		return "", false
+	}
+
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return domain.APIKeyScopeRead, true
+	}
+
+	switch segments[0] {
+	case "accounts":
+		if len(segments) > 2 && segments[2] == "ledgers" {
+			return domain.APIKeyScopeLedgersWrite, true
+		}
+		if len(segments) > 2 && segments[2] != "bank-account" {
+			return "", false
+		}
+		return domain.APIKeyScopeAccountsWrite, true
+	case "bank-accounts":
+		return domain.APIKeyScopeAccountsWrite, true
+	case "ledgers":
+		return domain.APIKeyScopeLedgersWrite, true
+	case "recurring":
+		return domain.APIKeyScopeRecurringWrite, true
+	case "payees", "payee-rules":
+		return domain.APIKeyScopePayeesWrite, true
+	}
+
+	return "", false
+}
+
+func authenticated(
+	authenticator domain.Authenticator,
+	sessionRepo domain.SessionRepository,
+	apiKeyRepo domain.APIKeyRepository,
+	userRepo domain.UserRepository,
+) middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			abortWithUnauthorized := func(message string) {
+			abort := func(statusCode, code int, message string) {
 				bs, err := json.Marshal(engine.ResponseError{
-					Code:    app.CodeUnauthorized,
+					Code:    code,
 					Message: message,
 				})
 				if err != nil {
 					panic(err)
 				}
 
-				http.Error(w, string(bs), http.StatusUnauthorized)
+				http.Error(w, string(bs), statusCode)
+			}
+
+			abortWithUnauthorized := func(message string) {
+				abort(http.StatusUnauthorized, app.CodeUnauthorized, message)
 			}
 
 			authToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
@@ -104,6 +155,48 @@ func authenticated(authenticator domain.Authenticator) middleware {
 				return
 			}
 
+			// Personal API keys act as their user within the scopes they were granted
+			if auth.IsAPIKey(authToken) {
+				apiKey, err := apiKeyRepo.GetAPIKeyByHash(auth.HashAPIKey(authToken))
+				if err != nil && !errors.Is(err, domain.ErrNotFound) {
+					abortWithUnauthorized(err.Error())
+					return
+				}
+
+				now := time.Now()
+				if apiKey == nil || !apiKey.IsActive(now) {
+					abortWithUnauthorized("invalid api key")
+					return
+				}
+
+				scope, ok := requiredAPIKeyScope(r)
+				if !ok {
+					abort(http.StatusForbidden, app.CodeForbidden, "not available with an api key")
+					return
+				}
+				if !apiKey.HasScope(scope) {
+					abort(http.StatusForbidden, app.CodeForbidden, fmt.Sprintf("api key lacks the %s scope", scope))
+					return
+				}
+
+				user, err := userRepo.GetUserByID(apiKey.UserID)
+				if err != nil || user.Disabled {
+					abortWithUnauthorized("invalid api key")
+					return
+				}
+
+				if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyLastUsedInterval {
+					if err := apiKeyRepo.TouchAPIKey(apiKey.ID, now); err != nil {
+						slog.WarnContext(r.Context(), "failed to record api key use", slog.String("error", err.Error()))
+					}
+				}
+
+				ctx := engine.WithUserID(r.Context(), user.ID)
+				ctx = engine.WithUserRole(ctx, user.Role)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
 			tokenResult, err := authenticator.ValidateToken(domain.ValidateTokenRequest{
 				Token: authToken,
 			})
@@ -117,8 +210,27 @@ func authenticated(authenticator domain.Authenticator) middleware {
 				return
 			}
 
-			// Store user ID in request context for the engine to retrieve
+			// Access tokens are bound to a session, which may have been revoked since the token was issued
+			if tokenResult.SessionID == 0 {
+				abortWithUnauthorized("invalid token")
+				return
+			}
+
+			session, err := sessionRepo.GetSessionByID(tokenResult.SessionID)
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				abortWithUnauthorized(err.Error())
+				return
+			}
+
+			if session == nil || session.UserID != tokenResult.UserID || !session.IsActive(time.Now()) {
+				abortWithUnauthorized("session revoked")
+				return
+			}
+
+			// Store user ID, role and session ID in request context for the engine to retrieve
 			ctx := engine.WithUserID(r.Context(), tokenResult.UserID)
+			ctx = engine.WithUserRole(ctx, tokenResult.Role)
+			ctx = engine.WithSessionID(ctx, tokenResult.SessionID)
 			r = r.WithContext(ctx)
 
 			next.ServeHTTP(w, r)
@@ -126,6 +238,75 @@ func authenticated(authenticator domain.Authenticator) middleware {
 	}
 }
 
+func authorized(roles ...domain.UserRole) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role := engine.UserRoleFromContext(r.Context())
+			if !slices.Contains(roles, role) {
+				bs, err := json.Marshal(engine.ResponseError{
+					Code:    app.CodeForbidden,
+					Message: "permission denied",
+				})
+				if err != nil {
+					panic(err)
+				}
+
+				http.Error(w, string(bs), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// workspaceHeader selects the workspace a request acts on
+const workspaceHeader = "X-Workspace-ID"
+
+func selectWorkspace(repo domain.WorkspaceRepository) middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			abort := func(statusCode, code int, message string) {
+				bs, err := json.Marshal(engine.ResponseError{
+					Code:    code,
+					Message: message,
+				})
+				if err != nil {
+					panic(err)
+				}
+
+				http.Error(w, string(bs), statusCode)
+			}
+
+			header := r.Header.Get(workspaceHeader)
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			workspaceID, err := strconv.ParseInt(header, 10, 32)
+			if err != nil || workspaceID <= 0 {
+				abort(http.StatusBadRequest, app.CodeBadParam, "invalid workspace id")
+				return
+			}
+
+			member, err := repo.GetWorkspaceMember(int32(workspaceID), engine.UserIDFromContext(r.Context()))
+			if err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					abort(http.StatusForbidden, app.CodeForbidden, "not a member of the workspace")
+					return
+				}
+
+				abort(http.StatusInternalServerError, app.CodeInternalError, err.Error())
+				return
+			}
+
+			ctx := engine.WithWorkspace(r.Context(), member.WorkspaceID, member.Role)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 func onlyInternal(internalToken string) middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+)
+
+func TestRequiredAPIKeyScope(t *testing.T) {
+	for _, tc := range []struct {
+		method string
+		path   string
+		scope  domain.APIKeyScope
+		ok     bool
+	}{
+		{"GET", "/accounts", domain.APIKeyScopeRead, true},
+		{"GET", "/ledgers/1", domain.APIKeyScopeRead, true},
+		{"POST", "/accounts", domain.APIKeyScopeAccountsWrite, true},
+		{"PATCH", "/accounts/1", domain.APIKeyScopeAccountsWrite, true},
+		{"POST", "/accounts/1/bank-account", domain.APIKeyScopeAccountsWrite, true},
+		{"PATCH", "/bank-accounts/1", domain.APIKeyScopeAccountsWrite, true},
+		{"POST", "/accounts/1/ledgers", domain.APIKeyScopeLedgersWrite, true},
+		{"DELETE", "/ledgers/1", domain.APIKeyScopeLedgersWrite, true},
+		{"PUT", "/recurring/1", domain.APIKeyScopeRecurringWrite, true},
+		{"POST", "/payee-rules/apply", domain.APIKeyScopePayeesWrite, true},
+		{"POST", "/accounts/1/invitations", "", false},
+		{"PATCH", "/users/1", "", false},
+		{"POST", "/workspaces", "", false},
+		{"GET", "/api-keys", "", false},
+		{"POST", "/api-keys", "", false},
+		{"GET", "/sessions", "", false},
+		{"POST", "/auth/password", "", false},
+		{"GET", "/admin/users", "", false},
+	} {
+		scope, ok := requiredAPIKeyScope(httptest.NewRequest(tc.method, tc.path, nil))
+		assert.Equal(t, tc.ok, ok, "%s %s", tc.method, tc.path)
+		assert.Equal(t, tc.scope, scope, "%s %s", tc.method, tc.path)
+	}
+}
+
+func TestAuthorized(t *testing.T) {
+	handler := authorized(domain.UserRoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for _, tc := range []struct {
+		role domain.UserRole
+		code int
+	}{
+		{domain.UserRoleAdmin, http.StatusNoContent},
+		{domain.UserRoleUser, http.StatusForbidden},
+		{"", http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/admin/users", nil)
+		req = req.WithContext(engine.WithUserRole(req.Context(), tc.role))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, tc.code, w.Code, "role %q", tc.role)
+	}
+}
@@ -1,5 +1,7 @@
 package api
 
+import "github.com/omegaatt36/bookly/domain"
+
 func ptr[T any](v T) *T {
 	return &v
 }
@@ -37,3 +39,39 @@ type PortOption struct {
 func (opt *PortOption) apply(router *Server) {
 	router.port = opt.Port
 }
+
+// NotifierOption defines an option to set the notifier delivering messages to users.
+type NotifierOption struct {
+	Notifier domain.Notifier
+}
+
+func (opt *NotifierOption) apply(router *Server) {
+	router.notifier = opt.Notifier
+}
+
+// WebURLOption defines an option to set the base URL of the web app, used in links sent to users.
+type WebURLOption struct {
+	WebURL string
+}
+
+func (opt *WebURLOption) apply(router *Server) {
+	router.webURL = opt.WebURL
+}
+
+// PublicRegistrationOption defines an option to let users register themselves.
+type PublicRegistrationOption struct {
+	Enabled bool
+}
+
+func (opt *PublicRegistrationOption) apply(router *Server) {
+	router.publicRegistration = opt.Enabled
+}
+
+// OIDCProviderOption defines an option to let users log in with an OpenID Connect provider.
+type OIDCProviderOption struct {
+	Provider domain.OIDCProvider
+}
+
+func (opt *OIDCProviderOption) apply(router *Server) {
+	router.oidcProvider = opt.Provider
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/omegaatt36/bookly/app/api/bookkeeping"
+	"github.com/omegaatt36/bookly/app/api/cronjob"
 	"github.com/omegaatt36/bookly/app/api/user"
 	"github.com/omegaatt36/bookly/domain"
 	"github.com/omegaatt36/bookly/persistence/database"
@@ -16,11 +17,16 @@ func (s *Server) registerRouters() {
 	if s.jwtSalt != nil && s.jwtSecret != nil {
 		authenticators[domain.IdentityProviderPassword] =
 			auth.NewJWTAuthorizator(*s.jwtSalt, *s.jwtSecret)
+		if s.oidcProvider != nil {
+			// Sessions of external identities get the same tokens as password logins
+			authenticators[domain.IdentityProviderOIDC] = authenticators[domain.IdentityProviderPassword]
+		}
 	}
 
 	publicRouter := http.NewServeMux()
 	internalRouter := http.NewServeMux()
 	v1Router := http.NewServeMux()
+	adminRouter := http.NewServeMux()
 
 	db := database.GetDB()
 	repo := repository.NewSQLCRepository(db)
@@ -32,6 +38,16 @@ func (s *Server) registerRouters() {
 			RecurringTransactionRepository: repo,
 			ReminderRepository:             repo,
 			BankAccountRepository:          repo,
+			PayeeRepository:                repo,
+			AccountMemberRepository:        repo,
+			WorkspaceRepository:            repo,
+			AuditLogRepository:             repo,
+			BooksLockRepository:            repo,
+			NotificationRepository:         repo,
+			DigestRepository:               repo,
+			UserRepository:                 repo,
+			Notifier:                       s.notifier,
+			WebURL:                         s.webURL,
 		})
 
 		// Register account routes
@@ -42,6 +58,28 @@ func (s *Server) registerRouters() {
 		v1Router.HandleFunc("DELETE /accounts/{id}", bookkeepingX.DeactivateAccountByID())
 		v1Router.HandleFunc("GET /users/{user_id}/accounts", bookkeepingX.GetUserAccounts())
 
+		// Register account member and invitation routes
+		v1Router.HandleFunc("GET /accounts/{id}/members", bookkeepingX.GetAccountMembers())
+		v1Router.HandleFunc("PATCH /accounts/{id}/members/{user_id}", bookkeepingX.UpdateAccountMember())
+		v1Router.HandleFunc("DELETE /accounts/{id}/members/{user_id}", bookkeepingX.RemoveAccountMember())
+		v1Router.HandleFunc("POST /accounts/{id}/invitations", bookkeepingX.CreateAccountInvitation())
+		v1Router.HandleFunc("GET /accounts/{id}/invitations", bookkeepingX.GetAccountInvitations())
+		v1Router.HandleFunc("GET /account-invitations", bookkeepingX.GetPendingAccountInvitations())
+		v1Router.HandleFunc("DELETE /account-invitations/{id}", bookkeepingX.RevokeAccountInvitation())
+		v1Router.HandleFunc("POST /account-invitations/{id}/accept", bookkeepingX.AcceptAccountInvitation())
+		v1Router.HandleFunc("POST /account-invitations/{id}/decline", bookkeepingX.DeclineAccountInvitation())
+
+		// Register workspace routes
+		v1Router.HandleFunc("POST /workspaces", bookkeepingX.CreateWorkspace())
+		v1Router.HandleFunc("GET /workspaces", bookkeepingX.GetWorkspaces())
+		v1Router.HandleFunc("GET /workspaces/{id}", bookkeepingX.GetWorkspaceByID())
+		v1Router.HandleFunc("PATCH /workspaces/{id}", bookkeepingX.UpdateWorkspace())
+		v1Router.HandleFunc("DELETE /workspaces/{id}", bookkeepingX.DeleteWorkspace())
+		v1Router.HandleFunc("GET /workspaces/{id}/members", bookkeepingX.GetWorkspaceMembers())
+		v1Router.HandleFunc("POST /workspaces/{id}/members", bookkeepingX.AddWorkspaceMember())
+		v1Router.HandleFunc("PATCH /workspaces/{id}/members/{user_id}", bookkeepingX.UpdateWorkspaceMember())
+		v1Router.HandleFunc("DELETE /workspaces/{id}/members/{user_id}", bookkeepingX.RemoveWorkspaceMember())
+
 		// Register ledger routes
 		v1Router.HandleFunc("POST /accounts/{account_id}/ledgers", bookkeepingX.CreateLedger())
 		v1Router.HandleFunc("GET /accounts/{account_id}/ledgers", bookkeepingX.GetLedgersByAccount())
@@ -50,21 +88,60 @@ func (s *Server) registerRouters() {
 		v1Router.HandleFunc("DELETE /ledgers/{id}", bookkeepingX.VoidLedger())
 		v1Router.HandleFunc("POST /ledgers/{id}/adjust", bookkeepingX.AdjustLedger())
 
+		// Register books lock routes
+		v1Router.HandleFunc("GET /books-lock", bookkeepingX.GetBooksLock())
+		v1Router.HandleFunc("PUT /books-lock", bookkeepingX.SetBooksLock())
+		v1Router.HandleFunc("GET /accounts/{id}/books-lock", bookkeepingX.GetAccountBooksLock())
+		v1Router.HandleFunc("PUT /accounts/{id}/books-lock", bookkeepingX.SetAccountBooksLock())
+
 		// Register recurring transaction routes
 		v1Router.HandleFunc("POST /recurring", bookkeepingX.CreateRecurringTransaction())
 		v1Router.HandleFunc("GET /recurring", bookkeepingX.GetRecurringTransactions())
 		v1Router.HandleFunc("GET /recurring/{id}", bookkeepingX.GetRecurringTransaction())
+		v1Router.HandleFunc("GET /recurring/{id}/occurrences", bookkeepingX.GetRecurringOccurrences())
+		v1Router.HandleFunc("GET /recurring/{id}/history", bookkeepingX.GetRecurringTransactionHistory())
+		v1Router.HandleFunc("POST /recurring/{id}/skip", bookkeepingX.SkipNextOccurrence())
+		v1Router.HandleFunc("POST /recurring/{id}/postpone", bookkeepingX.PostponeNextOccurrence())
 		v1Router.HandleFunc("PUT /recurring/{id}", bookkeepingX.UpdateRecurringTransaction())
 		v1Router.HandleFunc("DELETE /recurring/{id}", bookkeepingX.DeleteRecurringTransaction())
 		v1Router.HandleFunc("GET /recurring/reminders", bookkeepingX.GetReminders())
 		v1Router.HandleFunc("POST /recurring/reminders/{id}/read", bookkeepingX.MarkReminderAsRead())
-		
+		v1Router.HandleFunc("POST /recurring/reminders/{id}/confirm", bookkeepingX.ConfirmReminder())
+		v1Router.HandleFunc("POST /recurring/reminders/{id}/skip", bookkeepingX.SkipReminder())
+		v1Router.HandleFunc("POST /recurring/reminders/{id}/snooze", bookkeepingX.SnoozeReminder())
+		v1Router.HandleFunc("GET /recurring/reminders/upcoming", bookkeepingX.GetUpcomingReminders())
+		v1Router.HandleFunc("GET /recurring/reminders/{id}/deliveries", bookkeepingX.GetReminderDeliveries())
+		v1Router.HandleFunc("GET /reminder-settings", bookkeepingX.GetReminderSettings())
+		v1Router.HandleFunc("PUT /reminder-settings", bookkeepingX.UpdateReminderSettings())
+		v1Router.HandleFunc("GET /notification-channels", bookkeepingX.GetNotificationChannels())
+		v1Router.HandleFunc("PUT /notification-channels/{channel}", bookkeepingX.UpdateNotificationChannel())
+		v1Router.HandleFunc("DELETE /notification-channels/{channel}", bookkeepingX.DeleteNotificationChannel())
+		publicRouter.HandleFunc("POST /notification-channels/email/confirm", bookkeepingX.ConfirmNotificationChannel())
+		v1Router.HandleFunc("GET /digest-settings", bookkeepingX.GetDigestSettings())
+		v1Router.HandleFunc("PUT /digest-settings", bookkeepingX.UpdateDigestSettings())
+
+		// Register audit log routes
+		v1Router.HandleFunc("GET /audit", bookkeepingX.GetAuditLogs())
+
 		// Register bank account routes
 		v1Router.HandleFunc("POST /accounts/{account_id}/bank-account", bookkeepingX.CreateBankAccount())
 		v1Router.HandleFunc("GET /accounts/{account_id}/bank-account", bookkeepingX.GetBankAccountByAccountID())
 		v1Router.HandleFunc("GET /bank-accounts/{id}", bookkeepingX.GetBankAccountByID())
 		v1Router.HandleFunc("PATCH /bank-accounts/{id}", bookkeepingX.UpdateBankAccount())
 		v1Router.HandleFunc("DELETE /bank-accounts/{id}", bookkeepingX.DeleteBankAccount())
+
+		// Register payee and payee rule routes
+		v1Router.HandleFunc("POST /payees", bookkeepingX.CreatePayee())
+		v1Router.HandleFunc("GET /payees", bookkeepingX.GetPayees())
+		v1Router.HandleFunc("GET /payees/{id}", bookkeepingX.GetPayeeByID())
+		v1Router.HandleFunc("PATCH /payees/{id}", bookkeepingX.UpdatePayee())
+		v1Router.HandleFunc("DELETE /payees/{id}", bookkeepingX.DeletePayee())
+		v1Router.HandleFunc("POST /payee-rules", bookkeepingX.CreatePayeeRule())
+		v1Router.HandleFunc("GET /payee-rules", bookkeepingX.GetPayeeRules())
+		v1Router.HandleFunc("GET /payee-rules/{id}", bookkeepingX.GetPayeeRuleByID())
+		v1Router.HandleFunc("PATCH /payee-rules/{id}", bookkeepingX.UpdatePayeeRule())
+		v1Router.HandleFunc("DELETE /payee-rules/{id}", bookkeepingX.DeletePayeeRule())
+		v1Router.HandleFunc("POST /payee-rules/apply", bookkeepingX.ApplyPayeeRules())
 	}
 	{
 		userOptions := make([]user.Option, 0)
@@ -72,26 +149,86 @@ func (s *Server) registerRouters() {
 			userOptions = append(userOptions, user.WithAuthenticator(identityProvider, authenticator))
 		}
 
+		userOptions = append(userOptions,
+			user.WithSessionRepository(repo),
+			user.WithPasswordResetRepository(repo),
+			user.WithEmailVerificationRepository(repo),
+			user.WithTwoFactorRepository(repo),
+			user.WithAPIKeyRepository(repo),
+			user.WithLoginAttemptRepository(repo),
+			user.WithAuthEventRepository(repo),
+			user.WithNotifier(s.notifier, s.webURL),
+		)
+		if s.oidcProvider != nil {
+			userOptions = append(userOptions, user.WithOIDCProvider(s.oidcProvider, repo, s.publicRegistration))
+		}
+
 		userX := user.NewController(repo, userOptions...)
 
-		v1Router.HandleFunc("GET /users", userX.GetAllUsers())
 		v1Router.HandleFunc("GET /users/{id}", userX.GetUserByID())
 		v1Router.HandleFunc("PATCH /users/{id}", userX.UpdateUser())
 		v1Router.HandleFunc("DELETE /users/{id}", userX.DeactivateUserByID())
 		internalRouter.HandleFunc("POST /users", userX.CreateUser())
 
+		adminRouter.HandleFunc("GET /users", userX.GetAllUsers())
+		adminRouter.HandleFunc("POST /users/{id}/disable", userX.DisableUser())
+		adminRouter.HandleFunc("POST /users/{id}/enable", userX.EnableUser())
+		adminRouter.HandleFunc("DELETE /users/{id}", userX.DeleteUser())
+
 		internalRouter.HandleFunc("POST /auth/register", userX.RegisterUser())
 		publicRouter.HandleFunc("POST /auth/login", userX.LoginUser())
+		publicRouter.HandleFunc("POST /auth/login/2fa", userX.CompleteLogin())
+		publicRouter.HandleFunc("POST /auth/refresh", userX.RefreshToken())
+		publicRouter.HandleFunc("POST /auth/password/forgot", userX.ForgotPassword())
+		publicRouter.HandleFunc("POST /auth/password/reset", userX.ResetPassword())
+		publicRouter.HandleFunc("POST /auth/verify-email", userX.VerifyEmail())
+		publicRouter.HandleFunc("POST /auth/verify-email/resend", userX.ResendVerificationEmail())
+		if s.publicRegistration {
+			publicRouter.HandleFunc("POST /auth/register", userX.SignUp())
+		}
+		if s.oidcProvider != nil {
+			publicRouter.HandleFunc("GET /auth/oidc/authorize", userX.StartOIDCLogin())
+			publicRouter.HandleFunc("POST /auth/oidc/callback", userX.CompleteOIDC())
+			v1Router.HandleFunc("POST /auth/oidc/link", userX.StartOIDCLink())
+			v1Router.HandleFunc("POST /auth/oidc/link/callback", userX.CompleteOIDC())
+		}
+
+		v1Router.HandleFunc("POST /auth/logout", userX.Logout())
+		v1Router.HandleFunc("POST /auth/password", userX.ChangePassword())
+		v1Router.HandleFunc("GET /auth/events", userX.GetAuthEvents())
+		v1Router.HandleFunc("GET /auth/2fa", userX.GetTwoFactorStatus())
+		v1Router.HandleFunc("DELETE /auth/2fa", userX.DisableTwoFactor())
+		v1Router.HandleFunc("POST /auth/2fa/totp", userX.EnrollTOTP())
+		v1Router.HandleFunc("POST /auth/2fa/totp/confirm", userX.ConfirmTOTP())
+		v1Router.HandleFunc("POST /auth/2fa/recovery-codes", userX.RegenerateRecoveryCodes())
+		v1Router.HandleFunc("POST /api-keys", userX.CreateAPIKey())
+		v1Router.HandleFunc("GET /api-keys", userX.GetAPIKeys())
+		v1Router.HandleFunc("DELETE /api-keys/{id}", userX.RevokeAPIKey())
+		v1Router.HandleFunc("GET /sessions", userX.GetSessions())
+		v1Router.HandleFunc("DELETE /sessions", userX.RevokeOtherSessions())
+		v1Router.HandleFunc("DELETE /sessions/{id}", userX.RevokeSession())
+	}
+
+	{
+		// Register crond job routes
+		cronjobX := cronjob.NewController(repo)
+
+		adminRouter.HandleFunc("GET /jobs", cronjobX.GetJobs())
+		adminRouter.HandleFunc("GET /jobs/{name}/runs", cronjobX.GetJobRuns())
+		adminRouter.HandleFunc("POST /jobs/{name}/trigger", cronjobX.TriggerJob())
 	}
 
 	authMiddlewares := []middleware{}
 	if s.jwtSalt != nil && s.jwtSecret != nil {
 		jwtAuthenticator := auth.NewJWTAuthorizator(*s.jwtSalt, *s.jwtSecret)
-		authMiddlewares = append(authMiddlewares, authenticated(jwtAuthenticator))
+		authMiddlewares = append(authMiddlewares, authenticated(jwtAuthenticator, repo, repo, repo))
 	}
+	v1Middlewares := append(authMiddlewares, selectWorkspace(repo))
+
+	v1Router.Handle("/admin/", http.StripPrefix("/admin", authorized(domain.UserRoleAdmin)(adminRouter)))
 
 	router := http.NewServeMux()
-	router.Handle("/v1/", http.StripPrefix("/v1", chainMiddleware(authMiddlewares...)(v1Router)))
+	router.Handle("/v1/", http.StripPrefix("/v1", chainMiddleware(v1Middlewares...)(v1Router)))
 	router.Handle("/internal/", http.StripPrefix("/internal", onlyInternal(*s.internalToken)(internalRouter)))
 	router.Handle("/public/", http.StripPrefix("/public", publicRouter))
 
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/notify"
 )
 
 // Server represents a router
@@ -14,6 +17,12 @@ type Server struct {
 	jwtSecret     *string
 	internalToken *string
 
+	notifier domain.Notifier
+	webURL   string
+
+	publicRegistration bool
+	oidcProvider       domain.OIDCProvider
+
 	port int
 
 	router http.Handler
@@ -22,7 +31,9 @@ type Server struct {
 // NewServer creates a new router.
 func NewServer(options ...Option) *Server {
 	server := Server{
-		port: 8080,
+		port:     8080,
+		notifier: notify.NewLogNotifier(),
+		webURL:   "http://localhost:3000",
 	}
 
 	for _, option := range options {
@@ -0,0 +1,62 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+)
+
+// DisableUser handles disabling a user by an admin.
+func (x *Controller) DisableUser() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			if id == ctx.GetUserID() {
+				return nil, app.ParamError(errors.New("cannot disable yourself"))
+			}
+
+			if _, err := x.service.GetUserByID(id); err != nil {
+				return nil, err
+			}
+
+			return nil, x.service.DisableUserByID(id)
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// EnableUser handles re-enabling a disabled user by an admin.
+func (x *Controller) EnableUser() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			if _, err := x.service.GetUserByID(id); err != nil {
+				return nil, err
+			}
+
+			return nil, x.service.EnableUserByID(id)
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// DeleteUser handles deleting a user by an admin.
+func (x *Controller) DeleteUser() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			if id == ctx.GetUserID() {
+				return nil, app.ParamError(errors.New("cannot delete yourself"))
+			}
+
+			if _, err := x.service.GetUserByID(id); err != nil {
+				return nil, err
+			}
+
+			return nil, x.service.DeleteUserByID(id)
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
@@ -0,0 +1,127 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/user"
+)
+
+type jsonAPIKey struct {
+	ID         int32      `json:"id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+func (k *jsonAPIKey) fromDomain(apiKey *domain.APIKey) {
+	k.ID = apiKey.ID
+	k.CreatedAt = apiKey.CreatedAt
+	k.Name = apiKey.Name
+	k.Prefix = apiKey.Prefix
+	k.Scopes = make([]string, len(apiKey.Scopes))
+	for i, scope := range apiKey.Scopes {
+		k.Scopes[i] = string(scope)
+	}
+	k.ExpiresAt = apiKey.ExpiresAt
+	k.LastUsedAt = apiKey.LastUsedAt
+}
+
+// CreateAPIKey handles creating a personal API key for the current user. The key is only
+// part of this response.
+func (x *Controller) CreateAPIKey() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Name      string     `json:"name"`
+			Scopes    []string   `json:"scopes"`
+			ExpiresAt *time.Time `json:"expires_at"`
+		}
+
+		type response struct {
+			jsonAPIKey
+
+			Key string `json:"key"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*response, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			scopes := make([]domain.APIKeyScope, len(req.Scopes))
+			for i, scope := range req.Scopes {
+				scopes[i] = domain.APIKeyScope(scope)
+			}
+
+			newAPIKey, err := x.service.CreateAPIKey(user.CreateAPIKeyRequest{
+				UserID:    userID,
+				Name:      req.Name,
+				Scopes:    scopes,
+				ExpiresAt: req.ExpiresAt,
+			})
+			if err != nil {
+				switch {
+				case errors.Is(err, user.ErrInvalidAPIKeyName),
+					errors.Is(err, user.ErrInvalidAPIKeyScope),
+					errors.Is(err, user.ErrInvalidAPIKeyExpiry):
+					return nil, app.ParamError(err)
+				}
+				return nil, err
+			}
+
+			var resp response
+			resp.fromDomain(newAPIKey.APIKey)
+			resp.Key = newAPIKey.Key
+
+			return &resp, nil
+		}).BindJSON(&req).Call(req).ResponseCreated()
+	}
+}
+
+// GetAPIKeys handles listing the API keys of the current user
+func (x *Controller) GetAPIKeys() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonAPIKey, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			apiKeys, err := x.service.GetAPIKeys(userID)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonAPIKeys := make([]jsonAPIKey, len(apiKeys))
+			for index, apiKey := range apiKeys {
+				jsonAPIKeys[index].fromDomain(apiKey)
+			}
+
+			return jsonAPIKeys, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// RevokeAPIKey handles revoking one of the current user's API keys
+func (x *Controller) RevokeAPIKey() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			return nil, x.service.RevokeAPIKey(userID, id)
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
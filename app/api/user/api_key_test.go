@@ -0,0 +1,98 @@
+package user_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/omegaatt36/bookly/service/auth"
+)
+
+type apiKeyResponse struct {
+	ID     int32    `json:"id"`
+	Name   string   `json:"name"`
+	Prefix string   `json:"prefix"`
+	Scopes []string `json:"scopes"`
+	Key    string   `json:"key"`
+}
+
+func (s *testAuthSuite) getAPIKeys(token string) []apiKeyResponse {
+	req := httptest.NewRequest(http.MethodGet, "/api-keys", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	var resp struct {
+		Data []apiKeyResponse `json:"data"`
+	}
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+
+	return resp.Data
+}
+
+func (s *testAuthSuite) deleteWithToken(path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodDelete, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func (s *testAuthSuite) TestAPIKeyLifecycle() {
+	tokens := s.registerAndLogin("api-key@example.com")
+
+	w := s.postWithToken("/api-keys", tokens.Data.Token, `{"name": "import script", "scopes": ["read", "ledgers:write", "read"]}`)
+	s.Require().Equal(http.StatusCreated, w.Code)
+
+	var created struct {
+		Data apiKeyResponse `json:"data"`
+	}
+	s.NoError(json.NewDecoder(w.Body).Decode(&created))
+	s.True(auth.IsAPIKey(created.Data.Key))
+	s.True(strings.HasPrefix(created.Data.Key, created.Data.Prefix))
+	s.Equal([]string{"read", "ledgers:write"}, created.Data.Scopes)
+
+	// The key is stored hashed and never shown again
+	apiKey, err := s.repo.GetAPIKeyByHash(auth.HashAPIKey(created.Data.Key))
+	s.NoError(err)
+	s.Equal(created.Data.ID, apiKey.ID)
+
+	keys := s.getAPIKeys(tokens.Data.Token)
+	s.Require().Len(keys, 1)
+	s.Equal("import script", keys[0].Name)
+	s.Empty(keys[0].Key)
+
+	// Other users cannot revoke it
+	other := s.registerAndLogin("api-key-other@example.com")
+	w = s.deleteWithToken(fmt.Sprintf("/api-keys/%d", created.Data.ID), other.Data.Token)
+	s.Equal(http.StatusNotFound, w.Code)
+
+	w = s.deleteWithToken(fmt.Sprintf("/api-keys/%d", created.Data.ID), tokens.Data.Token)
+	s.Equal(http.StatusOK, w.Code)
+	s.Empty(s.getAPIKeys(tokens.Data.Token))
+
+	apiKey, err = s.repo.GetAPIKeyByHash(auth.HashAPIKey(created.Data.Key))
+	s.NoError(err)
+	s.NotNil(apiKey.RevokedAt)
+
+	w = s.deleteWithToken(fmt.Sprintf("/api-keys/%d", created.Data.ID), tokens.Data.Token)
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *testAuthSuite) TestCreateAPIKeyValidation() {
+	tokens := s.registerAndLogin("api-key-validation@example.com")
+
+	for _, body := range []string{
+		`{"name": "", "scopes": ["read"]}`,
+		`{"name": "no scopes", "scopes": []}`,
+		`{"name": "unknown scope", "scopes": ["everything"]}`,
+		`{"name": "expired", "scopes": ["read"], "expires_at": "2000-01-01T00:00:00Z"}`,
+	} {
+		w := s.postWithToken("/api-keys", tokens.Data.Token, body)
+		s.Equal(http.StatusBadRequest, w.Code, body)
+	}
+}
@@ -2,7 +2,10 @@ package user
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/omegaatt36/bookly/app"
 	"github.com/omegaatt36/bookly/app/api/engine"
@@ -37,7 +40,8 @@ func (x *Controller) RegisterUser() func(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-// LoginUser logs in a user.
+// LoginUser logs in a user. Users with two-factor authentication enabled get a short-lived
+// challenge token instead of session tokens, to exchange along with a code at CompleteLogin.
 func (x *Controller) LoginUser() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		type request struct {
@@ -45,25 +49,82 @@ func (x *Controller) LoginUser() func(w http.ResponseWriter, r *http.Request) {
 			Password string `json:"password"`
 		}
 
-		type response struct {
-			Token string `json:"token"`
-		}
-
 		var req request
-		engine.Chain(r, w, func(_ *engine.Context, req request) (response, error) {
+		engine.Chain(r, w, func(_ *engine.Context, req request) (*jsonLoginResponse, error) {
 			if req.Email == "" {
-				return response{}, app.ParamError(errors.New("email is required"))
+				return nil, app.ParamError(errors.New("email is required"))
 			}
 			if req.Password == "" {
-				return response{}, app.ParamError(errors.New("password is required"))
+				return nil, app.ParamError(errors.New("password is required"))
 			}
 
-			token, err := x.service.Login(user.LoginRequest{
+			result, err := x.service.Login(user.LoginRequest{
 				Provider:   domain.IdentityProviderPassword,
 				Identifier: req.Email,
 				Credential: req.Password,
+				Metadata:   sessionMetadata(r),
 			})
-			return response{Token: token}, err
+			if err != nil {
+				var lockedErr *user.LoginLockedError
+				switch {
+				case errors.Is(err, user.ErrInvalidCredentials), errors.Is(err, user.ErrUserDisabled):
+					return nil, app.Unauthorized(err)
+				case errors.Is(err, user.ErrEmailNotVerified):
+					return nil, app.Forbidden(err)
+				case errors.As(err, &lockedErr):
+					retryAfter := int(math.Ceil(time.Until(lockedErr.Until).Seconds()))
+					w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+					return nil, app.TooManyRequests(err)
+				}
+				return nil, err
+			}
+
+			var resp jsonLoginResponse
+			resp.fromDomain(result)
+
+			return &resp, nil
 		}).BindJSON(&req).Call(req).ResponseJSON()
 	}
 }
+
+type jsonAuthEvent struct {
+	ID         int32     `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	Type       string    `json:"type"`
+	Provider   string    `json:"provider"`
+	Identifier string    `json:"identifier"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+}
+
+// GetAuthEvents lists the latest logins and login attempts of the current user.
+func (x *Controller) GetAuthEvents() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonAuthEvent, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			events, err := x.service.GetAuthEvents(userID)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonEvents := make([]jsonAuthEvent, len(events))
+			for index, event := range events {
+				jsonEvents[index] = jsonAuthEvent{
+					ID:         event.ID,
+					CreatedAt:  event.CreatedAt,
+					Type:       event.Type.String(),
+					Provider:   string(event.Provider),
+					Identifier: event.Identifier,
+					IPAddress:  event.IPAddress,
+					UserAgent:  event.UserAgent,
+				}
+			}
+
+			return jsonEvents, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
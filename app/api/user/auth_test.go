@@ -7,16 +7,22 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/argon2"
 
+	"github.com/omegaatt36/bookly/app/api/engine"
 	"github.com/omegaatt36/bookly/app/api/user"
 	"github.com/omegaatt36/bookly/domain"
 	"github.com/omegaatt36/bookly/persistence/database"
 	"github.com/omegaatt36/bookly/persistence/repository"
 	"github.com/omegaatt36/bookly/persistence/sqlc"
 	"github.com/omegaatt36/bookly/service/auth"
+	"github.com/omegaatt36/bookly/service/oidc"
+	"github.com/omegaatt36/bookly/service/oidc/oidctest"
 )
 
 type testAuthSuite struct {
@@ -28,26 +34,101 @@ type testAuthSuite struct {
 	finalize func()
 
 	authenticator domain.Authenticator
+	notifier      *recordingNotifier
+	issuer        *oidctest.Issuer
 	userID        int32
 }
 
+// recordingNotifier keeps the notifications sent during a test
+type recordingNotifier struct {
+	notifications []domain.Notification
+}
+
+func (n *recordingNotifier) Notify(notification domain.Notification) error {
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
 func (s *testAuthSuite) SetupTest() {
 	s.finalize = database.TestingInitialize(database.PostgresOpt)
 	db := database.GetDB()
 	s.repo = repository.NewSQLCRepository(db)
 	s.router = http.NewServeMux()
 	s.authenticator = auth.NewJWTAuthorizator("salt", "secret")
+	s.notifier = &recordingNotifier{}
+	s.issuer = oidctest.NewIssuer("bookly", "client-secret")
+	oidcProvider := oidc.NewProvider(oidc.Option{
+		Issuer:       s.issuer.URL,
+		ClientID:     "bookly",
+		ClientSecret: "client-secret",
+		RedirectURL:  "http://web.test/auth/oidc/callback",
+	})
 	controller := user.NewController(s.repo,
 		user.WithAuthenticator(domain.IdentityProviderPassword, s.authenticator),
+		user.WithSessionRepository(s.repo),
+		user.WithPasswordResetRepository(s.repo),
+		user.WithEmailVerificationRepository(s.repo),
+		user.WithTwoFactorRepository(s.repo),
+		user.WithAPIKeyRepository(s.repo),
+		user.WithLoginAttemptRepository(s.repo),
+		user.WithAuthEventRepository(s.repo),
+		user.WithNotifier(s.notifier, "http://web.test"),
+		user.WithAuthenticator(domain.IdentityProviderOIDC, s.authenticator),
+		user.WithOIDCProvider(oidcProvider, s.repo, true),
 	)
 
+	authMiddleware := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result, err := s.authenticator.ValidateToken(domain.ValidateTokenRequest{
+				Token: strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "),
+			})
+			if err != nil || !result.Valid {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			ctx := engine.WithUserID(r.Context(), result.UserID)
+			ctx = engine.WithSessionID(ctx, result.SessionID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
 	s.router.HandleFunc("POST /auth/register", controller.RegisterUser())
 	s.router.HandleFunc("POST /auth/login", controller.LoginUser())
+	s.router.HandleFunc("POST /auth/login/2fa", controller.CompleteLogin())
+	s.router.HandleFunc("POST /auth/refresh", controller.RefreshToken())
+	s.router.HandleFunc("POST /auth/password/forgot", controller.ForgotPassword())
+	s.router.HandleFunc("POST /auth/verify-email", controller.VerifyEmail())
+	s.router.HandleFunc("POST /auth/verify-email/resend", controller.ResendVerificationEmail())
+	s.router.HandleFunc("POST /auth/password/reset", controller.ResetPassword())
+	s.router.HandleFunc("GET /auth/oidc/authorize", controller.StartOIDCLogin())
+	s.router.HandleFunc("POST /auth/oidc/callback", controller.CompleteOIDC())
+	s.router.Handle("POST /auth/oidc/link", authMiddleware(http.HandlerFunc(controller.StartOIDCLink())))
+	s.router.Handle("POST /auth/oidc/link/callback", authMiddleware(http.HandlerFunc(controller.CompleteOIDC())))
+	s.router.Handle("POST /auth/password", authMiddleware(http.HandlerFunc(controller.ChangePassword())))
+	s.router.Handle("POST /auth/logout", authMiddleware(http.HandlerFunc(controller.Logout())))
+	s.router.Handle("GET /auth/2fa", authMiddleware(http.HandlerFunc(controller.GetTwoFactorStatus())))
+	s.router.Handle("DELETE /auth/2fa", authMiddleware(http.HandlerFunc(controller.DisableTwoFactor())))
+	s.router.Handle("POST /auth/2fa/totp", authMiddleware(http.HandlerFunc(controller.EnrollTOTP())))
+	s.router.Handle("POST /auth/2fa/totp/confirm", authMiddleware(http.HandlerFunc(controller.ConfirmTOTP())))
+	s.router.Handle("GET /auth/events", authMiddleware(http.HandlerFunc(controller.GetAuthEvents())))
+	s.router.Handle("POST /api-keys", authMiddleware(http.HandlerFunc(controller.CreateAPIKey())))
+	s.router.Handle("GET /api-keys", authMiddleware(http.HandlerFunc(controller.GetAPIKeys())))
+	s.router.Handle("DELETE /api-keys/{id}", authMiddleware(http.HandlerFunc(controller.RevokeAPIKey())))
+	s.router.Handle("GET /sessions", authMiddleware(http.HandlerFunc(controller.GetSessions())))
+	s.router.Handle("DELETE /sessions", authMiddleware(http.HandlerFunc(controller.RevokeOtherSessions())))
+	s.router.Handle("DELETE /sessions/{id}", authMiddleware(http.HandlerFunc(controller.RevokeSession())))
+
+	// Sign-up shares its path with the internal register route, it is mounted like the server does
+	publicRouter := http.NewServeMux()
+	publicRouter.HandleFunc("POST /auth/register", controller.SignUp())
+	s.router.Handle("/public/", http.StripPrefix("/public", publicRouter))
 
 	s.NoError(sqlc.MigrateForTest(context.Background(), db))
 }
 
 func (s *testAuthSuite) TearDownTest() {
+	s.issuer.Close()
 	s.finalize()
 	s.router = nil
 	s.repo = nil
@@ -110,3 +191,321 @@ func (s *testAuthSuite) TestRegisterAndLogin() {
 		s.Equal(user.ID, result.UserID)
 	})
 }
+
+type tokensResponse struct {
+	Code int `json:"code"`
+	Data struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	} `json:"data"`
+}
+
+func (s *testAuthSuite) registerAndLogin(email string) tokensResponse {
+	reqBody := fmt.Sprintf(`{"email": %q, "password": "password"}`, email)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewBufferString(reqBody))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusCreated, w.Code)
+
+	return s.login(email)
+}
+
+func (s *testAuthSuite) login(email string) tokensResponse {
+	reqBody := fmt.Sprintf(`{"email": %q, "password": "password"}`, email)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBufferString(reqBody))
+	req.Header.Set("User-Agent", "test-agent")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp tokensResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.NotEmpty(resp.Data.Token)
+	s.NotEmpty(resp.Data.RefreshToken)
+
+	return resp
+}
+
+func (s *testAuthSuite) refresh(refreshToken string) *httptest.ResponseRecorder {
+	reqBody := fmt.Sprintf(`{"refresh_token": %q}`, refreshToken)
+	req := httptest.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewBufferString(reqBody))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func (s *testAuthSuite) TestRefreshRotatesToken() {
+	tokens := s.registerAndLogin("refresh@example.com")
+
+	w := s.refresh(tokens.Data.RefreshToken)
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp tokensResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.NotEmpty(resp.Data.Token)
+	s.NotEqual(tokens.Data.RefreshToken, resp.Data.RefreshToken)
+
+	result, err := s.authenticator.ValidateToken(domain.ValidateTokenRequest{Token: resp.Data.Token})
+	s.NoError(err)
+	s.True(result.Valid)
+	s.NotZero(result.SessionID)
+
+	// The rotated refresh token keeps working
+	w = s.refresh(resp.Data.RefreshToken)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *testAuthSuite) TestRefreshTokenReuseRevokesSession() {
+	tokens := s.registerAndLogin("reuse@example.com")
+
+	w := s.refresh(tokens.Data.RefreshToken)
+	s.Equal(http.StatusOK, w.Code)
+
+	var rotated tokensResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&rotated))
+
+	// Presenting the old refresh token again revokes the session
+	w = s.refresh(tokens.Data.RefreshToken)
+	s.Equal(http.StatusUnauthorized, w.Code)
+
+	w = s.refresh(rotated.Data.RefreshToken)
+	s.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func (s *testAuthSuite) TestSessionsListAndRevoke() {
+	first := s.registerAndLogin("sessions@example.com")
+	second := s.login("sessions@example.com")
+
+	type sessionsResponse struct {
+		Code int `json:"code"`
+		Data []struct {
+			ID        int32  `json:"id"`
+			UserAgent string `json:"user_agent"`
+			Current   bool   `json:"current"`
+		} `json:"data"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/sessions", nil)
+	req.Header.Set("Authorization", "Bearer "+first.Data.Token)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp sessionsResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Len(resp.Data, 2)
+
+	var otherID int32
+	for _, session := range resp.Data {
+		s.Equal("test-agent", session.UserAgent)
+		if !session.Current {
+			otherID = session.ID
+		}
+	}
+	s.NotZero(otherID)
+
+	// Revoke the second device
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/sessions/%d", otherID), nil)
+	req.Header.Set("Authorization", "Bearer "+first.Data.Token)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	session, err := s.repo.GetSessionByID(otherID)
+	s.NoError(err)
+	s.NotNil(session.RevokedAt)
+
+	w = s.refresh(second.Data.RefreshToken)
+	s.Equal(http.StatusUnauthorized, w.Code)
+
+	// Logging out revokes the current session
+	req = httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+	req.Header.Set("Authorization", "Bearer "+first.Data.Token)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	w = s.refresh(first.Data.RefreshToken)
+	s.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func (s *testAuthSuite) TestLoginRehashesLegacyCredential() {
+	email := "legacy@example.com"
+
+	userID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: email})
+	s.NoError(err)
+
+	// Hashes stored before per-identity salts were bare hex salted with the global salt
+	legacyHash := fmt.Sprintf("%x", argon2.IDKey([]byte("password"), []byte("salt"), 1, 64*1024, 4, 32))
+	verifiedAt := time.Now()
+	s.NoError(s.repo.AddIdentity(userID, domain.Identity{
+		Provider:   domain.IdentityProviderPassword,
+		Identifier: email,
+		Credential: legacyHash,
+		VerifiedAt: &verifiedAt,
+	}))
+
+	s.login(email)
+
+	_, identity, err := s.repo.GetUserByIdentity(domain.IdentityProviderPassword, email)
+	s.NoError(err)
+	s.True(strings.HasPrefix(identity.Credential, "$argon2id$"))
+	s.False(s.authenticator.NeedsRehash(identity.Credential))
+
+	// The upgraded hash keeps working
+	s.login(email)
+}
+
+func (s *testAuthSuite) loginWith(email, password string) int {
+	reqBody := fmt.Sprintf(`{"email": %q, "password": %q}`, email, password)
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBufferString(reqBody))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	return w.Code
+}
+
+func (s *testAuthSuite) TestChangePassword() {
+	email := "change@example.com"
+	tokens := s.registerAndLogin(email)
+	other := s.login(email)
+
+	changePassword := func(body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/auth/password", bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer "+tokens.Data.Token)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	s.Equal(http.StatusBadRequest, changePassword(`{"current_password": "wrong", "new_password": "new-password"}`))
+	s.Equal(http.StatusBadRequest, changePassword(`{"current_password": "password", "new_password": "short"}`))
+	s.Equal(http.StatusOK, changePassword(`{"current_password": "password", "new_password": "new-password"}`))
+
+	s.Equal(http.StatusUnauthorized, s.loginWith(email, "password"))
+	s.Equal(http.StatusOK, s.loginWith(email, "new-password"))
+
+	// Other devices are signed out, the current one is kept
+	s.Equal(http.StatusUnauthorized, s.refresh(other.Data.RefreshToken).Code)
+	s.Equal(http.StatusOK, s.refresh(tokens.Data.RefreshToken).Code)
+}
+
+func (s *testAuthSuite) TestResetPassword() {
+	email := "reset@example.com"
+	tokens := s.registerAndLogin(email)
+
+	forgotPassword := func(email string) {
+		req := httptest.NewRequest(http.MethodPost, "/auth/password/forgot", bytes.NewBufferString(fmt.Sprintf(`{"email": %q}`, email)))
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		s.Equal(http.StatusOK, w.Code)
+	}
+
+	// Unknown emails succeed without sending anything
+	forgotPassword("unknown@example.com")
+	s.Empty(s.notifier.notifications)
+
+	forgotPassword(email)
+	s.Len(s.notifier.notifications, 1)
+	notification := s.notifier.notifications[0]
+	s.Equal(email, notification.Recipient)
+
+	token := s.tokenFromLink("http://web.test/page/password/reset")
+
+	resetPassword := func(token, password string) int {
+		reqBody := fmt.Sprintf(`{"token": %q, "new_password": %q}`, token, password)
+		req := httptest.NewRequest(http.MethodPost, "/auth/password/reset", bytes.NewBufferString(reqBody))
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	s.Equal(http.StatusBadRequest, resetPassword("invalid-token", "new-password"))
+
+	// A short password is rejected without using up the token
+	s.Equal(http.StatusBadRequest, resetPassword(token, "short"))
+	s.Equal(http.StatusOK, resetPassword(token, "new-password"))
+
+	// Tokens are single-use
+	s.Equal(http.StatusBadRequest, resetPassword(token, "new-password"))
+
+	s.Equal(http.StatusOK, s.loginWith(email, "new-password"))
+
+	// Every session is signed out
+	s.Equal(http.StatusUnauthorized, s.refresh(tokens.Data.RefreshToken).Code)
+}
+
+// tokenFromLink extracts the token of the link in the last notification sent
+func (s *testAuthSuite) tokenFromLink(link string) string {
+	s.NotEmpty(s.notifier.notifications)
+	body := s.notifier.notifications[len(s.notifier.notifications)-1].Body
+
+	_, token, found := strings.Cut(body, link+"?token=")
+	s.True(found)
+	token, _, _ = strings.Cut(token, "\n")
+
+	return token
+}
+
+func (s *testAuthSuite) TestSignUpRequiresVerification() {
+	email := "signup@example.com"
+	reqBody := fmt.Sprintf(`{"email": %q, "password": "password"}`, email)
+
+	signUp := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/public/auth/register", bytes.NewBufferString(reqBody))
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	verifyEmail := func(token string) int {
+		req := httptest.NewRequest(http.MethodPost, "/auth/verify-email", bytes.NewBufferString(fmt.Sprintf(`{"token": %q}`, token)))
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Short passwords are rejected before anything is registered
+	req := httptest.NewRequest(http.MethodPost, "/public/auth/register",
+		bytes.NewBufferString(fmt.Sprintf(`{"email": %q, "password": "short"}`, email)))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Empty(s.notifier.notifications)
+
+	s.Equal(http.StatusCreated, signUp())
+	s.Len(s.notifier.notifications, 1)
+	s.Equal(email, s.notifier.notifications[0].Recipient)
+
+	// The same email cannot sign up twice
+	s.Equal(http.StatusBadRequest, signUp())
+
+	// Login is blocked until the email is verified
+	s.Equal(http.StatusForbidden, s.loginWith(email, "password"))
+	s.Equal(http.StatusUnauthorized, s.loginWith(email, "wrong-password"))
+
+	// Resending issues a new link
+	req = httptest.NewRequest(http.MethodPost, "/auth/verify-email/resend", bytes.NewBufferString(fmt.Sprintf(`{"email": %q}`, email)))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+	s.Len(s.notifier.notifications, 2)
+
+	token := s.tokenFromLink("http://web.test/page/verify-email")
+
+	s.Equal(http.StatusBadRequest, verifyEmail("invalid-token"))
+	s.Equal(http.StatusOK, verifyEmail(token))
+	s.Equal(http.StatusBadRequest, verifyEmail(token))
+
+	s.Equal(http.StatusOK, s.loginWith(email, "password"))
+
+	// Verified addresses get no more links
+	req = httptest.NewRequest(http.MethodPost, "/auth/verify-email/resend", bytes.NewBufferString(fmt.Sprintf(`{"email": %q}`, email)))
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+	s.Len(s.notifier.notifications, 2)
+}
@@ -45,3 +45,155 @@ func WithAuthenticator(identityProvider domain.IdentityProvider, authenticator d
 func (o *WithAuthenticatorOption) apply(c *Controller) {
 	c.service.RegisterAuthenticator(o.IdentityProvider, o.Authenticator)
 }
+
+// WithSessionRepositoryOption defines an option to register the repository storing login sessions.
+type WithSessionRepositoryOption struct {
+	SessionRepository domain.SessionRepository
+}
+
+// WithSessionRepository creates an option to register the repository storing login sessions.
+func WithSessionRepository(sessionRepo domain.SessionRepository) Option {
+	return &WithSessionRepositoryOption{
+		SessionRepository: sessionRepo,
+	}
+}
+
+func (o *WithSessionRepositoryOption) apply(c *Controller) {
+	c.service.RegisterSessionRepository(o.SessionRepository)
+}
+
+// WithNotifierOption defines an option to register the notifier delivering messages to users.
+type WithNotifierOption struct {
+	Notifier domain.Notifier
+	WebURL   string
+}
+
+// WithNotifier creates an option to register the notifier delivering messages to users,
+// and the base URL of the web app the links in those messages point to.
+func WithNotifier(notifier domain.Notifier, webURL string) Option {
+	return &WithNotifierOption{
+		Notifier: notifier,
+		WebURL:   webURL,
+	}
+}
+
+func (o *WithNotifierOption) apply(c *Controller) {
+	c.service.RegisterNotifier(o.Notifier, o.WebURL)
+}
+
+// WithPasswordResetRepositoryOption defines an option to register the repository storing password reset tokens.
+type WithPasswordResetRepositoryOption struct {
+	PasswordResetRepository domain.PasswordResetTokenRepository
+}
+
+// WithPasswordResetRepository creates an option to register the repository storing password reset tokens.
+func WithPasswordResetRepository(passwordResetRepo domain.PasswordResetTokenRepository) Option {
+	return &WithPasswordResetRepositoryOption{
+		PasswordResetRepository: passwordResetRepo,
+	}
+}
+
+func (o *WithPasswordResetRepositoryOption) apply(c *Controller) {
+	c.service.RegisterPasswordResetRepository(o.PasswordResetRepository)
+}
+
+// WithEmailVerificationRepositoryOption defines an option to register the repository storing email verification tokens.
+type WithEmailVerificationRepositoryOption struct {
+	EmailVerificationRepository domain.EmailVerificationTokenRepository
+}
+
+// WithEmailVerificationRepository creates an option to register the repository storing email verification tokens.
+func WithEmailVerificationRepository(emailVerificationRepo domain.EmailVerificationTokenRepository) Option {
+	return &WithEmailVerificationRepositoryOption{
+		EmailVerificationRepository: emailVerificationRepo,
+	}
+}
+
+func (o *WithEmailVerificationRepositoryOption) apply(c *Controller) {
+	c.service.RegisterEmailVerificationRepository(o.EmailVerificationRepository)
+}
+
+// WithTwoFactorRepositoryOption defines an option to register the repository storing two-factor authentication.
+type WithTwoFactorRepositoryOption struct {
+	TwoFactorRepository domain.TwoFactorRepository
+}
+
+// WithTwoFactorRepository creates an option to register the repository storing two-factor authentication.
+func WithTwoFactorRepository(twoFactorRepo domain.TwoFactorRepository) Option {
+	return &WithTwoFactorRepositoryOption{
+		TwoFactorRepository: twoFactorRepo,
+	}
+}
+
+func (o *WithTwoFactorRepositoryOption) apply(c *Controller) {
+	c.service.RegisterTwoFactorRepository(o.TwoFactorRepository)
+}
+
+// WithOIDCProviderOption defines an option to register the OpenID Connect provider users log in with.
+type WithOIDCProviderOption struct {
+	Provider              domain.OIDCProvider
+	AuthRequestRepository domain.OIDCAuthRequestRepository
+	AllowSignUp           bool
+}
+
+// WithOIDCProvider creates an option to register the OpenID Connect provider users log in with,
+// and the repository storing its authorization requests.
+func WithOIDCProvider(provider domain.OIDCProvider, authRequestRepo domain.OIDCAuthRequestRepository, allowSignUp bool) Option {
+	return &WithOIDCProviderOption{
+		Provider:              provider,
+		AuthRequestRepository: authRequestRepo,
+		AllowSignUp:           allowSignUp,
+	}
+}
+
+func (o *WithOIDCProviderOption) apply(c *Controller) {
+	c.service.RegisterOIDCProvider(o.Provider, o.AuthRequestRepository, o.AllowSignUp)
+}
+
+// WithAPIKeyRepositoryOption defines an option to register the repository storing personal API keys.
+type WithAPIKeyRepositoryOption struct {
+	APIKeyRepository domain.APIKeyRepository
+}
+
+// WithAPIKeyRepository creates an option to register the repository storing personal API keys.
+func WithAPIKeyRepository(apiKeyRepo domain.APIKeyRepository) Option {
+	return &WithAPIKeyRepositoryOption{
+		APIKeyRepository: apiKeyRepo,
+	}
+}
+
+func (o *WithAPIKeyRepositoryOption) apply(c *Controller) {
+	c.service.RegisterAPIKeyRepository(o.APIKeyRepository)
+}
+
+// WithLoginAttemptRepositoryOption defines an option to register the repository tracking failed logins.
+type WithLoginAttemptRepositoryOption struct {
+	LoginAttemptRepository domain.LoginAttemptRepository
+}
+
+// WithLoginAttemptRepository creates an option to register the repository tracking failed logins.
+func WithLoginAttemptRepository(loginAttemptRepo domain.LoginAttemptRepository) Option {
+	return &WithLoginAttemptRepositoryOption{
+		LoginAttemptRepository: loginAttemptRepo,
+	}
+}
+
+func (o *WithLoginAttemptRepositoryOption) apply(c *Controller) {
+	c.service.RegisterLoginAttemptRepository(o.LoginAttemptRepository)
+}
+
+// WithAuthEventRepositoryOption defines an option to register the repository recording logins.
+type WithAuthEventRepositoryOption struct {
+	AuthEventRepository domain.AuthEventRepository
+}
+
+// WithAuthEventRepository creates an option to register the repository recording logins.
+func WithAuthEventRepository(authEventRepo domain.AuthEventRepository) Option {
+	return &WithAuthEventRepositoryOption{
+		AuthEventRepository: authEventRepo,
+	}
+}
+
+func (o *WithAuthEventRepositoryOption) apply(c *Controller) {
+	c.service.RegisterAuthEventRepository(o.AuthEventRepository)
+}
@@ -0,0 +1,110 @@
+package user_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+func (s *testAuthSuite) TestLoginLockout() {
+	email := "lockout@example.com"
+	tokens := s.registerAndLogin(email)
+
+	for range domain.LoginFreeAttempts {
+		s.Equal(http.StatusUnauthorized, s.loginWith(email, "wrong-password"))
+	}
+
+	// Even the right password is refused while the identifier is locked
+	attempt, err := s.repo.GetLoginAttempt(domain.IdentityProviderPassword, email)
+	s.Require().NoError(err)
+	s.EqualValues(domain.LoginFreeAttempts, attempt.FailedCount)
+	s.Require().NotNil(attempt.LockedUntil)
+	s.True(attempt.IsLocked(time.Now()))
+	s.Equal(http.StatusTooManyRequests, s.loginWith(email, "password"))
+
+	// Once the lock expires the right password goes through and clears the failures
+	s.NoError(s.repo.LockLogin(domain.IdentityProviderPassword, email, time.Now().Add(-time.Second)))
+	s.Equal(http.StatusOK, s.loginWith(email, "password"))
+
+	_, err = s.repo.GetLoginAttempt(domain.IdentityProviderPassword, email)
+	s.ErrorIs(err, domain.ErrNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/events", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens.Data.Token)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	var eventsResp struct {
+		Data []struct {
+			Type string `json:"type"`
+		} `json:"data"`
+	}
+	s.NoError(json.NewDecoder(w.Body).Decode(&eventsResp))
+
+	types := make([]string, len(eventsResp.Data))
+	for i, event := range eventsResp.Data {
+		types[i] = event.Type
+	}
+	s.Equal([]string{
+		domain.AuthEventTypeLoginSucceeded.String(),
+		domain.AuthEventTypeLoginLocked.String(),
+		domain.AuthEventTypeLoginFailed.String(),
+		domain.AuthEventTypeLoginFailed.String(),
+		domain.AuthEventTypeLoginFailed.String(),
+		domain.AuthEventTypeLoginSucceeded.String(),
+	}, types)
+}
+
+func (s *testAuthSuite) TestLoginLockoutOfUnknownIdentifier() {
+	email := "nobody@example.com"
+
+	for range domain.LoginFreeAttempts {
+		s.Equal(http.StatusUnauthorized, s.loginWith(email, "password"))
+	}
+
+	s.Equal(http.StatusTooManyRequests, s.loginWith(email, "password"))
+}
+
+func (s *testAuthSuite) TestLoginFailuresAreClearedBySession() {
+	email := "lockout-2fa@example.com"
+	tokens := s.registerAndLogin(email)
+	secret, _ := s.enableTwoFactor(tokens.Data.Token)
+
+	for range domain.LoginFreeAttempts - 1 {
+		s.Equal(http.StatusUnauthorized, s.loginWith(email, "wrong-password"))
+	}
+
+	// The right password alone does not clear the failures, the second factor is still missing
+	challenge := s.loginChallenge(email)
+	attempt, err := s.repo.GetLoginAttempt(domain.IdentityProviderPassword, email)
+	s.Require().NoError(err)
+	s.EqualValues(domain.LoginFreeAttempts-1, attempt.FailedCount)
+
+	s.Equal(http.StatusOK, s.completeLogin(challenge, totpCode(secret, time.Now().Add(30*time.Second))).Code)
+	_, err = s.repo.GetLoginAttempt(domain.IdentityProviderPassword, email)
+	s.ErrorIs(err, domain.ErrNotFound)
+}
+
+func (s *testAuthSuite) TestLoginLockoutBacksOffExponentially() {
+	s.Equal(time.Duration(0), domain.LoginLockout(domain.LoginFreeAttempts-1))
+	s.Equal(domain.LoginBackoffBase, domain.LoginLockout(domain.LoginFreeAttempts))
+	s.Equal(4*domain.LoginBackoffBase, domain.LoginLockout(domain.LoginFreeAttempts+2))
+	s.Equal(domain.LoginMaxLockout, domain.LoginLockout(domain.LoginFreeAttempts+20))
+}
+
+func (s *testAuthSuite) TestDisabledUserCannotLogin() {
+	email := "disabled@example.com"
+	tokens := s.registerAndLogin(email)
+
+	result, err := s.authenticator.ValidateToken(domain.ValidateTokenRequest{Token: tokens.Data.Token})
+	s.Require().NoError(err)
+
+	disabled := true
+	s.NoError(s.repo.UpdateUser(domain.UpdateUserRequest{ID: result.UserID, Disabled: &disabled}))
+
+	s.Equal(http.StatusUnauthorized, s.loginWith(email, "password"))
+}
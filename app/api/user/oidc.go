@@ -0,0 +1,165 @@
+package user
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/user"
+)
+
+// oidcStateCookie keeps the state of an OIDC flow in the browser that started it. It is lax,
+// as the provider sends the user back with a top-level navigation from another site.
+const oidcStateCookie = "oidc_state"
+
+type jsonOIDCAuthorization struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+func (r *jsonOIDCAuthorization) fromDomain(authorization *user.OIDCAuthorization) {
+	r.AuthorizationURL = authorization.URL
+	r.State = authorization.State
+}
+
+func setOIDCStateCookie(w http.ResponseWriter, state string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   int(domain.OIDCAuthRequestTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// checkOIDCState requires the state of a callback to match the one kept in the browser, then
+// clears it, so that a callback cannot be replayed in the browser of someone else.
+func checkOIDCState(w http.ResponseWriter, r *http.Request, state string) error {
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil {
+		return app.ParamError(user.ErrInvalidOIDCState)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	if subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		return app.ParamError(user.ErrInvalidOIDCState)
+	}
+
+	return nil
+}
+
+// StartOIDCLogin handles starting a login with the OpenID Connect provider
+func (x *Controller) StartOIDCLogin() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(_ *engine.Context, _ *engine.Empty) (*jsonOIDCAuthorization, error) {
+			authorization, err := x.service.StartOIDC(user.StartOIDCRequest{})
+			if err != nil {
+				return nil, err
+			}
+
+			setOIDCStateCookie(w, authorization.State)
+
+			var resp jsonOIDCAuthorization
+			resp.fromDomain(authorization)
+
+			return &resp, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// StartOIDCLink handles starting to link an OpenID Connect identity to the current user
+func (x *Controller) StartOIDCLink() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*jsonOIDCAuthorization, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			authorization, err := x.service.StartOIDC(user.StartOIDCRequest{LinkUserID: userID})
+			if err != nil {
+				return nil, err
+			}
+
+			setOIDCStateCookie(w, authorization.State)
+
+			var resp jsonOIDCAuthorization
+			resp.fromDomain(authorization)
+
+			return &resp, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// CompleteOIDC handles the callback of the OpenID Connect provider. Signed out, it answers
+// like LoginUser; signed in, it links the identity to the user who started the link.
+func (x *Controller) CompleteOIDC() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			State string `json:"state"`
+			Code  string `json:"code"`
+		}
+
+		type response struct {
+			*jsonLoginResponse
+
+			Linked bool `json:"linked,omitempty"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*response, error) {
+			if req.State == "" {
+				return nil, app.ParamError(errors.New("state is required"))
+			}
+			if req.Code == "" {
+				return nil, app.ParamError(errors.New("code is required"))
+			}
+
+			if err := checkOIDCState(w, r, req.State); err != nil {
+				return nil, err
+			}
+
+			result, err := x.service.CompleteOIDC(user.CompleteOIDCRequest{
+				State:    req.State,
+				Code:     req.Code,
+				UserID:   ctx.GetUserID(),
+				Metadata: sessionMetadata(r),
+			})
+			if err != nil {
+				switch {
+				case errors.Is(err, user.ErrInvalidOIDCState):
+					return nil, app.ParamError(err)
+				case errors.Is(err, user.ErrOIDCLoginFailed),
+					errors.Is(err, user.ErrOIDCIdentityNotLinked),
+					errors.Is(err, user.ErrUserDisabled):
+					return nil, app.Unauthorized(err)
+				case errors.Is(err, user.ErrOIDCIdentityTaken):
+					return nil, app.Forbidden(err)
+				}
+				return nil, err
+			}
+
+			if result.Linked {
+				return &response{Linked: true}, nil
+			}
+
+			var resp jsonLoginResponse
+			resp.fromDomain(result.Login)
+
+			return &response{jsonLoginResponse: &resp}, nil
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
@@ -0,0 +1,263 @@
+package user_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/oidc/oidctest"
+)
+
+type oidcCallbackResponse struct {
+	Data struct {
+		Token             string `json:"token"`
+		RefreshToken      string `json:"refresh_token"`
+		TwoFactorRequired bool   `json:"two_factor_required"`
+		ChallengeToken    string `json:"challenge_token"`
+		Linked            bool   `json:"linked"`
+	} `json:"data"`
+}
+
+// oidcAuthorization is an OIDC flow started by a browser, with the state cookie it was given
+type oidcAuthorization struct {
+	url         string
+	stateCookie *http.Cookie
+}
+
+func (s *testAuthSuite) decodeAuthorization(w *httptest.ResponseRecorder) oidcAuthorization {
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	var resp struct {
+		Data struct {
+			AuthorizationURL string `json:"authorization_url"`
+			State            string `json:"state"`
+		} `json:"data"`
+	}
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.Contains(resp.Data.AuthorizationURL, s.issuer.URL)
+
+	cookies := w.Result().Cookies()
+	s.Require().Len(cookies, 1)
+	s.Equal(http.SameSiteLaxMode, cookies[0].SameSite)
+	s.True(cookies[0].HttpOnly)
+	s.Equal(resp.Data.State, cookies[0].Value)
+
+	return oidcAuthorization{url: resp.Data.AuthorizationURL, stateCookie: cookies[0]}
+}
+
+// postOIDCCallback posts a callback from the browser holding the state cookie, signed in
+// with the token when it is not empty
+func (s *testAuthSuite) postOIDCCallback(path, token, state, code string, stateCookie *http.Cookie) *httptest.ResponseRecorder {
+	reqBody := fmt.Sprintf(`{"state": %q, "code": %q}`, state, code)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(reqBody))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if stateCookie != nil {
+		req.AddCookie(stateCookie)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	return w
+}
+
+// oidcCallback follows the authorization URL at the mock issuer and posts the login callback
+func (s *testAuthSuite) oidcCallback(authorization oidcAuthorization) *httptest.ResponseRecorder {
+	code, state, err := s.issuer.Authorize(authorization.url)
+	s.Require().NoError(err)
+
+	return s.postOIDCCallback("/auth/oidc/callback", "", state, code, authorization.stateCookie)
+}
+
+// oidcLinkCallback follows the authorization URL at the mock issuer and posts the link
+// callback signed in with the token
+func (s *testAuthSuite) oidcLinkCallback(authorization oidcAuthorization, token string) *httptest.ResponseRecorder {
+	code, state, err := s.issuer.Authorize(authorization.url)
+	s.Require().NoError(err)
+
+	return s.postOIDCCallback("/auth/oidc/link/callback", token, state, code, authorization.stateCookie)
+}
+
+func (s *testAuthSuite) startOIDCLogin() oidcAuthorization {
+	req := httptest.NewRequest(http.MethodGet, "/auth/oidc/authorize", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	return s.decodeAuthorization(w)
+}
+
+func (s *testAuthSuite) oidcLogin() *httptest.ResponseRecorder {
+	return s.oidcCallback(s.startOIDCLogin())
+}
+
+func (s *testAuthSuite) oidcLink(token string) *httptest.ResponseRecorder {
+	return s.oidcLinkCallback(s.decodeAuthorization(s.postWithToken("/auth/oidc/link", token, "")), token)
+}
+
+func (s *testAuthSuite) TestOIDCLoginSignsUpNewUser() {
+	s.issuer.SetUser(oidctest.User{
+		Subject:       "new-subject",
+		Email:         "sso@example.com",
+		EmailVerified: true,
+		Name:          "SSO User",
+	})
+
+	w := s.oidcLogin()
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	var resp oidcCallbackResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.NotEmpty(resp.Data.Token)
+	s.NotEmpty(resp.Data.RefreshToken)
+	s.False(resp.Data.Linked)
+
+	// Logging in again finds the same user
+	w = s.oidcLogin()
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	var again oidcCallbackResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&again))
+
+	first, err := s.authenticator.ValidateToken(domain.ValidateTokenRequest{Token: resp.Data.Token})
+	s.NoError(err)
+	second, err := s.authenticator.ValidateToken(domain.ValidateTokenRequest{Token: again.Data.Token})
+	s.NoError(err)
+	s.Equal(first.UserID, second.UserID)
+
+	users, err := s.repo.GetAllUsers()
+	s.NoError(err)
+	s.Len(users, 1)
+	s.Equal("SSO User", users[0].Name)
+}
+
+func (s *testAuthSuite) TestOIDCStateIsSingleUse() {
+	authorization := s.startOIDCLogin()
+
+	code, state, err := s.issuer.Authorize(authorization.url)
+	s.Require().NoError(err)
+
+	w := s.postOIDCCallback("/auth/oidc/callback", "", state, code, authorization.stateCookie)
+	s.Equal(http.StatusOK, w.Code)
+
+	w = s.postOIDCCallback("/auth/oidc/callback", "", state, code, authorization.stateCookie)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	// An unknown state is rejected before the code reaches the issuer
+	w = s.postOIDCCallback("/auth/oidc/callback", "", "unknown", code, &http.Cookie{Name: "oidc_state", Value: "unknown"})
+	s.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (s *testAuthSuite) TestOIDCCallbackRequiresStateCookie() {
+	s.issuer.SetUser(oidctest.User{Subject: "cookie-subject", Email: "cookie@example.com", EmailVerified: true})
+
+	authorization := s.startOIDCLogin()
+	code, state, err := s.issuer.Authorize(authorization.url)
+	s.Require().NoError(err)
+
+	// Another browser, without the state cookie or with the one of its own flow, cannot
+	// complete the flow
+	w := s.postOIDCCallback("/auth/oidc/callback", "", state, code, nil)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	other := s.startOIDCLogin()
+	w = s.postOIDCCallback("/auth/oidc/callback", "", state, code, other.stateCookie)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	// The flow is still usable by the browser that started it, which gets the cookie cleared
+	w = s.postOIDCCallback("/auth/oidc/callback", "", state, code, authorization.stateCookie)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	cookies := w.Result().Cookies()
+	s.Require().Len(cookies, 1)
+	s.Equal("oidc_state", cookies[0].Name)
+	s.Negative(cookies[0].MaxAge)
+}
+
+func (s *testAuthSuite) TestOIDCLinkIsBoundToUser() {
+	owner := s.registerAndLogin("link-owner@example.com")
+	other := s.registerAndLogin("link-other@example.com")
+	s.issuer.SetUser(oidctest.User{Subject: "bound-subject", Email: "bound@example.com", EmailVerified: true})
+
+	// A link cannot be completed signed out, nor by another user
+	authorization := s.decodeAuthorization(s.postWithToken("/auth/oidc/link", owner.Data.Token, ""))
+	w := s.oidcCallback(authorization)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	authorization = s.decodeAuthorization(s.postWithToken("/auth/oidc/link", owner.Data.Token, ""))
+	w = s.oidcLinkCallback(authorization, other.Data.Token)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	// Nor a login completed as a link
+	w = s.oidcLinkCallback(s.startOIDCLogin(), owner.Data.Token)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	w = s.oidcLink(owner.Data.Token)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	var resp oidcCallbackResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.True(resp.Data.Linked)
+}
+
+func (s *testAuthSuite) TestOIDCLoginRequiresLinkForExistingEmail() {
+	email := "linked@example.com"
+	tokens := s.registerAndLogin(email)
+	s.issuer.SetUser(oidctest.User{
+		Subject:       "existing-subject",
+		Email:         email,
+		EmailVerified: true,
+	})
+
+	// The address has a password account, so the identity is not signed up on its own
+	w := s.oidcLogin()
+	s.Equal(http.StatusUnauthorized, w.Code)
+
+	w = s.oidcLink(tokens.Data.Token)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	var linkResp oidcCallbackResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&linkResp))
+	s.True(linkResp.Data.Linked)
+	s.Empty(linkResp.Data.Token)
+
+	w = s.oidcLogin()
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	var loginResp oidcCallbackResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&loginResp))
+
+	result, err := s.authenticator.ValidateToken(domain.ValidateTokenRequest{Token: loginResp.Data.Token})
+	s.NoError(err)
+	passwordUser, err := s.authenticator.ValidateToken(domain.ValidateTokenRequest{Token: tokens.Data.Token})
+	s.NoError(err)
+	s.Equal(passwordUser.UserID, result.UserID)
+
+	// Another user cannot take the identity over
+	other := s.registerAndLogin("other@example.com")
+	w = s.oidcLink(other.Data.Token)
+	s.Equal(http.StatusForbidden, w.Code)
+}
+
+func (s *testAuthSuite) TestOIDCLoginHonorsTwoFactor() {
+	email := "sso-2fa@example.com"
+	tokens := s.registerAndLogin(email)
+	s.issuer.SetUser(oidctest.User{Subject: "2fa-subject", Email: email, EmailVerified: true})
+
+	w := s.oidcLink(tokens.Data.Token)
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	s.enableTwoFactor(tokens.Data.Token)
+
+	w = s.oidcLogin()
+	s.Require().Equal(http.StatusOK, w.Code)
+
+	var resp oidcCallbackResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.True(resp.Data.TwoFactorRequired)
+	s.NotEmpty(resp.Data.ChallengeToken)
+	s.Empty(resp.Data.Token)
+}
@@ -0,0 +1,93 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/service/user"
+)
+
+// ChangePassword handles changing the password of the current user, which requires the current password
+func (x *Controller) ChangePassword() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			CurrentPassword string `json:"current_password"`
+			NewPassword     string `json:"new_password"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			if req.CurrentPassword == "" {
+				return nil, app.ParamError(errors.New("current_password is required"))
+			}
+			if req.NewPassword == "" {
+				return nil, app.ParamError(errors.New("new_password is required"))
+			}
+
+			err := x.service.ChangePassword(user.ChangePasswordRequest{
+				UserID:          userID,
+				SessionID:       ctx.GetSessionID(),
+				CurrentPassword: req.CurrentPassword,
+				NewPassword:     req.NewPassword,
+			})
+			if errors.Is(err, user.ErrIncorrectPassword) || errors.Is(err, user.ErrPasswordTooShort) {
+				return nil, app.ParamError(err)
+			}
+
+			return nil, err
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// ForgotPassword handles requesting a password reset link. It succeeds whether or not
+// the email belongs to an account.
+func (x *Controller) ForgotPassword() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Email string `json:"email"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(_ *engine.Context, req request) (*engine.Empty, error) {
+			if req.Email == "" {
+				return nil, app.ParamError(errors.New("email is required"))
+			}
+
+			return nil, x.service.RequestPasswordReset(req.Email)
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// ResetPassword handles setting a new password with a password reset token
+func (x *Controller) ResetPassword() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Token       string `json:"token"`
+			NewPassword string `json:"new_password"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(_ *engine.Context, req request) (*engine.Empty, error) {
+			if req.Token == "" {
+				return nil, app.ParamError(errors.New("token is required"))
+			}
+			if req.NewPassword == "" {
+				return nil, app.ParamError(errors.New("new_password is required"))
+			}
+
+			err := x.service.ResetPassword(req.Token, req.NewPassword)
+			if errors.Is(err, user.ErrInvalidResetToken) || errors.Is(err, user.ErrPasswordTooShort) {
+				return nil, app.ParamError(err)
+			}
+
+			return nil, err
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
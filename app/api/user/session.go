@@ -0,0 +1,155 @@
+package user
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/user"
+)
+
+type jsonAuthTokens struct {
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func (t *jsonAuthTokens) fromDomain(tokens *domain.AuthTokens) {
+	t.Token = tokens.AccessToken
+	t.RefreshToken = tokens.RefreshToken
+	t.ExpiresAt = tokens.ExpiresAt
+}
+
+type jsonSession struct {
+	ID         int32     `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	Provider   string    `json:"provider"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+func (s *jsonSession) fromDomain(session *domain.Session) {
+	s.ID = session.ID
+	s.CreatedAt = session.CreatedAt
+	s.Provider = string(session.Provider)
+	s.UserAgent = session.UserAgent
+	s.IPAddress = session.IPAddress
+	s.LastUsedAt = session.LastUsedAt
+	s.ExpiresAt = session.ExpiresAt
+}
+
+// sessionMetadata describes the device a request comes from.
+func sessionMetadata(r *http.Request) user.SessionMetadata {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return user.SessionMetadata{
+		UserAgent: r.UserAgent(),
+		IPAddress: ip,
+	}
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a rotated refresh token.
+func (x *Controller) RefreshToken() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			RefreshToken string `json:"refresh_token"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(_ *engine.Context, req request) (*jsonAuthTokens, error) {
+			if req.RefreshToken == "" {
+				return nil, app.ParamError(errors.New("refresh_token is required"))
+			}
+
+			tokens, err := x.service.RefreshSession(req.RefreshToken)
+			if err != nil {
+				if errors.Is(err, user.ErrInvalidRefreshToken) || errors.Is(err, user.ErrUserDisabled) {
+					return nil, app.Unauthorized(err)
+				}
+				return nil, err
+			}
+
+			var resp jsonAuthTokens
+			resp.fromDomain(tokens)
+
+			return &resp, nil
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// Logout revokes the session of the current access token.
+func (x *Controller) Logout() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			if ctx.GetSessionID() == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			return nil, x.service.Logout(ctx.GetSessionID())
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// GetSessions lists the active sessions of the current user.
+func (x *Controller) GetSessions() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonSession, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			sessions, err := x.service.GetActiveSessions(userID)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonSessions := make([]jsonSession, len(sessions))
+			for index, session := range sessions {
+				jsonSessions[index].fromDomain(session)
+				jsonSessions[index].Current = session.ID == ctx.GetSessionID()
+			}
+
+			return jsonSessions, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// RevokeSession signs out one of the current user's devices.
+func (x *Controller) RevokeSession() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var id int32
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			return nil, x.service.RevokeSession(userID, id)
+		}).Param("id", &id).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// RevokeOtherSessions signs out every device of the current user except the current one.
+func (x *Controller) RevokeOtherSessions() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*engine.Empty, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			return nil, x.service.RevokeOtherSessions(userID, ctx.GetSessionID())
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
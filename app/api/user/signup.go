@@ -0,0 +1,83 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/service/user"
+)
+
+// SignUp handles users registering themselves. The account can be used once the
+// email address is verified through the link sent to it.
+func (x *Controller) SignUp() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Email    string `json:"email"`
+			Password string `json:"password"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(_ *engine.Context, req request) (*engine.Empty, error) {
+			if req.Email == "" {
+				return nil, app.ParamError(errors.New("email is required"))
+			}
+			if req.Password == "" {
+				return nil, app.ParamError(errors.New("password is required"))
+			}
+
+			err := x.service.SignUp(user.SignUpRequest{
+				Email:    req.Email,
+				Password: req.Password,
+			})
+			if errors.Is(err, user.ErrEmailTaken) || errors.Is(err, user.ErrPasswordTooShort) {
+				return nil, app.ParamError(err)
+			}
+
+			return nil, err
+		}).BindJSON(&req).Call(req).ResponseCreated()
+	}
+}
+
+// VerifyEmail handles verifying an email address with the token from the verification link
+func (x *Controller) VerifyEmail() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Token string `json:"token"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(_ *engine.Context, req request) (*engine.Empty, error) {
+			if req.Token == "" {
+				return nil, app.ParamError(errors.New("token is required"))
+			}
+
+			err := x.service.VerifyEmail(req.Token)
+			if errors.Is(err, user.ErrInvalidVerificationToken) {
+				return nil, app.ParamError(err)
+			}
+
+			return nil, err
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// ResendVerificationEmail handles sending a new verification link. It succeeds whether or not
+// the email belongs to an unverified account.
+func (x *Controller) ResendVerificationEmail() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Email string `json:"email"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(_ *engine.Context, req request) (*engine.Empty, error) {
+			if req.Email == "" {
+				return nil, app.ParamError(errors.New("email is required"))
+			}
+
+			return nil, x.service.ResendVerificationEmail(req.Email)
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
@@ -0,0 +1,228 @@
+package user
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/omegaatt36/bookly/app"
+	"github.com/omegaatt36/bookly/app/api/engine"
+	"github.com/omegaatt36/bookly/service/user"
+)
+
+// jsonLoginResponse holds either the session tokens, or the challenge of a login
+// waiting for a second factor.
+type jsonLoginResponse struct {
+	*jsonAuthTokens
+
+	TwoFactorRequired  bool       `json:"two_factor_required,omitempty"`
+	ChallengeToken     string     `json:"challenge_token,omitempty"`
+	ChallengeExpiresAt *time.Time `json:"challenge_expires_at,omitempty"`
+}
+
+func (r *jsonLoginResponse) fromDomain(result *user.LoginResult) {
+	if result.Challenge != nil {
+		r.TwoFactorRequired = true
+		r.ChallengeToken = result.Challenge.Token
+		r.ChallengeExpiresAt = &result.Challenge.ExpiresAt
+		return
+	}
+
+	r.jsonAuthTokens = &jsonAuthTokens{}
+	r.jsonAuthTokens.fromDomain(result.Tokens)
+}
+
+type jsonTwoFactorStatus struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+type jsonRecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func toTwoFactorError(err error) error {
+	switch {
+	case errors.Is(err, user.ErrInvalidTwoFactorCode),
+		errors.Is(err, user.ErrTwoFactorEnabled),
+		errors.Is(err, user.ErrTwoFactorNotEnabled):
+		return app.ParamError(err)
+	}
+
+	return err
+}
+
+// CompleteLogin handles exchanging a login challenge and a TOTP or recovery code for session tokens
+func (x *Controller) CompleteLogin() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			ChallengeToken string `json:"challenge_token"`
+			Code           string `json:"code"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(_ *engine.Context, req request) (*jsonAuthTokens, error) {
+			if req.ChallengeToken == "" {
+				return nil, app.ParamError(errors.New("challenge_token is required"))
+			}
+			if req.Code == "" {
+				return nil, app.ParamError(errors.New("code is required"))
+			}
+
+			tokens, err := x.service.CompleteLogin(user.CompleteLoginRequest{
+				ChallengeToken: req.ChallengeToken,
+				Code:           req.Code,
+				Metadata:       sessionMetadata(r),
+			})
+			if err != nil {
+				var lockedErr *user.LoginLockedError
+				switch {
+				case errors.Is(err, user.ErrInvalidTwoFactorCode),
+					errors.Is(err, user.ErrInvalidTwoFactorChallenge),
+					errors.Is(err, user.ErrUserDisabled):
+					return nil, app.Unauthorized(err)
+				case errors.As(err, &lockedErr):
+					retryAfter := int(math.Ceil(time.Until(lockedErr.Until).Seconds()))
+					w.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+					return nil, app.TooManyRequests(err)
+				}
+				return nil, err
+			}
+
+			var resp jsonAuthTokens
+			resp.fromDomain(tokens)
+
+			return &resp, nil
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// GetTwoFactorStatus handles the retrieval of the two-factor authentication status of the current user
+func (x *Controller) GetTwoFactorStatus() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*jsonTwoFactorStatus, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			status, err := x.service.GetTwoFactorStatus(userID)
+			if err != nil {
+				return nil, err
+			}
+
+			return &jsonTwoFactorStatus{
+				Enabled:                status.Enabled,
+				RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+			}, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// EnrollTOTP handles generating a TOTP secret for the current user, to be confirmed with ConfirmTOTP
+func (x *Controller) EnrollTOTP() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type response struct {
+			Secret          string `json:"secret"`
+			ProvisioningURI string `json:"provisioning_uri"`
+		}
+
+		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) (*response, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			enrollment, err := x.service.EnrollTOTP(userID)
+			if err != nil {
+				return nil, toTwoFactorError(err)
+			}
+
+			return &response{
+				Secret:          enrollment.Secret,
+				ProvisioningURI: enrollment.ProvisioningURI,
+			}, nil
+		}).Call(&engine.Empty{}).ResponseJSON()
+	}
+}
+
+// ConfirmTOTP handles enabling two-factor authentication with the first code of the enrolled secret
+func (x *Controller) ConfirmTOTP() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Code string `json:"code"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*jsonRecoveryCodes, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			if req.Code == "" {
+				return nil, app.ParamError(errors.New("code is required"))
+			}
+
+			codes, err := x.service.ConfirmTOTP(userID, req.Code)
+			if err != nil {
+				return nil, toTwoFactorError(err)
+			}
+
+			return &jsonRecoveryCodes{RecoveryCodes: codes}, nil
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// RegenerateRecoveryCodes handles replacing the recovery codes of the current user
+func (x *Controller) RegenerateRecoveryCodes() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Code string `json:"code"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*jsonRecoveryCodes, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			if req.Code == "" {
+				return nil, app.ParamError(errors.New("code is required"))
+			}
+
+			codes, err := x.service.RegenerateRecoveryCodes(userID, req.Code)
+			if err != nil {
+				return nil, toTwoFactorError(err)
+			}
+
+			return &jsonRecoveryCodes{RecoveryCodes: codes}, nil
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
+
+// DisableTwoFactor handles turning off two-factor authentication for the current user
+func (x *Controller) DisableTwoFactor() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type request struct {
+			Code string `json:"code"`
+		}
+
+		var req request
+		engine.Chain(r, w, func(ctx *engine.Context, req request) (*engine.Empty, error) {
+			userID := ctx.GetUserID()
+			if userID == 0 {
+				return nil, app.Unauthorized(errors.New("user not authenticated"))
+			}
+
+			if req.Code == "" {
+				return nil, app.ParamError(errors.New("code is required"))
+			}
+
+			return nil, toTwoFactorError(x.service.DisableTwoFactor(userID, req.Code))
+		}).BindJSON(&req).Call(req).ResponseJSON()
+	}
+}
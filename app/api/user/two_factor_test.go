@@ -0,0 +1,209 @@
+package user_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// totpCode computes the TOTP code of a base32 secret at the given time
+func totpCode(secret string, at time.Time) string {
+	key, _ := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(at.Unix()/30))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+func (s *testAuthSuite) postWithToken(path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	return w
+}
+
+// enableTwoFactor enrolls and confirms a TOTP secret, returning the secret and the recovery codes
+func (s *testAuthSuite) enableTwoFactor(token string) (string, []string) {
+	w := s.postWithToken("/auth/2fa/totp", token, "")
+	s.Equal(http.StatusOK, w.Code)
+
+	var enrollResp struct {
+		Data struct {
+			Secret          string `json:"secret"`
+			ProvisioningURI string `json:"provisioning_uri"`
+		} `json:"data"`
+	}
+	s.NoError(json.NewDecoder(w.Body).Decode(&enrollResp))
+	s.NotEmpty(enrollResp.Data.Secret)
+	s.Contains(enrollResp.Data.ProvisioningURI, "otpauth://totp/")
+
+	// A wrong code does not enable it
+	w = s.postWithToken("/auth/2fa/totp/confirm", token, `{"code": "000000"}`)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	code := totpCode(enrollResp.Data.Secret, time.Now())
+	w = s.postWithToken("/auth/2fa/totp/confirm", token, fmt.Sprintf(`{"code": %q}`, code))
+	s.Equal(http.StatusOK, w.Code)
+
+	var confirmResp struct {
+		Data struct {
+			RecoveryCodes []string `json:"recovery_codes"`
+		} `json:"data"`
+	}
+	s.NoError(json.NewDecoder(w.Body).Decode(&confirmResp))
+	s.Len(confirmResp.Data.RecoveryCodes, 10)
+
+	return enrollResp.Data.Secret, confirmResp.Data.RecoveryCodes
+}
+
+// loginChallenge logs in with the password and returns the two-factor challenge token
+func (s *testAuthSuite) loginChallenge(email string) string {
+	reqBody := fmt.Sprintf(`{"email": %q, "password": "password"}`, email)
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBufferString(reqBody))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp struct {
+		Data struct {
+			Token             string `json:"token"`
+			TwoFactorRequired bool   `json:"two_factor_required"`
+			ChallengeToken    string `json:"challenge_token"`
+		} `json:"data"`
+	}
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.True(resp.Data.TwoFactorRequired)
+	s.Empty(resp.Data.Token)
+	s.NotEmpty(resp.Data.ChallengeToken)
+
+	return resp.Data.ChallengeToken
+}
+
+func (s *testAuthSuite) completeLogin(challengeToken, code string) *httptest.ResponseRecorder {
+	reqBody := fmt.Sprintf(`{"challenge_token": %q, "code": %q}`, challengeToken, code)
+	req := httptest.NewRequest(http.MethodPost, "/auth/login/2fa", bytes.NewBufferString(reqBody))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	return w
+}
+
+func (s *testAuthSuite) TestTwoFactorLogin() {
+	email := "2fa@example.com"
+	tokens := s.registerAndLogin(email)
+	secret, recoveryCodes := s.enableTwoFactor(tokens.Data.Token)
+
+	challenge := s.loginChallenge(email)
+	s.Equal(http.StatusUnauthorized, s.completeLogin(challenge, "000000").Code)
+	s.Equal(http.StatusUnauthorized, s.completeLogin("invalid-challenge", "000000").Code)
+
+	// The confirmation code cannot be replayed, a code of the next time step is accepted
+	user, _, err := s.repo.GetUserByIdentity(domain.IdentityProviderPassword, email)
+	s.NoError(err)
+	totpSecret, err := s.repo.GetTOTPSecretByUserID(user.ID)
+	s.NoError(err)
+	usedAt := time.Unix(totpSecret.LastUsedStep*30, 0)
+
+	s.Equal(http.StatusUnauthorized, s.completeLogin(challenge, totpCode(secret, usedAt)).Code)
+	w := s.completeLogin(challenge, totpCode(secret, usedAt.Add(30*time.Second)))
+	s.Equal(http.StatusOK, w.Code)
+
+	var resp tokensResponse
+	s.NoError(json.NewDecoder(w.Body).Decode(&resp))
+	s.NotEmpty(resp.Data.Token)
+	s.NotEmpty(resp.Data.RefreshToken)
+
+	// A challenge can only be answered once
+	s.Equal(http.StatusUnauthorized, s.completeLogin(challenge, recoveryCodes[0]).Code)
+
+	// Recovery codes work once each
+	w = s.completeLogin(s.loginChallenge(email), recoveryCodes[0])
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal(http.StatusUnauthorized, s.completeLogin(s.loginChallenge(email), recoveryCodes[0]).Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/2fa", nil)
+	req.Header.Set("Authorization", "Bearer "+resp.Data.Token)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	var statusResp struct {
+		Data struct {
+			Enabled                bool `json:"enabled"`
+			RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+		} `json:"data"`
+	}
+	s.NoError(json.NewDecoder(w.Body).Decode(&statusResp))
+	s.True(statusResp.Data.Enabled)
+	s.Equal(9, statusResp.Data.RecoveryCodesRemaining)
+}
+
+func (s *testAuthSuite) TestTwoFactorChallengeAttemptsAreLimited() {
+	email := "2fa-attempts@example.com"
+	tokens := s.registerAndLogin(email)
+	secret, _ := s.enableTwoFactor(tokens.Data.Token)
+
+	challenge := s.loginChallenge(email)
+	for range domain.TwoFactorChallengeMaxAttempts {
+		s.Equal(http.StatusUnauthorized, s.completeLogin(challenge, "000000").Code)
+		// Lift the lockout of the identifier, to reach the limit of the challenge
+		s.NoError(s.repo.LockLogin(domain.IdentityProviderPassword, email, time.Now().Add(-time.Second)))
+	}
+
+	// Even the right code is refused once the challenge had too many attempts
+	s.Equal(http.StatusUnauthorized, s.completeLogin(challenge, totpCode(secret, time.Now().Add(30*time.Second))).Code)
+}
+
+func (s *testAuthSuite) TestWrongSecondFactorsLockIdentifier() {
+	email := "2fa-lockout@example.com"
+	tokens := s.registerAndLogin(email)
+	secret, _ := s.enableTwoFactor(tokens.Data.Token)
+
+	challenge := s.loginChallenge(email)
+	for range domain.LoginFreeAttempts {
+		s.Equal(http.StatusUnauthorized, s.completeLogin(challenge, "000000").Code)
+	}
+
+	// The identifier is locked for the second factor as well as for the password
+	s.Equal(http.StatusTooManyRequests, s.completeLogin(challenge, totpCode(secret, time.Now().Add(30*time.Second))).Code)
+	s.Equal(http.StatusTooManyRequests, s.loginWith(email, "password"))
+}
+
+func (s *testAuthSuite) TestDisableTwoFactor() {
+	email := "2fa-disable@example.com"
+	tokens := s.registerAndLogin(email)
+	_, recoveryCodes := s.enableTwoFactor(tokens.Data.Token)
+
+	disable := func(code string) int {
+		req := httptest.NewRequest(http.MethodDelete, "/auth/2fa", bytes.NewBufferString(fmt.Sprintf(`{"code": %q}`, code)))
+		req.Header.Set("Authorization", "Bearer "+tokens.Data.Token)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	s.Equal(http.StatusBadRequest, disable("000000"))
+	s.Equal(http.StatusOK, disable(recoveryCodes[0]))
+
+	// Logins go straight through again
+	s.login(email)
+}
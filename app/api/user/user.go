@@ -17,6 +17,7 @@ type jsonUser struct {
 	Name      string `json:"name"`
 	Nickname  string `json:"nickname"`
 	Disabled  bool   `json:"disabled"`
+	Role      string `json:"role"`
 }
 
 func (r *jsonUser) fromDomain(u *domain.User) {
@@ -26,20 +27,21 @@ func (r *jsonUser) fromDomain(u *domain.User) {
 	r.Name = u.Name
 	r.Nickname = u.Nickname
 	r.Disabled = u.Disabled
+	r.Role = u.Role.String()
 }
 
 // CreateUser handles the creation of a new user.
 func (x *Controller) CreateUser() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		type request struct {
-			Name     string `json:"name"`
-			Nickname string `json:"nickname"`
+			Name     string  `json:"name"`
+			Nickname string  `json:"nickname"`
+			Role     *string `json:"role"`
 		}
 
 		var req request
 		engine.Chain(r, w, func(_ *engine.Context, req request) (*engine.Empty, error) {
-			// Allow user creation without auth - this is typically for signup
-			// In a real system with admin roles, we would check if the authenticated user has admin privileges
+			// User creation is only exposed on the internal router, so the role may be chosen freely
 			if req.Name == "" {
 				return nil, app.ParamError(errors.New("name is required"))
 			}
@@ -48,23 +50,28 @@ func (x *Controller) CreateUser() func(w http.ResponseWriter, r *http.Request) {
 				return nil, app.ParamError(errors.New("nickname is required"))
 			}
 
+			role := domain.UserRoleUser
+			if req.Role != nil {
+				parsedRole, err := domain.ParseUserRole(*req.Role)
+				if err != nil {
+					return nil, app.ParamError(err)
+				}
+				role = parsedRole
+			}
+
 			return nil, x.service.CreateUser(domain.CreateUserRequest{
 				Name:     req.Name,
 				Nickname: req.Nickname,
+				Role:     role,
 			})
 		}).BindJSON(&req).Call(req).ResponseCreated()
 	}
 }
 
-// GetAllUsers retrieves all users from the system.
+// GetAllUsers retrieves all users from the system. It is only mounted for admins.
 func (x *Controller) GetAllUsers() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		engine.Chain(r, w, func(ctx *engine.Context, _ *engine.Empty) ([]jsonUser, error) {
-			// Admin validation would go here in a real system
-			// For now, check if the user is authenticated
-			if ctx.GetUserID() == 0 {
-				return nil, app.Unauthorized(errors.New("user not authenticated"))
-			}
+		engine.Chain(r, w, func(_ *engine.Context, _ *engine.Empty) ([]jsonUser, error) {
 			users, err := x.service.GetAllUsers()
 			if err != nil {
 				return nil, err
@@ -91,8 +98,7 @@ func (x *Controller) GetUserByID() func(w http.ResponseWriter, r *http.Request)
 			}
 
 			// User can only retrieve their own information unless they're an admin
-			// In a real system, we would check admin role here
-			if id != authenticatedUserID {
+			if id != authenticatedUserID && !ctx.IsAdmin() {
 				return nil, app.Forbidden(errors.New("access denied: cannot view other user's information"))
 			}
 			u, err := x.service.GetUserByID(id)
@@ -125,8 +131,7 @@ func (x *Controller) UpdateUser() func(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// User can only update their own information unless they're an admin
-			// In a real system, we would check admin role here
-			if req.id != authenticatedUserID {
+			if req.id != authenticatedUserID && !ctx.IsAdmin() {
 				return nil, app.Forbidden(errors.New("access denied: cannot update other user's information"))
 			}
 
@@ -151,8 +156,7 @@ func (x *Controller) DeactivateUserByID() func(w http.ResponseWriter, r *http.Re
 			}
 
 			// User can only deactivate their own account unless they're an admin
-			// In a real system, we would check admin role here
-			if id != authenticatedUserID {
+			if id != authenticatedUserID && !ctx.IsAdmin() {
 				return nil, app.Forbidden(errors.New("access denied: cannot deactivate other user's account"))
 			}
 			return nil, x.service.DeactivateUserByID(id)
@@ -27,6 +27,7 @@ type testUserSuite struct {
 	repo     *repository.SQLCRepository
 	finalize func()
 	userID   int32
+	userRole domain.UserRole
 }
 
 func (s *testUserSuite) SetupTest() {
@@ -39,6 +40,7 @@ func (s *testUserSuite) SetupTest() {
 	authMiddleware := func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := engine.WithUserID(r.Context(), s.userID)
+			ctx = engine.WithUserRole(ctx, s.userRole)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -52,6 +54,9 @@ func (s *testUserSuite) SetupTest() {
 	registerWithAuth("GET /users/{id}", http.HandlerFunc(controller.GetUserByID()))
 	registerWithAuth("PATCH /users/{id}", http.HandlerFunc(controller.UpdateUser()))
 	registerWithAuth("DELETE /users/{id}", http.HandlerFunc(controller.DeactivateUserByID()))
+	registerWithAuth("POST /admin/users/{id}/disable", http.HandlerFunc(controller.DisableUser()))
+	registerWithAuth("POST /admin/users/{id}/enable", http.HandlerFunc(controller.EnableUser()))
+	registerWithAuth("DELETE /admin/users/{id}", http.HandlerFunc(controller.DeleteUser()))
 
 	s.NoError(sqlc.MigrateForTest(context.Background(), db))
 }
@@ -60,6 +65,7 @@ func (s *testUserSuite) TearDownTest() {
 	s.finalize()
 	s.router = nil
 	s.repo = nil
+	s.userRole = ""
 }
 
 func TestUserSuite(t *testing.T) {
@@ -105,14 +111,16 @@ func (s *testUserSuite) TestGetAllUsers() {
 		} `json:"data"`
 	}
 
-	// Create a test user
+	// Create a test admin
 	userID, err := s.repo.CreateUser(domain.CreateUserRequest{
 		Name:     "Test User",
 		Nickname: "test",
+		Role:     domain.UserRoleAdmin,
 	})
 	s.NoError(err)
 
 	s.userID = userID
+	s.userRole = domain.UserRoleAdmin
 
 	req := httptest.NewRequest(http.MethodGet, "/users", nil)
 	w := httptest.NewRecorder()
@@ -130,6 +138,48 @@ func (s *testUserSuite) TestGetAllUsers() {
 	s.False(resp.Data[0].Disabled)
 }
 
+func (s *testUserSuite) TestAdminDisableAndDeleteUser() {
+	adminID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: "Admin", Nickname: "admin", Role: domain.UserRoleAdmin})
+	s.NoError(err)
+	userID, err := s.repo.CreateUser(domain.CreateUserRequest{Name: "Test User", Nickname: "test"})
+	s.NoError(err)
+
+	s.userID = adminID
+	s.userRole = domain.UserRoleAdmin
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/users/%d/disable", userID), nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	user, err := s.repo.GetUserByID(userID)
+	s.NoError(err)
+	s.True(user.Disabled)
+
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/admin/users/%d/enable", userID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	user, err = s.repo.GetUserByID(userID)
+	s.NoError(err)
+	s.False(user.Disabled)
+
+	// Admins cannot delete themselves
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/users/%d", adminID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/admin/users/%d", userID), nil)
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+
+	_, err = s.repo.GetUserByID(userID)
+	s.ErrorIs(err, domain.ErrNotFound)
+}
+
 func (s *testUserSuite) TestGetUserByID() {
 
 	type getUserByIDResponse struct {
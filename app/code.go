@@ -2,9 +2,10 @@ package app
 
 // Code enums.
 const (
-	CodeInternalError = 1000
-	CodeBadParam      = 1001
-	CodeNotFound      = 1002
-	CodeUnauthorized  = 1003
-	CodeForbidden     = 1004
+	CodeInternalError   = 1000
+	CodeBadParam        = 1001
+	CodeNotFound        = 1002
+	CodeUnauthorized    = 1003
+	CodeForbidden       = 1004
+	CodeTooManyRequests = 1005
 )
@@ -73,6 +73,15 @@ func Forbidden(err error) error {
 	}
 }
 
+// TooManyRequests generates a too many requests error with a custom message.
+func TooManyRequests(err error) error {
+	return &CodedError{
+		Err:        err,
+		StatusCode: http.StatusTooManyRequests,
+		AppCode:    CodeTooManyRequests,
+	}
+}
+
 // ParamError generates BadParamError.
 func ParamError(err error) error {
 	if err != nil {
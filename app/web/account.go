@@ -98,10 +98,19 @@ func (s *Server) pageAccounts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	workspaces, err := s.getWorkspaceList(r)
+	if err != nil {
+		slog.Error("failed to get workspaces", slog.String("error", err.Error()))
+	}
+
 	result := struct {
-		Accounts []account
+		Accounts          []account
+		Workspaces        []workspace
+		ActiveWorkspaceID int32
 	}{
-		Accounts: accounts,
+		Accounts:          accounts,
+		Workspaces:        workspaces,
+		ActiveWorkspaceID: activeWorkspaceID(r),
 	}
 
 	if err := s.templates.ExecuteTemplate(w, "accounts_page.html", result); err != nil {
@@ -147,7 +156,7 @@ func (s *Server) createAccount(w http.ResponseWriter, r *http.Request) {
 	payload.Currency = r.FormValue("currency")
 
 	// Get user ID from token
-	token, _ := r.Cookie("token")
+	token, _ := r.Cookie(tokenCookie)
 	userID, err := s.getUserIDFromToken(token.Value)
 	if err != nil {
 		slog.Error("failed to get user ID from token", slog.String("error", err.Error()))
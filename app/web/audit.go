@@ -0,0 +1,103 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/omegaatt36/bookly/app"
+)
+
+type auditLog struct {
+	ID                int32           `json:"id"`
+	CreatedAt         time.Time       `json:"created_at"`
+	ActorUserID       *int32          `json:"actor_user_id"`
+	Action            string          `json:"action"`
+	Before            json.RawMessage `json:"before"`
+	After             json.RawMessage `json:"after"`
+	IPAddress         string          `json:"ip_address"`
+	BooksLockOverride bool            `json:"books_lock_override"`
+}
+
+// auditChange is a field whose value differs between the before and after state of an audit log.
+type auditChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+type auditHistoryEntry struct {
+	auditLog
+	Changes []auditChange
+}
+
+// changes lists the fields changed by an update, void or adjustment.
+// Creations and deletions have nothing to compare and list no fields.
+func (l auditLog) changes() []auditChange {
+	var before, after map[string]any
+	if err := json.Unmarshal(l.Before, &before); err != nil || before == nil {
+		return nil
+	}
+	if err := json.Unmarshal(l.After, &after); err != nil || after == nil {
+		return nil
+	}
+
+	var changes []auditChange
+	for field, value := range after {
+		b, a := formatAuditValue(before[field]), formatAuditValue(value)
+		if b != a {
+			changes = append(changes, auditChange{Field: field, Before: b, After: a})
+		}
+	}
+
+	slices.SortFunc(changes, func(x, y auditChange) int {
+		return strings.Compare(x.Field, y.Field)
+	})
+
+	return changes
+}
+
+func formatAuditValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "none"
+	case string:
+		return v
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+func (s *Server) pageLedgerHistory(w http.ResponseWriter, r *http.Request) {
+	ledgerID := parseInt32(r.PathValue("ledger_id"))
+
+	var logs []auditLog
+	if err := s.sendRequest(r, "GET", fmt.Sprintf("/v1/audit?entity=ledger&id=%d", ledgerID), nil, &logs); err != nil {
+		slog.Error("failed to get ledger history", slog.String("error", err.Error()))
+
+		var sendRequestError *sendRequestError
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeUnauthorized {
+			s.clearTokenAndRedirect(w)
+			return
+		}
+
+		http.Error(w, "Failed to get ledger history", http.StatusInternalServerError)
+		return
+	}
+
+	entries := make([]auditHistoryEntry, len(logs))
+	for i, log := range logs {
+		entries[i] = auditHistoryEntry{auditLog: log, Changes: log.changes()}
+	}
+
+	if err := s.templates.ExecuteTemplate(w, "ledger_history.html", entries); err != nil {
+		slog.Error("failed to render ledger_history.html", slog.String("error", err.Error()))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
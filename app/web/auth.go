@@ -2,11 +2,45 @@ package web
 
 import (
 	"errors"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/golang-jwt/jwt"
+
+	"github.com/omegaatt36/bookly/app"
+)
+
+const (
+	tokenCookie        = "token"
+	refreshTokenCookie = "refresh_token"
 )
 
+type authTokens struct {
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func setAuthCookies(w http.ResponseWriter, tokens authTokens) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     tokenCookie,
+		Value:    tokens.Token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	http.SetCookie(w, &http.Cookie{
+		Name:     refreshTokenCookie,
+		Value:    tokens.RefreshToken,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
 func (s *Server) login(w http.ResponseWriter, r *http.Request) {
 	username := r.FormValue("username")
 	password := r.FormValue("password")
@@ -17,47 +51,129 @@ func (s *Server) login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var loginResp struct {
-		Token string `json:"token"`
+		authTokens
+		TwoFactorRequired bool   `json:"two_factor_required"`
+		ChallengeToken    string `json:"challenge_token"`
 	}
-
 	if err := s.sendRequest(r, "POST", "/public/auth/login", loginData, &loginResp); err != nil {
+		var sendRequestError *sendRequestError
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeForbidden {
+			writeMessage(w, "text-error", "Please verify your email address before signing in")
+			return
+		}
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeTooManyRequests {
+			writeMessage(w, "text-error", "Too many failed attempts, please try again later")
+			return
+		}
+
 		http.Error(w, "Login failed", http.StatusUnauthorized)
 		return
 	}
 
-	// Set the token as a cookie
-	http.SetCookie(w, &http.Cookie{
-		Name:     "token",
-		Value:    loginResp.Token,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteStrictMode,
-	})
+	// Ask for the second factor in place of the login form
+	if loginResp.TwoFactorRequired {
+		w.Header().Set("HX-Retarget", "#login-card")
+		w.Header().Set("HX-Reswap", "outerHTML")
+		if err := s.templates.ExecuteTemplate(w, "login_two_factor", loginResp.ChallengeToken); err != nil {
+			slog.Error("failed to render login_two_factor", slog.String("error", err.Error()))
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Set the token pair as cookies
+	setAuthCookies(w, loginResp.authTokens)
+
+	w.Header().Set("HX-Redirect", "/")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) loginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	payload := map[string]string{
+		"challenge_token": r.FormValue("challenge_token"),
+		"code":            r.FormValue("code"),
+	}
+
+	var tokens authTokens
+	if err := s.sendRequest(r, "POST", "/public/auth/login/2fa", payload, &tokens); err != nil {
+		writeMessage(w, "text-error", requestErrorMessage(err, "Login failed"))
+		return
+	}
+
+	setAuthCookies(w, tokens)
 
 	w.Header().Set("HX-Redirect", "/")
 	w.WriteHeader(http.StatusOK)
 }
 
-func (s *Server) logout(w http.ResponseWriter, _ *http.Request) {
+func (s *Server) logout(w http.ResponseWriter, r *http.Request) {
+	// Revoke the session server-side; the cookies are cleared regardless
+	if err := s.sendRequest(r, "POST", "/v1/auth/logout", nil, nil); err != nil {
+		slog.Warn("failed to revoke session", slog.String("error", err.Error()))
+	}
+
 	s.clearTokenAndRedirect(w)
 }
 
+func clearAuthCookies(w http.ResponseWriter) {
+	for _, name := range []string{tokenCookie, refreshTokenCookie, workspaceCookie} {
+		http.SetCookie(w, &http.Cookie{
+			Name:     name,
+			Value:    "",
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteStrictMode,
+		})
+	}
+}
+
 func (s *Server) clearTokenAndRedirect(w http.ResponseWriter) {
-	http.SetCookie(w, &http.Cookie{
-		Name:     "token",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteStrictMode,
-	})
+	clearAuthCookies(w)
 
 	w.Header().Set("HX-Redirect", "/")
 	w.WriteHeader(http.StatusOK)
 }
 
+// refreshTokens exchanges the refresh token cookie for a new token pair.
+func (s *Server) refreshTokens(r *http.Request) (*authTokens, error) {
+	refreshToken, err := r.Cookie(refreshTokenCookie)
+	if err != nil || refreshToken.Value == "" {
+		return nil, errors.New("refresh token not found")
+	}
+
+	var tokens authTokens
+	if err := s.sendRequest(r, "POST", "/public/auth/refresh", map[string]string{
+		"refresh_token": refreshToken.Value,
+	}, &tokens); err != nil {
+		return nil, err
+	}
+
+	return &tokens, nil
+}
+
+// isTokenExpired reports whether the access token is about to expire.
+// The signature is verified by the API, so the claims are parsed unverified.
+func isTokenExpired(tokenString string) bool {
+	token, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return true
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return true
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return true
+	}
+
+	return time.Now().Add(30 * time.Second).After(time.Unix(int64(exp), 0))
+}
+
 func (s *Server) getUserIDFromToken(tokenString string) (int32, error) {
 	token, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
 	if err != nil {
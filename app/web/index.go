@@ -5,9 +5,25 @@ import (
 	"net/http"
 )
 
+type indexPage struct {
+	IsAuthenticated    bool
+	PublicRegistration bool
+	OIDCProviderName   string
+	ChallengeToken     string
+	Message            string
+}
+
+func (s *Server) renderIndex(w http.ResponseWriter, page indexPage) {
+	page.PublicRegistration = s.publicRegistration
+	page.OIDCProviderName = s.oidcProviderName
+
+	s.renderTemplate(w, "index.html", page)
+}
+
 func (s *Server) pageIndex(w http.ResponseWriter, r *http.Request) {
-	token, err := r.Cookie("token")
-	isAuthenticated := err == nil && token.Value != ""
+	token, err := r.Cookie(tokenCookie)
+	refreshToken, refreshErr := r.Cookie(refreshTokenCookie)
+	isAuthenticated := (err == nil && token.Value != "") || (refreshErr == nil && refreshToken.Value != "")
 
 	if isAuthenticated {
 		// 如果用戶已驗證，重定向到 accounts 頁面
@@ -16,16 +32,7 @@ func (s *Server) pageIndex(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 如果未驗證，顯示登錄頁面
-	data := struct {
-		IsAuthenticated bool
-	}{
-		IsAuthenticated: isAuthenticated,
-	}
-
-	if err := s.templates.ExecuteTemplate(w, "index.html", data); err != nil {
-		slog.Error("failed to render layout.html", slog.String("error", err.Error()))
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-	}
+	s.renderIndex(w, indexPage{IsAuthenticated: isAuthenticated})
 }
 
 // page404 renders the 404 page
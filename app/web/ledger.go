@@ -23,6 +23,7 @@ type ledger struct {
 	AdjustedFrom *int32     `json:"adjusted_from"`
 	IsVoided     bool       `json:"is_voided"`
 	VoidedAt     *time.Time `json:"voided_at"`
+	IsPending    bool       `json:"is_pending"`
 }
 
 func (s *Server) pageLedger(w http.ResponseWriter, r *http.Request) {
@@ -35,14 +35,33 @@ func logging(next http.Handler) http.Handler {
 	})
 }
 
-func authenticatedHandler(next http.HandlerFunc) http.HandlerFunc {
+func (s *Server) authenticatedHandler(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		token, err := r.Cookie("token")
-		if err != nil || token.Value == "" {
+		token, err := r.Cookie(tokenCookie)
+		if err == nil && token.Value != "" && !isTokenExpired(token.Value) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// The access token is short-lived, rotate it with the refresh token
+		tokens, err := s.refreshTokens(r)
+		if err != nil {
+			clearAuthCookies(w)
 			http.Redirect(w, r, "/", http.StatusSeeOther)
 			return
 		}
 
+		setAuthCookies(w, *tokens)
+
+		// Forward the new tokens to the handler, which reads them from the request cookies
+		workspace, _ := r.Cookie(workspaceCookie)
+		r.Header.Del("Cookie")
+		r.AddCookie(&http.Cookie{Name: tokenCookie, Value: tokens.Token})
+		r.AddCookie(&http.Cookie{Name: refreshTokenCookie, Value: tokens.RefreshToken})
+		if workspace != nil {
+			r.AddCookie(workspace)
+		}
+
 		next.ServeHTTP(w, r)
 	}
 }
@@ -0,0 +1,136 @@
+package web
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+)
+
+// The state of an OIDC flow is kept in the browser that started it, under the name of the
+// flow. The cookies are lax, as the provider sends the user back from another site.
+const (
+	oidcStateCookie     = "oidc_state"
+	oidcLinkStateCookie = "oidc_link_state"
+)
+
+type oidcAuthorization struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+func setOIDCStateCookie(w http.ResponseWriter, name, state string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func clearOIDCStateCookies(w http.ResponseWriter) {
+	for _, name := range []string{oidcStateCookie, oidcLinkStateCookie} {
+		http.SetCookie(w, &http.Cookie{
+			Name:     name,
+			Path:     "/",
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+}
+
+func (s *Server) loginOIDC(w http.ResponseWriter, r *http.Request) {
+	var authorization oidcAuthorization
+	if err := s.sendRequest(r, "GET", "/public/auth/oidc/authorize", nil, &authorization); err != nil {
+		slog.Error("failed to start oidc login", slog.String("error", err.Error()))
+		s.renderIndex(w, indexPage{Message: requestErrorMessage(err, "Single sign-on is unavailable")})
+		return
+	}
+
+	setOIDCStateCookie(w, oidcStateCookie, authorization.State)
+	http.Redirect(w, r, authorization.AuthorizationURL, http.StatusSeeOther)
+}
+
+func (s *Server) linkOIDC(w http.ResponseWriter, r *http.Request) {
+	var authorization oidcAuthorization
+	if err := s.sendRequest(r, "POST", "/v1/auth/oidc/link", nil, &authorization); err != nil {
+		slog.Error("failed to start oidc link", slog.String("error", err.Error()))
+		writeMessage(w, "text-error", requestErrorMessage(err, "Failed to link account"))
+		return
+	}
+
+	setOIDCStateCookie(w, oidcLinkStateCookie, authorization.State)
+	w.Header().Set("HX-Redirect", authorization.AuthorizationURL)
+	w.WriteHeader(http.StatusOK)
+}
+
+// oidcCallback handles users coming back from the OpenID Connect provider. The response
+// navigates on to completeOIDC with a page instead of a redirect, as the strict auth cookies
+// are not sent along a redirect chain started by another site.
+func (s *Server) oidcCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("error") != "" {
+		clearOIDCStateCookies(w)
+
+		message := query.Get("error_description")
+		if message == "" {
+			message = query.Get("error")
+		}
+		s.renderIndex(w, indexPage{Message: "Single sign-on failed: " + message})
+		return
+	}
+
+	s.renderTemplate(w, "redirect.html", "/auth/oidc/complete?"+url.Values{
+		"state": {query.Get("state")},
+		"code":  {query.Get("code")},
+	}.Encode())
+}
+
+// completeOIDC completes the OIDC flow this browser started. A link is completed with the
+// session of the signed-in user, who has to be the one who started it.
+func (s *Server) completeOIDC(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	state := query.Get("state")
+	payload := map[string]string{
+		"state": state,
+		"code":  query.Get("code"),
+	}
+
+	path := "/public/auth/oidc/callback"
+	cookie, err := r.Cookie(oidcStateCookie)
+	if linkCookie, linkErr := r.Cookie(oidcLinkStateCookie); linkErr == nil && linkCookie.Value == state {
+		path, cookie, err = "/v1/auth/oidc/link/callback", linkCookie, nil
+	}
+	clearOIDCStateCookies(w)
+	if err != nil {
+		s.renderIndex(w, indexPage{Message: "Single sign-on expired, please try again"})
+		return
+	}
+
+	var callbackResp struct {
+		authTokens
+		TwoFactorRequired bool   `json:"two_factor_required"`
+		ChallengeToken    string `json:"challenge_token"`
+		Linked            bool   `json:"linked"`
+	}
+	// The API checks the state against the one kept in the browser
+	stateCookie := &http.Cookie{Name: oidcStateCookie, Value: cookie.Value}
+	if err := s.sendRequest(r, "POST", path, payload, &callbackResp, stateCookie); err != nil {
+		s.renderIndex(w, indexPage{Message: requestErrorMessage(err, "Single sign-on failed")})
+		return
+	}
+
+	switch {
+	case callbackResp.Linked:
+		http.Redirect(w, r, "/page/password?"+url.Values{"linked": {"1"}}.Encode(), http.StatusSeeOther)
+	case callbackResp.TwoFactorRequired:
+		s.renderIndex(w, indexPage{ChallengeToken: callbackResp.ChallengeToken})
+	default:
+		setAuthCookies(w, callbackResp.authTokens)
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+	}
+}
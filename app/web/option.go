@@ -22,3 +22,22 @@ type ServerURLOption struct {
 func (opt *ServerURLOption) apply(router *Server) {
 	router.serverURL = opt.ServerURL
 }
+
+// PublicRegistrationOption defines an option to show the sign up page.
+type PublicRegistrationOption struct {
+	Enabled bool
+}
+
+func (opt *PublicRegistrationOption) apply(router *Server) {
+	router.publicRegistration = opt.Enabled
+}
+
+// OIDCLoginOption defines an option to show the single sign-on button, the api must have
+// an OpenID Connect provider configured too.
+type OIDCLoginOption struct {
+	ProviderName string
+}
+
+func (opt *OIDCLoginOption) apply(router *Server) {
+	router.oidcProviderName = opt.ProviderName
+}
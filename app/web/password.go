@@ -0,0 +1,107 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+type passwordPage struct {
+	Mode             string // forgot, reset or change
+	Token            string
+	OIDCProviderName string
+	Linked           bool
+}
+
+func (s *Server) renderPasswordPage(w http.ResponseWriter, page passwordPage) {
+	if err := s.templates.ExecuteTemplate(w, "password.html", page); err != nil {
+		slog.Error("failed to render password.html", slog.String("error", err.Error()))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// writeMessage writes a message fragment swapped into the #message element of a form
+func writeMessage(w http.ResponseWriter, class, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, `<span class="%s">%s</span>`, class, template.HTMLEscapeString(message))
+}
+
+func requestErrorMessage(err error, fallback string) string {
+	var sendRequestError *sendRequestError
+	if errors.As(err, &sendRequestError) {
+		return strings.TrimPrefix(sendRequestError.Message, "failed to send request: ")
+	}
+
+	return fallback
+}
+
+func (s *Server) pageForgotPassword(w http.ResponseWriter, _ *http.Request) {
+	s.renderPasswordPage(w, passwordPage{Mode: "forgot"})
+}
+
+func (s *Server) pageResetPassword(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		http.Redirect(w, r, "/page/password/forgot", http.StatusSeeOther)
+		return
+	}
+
+	s.renderPasswordPage(w, passwordPage{Mode: "reset", Token: token})
+}
+
+func (s *Server) pageChangePassword(w http.ResponseWriter, r *http.Request) {
+	s.renderPasswordPage(w, passwordPage{
+		Mode:             "change",
+		OIDCProviderName: s.oidcProviderName,
+		Linked:           r.URL.Query().Get("linked") != "",
+	})
+}
+
+func (s *Server) forgotPassword(w http.ResponseWriter, r *http.Request) {
+	payload := map[string]string{
+		"email": r.FormValue("email"),
+	}
+
+	if err := s.sendRequest(r, "POST", "/public/auth/password/forgot", payload, nil); err != nil {
+		slog.Error("failed to request password reset", slog.String("error", err.Error()))
+		writeMessage(w, "text-error", "Failed to request a password reset, please try again later")
+		return
+	}
+
+	writeMessage(w, "text-success", "If the email belongs to an account, a reset link is on its way")
+}
+
+func (s *Server) resetPassword(w http.ResponseWriter, r *http.Request) {
+	payload := map[string]string{
+		"token":        r.FormValue("token"),
+		"new_password": r.FormValue("new_password"),
+	}
+
+	if err := s.sendRequest(r, "POST", "/public/auth/password/reset", payload, nil); err != nil {
+		slog.Error("failed to reset password", slog.String("error", err.Error()))
+		writeMessage(w, "text-error", requestErrorMessage(err, "Failed to reset password"))
+		return
+	}
+
+	// Every session has been signed out by the reset
+	clearAuthCookies(w)
+	writeMessage(w, "text-success", "Your password has been reset, you can now sign in")
+}
+
+func (s *Server) changePassword(w http.ResponseWriter, r *http.Request) {
+	payload := map[string]string{
+		"current_password": r.FormValue("current_password"),
+		"new_password":     r.FormValue("new_password"),
+	}
+
+	if err := s.sendRequest(r, "POST", "/v1/auth/password", payload, nil); err != nil {
+		slog.Error("failed to change password", slog.String("error", err.Error()))
+		writeMessage(w, "text-error", requestErrorMessage(err, "Failed to change password"))
+		return
+	}
+
+	writeMessage(w, "text-success", "Your password has been changed")
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/omegaatt36/bookly/app"
@@ -27,6 +29,10 @@ type recurringTransaction struct {
 	DayOfWeek    *int       `json:"day_of_week,omitempty"`
 	DayOfMonth   *int       `json:"day_of_month,omitempty"`
 	MonthOfYear  *int       `json:"month_of_year,omitempty"`
+	RRule        string     `json:"rrule,omitempty"`
+	CatchUpPolicy string    `json:"catch_up_policy"`
+	PostMode     string     `json:"post_mode"`
+	ReminderOffsets []int   `json:"reminder_offsets"`
 	LastExecuted *time.Time `json:"last_executed,omitempty"`
 	NextDue      time.Time  `json:"next_due"`
 }
@@ -38,6 +44,69 @@ type reminder struct {
 	ReminderDate           time.Time  `json:"reminder_date"`
 	IsRead                 bool       `json:"is_read"`
 	ReadAt                 *time.Time `json:"read_at,omitempty"`
+	LedgerID               *int32     `json:"ledger_id,omitempty"`
+
+	// PendingLedger is the drafted ledger to confirm, when it is still pending
+	PendingLedger *ledger `json:"-"`
+}
+
+type reminderSettings struct {
+	DefaultOffsets []int `json:"default_offsets"`
+}
+
+type notificationChannel struct {
+	Channel string `json:"channel"`
+	Target  string `json:"target"`
+	Enabled bool   `json:"enabled"`
+
+	// Configured is false for the channels the user has not set yet
+	Configured bool `json:"-"`
+}
+
+type digestSettings struct {
+	Enabled    bool   `json:"enabled"`
+	Frequency  string `json:"frequency"`
+	Weekday    int    `json:"weekday"`
+	DayOfMonth int    `json:"day_of_month"`
+}
+
+// digestWeekdays are the days weekly digests can be sent on, indexed like time.Weekday
+var digestWeekdays = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+// notificationChannelNames are the channels reminders can be delivered through
+var notificationChannelNames = []string{"email", "webhook", "chat"}
+
+// parseReminderOffsets parses comma separated days before a due date, like "7, 1"
+func parseReminderOffsets(value string) ([]int, error) {
+	var offsets []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		offset, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number of days %q", part)
+		}
+		offsets = append(offsets, offset)
+	}
+
+	return offsets, nil
+}
+
+type recurringExecution struct {
+	ScheduledFor time.Time `json:"scheduled_for"`
+	Status       string    `json:"status"`
+	ExecutedAt   time.Time `json:"executed_at"`
+	Ledger       *struct {
+		ID        int32     `json:"id"`
+		Date      time.Time `json:"date"`
+		Amount    string    `json:"amount"`
+		Currency  string    `json:"currency"`
+		IsPending bool      `json:"is_pending"`
+		IsVoided  bool      `json:"is_voided"`
+	} `json:"ledger,omitempty"`
 }
 
 func (s *Server) pageRecurringList(w http.ResponseWriter, r *http.Request) {
@@ -104,12 +173,32 @@ func (s *Server) pageRecurringDetails(w http.ResponseWriter, r *http.Request) {
 		// Continue with empty accounts list
 	}
 
+	// Preview the upcoming due dates
+	var occurrences []time.Time
+	err = s.sendRequest(r, "GET", fmt.Sprintf("/v1/recurring/%d/occurrences", id), nil, &occurrences)
+	if err != nil {
+		slog.Error("failed to get recurring occurrences", slog.String("error", err.Error()))
+		// Continue without a preview
+	}
+
+	// List the occurrences already processed
+	var history []recurringExecution
+	err = s.sendRequest(r, "GET", fmt.Sprintf("/v1/recurring/%d/history", id), nil, &history)
+	if err != nil {
+		slog.Error("failed to get recurring history", slog.String("error", err.Error()))
+		// Continue without the history
+	}
+
 	result := struct {
 		RecurringTransaction recurringTransaction
 		Accounts             []account
+		Occurrences          []time.Time
+		History              []recurringExecution
 	}{
 		RecurringTransaction: recurring,
 		Accounts:             accounts,
+		Occurrences:          occurrences,
+		History:              history,
 	}
 
 	if err := s.templates.ExecuteTemplate(w, "recurring_details.html", result); err != nil {
@@ -157,7 +246,71 @@ func (s *Server) pageReminders(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.templates.ExecuteTemplate(w, "reminders.html", reminders); err != nil {
+	for i := range reminders {
+		if reminders[i].LedgerID == nil || reminders[i].IsRead {
+			continue
+		}
+
+		var pending ledger
+		if err := s.sendRequest(r, "GET", fmt.Sprintf("/v1/ledgers/%d", *reminders[i].LedgerID), nil, &pending); err != nil {
+			slog.Error("failed to get pending ledger", slog.String("error", err.Error()))
+			continue
+		}
+
+		if pending.IsPending {
+			reminders[i].PendingLedger = &pending
+		}
+	}
+
+	// Default lead times of the reminders
+	var settings reminderSettings
+	err = s.sendRequest(r, "GET", "/v1/reminder-settings", nil, &settings)
+	if err != nil {
+		slog.Error("failed to get reminder settings", slog.String("error", err.Error()))
+		// Continue without the settings
+	}
+
+	// Channels the reminders are delivered through, listing the ones not set yet too
+	var configured []notificationChannel
+	err = s.sendRequest(r, "GET", "/v1/notification-channels", nil, &configured)
+	if err != nil {
+		slog.Error("failed to get notification channels", slog.String("error", err.Error()))
+		// Continue without the channels
+	}
+
+	channels := make([]notificationChannel, len(notificationChannelNames))
+	for i, name := range notificationChannelNames {
+		channels[i] = notificationChannel{Channel: name, Enabled: true}
+		for _, channel := range configured {
+			if channel.Channel == name {
+				channels[i] = channel
+				channels[i].Configured = true
+			}
+		}
+	}
+
+	var digest digestSettings
+	err = s.sendRequest(r, "GET", "/v1/digest-settings", nil, &digest)
+	if err != nil {
+		slog.Error("failed to get digest settings", slog.String("error", err.Error()))
+		// Continue without the digest settings
+	}
+
+	result := struct {
+		Reminders      []reminder
+		Settings       reminderSettings
+		Channels       []notificationChannel
+		Digest         digestSettings
+		DigestWeekdays []string
+	}{
+		Reminders:      reminders,
+		Settings:       settings,
+		Channels:       channels,
+		Digest:         digest,
+		DigestWeekdays: digestWeekdays,
+	}
+
+	if err := s.templates.ExecuteTemplate(w, "reminders.html", result); err != nil {
 		slog.Error("failed to render reminders.html", slog.String("error", err.Error()))
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 	}
@@ -177,6 +330,10 @@ func (s *Server) createRecurring(w http.ResponseWriter, r *http.Request) {
 		DayOfWeek   *int     `json:"day_of_week,omitempty"`
 		DayOfMonth  *int     `json:"day_of_month,omitempty"`
 		MonthOfYear *int     `json:"month_of_year,omitempty"`
+		RRule       string   `json:"rrule,omitempty"`
+		CatchUpPolicy string `json:"catch_up_policy,omitempty"`
+		PostMode    string   `json:"post_mode,omitempty"`
+		ReminderOffsets []int `json:"reminder_offsets,omitempty"`
 	}
 
 	accountIDStr := r.FormValue("account_id")
@@ -207,6 +364,13 @@ func (s *Server) createRecurring(w http.ResponseWriter, r *http.Request) {
 	}
 
 	payload.RecurType = r.FormValue("recur_type")
+	payload.CatchUpPolicy = r.FormValue("catch_up_policy")
+	payload.PostMode = r.FormValue("post_mode")
+	payload.ReminderOffsets, err = parseReminderOffsets(r.FormValue("reminder_offsets"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	frequency, err := strconv.Atoi(r.FormValue("frequency"))
 	if err != nil {
 		slog.Error("failed to parse frequency", slog.String("error", err.Error()))
@@ -237,6 +401,8 @@ func (s *Server) createRecurring(w http.ResponseWriter, r *http.Request) {
 				payload.MonthOfYear = &monthOfYear
 			}
 		}
+	case "custom":
+		payload.RRule = strings.TrimSpace(r.FormValue("rrule"))
 	}
 
 	if err := s.sendRequest(r, "POST", "/v1/recurring", payload, nil); err != nil {
@@ -247,6 +413,10 @@ func (s *Server) createRecurring(w http.ResponseWriter, r *http.Request) {
 			s.clearTokenAndRedirect(w)
 			return
 		}
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeBadParam {
+			http.Error(w, requestErrorMessage(err, "Invalid recurring transaction"), http.StatusBadRequest)
+			return
+		}
 
 		http.Error(w, "Failed to create recurring transaction", http.StatusInternalServerError)
 		return
@@ -272,6 +442,9 @@ func (s *Server) updateRecurring(w http.ResponseWriter, r *http.Request) {
 		DayOfWeek   *int     `json:"day_of_week,omitempty"`
 		DayOfMonth  *int     `json:"day_of_month,omitempty"`
 		MonthOfYear *int     `json:"month_of_year,omitempty"`
+		RRule       *string  `json:"rrule,omitempty"`
+		CatchUpPolicy *string `json:"catch_up_policy,omitempty"`
+		PostMode    *string  `json:"post_mode,omitempty"`
 	}
 
 	if name := r.FormValue("name"); name != "" {
@@ -340,6 +513,19 @@ func (s *Server) updateRecurring(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if catchUpPolicy := r.FormValue("catch_up_policy"); catchUpPolicy != "" {
+		payload.CatchUpPolicy = &catchUpPolicy
+	}
+
+	if postMode := r.FormValue("post_mode"); postMode != "" {
+		payload.PostMode = &postMode
+	}
+
+	if _, ok := r.Form["rrule"]; ok {
+		rule := strings.TrimSpace(r.FormValue("rrule"))
+		payload.RRule = &rule
+	}
+
 	if err := s.sendRequest(r, "PUT", fmt.Sprintf("/v1/recurring/%d", id), payload, nil); err != nil {
 		slog.Error("failed to update recurring transaction", slog.String("error", err.Error()))
 
@@ -348,6 +534,10 @@ func (s *Server) updateRecurring(w http.ResponseWriter, r *http.Request) {
 			s.clearTokenAndRedirect(w)
 			return
 		}
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeBadParam {
+			http.Error(w, requestErrorMessage(err, "Invalid recurring transaction"), http.StatusBadRequest)
+			return
+		}
 
 		http.Error(w, "Failed to update recurring transaction", http.StatusInternalServerError)
 		return
@@ -379,6 +569,54 @@ func (s *Server) deleteRecurring(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+func (s *Server) skipRecurringOccurrence(w http.ResponseWriter, r *http.Request) {
+	id := parseInt32(r.PathValue("recurring_id"))
+
+	if err := s.sendRequest(r, "POST", fmt.Sprintf("/v1/recurring/%d/skip", id), nil, nil); err != nil {
+		slog.Error("failed to skip next occurrence", slog.String("error", err.Error()))
+
+		var sendRequestError *sendRequestError
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeUnauthorized {
+			s.clearTokenAndRedirect(w)
+			return
+		}
+
+		http.Error(w, requestErrorMessage(err, "Failed to skip next occurrence"), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", "reloadRecurring")
+	w.Header().Set("HX-Redirect", "/page/recurring")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) postponeRecurringOccurrence(w http.ResponseWriter, r *http.Request) {
+	id := parseInt32(r.PathValue("recurring_id"))
+
+	payload := struct {
+		Until string `json:"until"`
+	}{
+		Until: strings.TrimSpace(r.FormValue("until")),
+	}
+
+	if err := s.sendRequest(r, "POST", fmt.Sprintf("/v1/recurring/%d/postpone", id), payload, nil); err != nil {
+		slog.Error("failed to postpone next occurrence", slog.String("error", err.Error()))
+
+		var sendRequestError *sendRequestError
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeUnauthorized {
+			s.clearTokenAndRedirect(w)
+			return
+		}
+
+		http.Error(w, requestErrorMessage(err, "Failed to postpone next occurrence"), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", "reloadRecurring")
+	w.Header().Set("HX-Redirect", "/page/recurring")
+	w.WriteHeader(http.StatusOK)
+}
+
 func (s *Server) markReminderAsRead(w http.ResponseWriter, r *http.Request) {
 	id := parseInt32(r.PathValue("reminder_id"))
 
@@ -397,4 +635,198 @@ func (s *Server) markReminderAsRead(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("HX-Trigger", "reloadReminders")
 	w.WriteHeader(http.StatusOK)
-}
\ No newline at end of file
+}
+
+func (s *Server) confirmReminder(w http.ResponseWriter, r *http.Request) {
+	id := parseInt32(r.PathValue("reminder_id"))
+
+	var payload struct {
+		Amount *string `json:"amount,omitempty"`
+	}
+
+	if amount := strings.TrimSpace(r.FormValue("amount")); amount != "" {
+		payload.Amount = &amount
+	}
+
+	if err := s.sendRequest(r, "POST", fmt.Sprintf("/v1/recurring/reminders/%d/confirm", id), payload, nil); err != nil {
+		slog.Error("failed to confirm reminder", slog.String("error", err.Error()))
+
+		var sendRequestError *sendRequestError
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeUnauthorized {
+			s.clearTokenAndRedirect(w)
+			return
+		}
+
+		http.Error(w, requestErrorMessage(err, "Failed to confirm reminder"), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", "reloadReminders")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) skipReminder(w http.ResponseWriter, r *http.Request) {
+	id := parseInt32(r.PathValue("reminder_id"))
+
+	if err := s.sendRequest(r, "POST", fmt.Sprintf("/v1/recurring/reminders/%d/skip", id), nil, nil); err != nil {
+		slog.Error("failed to skip reminder", slog.String("error", err.Error()))
+
+		var sendRequestError *sendRequestError
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeUnauthorized {
+			s.clearTokenAndRedirect(w)
+			return
+		}
+
+		http.Error(w, requestErrorMessage(err, "Failed to skip reminder"), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", "reloadReminders")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) snoozeReminder(w http.ResponseWriter, r *http.Request) {
+	id := parseInt32(r.PathValue("reminder_id"))
+
+	days, err := strconv.Atoi(r.FormValue("days"))
+	if err != nil {
+		http.Error(w, "Invalid number of days", http.StatusBadRequest)
+		return
+	}
+
+	payload := struct {
+		Days int `json:"days"`
+	}{
+		Days: days,
+	}
+
+	if err := s.sendRequest(r, "POST", fmt.Sprintf("/v1/recurring/reminders/%d/snooze", id), payload, nil); err != nil {
+		slog.Error("failed to snooze reminder", slog.String("error", err.Error()))
+
+		var sendRequestError *sendRequestError
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeUnauthorized {
+			s.clearTokenAndRedirect(w)
+			return
+		}
+
+		http.Error(w, requestErrorMessage(err, "Failed to snooze reminder"), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", "reloadReminders")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) updateReminderSettings(w http.ResponseWriter, r *http.Request) {
+	offsets, err := parseReminderOffsets(r.FormValue("default_offsets"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	payload := reminderSettings{DefaultOffsets: offsets}
+	if payload.DefaultOffsets == nil {
+		payload.DefaultOffsets = []int{}
+	}
+
+	if err := s.sendRequest(r, "PUT", "/v1/reminder-settings", payload, nil); err != nil {
+		slog.Error("failed to update reminder settings", slog.String("error", err.Error()))
+
+		var sendRequestError *sendRequestError
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeUnauthorized {
+			s.clearTokenAndRedirect(w)
+			return
+		}
+
+		http.Error(w, requestErrorMessage(err, "Failed to update reminder settings"), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", "reloadReminders")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) updateNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	channel := r.PathValue("channel")
+
+	payload := struct {
+		Target  string `json:"target"`
+		Enabled bool   `json:"enabled"`
+	}{
+		Target:  r.FormValue("target"),
+		Enabled: r.FormValue("enabled") == "on",
+	}
+
+	if err := s.sendRequest(r, "PUT", "/v1/notification-channels/"+url.PathEscape(channel), payload, nil); err != nil {
+		slog.Error("failed to update notification channel", slog.String("error", err.Error()))
+
+		var sendRequestError *sendRequestError
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeUnauthorized {
+			s.clearTokenAndRedirect(w)
+			return
+		}
+
+		http.Error(w, requestErrorMessage(err, "Failed to update notification channel"), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", "reloadReminders")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	channel := r.PathValue("channel")
+
+	if err := s.sendRequest(r, "DELETE", "/v1/notification-channels/"+url.PathEscape(channel), nil, nil); err != nil {
+		slog.Error("failed to delete notification channel", slog.String("error", err.Error()))
+
+		var sendRequestError *sendRequestError
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeUnauthorized {
+			s.clearTokenAndRedirect(w)
+			return
+		}
+
+		http.Error(w, requestErrorMessage(err, "Failed to delete notification channel"), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", "reloadReminders")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) updateDigestSettings(w http.ResponseWriter, r *http.Request) {
+	weekday, err := strconv.Atoi(r.FormValue("weekday"))
+	if err != nil {
+		http.Error(w, "Invalid weekday", http.StatusBadRequest)
+		return
+	}
+
+	dayOfMonth, err := strconv.Atoi(r.FormValue("day_of_month"))
+	if err != nil {
+		http.Error(w, "Invalid day of month", http.StatusBadRequest)
+		return
+	}
+
+	payload := digestSettings{
+		Enabled:    r.FormValue("enabled") == "on",
+		Frequency:  r.FormValue("frequency"),
+		Weekday:    weekday,
+		DayOfMonth: dayOfMonth,
+	}
+
+	if err := s.sendRequest(r, "PUT", "/v1/digest-settings", payload, nil); err != nil {
+		slog.Error("failed to update digest settings", slog.String("error", err.Error()))
+
+		var sendRequestError *sendRequestError
+		if errors.As(err, &sendRequestError) && sendRequestError.Code == app.CodeUnauthorized {
+			s.clearTokenAndRedirect(w)
+			return
+		}
+
+		http.Error(w, requestErrorMessage(err, "Failed to update digest settings"), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", "reloadReminders")
+	w.WriteHeader(http.StatusOK)
+}
@@ -11,42 +11,76 @@ func (s *Server) registerRoutes() {
 	router.HandleFunc("GET /{$}", s.pageIndex)
 	router.HandleFunc("GET /", s.page404)
 
-	router.HandleFunc("GET /page/accounts/create", authenticatedHandler(s.pageCreateAccount))
-	router.HandleFunc("GET /page/accounts", authenticatedHandler(s.pageAccounts))
-	router.HandleFunc("GET /page/accounts/list", authenticatedHandler(s.pageAccountList))
-	router.HandleFunc("GET /page/accounts/{account_id}", authenticatedHandler(s.pageAccount))
-	router.HandleFunc("GET /page/accounts/{account_id}/ledgers/create", authenticatedHandler(s.pageCreateLedger))
-	router.HandleFunc("GET /page/accounts/{account_id}/ledgers", authenticatedHandler(s.pageLedgersByAccount))
-	router.HandleFunc("GET /page/accounts/{account_id}/bank-account", authenticatedHandler(s.pageBankAccount))
-	router.HandleFunc("GET /page/ledgers/{ledger_id}/details", authenticatedHandler(s.pageLedgerDetails))
-	router.HandleFunc("GET /page/ledgers/{ledger_id}", authenticatedHandler(s.pageLedger))
-	router.HandleFunc("GET /page/recurring", authenticatedHandler(s.pageRecurringList))
-	router.HandleFunc("GET /page/recurring/create", authenticatedHandler(s.pageCreateRecurring))
-	router.HandleFunc("GET /page/recurring/{recurring_id}", authenticatedHandler(s.pageRecurringDetails))
-	router.HandleFunc("GET /page/reminders", authenticatedHandler(s.pageReminders))
+	router.HandleFunc("GET /page/accounts/create", s.authenticatedHandler(s.pageCreateAccount))
+	router.HandleFunc("GET /page/accounts", s.authenticatedHandler(s.pageAccounts))
+	router.HandleFunc("GET /page/accounts/list", s.authenticatedHandler(s.pageAccountList))
+	router.HandleFunc("GET /page/accounts/{account_id}", s.authenticatedHandler(s.pageAccount))
+	router.HandleFunc("GET /page/accounts/{account_id}/ledgers/create", s.authenticatedHandler(s.pageCreateLedger))
+	router.HandleFunc("GET /page/accounts/{account_id}/ledgers", s.authenticatedHandler(s.pageLedgersByAccount))
+	router.HandleFunc("GET /page/accounts/{account_id}/bank-account", s.authenticatedHandler(s.pageBankAccount))
+	router.HandleFunc("GET /page/ledgers/{ledger_id}/details", s.authenticatedHandler(s.pageLedgerDetails))
+	router.HandleFunc("GET /page/ledgers/{ledger_id}/history", s.authenticatedHandler(s.pageLedgerHistory))
+	router.HandleFunc("GET /page/ledgers/{ledger_id}", s.authenticatedHandler(s.pageLedger))
+	router.HandleFunc("GET /page/recurring", s.authenticatedHandler(s.pageRecurringList))
+	router.HandleFunc("GET /page/recurring/create", s.authenticatedHandler(s.pageCreateRecurring))
+	router.HandleFunc("GET /page/recurring/{recurring_id}", s.authenticatedHandler(s.pageRecurringDetails))
+	router.HandleFunc("GET /page/reminders", s.authenticatedHandler(s.pageReminders))
+	router.HandleFunc("GET /page/password", s.authenticatedHandler(s.pageChangePassword))
+	router.HandleFunc("GET /page/password/forgot", s.pageForgotPassword)
+	router.HandleFunc("GET /page/password/reset", s.pageResetPassword)
+	router.HandleFunc("GET /page/two-factor", s.authenticatedHandler(s.pageTwoFactor))
+	router.HandleFunc("GET /page/signup", s.pageSignup)
+	router.HandleFunc("GET /page/verify-email", s.pageVerifyEmail)
+	router.HandleFunc("GET /page/confirm-notification-email", s.pageConfirmNotificationEmail)
 
 	// Authentication
 	router.HandleFunc("POST /login", s.login)
+	router.HandleFunc("POST /login/2fa", s.loginTwoFactor)
 	router.HandleFunc("POST /logout", s.logout)
+	router.HandleFunc("POST /password", s.authenticatedHandler(s.changePassword))
+	router.HandleFunc("POST /password/forgot", s.forgotPassword)
+	router.HandleFunc("POST /password/reset", s.resetPassword)
+	router.HandleFunc("POST /two-factor/totp", s.authenticatedHandler(s.enrollTOTP))
+	router.HandleFunc("POST /two-factor/totp/confirm", s.authenticatedHandler(s.confirmTOTP))
+	router.HandleFunc("POST /two-factor/recovery-codes", s.authenticatedHandler(s.regenerateRecoveryCodes))
+	router.HandleFunc("POST /two-factor/disable", s.authenticatedHandler(s.disableTwoFactor))
+	router.HandleFunc("POST /signup", s.signup)
+	router.HandleFunc("POST /verify-email/resend", s.resendVerificationEmail)
+	router.HandleFunc("GET /auth/oidc/login", s.loginOIDC)
+	router.HandleFunc("GET /auth/oidc/callback", s.oidcCallback)
+	router.HandleFunc("GET /auth/oidc/complete", s.completeOIDC)
+	router.HandleFunc("POST /auth/oidc/link", s.authenticatedHandler(s.linkOIDC))
+
+	// Workspaces
+	router.HandleFunc("POST /workspace", s.authenticatedHandler(s.switchWorkspace))
 
 	// Accounts
-	router.HandleFunc("POST /accounts", authenticatedHandler(s.createAccount))
+	router.HandleFunc("POST /accounts", s.authenticatedHandler(s.createAccount))
 	
 	// Bank Accounts
-	router.HandleFunc("POST /accounts/{account_id}/bank-account", authenticatedHandler(s.createBankAccount))
-	router.HandleFunc("PATCH /bank-accounts/{id}", authenticatedHandler(s.updateBankAccount))
-	router.HandleFunc("DELETE /bank-accounts/{id}", authenticatedHandler(s.deleteBankAccount))
+	router.HandleFunc("POST /accounts/{account_id}/bank-account", s.authenticatedHandler(s.createBankAccount))
+	router.HandleFunc("PATCH /bank-accounts/{id}", s.authenticatedHandler(s.updateBankAccount))
+	router.HandleFunc("DELETE /bank-accounts/{id}", s.authenticatedHandler(s.deleteBankAccount))
 
 	// Ledgers
-	router.HandleFunc("POST /accounts/{account_id}/ledgers", authenticatedHandler(s.createLedger))
-	router.HandleFunc("PATCH /ledgers/{ledger_id}", authenticatedHandler(s.updateLedger))
-	router.HandleFunc("DELETE /ledgers/{ledger_id}", authenticatedHandler(s.voidLedger))
+	router.HandleFunc("POST /accounts/{account_id}/ledgers", s.authenticatedHandler(s.createLedger))
+	router.HandleFunc("PATCH /ledgers/{ledger_id}", s.authenticatedHandler(s.updateLedger))
+	router.HandleFunc("DELETE /ledgers/{ledger_id}", s.authenticatedHandler(s.voidLedger))
 
 	// Recurring transactions
-	router.HandleFunc("POST /recurring", authenticatedHandler(s.createRecurring))
-	router.HandleFunc("PUT /recurring/{recurring_id}", authenticatedHandler(s.updateRecurring))
-	router.HandleFunc("DELETE /recurring/{recurring_id}", authenticatedHandler(s.deleteRecurring))
-	router.HandleFunc("POST /reminders/{reminder_id}/read", authenticatedHandler(s.markReminderAsRead))
+	router.HandleFunc("POST /recurring", s.authenticatedHandler(s.createRecurring))
+	router.HandleFunc("PUT /recurring/{recurring_id}", s.authenticatedHandler(s.updateRecurring))
+	router.HandleFunc("DELETE /recurring/{recurring_id}", s.authenticatedHandler(s.deleteRecurring))
+	router.HandleFunc("POST /recurring/{recurring_id}/skip", s.authenticatedHandler(s.skipRecurringOccurrence))
+	router.HandleFunc("POST /recurring/{recurring_id}/postpone", s.authenticatedHandler(s.postponeRecurringOccurrence))
+	router.HandleFunc("POST /reminders/{reminder_id}/read", s.authenticatedHandler(s.markReminderAsRead))
+	router.HandleFunc("POST /reminders/{reminder_id}/confirm", s.authenticatedHandler(s.confirmReminder))
+	router.HandleFunc("POST /reminders/{reminder_id}/skip", s.authenticatedHandler(s.skipReminder))
+	router.HandleFunc("POST /reminders/{reminder_id}/snooze", s.authenticatedHandler(s.snoozeReminder))
+	router.HandleFunc("POST /reminder-settings", s.authenticatedHandler(s.updateReminderSettings))
+	router.HandleFunc("POST /notification-channels/{channel}", s.authenticatedHandler(s.updateNotificationChannel))
+	router.HandleFunc("DELETE /notification-channels/{channel}", s.authenticatedHandler(s.deleteNotificationChannel))
+	router.HandleFunc("POST /digest-settings", s.authenticatedHandler(s.updateDigestSettings))
 
 	s.router = logging(router)
 }
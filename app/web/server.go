@@ -35,7 +35,9 @@ type Server struct {
 	router    http.Handler
 	templates *template.Template
 
-	serverURL string
+	serverURL          string
+	publicRegistration bool
+	oidcProviderName   string
 }
 
 // NewServer creates a new web server
@@ -69,6 +71,13 @@ func (s *Server) initTemplates() {
 		"dollar": func(_, amount string) string {
 			return fmt.Sprintf("$%s", amount)
 		},
+		"joinInts": func(values []int) string {
+			parts := make([]string, len(values))
+			for i, v := range values {
+				parts[i] = strconv.Itoa(v)
+			}
+			return strings.Join(parts, ", ")
+		},
 		"seq": func(start, end int) []int {
 			seq := make([]int, end-start+1)
 			for i := range seq {
@@ -117,7 +126,7 @@ func (e *sendRequestError) Error() string {
 	return fmt.Sprintf("failed to send request: %s", e.Message)
 }
 
-func (s *Server) sendRequest(r *http.Request, method, path string, body any, result any) error {
+func (s *Server) sendRequest(r *http.Request, method, path string, body any, result any, cookies ...*http.Cookie) error {
 	url := fmt.Sprintf("%s%s", s.serverURL, path)
 	var reqBody []byte
 	var err error
@@ -134,13 +143,20 @@ func (s *Server) sendRequest(r *http.Request, method, path string, body any, res
 	}
 
 	if !strings.HasPrefix(path, "/public") {
-		token, err := r.Cookie("token")
+		token, err := r.Cookie(tokenCookie)
 		if err != nil {
 			return fmt.Errorf("failed to get token from cookie: %w", err)
 		}
 		req.Header.Set("Authorization", "Bearer "+token.Value)
+
+		if workspaceID := activeWorkspaceID(r); workspaceID != 0 {
+			req.Header.Set("X-Workspace-ID", strconv.Itoa(int(workspaceID)))
+		}
 	}
 	req.Header.Set("Content-Type", "application/json")
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -0,0 +1,116 @@
+package web
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+type signupPage struct {
+	Mode    string // signup, verify or confirm
+	Success bool
+	Message string
+}
+
+func (s *Server) renderSignupPage(w http.ResponseWriter, page signupPage) {
+	if err := s.templates.ExecuteTemplate(w, "signup.html", page); err != nil {
+		slog.Error("failed to render signup.html", slog.String("error", err.Error()))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) pageSignup(w http.ResponseWriter, r *http.Request) {
+	if !s.publicRegistration {
+		s.page404(w, r)
+		return
+	}
+
+	s.renderSignupPage(w, signupPage{Mode: "signup"})
+}
+
+func (s *Server) signup(w http.ResponseWriter, r *http.Request) {
+	if !s.publicRegistration {
+		http.NotFound(w, r)
+		return
+	}
+
+	payload := map[string]string{
+		"email":    r.FormValue("email"),
+		"password": r.FormValue("password"),
+	}
+
+	if err := s.sendRequest(r, "POST", "/public/auth/register", payload, nil); err != nil {
+		slog.Error("failed to sign up", slog.String("error", err.Error()))
+		writeMessage(w, "text-error", requestErrorMessage(err, "Failed to sign up, please try again later"))
+		return
+	}
+
+	writeMessage(w, "text-success", "Check your inbox for a link to verify your email address")
+}
+
+func (s *Server) pageVerifyEmail(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	payload := map[string]string{
+		"token": token,
+	}
+
+	if err := s.sendRequest(r, "POST", "/public/auth/verify-email", payload, nil); err != nil {
+		slog.Error("failed to verify email", slog.String("error", err.Error()))
+		s.renderSignupPage(w, signupPage{
+			Mode:    "verify",
+			Message: requestErrorMessage(err, "Failed to verify your email address"),
+		})
+		return
+	}
+
+	s.renderSignupPage(w, signupPage{
+		Mode:    "verify",
+		Success: true,
+		Message: "Your email address has been verified, you can now sign in",
+	})
+}
+
+func (s *Server) pageConfirmNotificationEmail(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	payload := map[string]string{
+		"token": token,
+	}
+
+	if err := s.sendRequest(r, "POST", "/public/notification-channels/email/confirm", payload, nil); err != nil {
+		slog.Error("failed to confirm notification email", slog.String("error", err.Error()))
+		s.renderSignupPage(w, signupPage{
+			Mode:    "confirm",
+			Message: requestErrorMessage(err, "Failed to confirm your email address"),
+		})
+		return
+	}
+
+	s.renderSignupPage(w, signupPage{
+		Mode:    "confirm",
+		Success: true,
+		Message: "Your reminders will now be sent to this email address",
+	})
+}
+
+func (s *Server) resendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	payload := map[string]string{
+		"email": r.FormValue("email"),
+	}
+
+	if err := s.sendRequest(r, "POST", "/public/auth/verify-email/resend", payload, nil); err != nil {
+		slog.Error("failed to resend verification email", slog.String("error", err.Error()))
+		writeMessage(w, "text-error", "Failed to send a verification link, please try again later")
+		return
+	}
+
+	writeMessage(w, "text-success", "If the email belongs to an unverified account, a new link is on its way")
+}
@@ -0,0 +1,96 @@
+package web
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+type twoFactorStatus struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+type totpEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type recoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (s *Server) renderTemplate(w http.ResponseWriter, name string, data any) {
+	if err := s.templates.ExecuteTemplate(w, name, data); err != nil {
+		slog.Error("failed to render "+name, slog.String("error", err.Error()))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) pageTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var status twoFactorStatus
+	if err := s.sendRequest(r, "GET", "/v1/auth/2fa", nil, &status); err != nil {
+		slog.Error("failed to get two-factor status", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get two-factor status", http.StatusInternalServerError)
+		return
+	}
+
+	s.renderTemplate(w, "two_factor.html", status)
+}
+
+func (s *Server) enrollTOTP(w http.ResponseWriter, r *http.Request) {
+	var enrollment totpEnrollment
+	if err := s.sendRequest(r, "POST", "/v1/auth/2fa/totp", nil, &enrollment); err != nil {
+		slog.Error("failed to enroll totp", slog.String("error", err.Error()))
+		writeMessage(w, "text-error", requestErrorMessage(err, "Failed to set up two-factor authentication"))
+		return
+	}
+
+	s.renderTemplate(w, "two_factor_enroll", enrollment)
+}
+
+func (s *Server) confirmTOTP(w http.ResponseWriter, r *http.Request) {
+	payload := map[string]string{
+		"code": r.FormValue("code"),
+	}
+
+	var codes recoveryCodes
+	if err := s.sendRequest(r, "POST", "/v1/auth/2fa/totp/confirm", payload, &codes); err != nil {
+		slog.Error("failed to confirm totp", slog.String("error", err.Error()))
+		w.Header().Set("HX-Retarget", "#enroll-message")
+		writeMessage(w, "text-error", requestErrorMessage(err, "Failed to enable two-factor authentication"))
+		return
+	}
+
+	s.renderTemplate(w, "two_factor_recovery_codes", codes)
+}
+
+func (s *Server) regenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	payload := map[string]string{
+		"code": r.FormValue("code"),
+	}
+
+	var codes recoveryCodes
+	if err := s.sendRequest(r, "POST", "/v1/auth/2fa/recovery-codes", payload, &codes); err != nil {
+		slog.Error("failed to regenerate recovery codes", slog.String("error", err.Error()))
+		w.Header().Set("HX-Retarget", "#message")
+		writeMessage(w, "text-error", requestErrorMessage(err, "Failed to regenerate recovery codes"))
+		return
+	}
+
+	s.renderTemplate(w, "two_factor_recovery_codes", codes)
+}
+
+func (s *Server) disableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	payload := map[string]string{
+		"code": r.FormValue("code"),
+	}
+
+	if err := s.sendRequest(r, "DELETE", "/v1/auth/2fa", payload, nil); err != nil {
+		slog.Error("failed to disable two-factor authentication", slog.String("error", err.Error()))
+		writeMessage(w, "text-error", requestErrorMessage(err, "Failed to disable two-factor authentication"))
+		return
+	}
+
+	w.Header().Set("HX-Refresh", "true")
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,71 @@
+package web
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// workspaceCookie stores the workspace the user is currently working in
+const workspaceCookie = "workspace_id"
+
+type workspace struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Role string `json:"role"`
+}
+
+// activeWorkspaceID returns the workspace selected in the web UI, or zero for personal data.
+func activeWorkspaceID(r *http.Request) int32 {
+	cookie, err := r.Cookie(workspaceCookie)
+	if err != nil {
+		return 0
+	}
+
+	return parseInt32(cookie.Value)
+}
+
+func (s *Server) getWorkspaceList(r *http.Request) ([]workspace, error) {
+	var workspaces []workspace
+	if err := s.sendRequest(r, "GET", "/v1/workspaces", nil, &workspaces); err != nil {
+		return nil, err
+	}
+
+	return workspaces, nil
+}
+
+func (s *Server) switchWorkspace(w http.ResponseWriter, r *http.Request) {
+	workspaceID := parseInt32(r.FormValue("workspace_id"))
+
+	cookie := &http.Cookie{
+		Name:     workspaceCookie,
+		Value:    strconv.Itoa(int(workspaceID)),
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	}
+	if workspaceID == 0 {
+		cookie.Value = ""
+		cookie.MaxAge = -1
+	} else {
+		workspaces, err := s.getWorkspaceList(r)
+		if err != nil {
+			slog.Error("failed to get workspaces", slog.String("error", err.Error()))
+			http.Error(w, "Failed to switch workspace", http.StatusInternalServerError)
+			return
+		}
+
+		if !slices.ContainsFunc(workspaces, func(workspace workspace) bool { return workspace.ID == workspaceID }) {
+			http.Error(w, "Workspace not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	http.SetCookie(w, cookie)
+
+	w.Header().Set("HX-Redirect", "/page/accounts")
+	w.WriteHeader(http.StatusOK)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"strings"
 
 	slogzap "github.com/samber/slog-zap/v2"
 	"github.com/urfave/cli/v2"
@@ -13,6 +14,8 @@ import (
 	"github.com/omegaatt36/bookly/app"
 	"github.com/omegaatt36/bookly/app/api"
 	"github.com/omegaatt36/bookly/persistence/database"
+	"github.com/omegaatt36/bookly/service/notify"
+	"github.com/omegaatt36/bookly/service/oidc"
 )
 
 var config struct {
@@ -22,6 +25,10 @@ var config struct {
 	internalTokenOption api.InternalTokenOption
 	jwtOption           api.JWTOption
 	portOption          api.PortOption
+	webURLOption        api.WebURLOption
+	registrationOption  api.PublicRegistrationOption
+	notifyOption        notify.Option
+	oidcOption          oidc.Option
 }
 
 func before(_ *cli.Context) error {
@@ -37,11 +44,30 @@ func after(_ *cli.Context) error {
 }
 
 func action(ctx context.Context) {
-	server := api.NewServer(
+	notifier, err := config.notifyOption.NewNotifier()
+	if err != nil {
+		slog.Error("failed to create notifier", slog.String("error", err.Error()))
+		return
+	}
+
+	options := []api.Option{
 		&config.jwtOption,
 		&config.internalTokenOption,
 		&config.portOption,
-	)
+		&config.webURLOption,
+		&config.registrationOption,
+		&api.NotifierOption{Notifier: notifier},
+	}
+
+	if config.oidcOption.Enabled() {
+		oidcOption := config.oidcOption
+		if oidcOption.RedirectURL == "" {
+			oidcOption.RedirectURL = strings.TrimSuffix(config.webURLOption.WebURL, "/") + "/auth/oidc/callback"
+		}
+		options = append(options, &api.OIDCProviderOption{Provider: oidc.NewProvider(oidcOption)})
+	}
+
+	server := api.NewServer(options...)
 
 	server.Run(ctx)
 }
@@ -93,6 +119,19 @@ func main() {
 			DefaultText: "8080",
 			Destination: &config.portOption.Port,
 		},
+		&cli.StringFlag{
+			Name:        "web-url",
+			Usage:       "base URL of the web app, used in links sent to users",
+			EnvVars:     []string{"WEB_URL"},
+			Value:       "http://localhost:3000",
+			Destination: &config.webURLOption.WebURL,
+		},
+		&cli.BoolFlag{
+			Name:        "public-registration",
+			Usage:       "let users sign up at /public/auth/register",
+			EnvVars:     []string{"PUBLIC_REGISTRATION"},
+			Destination: &config.registrationOption.Enabled,
+		},
 		&cli.StringFlag{
 			Name:        "log-level",
 			EnvVars:     []string{"LOG_LEVEL"},
@@ -101,6 +140,8 @@ func main() {
 		},
 	}
 	cliFlags = append(cliFlags, config.databaseConnectionOption.CliFlags()...)
+	cliFlags = append(cliFlags, config.notifyOption.CliFlags()...)
+	cliFlags = append(cliFlags, config.oidcOption.CliFlags()...)
 
 	server := &app.App{
 		Action: action,
@@ -8,7 +8,6 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/go-co-op/gocron/v2"
 	slogzap "github.com/samber/slog-zap/v2"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
@@ -18,11 +17,40 @@ import (
 	"github.com/omegaatt36/bookly/persistence/database"
 	"github.com/omegaatt36/bookly/persistence/repository"
 	"github.com/omegaatt36/bookly/service/bookkeeping"
+	"github.com/omegaatt36/bookly/service/cronjob"
+	"github.com/omegaatt36/bookly/service/notify"
 )
 
-var config struct {
+const (
+	jobProcessRecurring  = "process-recurring"
+	jobDispatchReminders = "dispatch-reminders"
+	jobSendDigests       = "send-digests"
+)
+
+var config = struct {
 	databaseConnectionOption database.ConnectOption
+	notifyOption             notify.Option
+	deliveryRetry            bookkeeping.DeliveryRetry
+	processRecurringJob      cronjob.Config
+	dispatchRemindersJob     cronjob.Config
+	sendDigestsJob           cronjob.Config
 	logLevel                 string
+}{
+	processRecurringJob: cronjob.Config{
+		Schedule: "0 * * * *", // Every hour
+		Timeout:  30 * time.Second,
+		Enabled:  true,
+	},
+	dispatchRemindersJob: cronjob.Config{
+		Schedule: "*/5 * * * *", // Every 5 minutes
+		Timeout:  4 * time.Minute,
+		Enabled:  true,
+	},
+	sendDigestsJob: cronjob.Config{
+		Schedule: "0 7 * * *", // Every day at 7, digests go out on the day users chose
+		Timeout:  10 * time.Minute,
+		Enabled:  true,
+	},
 }
 
 // initSLog initializes structured logging using slog and zap.
@@ -65,6 +93,24 @@ func after(_ *cli.Context) error {
 func action(ctx context.Context) {
 	slog.Info("Starting bookkeeping crond")
 
+	notifier, err := config.notifyOption.NewNotifier()
+	if err != nil {
+		slog.Error("failed to create notifier", slog.String("error", err.Error()))
+		return
+	}
+
+	channelNotifiers, err := config.notifyOption.NewChannelNotifiers()
+	if err != nil {
+		slog.Error("failed to create channel notifiers", slog.String("error", err.Error()))
+		return
+	}
+
+	mailer, err := config.notifyOption.NewMailer()
+	if err != nil {
+		slog.Error("failed to create mailer", slog.String("error", err.Error()))
+		return
+	}
+
 	// Get database connection
 	db := database.GetDB()
 
@@ -76,29 +122,47 @@ func action(ctx context.Context) {
 		LedgerRepo:               repo,
 		RecurringTransactionRepo: repo,
 		ReminderRepo:             repo,
+		UserRepo:                 repo,
+		Notifier:                 notifier,
+		NotificationRepo:         repo,
+		ChannelNotifiers:         channelNotifiers,
+		DeliveryRetry:            config.deliveryRetry,
+		DigestRepo:               repo,
+		Mailer:                   mailer,
 	})
 
-	funcProcessDueTransactions := func() {
-		slog.Info("Running scheduled ProcessDueTransactions")
-		ctx, cancle := context.WithTimeout(ctx, time.Second*30)
-		defer cancle()
-
-		if err := service.ProcessDueTransactions(ctx); err != nil {
-			slog.Error("Failed to process recurring transactions", "error", err)
-			return
+	runner := cronjob.NewRunner(repo)
+	for _, job := range []cronjob.Job{
+		{
+			Name:   jobProcessRecurring,
+			Config: config.processRecurringJob,
+			Run:    service.ProcessDueTransactions,
+		},
+		{
+			Name:   jobDispatchReminders,
+			Config: config.dispatchRemindersJob,
+			Run:    service.DispatchDueReminders,
+		},
+		{
+			Name:   jobSendDigests,
+			Config: config.sendDigestsJob,
+			Run:    service.SendDigests,
+		},
+	} {
+		if err := runner.Register(job); err != nil {
+			slog.Error("Failed to register job", "error", err)
+			panic(err)
 		}
-
-		slog.Info("Successfully processed recurring transactions")
 	}
 
-	s, err := gocron.NewScheduler()
-	if err != nil {
-		slog.Error("Failed to create scheduler", "error", err)
-		panic(err) // Crond cannot function without a scheduler
+	if err := runner.Start(ctx); err != nil {
+		slog.Error("Failed to start jobs", "error", err)
+		// Crond cannot function without its jobs
+		panic(err)
 	}
 	defer func() {
 		slog.Info("Scheduler deferred shutdown")
-		if err := s.Shutdown(); err != nil {
+		if err := runner.Shutdown(); err != nil {
 			slog.Error("Failed to shutdown scheduler", "error", err)
 			return
 		}
@@ -106,20 +170,6 @@ func action(ctx context.Context) {
 		slog.Info("Scheduler stopped, crond exiting")
 	}()
 
-	job, err := s.NewJob(
-		gocron.DurationJob(1*time.Hour), // Schedule every hour
-		gocron.NewTask(funcProcessDueTransactions),
-		gocron.WithSingletonMode(gocron.LimitModeWait), // Ensure only one instance runs at a time
-	)
-	if err != nil {
-		slog.Error("Failed to schedule job", "error", err)
-		// Cannot schedule the main job, crond is non-functional
-		panic(err)
-	}
-	slog.Info("Job scheduled", "job_id", job.ID().String(), "schedule", "every 1 hour")
-
-	// Start the scheduler in a goroutine
-	s.Start()
 	slog.Info("Scheduler started")
 
 	sigChan := make(chan os.Signal, 1)
@@ -138,9 +188,27 @@ func main() {
 			Value:       "debug",
 			Destination: &config.logLevel,
 		},
+		&cli.IntFlag{
+			Name:        "reminder-delivery-max-attempts",
+			Usage:       "attempts before a reminder delivery is given up",
+			EnvVars:     []string{"REMINDER_DELIVERY_MAX_ATTEMPTS"},
+			Value:       5,
+			Destination: &config.deliveryRetry.MaxAttempts,
+		},
+		&cli.DurationFlag{
+			Name:        "reminder-delivery-backoff",
+			Usage:       "wait before retrying a failed reminder delivery, doubled after every attempt",
+			EnvVars:     []string{"REMINDER_DELIVERY_BACKOFF"},
+			Value:       time.Minute,
+			Destination: &config.deliveryRetry.Backoff,
+		},
 	}
 	// Add database connection flags from the database package
 	cliFlags = append(cliFlags, config.databaseConnectionOption.CliFlags()...)
+	cliFlags = append(cliFlags, config.notifyOption.CliFlags()...)
+	cliFlags = append(cliFlags, config.processRecurringJob.CliFlags(jobProcessRecurring)...)
+	cliFlags = append(cliFlags, config.dispatchRemindersJob.CliFlags(jobDispatchReminders)...)
+	cliFlags = append(cliFlags, config.sendDigestsJob.CliFlags(jobSendDigests)...)
 
 	crondApp := &app.App{
 		Action: action,
@@ -17,8 +17,10 @@ import (
 var config struct {
 	logLevel string
 
-	serverURLOption web.ServerURLOption
-	portOption      web.PortOption
+	serverURLOption    web.ServerURLOption
+	portOption         web.PortOption
+	registrationOption web.PublicRegistrationOption
+	oidcLoginOption    web.OIDCLoginOption
 }
 
 func before(_ *cli.Context) error {
@@ -29,6 +31,8 @@ func action(ctx context.Context) {
 	server := web.NewServer(
 		&config.portOption,
 		&config.serverURLOption,
+		&config.registrationOption,
+		&config.oidcLoginOption,
 	)
 
 	server.Run(ctx)
@@ -68,6 +72,18 @@ func main() {
 			Value:       "http://localhost:8080",
 			Destination: &config.serverURLOption.ServerURL,
 		},
+		&cli.BoolFlag{
+			Name:        "public-registration",
+			Usage:       "show the sign up page, the api must allow public registration too",
+			EnvVars:     []string{"PUBLIC_REGISTRATION"},
+			Destination: &config.registrationOption.Enabled,
+		},
+		&cli.StringFlag{
+			Name:        "oidc-provider-name",
+			Usage:       "name of the OpenID Connect provider on the sign in button, single sign-on is hidden when empty",
+			EnvVars:     []string{"OIDC_PROVIDER_NAME"},
+			Destination: &config.oidcLoginOption.ProviderName,
+		},
 		&cli.StringFlag{
 			Name:        "log-level",
 			EnvVars:     []string{"LOG_LEVEL"},
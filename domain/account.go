@@ -12,6 +12,33 @@ import (
 // ENUM(active, closed, archived)
 type AccountStatus string
 
+// LedgerEditMode represents how ledgers of an account can be corrected
+// ENUM(window, adjust_only)
+type LedgerEditMode string
+
+// EditPolicy decides how long ledgers of an account can be edited in place.
+// Once a ledger is no longer editable it can only be corrected by an adjustment.
+type EditPolicy struct {
+	Mode LedgerEditMode
+	// Window is how long after its creation a ledger stays editable in window mode
+	Window time.Duration
+}
+
+// DefaultEditPolicy is the edit policy of accounts that did not configure one
+var DefaultEditPolicy = EditPolicy{
+	Mode:   LedgerEditModeWindow,
+	Window: EditableDuration,
+}
+
+// Editable reports whether a ledger can still be edited in place at the given time
+func (p EditPolicy) Editable(ledger *Ledger, now time.Time) bool {
+	if p.Mode == LedgerEditModeAdjustOnly {
+		return false
+	}
+
+	return now.Sub(ledger.CreatedAt) <= p.Window
+}
+
 // Account represents a ledger account
 type Account struct {
 	ID        int32
@@ -23,22 +50,31 @@ type Account struct {
 	Currency  string
 	Balance   decimal.Decimal
 	DeletedAt *time.Time
+
+	EditPolicy EditPolicy
+
+	// WorkspaceID is set when the account is owned by a workspace rather than by the user alone
+	WorkspaceID *int32
 }
 
 // CreateAccountRequest defines the request to create a ledger account
 type CreateAccountRequest struct {
-	UserID   int32
-	Name     string
-	Currency string
+	UserID      int32
+	Name        string
+	Currency    string
+	WorkspaceID *int32
+	Actor       AuditActor
 }
 
 // UpdateAccountRequest defines the request to update a ledger account
 type UpdateAccountRequest struct {
-	ID       int32
-	UserID   *int32
-	Name     *string
-	Currency *string
-	Status   *AccountStatus
+	ID         int32
+	UserID     *int32
+	Name       *string
+	Currency   *string
+	Status     *AccountStatus
+	EditPolicy *EditPolicy
+	Actor      AuditActor
 }
 
 // AccountRepository represents a ledger account repository interface
@@ -46,8 +82,9 @@ type AccountRepository interface {
 	CreateAccount(CreateAccountRequest) error
 	GetAccountByID(int32) (*Account, error)
 	UpdateAccount(UpdateAccountRequest) error
-	DeactivateAccountByID(int32) error
-	DeleteAccount(int32) error
+	DeactivateAccountByID(id int32, actor AuditActor) error
+	DeleteAccount(id int32, actor AuditActor) error
 	GetAllAccounts() ([]*Account, error)
 	GetAccountsByUserID(int32) ([]*Account, error)
+	GetAccountsByWorkspaceID(int32) ([]*Account, error)
 }
@@ -47,3 +47,37 @@ func ParseAccountStatus(name string) (AccountStatus, error) {
 	}
 	return AccountStatus(""), fmt.Errorf("%s is %w", name, ErrInvalidAccountStatus)
 }
+
+const (
+	// LedgerEditModeWindow is a LedgerEditMode of type window.
+	LedgerEditModeWindow LedgerEditMode = "window"
+	// LedgerEditModeAdjustOnly is a LedgerEditMode of type adjust_only.
+	LedgerEditModeAdjustOnly LedgerEditMode = "adjust_only"
+)
+
+var ErrInvalidLedgerEditMode = errors.New("not a valid LedgerEditMode")
+
+// String implements the Stringer interface.
+func (x LedgerEditMode) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x LedgerEditMode) IsValid() bool {
+	_, err := ParseLedgerEditMode(string(x))
+	return err == nil
+}
+
+var _LedgerEditModeValue = map[string]LedgerEditMode{
+	"window":      LedgerEditModeWindow,
+	"adjust_only": LedgerEditModeAdjustOnly,
+}
+
+// ParseLedgerEditMode attempts to convert a string to a LedgerEditMode.
+func ParseLedgerEditMode(name string) (LedgerEditMode, error) {
+	if x, ok := _LedgerEditModeValue[name]; ok {
+		return x, nil
+	}
+	return LedgerEditMode(""), fmt.Errorf("%s is %w", name, ErrInvalidLedgerEditMode)
+}
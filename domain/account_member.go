@@ -0,0 +1,88 @@
+//go:generate go-enum
+
+package domain
+
+import "time"
+
+// AccountMemberRole represents the permission level of a user on an account
+// ENUM(owner, editor, viewer)
+type AccountMemberRole string
+
+// AccountInvitationStatus represents the status of an account invitation
+// ENUM(pending, accepted, declined, revoked)
+type AccountInvitationStatus string
+
+// AccountInvitationTTL is how long an account invitation stays valid
+const AccountInvitationTTL = 7 * 24 * time.Hour
+
+var accountMemberRoleRanks = map[AccountMemberRole]int{
+	AccountMemberRoleViewer: 1,
+	AccountMemberRoleEditor: 2,
+	AccountMemberRoleOwner:  3,
+}
+
+// Allows reports whether the role grants at least the required permission.
+// Owners can do everything editors can, editors everything viewers can.
+func (x AccountMemberRole) Allows(required AccountMemberRole) bool {
+	rank, ok := accountMemberRoleRanks[x]
+	if !ok {
+		return false
+	}
+
+	return rank >= accountMemberRoleRanks[required]
+}
+
+// AccountMember represents a user with access to an account they do not own
+type AccountMember struct {
+	ID        int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	AccountID int32
+	UserID    int32
+	Role      AccountMemberRole
+}
+
+// SharedAccount represents an account shared with a user and the user's role on it
+type SharedAccount struct {
+	Account *Account
+	Role    AccountMemberRole
+}
+
+// AccountInvitation represents an invitation to join an account, addressed by email
+type AccountInvitation struct {
+	ID          int32
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	AccountID   int32
+	InviterID   int32
+	Email       string
+	Role        AccountMemberRole
+	Status      AccountInvitationStatus
+	ExpiresAt   time.Time
+	RespondedAt *time.Time
+}
+
+// CreateAccountInvitationRequest defines the request to invite a user to an account
+type CreateAccountInvitationRequest struct {
+	AccountID int32
+	InviterID int32
+	Email     string
+	Role      AccountMemberRole
+	ExpiresAt time.Time
+}
+
+// AccountMemberRepository represents an account membership and invitation repository interface
+type AccountMemberRepository interface {
+	GetAccountMember(accountID, userID int32) (*AccountMember, error)
+	GetAccountMembersByAccountID(int32) ([]*AccountMember, error)
+	GetSharedAccountsByUserID(int32) ([]*SharedAccount, error)
+	UpdateAccountMemberRole(accountID, userID int32, role AccountMemberRole) error
+	DeleteAccountMember(accountID, userID int32) error
+
+	CreateAccountInvitation(CreateAccountInvitationRequest) (*AccountInvitation, error)
+	GetAccountInvitationByID(int32) (*AccountInvitation, error)
+	GetAccountInvitationsByAccountID(int32) ([]*AccountInvitation, error)
+	GetPendingAccountInvitationsByUserID(int32) ([]*AccountInvitation, error)
+	UpdateAccountInvitationStatus(id int32, status AccountInvitationStatus) error
+	AcceptAccountInvitation(invitationID, userID int32) error
+}
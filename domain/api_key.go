@@ -0,0 +1,58 @@
+//go:generate go-enum
+
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// APIKeyScope represents what a personal API key is allowed to do. Write scopes cover accounts
+// and their bank accounts, ledgers, recurring transactions and reminders, and payees and rules.
+// ENUM(read, accounts_write=accounts:write, ledgers_write=ledgers:write, recurring_write=recurring:write, payees_write=payees:write)
+type APIKeyScope string
+
+// APIKey represents a long-lived personal access token for scripts and integrations.
+// The key itself is only shown once; it is stored hashed, along with a prefix to
+// tell keys apart.
+type APIKey struct {
+	ID         int32
+	CreatedAt  time.Time
+	UserID     int32
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []APIKeyScope
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// IsActive reports whether the key can still be used at the given time.
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// HasScope reports whether the key was granted the scope
+func (k *APIKey) HasScope(scope APIKeyScope) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+// CreateAPIKeyRequest defines the request to create an API key
+type CreateAPIKeyRequest struct {
+	UserID    int32
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []APIKeyScope
+	ExpiresAt *time.Time
+}
+
+// APIKeyRepository represents an API key repository interface
+type APIKeyRepository interface {
+	CreateAPIKey(CreateAPIKeyRequest) (*APIKey, error)
+	GetAPIKeyByHash(string) (*APIKey, error)
+	GetAPIKeysByUserID(int32) ([]*APIKey, error)
+	TouchAPIKey(id int32, usedAt time.Time) error
+	RevokeAPIKey(id, userID int32) error
+}
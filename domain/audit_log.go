@@ -0,0 +1,48 @@
+//go:generate go-enum
+
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditAction represents what a mutation did to an entity
+// ENUM(create, update, void, adjust, deactivate, delete)
+type AuditAction string
+
+// AuditEntityType represents the kind of entity an audit log refers to
+// ENUM(account, ledger, recurring_transaction, user_books_lock, account_books_lock)
+type AuditEntityType string
+
+// AuditActor identifies who performed a mutation and where it came from.
+// The zero value stands for the system itself, e.g. the recurring transaction scheduler.
+type AuditActor struct {
+	UserID    int32
+	IPAddress string
+
+	// OverrideBooksLock is set when an admin writes into a period closed by a books lock
+	OverrideBooksLock bool
+}
+
+// AuditLog records a single mutation of a financial entity. Audit logs are append-only.
+type AuditLog struct {
+	ID          int32
+	CreatedAt   time.Time
+	ActorUserID *int32
+	Action      AuditAction
+	EntityType  AuditEntityType
+	EntityID    int32
+	Before      json.RawMessage
+	After       json.RawMessage
+	IPAddress   string
+
+	// BooksLockOverride is set when the mutation was written into a closed period by an admin
+	BooksLockOverride bool
+}
+
+// AuditLogRepository represents an audit log repository interface.
+// Audit logs are written by the mutating repository methods in the same transaction.
+type AuditLogRepository interface {
+	GetAuditLogs(entityType AuditEntityType, entityID int32) ([]*AuditLog, error)
+}
@@ -1,8 +1,12 @@
 package domain
 
+import "time"
+
 // GenerateTokenRequest defines the request to generate a token
 type GenerateTokenRequest struct {
-	UserID int32
+	UserID    int32
+	Role      UserRole
+	SessionID int32
 }
 
 // ValidateTokenRequest defines the request to validate a token
@@ -12,8 +16,18 @@ type ValidateTokenRequest struct {
 
 // TokenValidationResponse defines the response for token validation
 type TokenValidationResponse struct {
-	Valid  bool
-	UserID int32
+	Valid     bool
+	UserID    int32
+	Role      UserRole
+	SessionID int32
+}
+
+// AuthTokens represents the tokens issued when a session starts or is refreshed
+type AuthTokens struct {
+	SessionID    int32
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time // when the access token expires
 }
 
 // Authenticator represents an authentication service
@@ -22,4 +36,5 @@ type Authenticator interface {
 	GenerateToken(GenerateTokenRequest) (string, error)
 	ValidateToken(ValidateTokenRequest) (TokenValidationResponse, error)
 	VerifyCredential(credential string, identity *Identity) (bool, error)
+	NeedsRehash(hash string) bool
 }
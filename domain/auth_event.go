@@ -0,0 +1,37 @@
+//go:generate go-enum
+
+package domain
+
+import "time"
+
+// AuthEventType represents what happened in an authentication event
+// ENUM(login_succeeded, login_failed, login_locked)
+type AuthEventType string
+
+// AuthEvent records a login of a user, or an attempt at one
+type AuthEvent struct {
+	ID         int32
+	CreatedAt  time.Time
+	UserID     *int32
+	Type       AuthEventType
+	Provider   IdentityProvider
+	Identifier string
+	IPAddress  string
+	UserAgent  string
+}
+
+// CreateAuthEventRequest defines the request to record an authentication event
+type CreateAuthEventRequest struct {
+	UserID     *int32
+	Type       AuthEventType
+	Provider   IdentityProvider
+	Identifier string
+	IPAddress  string
+	UserAgent  string
+}
+
+// AuthEventRepository represents an authentication event repository interface
+type AuthEventRepository interface {
+	CreateAuthEvent(CreateAuthEventRequest) error
+	GetAuthEventsByUserID(userID, limit int32) ([]*AuthEvent, error)
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// BooksLock closes the books of a user, or of a single account, through a date.
+// Ledgers dated on or before that date can no longer be written.
+type BooksLock struct {
+	ID            int32
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	UserID        *int32
+	AccountID     *int32
+	ClosedThrough time.Time
+}
+
+// Covers reports whether a write dated at date falls into the closed period.
+// Dates are compared by their UTC calendar day.
+func (l *BooksLock) Covers(date time.Time) bool {
+	return date.UTC().Before(l.ClosedThrough.AddDate(0, 0, 1))
+}
+
+// BooksLockRepository represents a books lock repository interface.
+// Setting a nil date reopens the books. Lock changes are recorded in the audit trail.
+type BooksLockRepository interface {
+	GetUserBooksLock(userID int32) (*BooksLock, error)
+	GetAccountBooksLock(accountID int32) (*BooksLock, error)
+	SetUserBooksLock(userID int32, closedThrough *time.Time, actor AuditActor) error
+	SetAccountBooksLock(accountID int32, closedThrough *time.Time, actor AuditActor) error
+}
@@ -0,0 +1,66 @@
+//go:generate go-enum
+
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CronJobTrigger represents what started a run of a cron job
+// ENUM(schedule, manual)
+type CronJobTrigger string
+
+// CronJobRunStatus represents the status of a run of a cron job
+// ENUM(running, succeeded, failed)
+type CronJobRunStatus string
+
+// CronJob represents a job crond runs on a schedule
+type CronJob struct {
+	ID                 int32
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	Name               string
+	Schedule           string // Cron expression
+	Timeout            time.Duration
+	Enabled            bool
+	TriggerRequestedAt *time.Time // Set when a manual run is waiting for crond
+}
+
+// CronJobRun represents a run of a cron job
+type CronJobRun struct {
+	ID             int32
+	JobName        string
+	Trigger        CronJobTrigger
+	Status         CronJobRunStatus
+	StartedAt      time.Time
+	FinishedAt     *time.Time
+	ItemsProcessed int
+	Error          string
+}
+
+// FinishCronJobRunRequest defines the request to record the outcome of a run of a cron job
+type FinishCronJobRunRequest struct {
+	ID             int32
+	Status         CronJobRunStatus
+	FinishedAt     time.Time
+	ItemsProcessed int
+	Error          string
+}
+
+// CronJobRepository represents a cron job repository
+type CronJobRepository interface {
+	// RegisterCronJob creates a cron job or updates its configuration
+	RegisterCronJob(ctx context.Context, job CronJob) (*CronJob, error)
+	GetCronJobs(ctx context.Context) ([]*CronJob, error)
+	GetCronJobByName(ctx context.Context, name string) (*CronJob, error)
+	// RequestCronJobTrigger asks crond to run a job, returning ErrNotFound for an unknown job
+	RequestCronJobTrigger(ctx context.Context, name string) (*CronJob, error)
+	// ClaimCronJobTrigger clears the trigger request of a job and reports whether there was
+	// one, so that a single crond replica runs it
+	ClaimCronJobTrigger(ctx context.Context, name string) (bool, error)
+	CreateCronJobRun(ctx context.Context, jobName string, trigger CronJobTrigger, startedAt time.Time) (*CronJobRun, error)
+	FinishCronJobRun(ctx context.Context, req FinishCronJobRunRequest) error
+	// GetCronJobRuns gets the latest runs of a job, the latest first
+	GetCronJobRuns(ctx context.Context, jobName string, limit int) ([]*CronJobRun, error)
+}
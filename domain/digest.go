@@ -0,0 +1,49 @@
+//go:generate go-enum
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DigestFrequency represents how often a digest email is sent
+// ENUM(weekly, monthly)
+type DigestFrequency string
+
+// DigestSettings represents the report emails a user opted in to
+type DigestSettings struct {
+	UserID        int32
+	UpdatedAt     time.Time
+	Enabled       bool
+	Frequency     DigestFrequency
+	Weekday       time.Weekday // Day weekly digests are sent
+	DayOfMonth    int          // Day monthly digests are sent, 1-28
+	LastPeriodEnd *time.Time   // End of the latest period a digest was sent for
+}
+
+// CategorySpending represents the expenses of a category in a currency over a period
+type CategorySpending struct {
+	Category    string // Empty for uncategorized expenses
+	Currency    string
+	Amount      decimal.Decimal
+	LedgerCount int
+}
+
+// DigestRepository represents a digest settings and reporting repository
+type DigestRepository interface {
+	GetDigestSettings(ctx context.Context, userID int32) (*DigestSettings, error)
+	UpdateDigestSettings(ctx context.Context, settings DigestSettings) (*DigestSettings, error)
+	GetEnabledDigestSettings(ctx context.Context) ([]*DigestSettings, error)
+	// ClaimDigestPeriod records that the digest of a user for the period ending at periodEnd is
+	// being sent. It returns ErrNotFound when it already was, so that concurrent runs send it once.
+	ClaimDigestPeriod(ctx context.Context, userID int32, periodEnd time.Time) error
+	// ReleaseDigestPeriod restores the previous period end of a claimed digest that could not be
+	// sent, so that a later run retries it
+	ReleaseDigestPeriod(ctx context.Context, userID int32, periodEnd time.Time, previous *time.Time) error
+	// GetSpendingByCategory sums the posted expenses of the accounts of a user dated from since,
+	// inclusive, to until, exclusive
+	GetSpendingByCategory(ctx context.Context, userID int32, since, until time.Time) ([]*CategorySpending, error)
+}
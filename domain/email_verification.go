@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// EmailVerificationTokenTTL is how long an email verification link stays valid
+const EmailVerificationTokenTTL = 24 * time.Hour
+
+// EmailVerificationToken represents a single-use token proving that a user controls
+// the email address of an identity. Only the hash of the token is stored.
+type EmailVerificationToken struct {
+	ID         int32
+	CreatedAt  time.Time
+	UserID     int32
+	Identifier string // the email address being verified
+	TokenHash  string
+	ExpiresAt  time.Time
+	UsedAt     *time.Time
+}
+
+// CreateEmailVerificationTokenRequest defines the request to create an email verification token
+type CreateEmailVerificationTokenRequest struct {
+	UserID     int32
+	Identifier string
+	TokenHash  string
+	ExpiresAt  time.Time
+}
+
+// EmailVerificationTokenRepository represents an email verification token repository interface
+type EmailVerificationTokenRepository interface {
+	CreateEmailVerificationToken(CreateEmailVerificationTokenRequest) (*EmailVerificationToken, error)
+	// UseEmailVerificationToken marks an unused and unexpired token as used and returns it
+	UseEmailVerificationToken(tokenHash string) (*EmailVerificationToken, error)
+}
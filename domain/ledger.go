@@ -8,7 +8,7 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-// EditableDuration is the duration after which a ledger is editable
+// EditableDuration is how long ledgers stay editable under the default edit policy
 const EditableDuration = time.Minute * 15
 
 // LedgerType represents a ledger type
@@ -30,6 +30,49 @@ type Ledger struct {
 	AdjustedFrom *int32
 	IsVoided     bool
 	VoidedAt     *time.Time
+	IsPending    bool // Drafted and waiting for confirmation, not counted towards the balance
+	PayeeID      *int32
+	Category     string
+	Tags         []string
+
+	// RecurringTransactionID is set when the ledger was booked for a recurring transaction
+	RecurringTransactionID *int32
+}
+
+// Classification returns the payee, category and tags of the ledger
+func (l *Ledger) Classification() LedgerClassification {
+	return LedgerClassification{
+		PayeeID:  l.PayeeID,
+		Category: l.Category,
+		Tags:     l.Tags,
+	}
+}
+
+// LedgerClassification holds the payee, category and tags of a ledger
+type LedgerClassification struct {
+	PayeeID  *int32
+	Category string
+	Tags     []string
+}
+
+// Equal reports whether two classifications are the same
+func (c LedgerClassification) Equal(other LedgerClassification) bool {
+	if (c.PayeeID == nil) != (other.PayeeID == nil) {
+		return false
+	}
+	if c.PayeeID != nil && *c.PayeeID != *other.PayeeID {
+		return false
+	}
+	if c.Category != other.Category || len(c.Tags) != len(other.Tags) {
+		return false
+	}
+	for i := range c.Tags {
+		if c.Tags[i] != other.Tags[i] {
+			return false
+		}
+	}
+
+	return true
 }
 
 // CreateLedgerRequest defines the request to create a ledger
@@ -39,15 +82,26 @@ type CreateLedgerRequest struct {
 	Type      LedgerType
 	Amount    decimal.Decimal
 	Note      string
+	PayeeID   *int32
+	Category  string
+	Tags      []string
+	IsPending bool
+	Actor     AuditActor
+
+	RecurringTransactionID *int32
 }
 
 // UpdateLedgerRequest defines the request to update a ledger
 type UpdateLedgerRequest struct {
-	ID     int32
-	Date   *time.Time
-	Type   *LedgerType
-	Amount *decimal.Decimal
-	Note   *string
+	ID       int32
+	Date     *time.Time
+	Type     *LedgerType
+	Amount   *decimal.Decimal
+	Note     *string
+	PayeeID  *int32
+	Category *string
+	Tags     *[]string
+	Actor    AuditActor
 }
 
 // LedgerRepository represents a ledger repository
@@ -55,8 +109,16 @@ type LedgerRepository interface {
 	CreateLedger(CreateLedgerRequest) (int32, error)
 	GetLedgerByID(int32) (*Ledger, error)
 	GetLedgersByAccountID(int32) ([]*Ledger, error)
+	GetLedgersByUserID(int32) ([]*Ledger, error)
+	GetLedgersByRecurringTransactionID(int32) ([]*Ledger, error)
 	UpdateLedger(UpdateLedgerRequest) error
-	VoidLedger(id int32) error
+	VoidLedger(id int32, actor AuditActor) error
 	AdjustLedger(originalID int32, adjustment CreateLedgerRequest) error
-	DeleteLedger(id int32) error
+	DeleteLedger(id int32, actor AuditActor) error
+	// ClassifyLedgers sets the classification after each match, in a single transaction
+	ClassifyLedgers(matches []PayeeRuleMatch) error
+	// PostPendingLedger posts a pending ledger, optionally with a new amount
+	PostPendingLedger(id int32, amount *decimal.Decimal, actor AuditActor) error
+	// DiscardPendingLedger deletes a pending ledger that will not be posted
+	DiscardPendingLedger(id int32, actor AuditActor) error
 }
@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+const (
+	// LoginFreeAttempts is how many wrong credentials an identifier gets before it is locked
+	LoginFreeAttempts = 3
+	// LoginBackoffBase is how long an identifier is locked after the first failure past the free attempts,
+	// doubling with every further failure
+	LoginBackoffBase = time.Second
+	// LoginMaxLockout caps how long an identifier is locked
+	LoginMaxLockout = 15 * time.Minute
+	// LoginAttemptWindow is how long failures count towards the lockout
+	LoginAttemptWindow = 24 * time.Hour
+)
+
+// LoginLockout returns how long an identifier is locked after the given number of consecutive failures
+func LoginLockout(failedCount int32) time.Duration {
+	if failedCount < LoginFreeAttempts {
+		return 0
+	}
+
+	lockout := LoginBackoffBase
+	for range failedCount - LoginFreeAttempts {
+		lockout *= 2
+		if lockout >= LoginMaxLockout {
+			return LoginMaxLockout
+		}
+	}
+
+	return lockout
+}
+
+// LoginAttempt tracks the consecutive failed logins of an identifier, whether or not
+// it belongs to a user.
+type LoginAttempt struct {
+	Provider     IdentityProvider
+	Identifier   string
+	FailedCount  int32
+	LastFailedAt time.Time
+	LockedUntil  *time.Time
+}
+
+// IsLocked reports whether logins with the identifier are refused at the given time.
+func (a *LoginAttempt) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
+
+// RecordFailedLoginRequest defines the request to count a failed login
+type RecordFailedLoginRequest struct {
+	Provider   IdentityProvider
+	Identifier string
+	FailedAt   time.Time
+	// ResetBefore restarts the count when the previous failure is older
+	ResetBefore time.Time
+}
+
+// LoginAttemptRepository represents a login attempt repository interface
+type LoginAttemptRepository interface {
+	GetLoginAttempt(provider IdentityProvider, identifier string) (*LoginAttempt, error)
+	RecordFailedLogin(RecordFailedLoginRequest) (*LoginAttempt, error)
+	LockLogin(provider IdentityProvider, identifier string, until time.Time) error
+	ClearLoginAttempts(provider IdentityProvider, identifier string) error
+}
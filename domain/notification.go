@@ -0,0 +1,104 @@
+//go:generate go-enum
+
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// NotificationChannel represents a way notifications reach a user
+// ENUM(email, webhook, chat)
+type NotificationChannel string
+
+// ReminderDeliveryStatus represents the status of sending a reminder through a channel
+// ENUM(pending, sent, failed, cancelled)
+type ReminderDeliveryStatus string
+
+// Notification represents a message delivered to a user outside of the application
+type Notification struct {
+	Recipient string // like an email address
+	Subject   string
+	Body      string
+}
+
+// Notifier represents a channel delivering notifications to users
+type Notifier interface {
+	Notify(Notification) error
+}
+
+// Email represents an email with a plain text and an HTML version of its content
+type Email struct {
+	Recipient string
+	Subject   string
+	Text      string
+	HTML      string // Optional, clients showing HTML prefer it to the text
+}
+
+// Mailer represents a way emails are sent to users
+type Mailer interface {
+	Send(Email) error
+}
+
+// NotificationConfirmationTTL is how long the link confirming an email target stays valid
+const NotificationConfirmationTTL = 24 * time.Hour
+
+// NotificationPreference represents a channel a user wants their reminders delivered through
+type NotificationPreference struct {
+	ID        int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    int32
+	Channel   NotificationChannel
+	Target    string // Email address, webhook URL or chat ID, depending on the channel
+	Enabled   bool
+	// ConfirmedAt is when the user proved control of an email target, reminders are only
+	// delivered to confirmed targets. Other channels are confirmed when set.
+	ConfirmedAt *time.Time
+	// ConfirmationTokenHash is the hash of the token sent to an unconfirmed email target
+	ConfirmationTokenHash string
+	ConfirmationExpiresAt *time.Time
+}
+
+// ReminderDelivery represents sending a reminder through a channel of its user
+type ReminderDelivery struct {
+	ID            int32
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	ReminderID    int32
+	ReminderDate  time.Time // Reminder date the delivery was queued for, a snoozed reminder is sent again
+	Channel       NotificationChannel
+	Target        string
+	Status        ReminderDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	DeliveredAt   *time.Time
+}
+
+// UpdateReminderDeliveryRequest defines the request to record the outcome of a delivery attempt
+type UpdateReminderDeliveryRequest struct {
+	ID            int32
+	Status        ReminderDeliveryStatus
+	NextAttemptAt time.Time
+	LastError     string
+	DeliveredAt   *time.Time
+}
+
+// NotificationRepository represents a notification channel and reminder delivery repository
+type NotificationRepository interface {
+	GetNotificationPreferences(ctx context.Context, userID int32) ([]*NotificationPreference, error)
+	UpsertNotificationPreference(ctx context.Context, preference NotificationPreference) (*NotificationPreference, error)
+	DeleteNotificationPreference(ctx context.Context, userID int32, channel NotificationChannel) error
+	// ConfirmNotificationPreference confirms the target whose unexpired confirmation token has the
+	// hash and returns it
+	ConfirmNotificationPreference(ctx context.Context, tokenHash string) (*NotificationPreference, error)
+	// QueueReminderDeliveries queues a delivery through every enabled and confirmed channel of the unread
+	// reminders dated between since and until, once per reminder date
+	QueueReminderDeliveries(ctx context.Context, since, until time.Time) ([]*ReminderDelivery, error)
+	// ClaimReminderDeliveries claims pending deliveries due at now, counting an attempt and
+	// holding them until leaseUntil so that concurrent runs do not send them twice
+	ClaimReminderDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*ReminderDelivery, error)
+	UpdateReminderDelivery(ctx context.Context, req UpdateReminderDeliveryRequest) error
+	GetReminderDeliveries(ctx context.Context, reminderID int32) ([]*ReminderDelivery, error)
+}
@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+// OIDCAuthRequestTTL is how long a user has to come back from the OIDC provider
+const OIDCAuthRequestTTL = 10 * time.Minute
+
+// OIDCClaims are the claims of a verified OIDC ID token
+type OIDCClaims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Identifier returns the identifier of the OIDC identity the claims belong to.
+// Subjects are only unique per issuer, so the issuer is part of it.
+func (c *OIDCClaims) Identifier() string {
+	return c.Issuer + "|" + c.Subject
+}
+
+// OIDCProvider represents an OpenID Connect provider users sign in with through
+// the authorization code flow with PKCE
+type OIDCProvider interface {
+	// AuthCodeURL returns the URL of the provider the user is sent to
+	AuthCodeURL(state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems an authorization code and returns the claims of the verified ID token,
+	// which must carry the nonce of the authorization request
+	Exchange(code, codeVerifier, nonce string) (*OIDCClaims, error)
+}
+
+// OIDCAuthRequest represents an authorization request sent to the OIDC provider. It keeps
+// what the callback needs to redeem the code, only the hash of the state is stored.
+type OIDCAuthRequest struct {
+	ID           int32
+	CreatedAt    time.Time
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	LinkUserID   *int32 // set when an authenticated user links the identity instead of logging in
+	ExpiresAt    time.Time
+	UsedAt       *time.Time
+}
+
+// CreateOIDCAuthRequestRequest defines the request to create an OIDC authorization request
+type CreateOIDCAuthRequestRequest struct {
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	LinkUserID   *int32
+	ExpiresAt    time.Time
+}
+
+// OIDCAuthRequestRepository represents an OIDC authorization request repository interface
+type OIDCAuthRequestRepository interface {
+	CreateOIDCAuthRequest(CreateOIDCAuthRequestRequest) (*OIDCAuthRequest, error)
+	// UseOIDCAuthRequest marks an unused and unexpired request as used and returns it
+	UseOIDCAuthRequest(stateHash string) (*OIDCAuthRequest, error)
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// PasswordResetTokenTTL is how long a password reset link stays valid
+const PasswordResetTokenTTL = time.Hour
+
+// PasswordResetToken represents a single-use token allowing a user to choose a new
+// password without knowing the current one. Only the hash of the token is stored.
+type PasswordResetToken struct {
+	ID         int32
+	CreatedAt  time.Time
+	UserID     int32
+	Identifier string // the password identity the token resets
+	TokenHash  string
+	ExpiresAt  time.Time
+	UsedAt     *time.Time
+}
+
+// CreatePasswordResetTokenRequest defines the request to create a password reset token
+type CreatePasswordResetTokenRequest struct {
+	UserID     int32
+	Identifier string
+	TokenHash  string
+	ExpiresAt  time.Time
+}
+
+// PasswordResetTokenRepository represents a password reset token repository interface
+type PasswordResetTokenRepository interface {
+	CreatePasswordResetToken(CreatePasswordResetTokenRequest) (*PasswordResetToken, error)
+	// UsePasswordResetToken marks an unused and unexpired token as used and returns it
+	UsePasswordResetToken(tokenHash string) (*PasswordResetToken, error)
+	InvalidatePasswordResetTokensByUserID(userID int32) error
+}
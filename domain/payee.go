@@ -0,0 +1,142 @@
+//go:generate go-enum
+
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// PayeeRuleMatchType represents how a payee rule matches a ledger note
+// ENUM(contains, regex)
+type PayeeRuleMatchType string
+
+// Payee represents a payee or merchant
+type Payee struct {
+	ID        int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    int32
+	Name      string
+}
+
+// CreatePayeeRequest defines the request to create a payee
+type CreatePayeeRequest struct {
+	UserID int32
+	Name   string
+}
+
+// UpdatePayeeRequest defines the request to update a payee
+type UpdatePayeeRequest struct {
+	ID   int32
+	Name *string
+}
+
+// PayeeRule represents a rule that classifies ledgers by their note
+type PayeeRule struct {
+	ID        int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    int32
+	PayeeID   *int32
+	MatchType PayeeRuleMatchType
+	Pattern   string
+	Category  string
+	Tags      []string
+	Priority  int32
+
+	regex *regexp.Regexp // compiled pattern of a regex rule, set by Compile
+}
+
+// Compile compiles the pattern of a regex rule, so that matching many notes compiles it once.
+// Rules are compiled when they are loaded.
+func (r *PayeeRule) Compile() error {
+	if r.MatchType != PayeeRuleMatchTypeRegex {
+		return nil
+	}
+
+	re, err := regexp.Compile(r.Pattern)
+	if err != nil {
+		return err
+	}
+
+	r.regex = re
+	return nil
+}
+
+// Match reports whether the rule matches the given ledger note.
+// Contains rules are case-insensitive; regex rules use the pattern as-is and only match once
+// compiled.
+func (r *PayeeRule) Match(note string) bool {
+	switch r.MatchType {
+	case PayeeRuleMatchTypeContains:
+		return strings.Contains(strings.ToLower(note), strings.ToLower(r.Pattern))
+	case PayeeRuleMatchTypeRegex:
+		return r.regex != nil && r.regex.MatchString(note)
+	}
+
+	return false
+}
+
+// Apply returns the classification after applying the rule on top of the given one.
+// Fields the rule does not set are kept.
+func (r *PayeeRule) Apply(classification LedgerClassification) LedgerClassification {
+	if r.PayeeID != nil {
+		classification.PayeeID = r.PayeeID
+	}
+	if r.Category != "" {
+		classification.Category = r.Category
+	}
+	if len(r.Tags) > 0 {
+		classification.Tags = r.Tags
+	}
+
+	return classification
+}
+
+// CreatePayeeRuleRequest defines the request to create a payee rule
+type CreatePayeeRuleRequest struct {
+	UserID    int32
+	PayeeID   *int32
+	MatchType PayeeRuleMatchType
+	Pattern   string
+	Category  string
+	Tags      []string
+	Priority  int32
+}
+
+// UpdatePayeeRuleRequest defines the request to update a payee rule
+type UpdatePayeeRuleRequest struct {
+	ID        int32
+	PayeeID   *int32
+	MatchType *PayeeRuleMatchType
+	Pattern   *string
+	Category  *string
+	Tags      *[]string
+	Priority  *int32
+}
+
+// PayeeRuleMatch describes a ledger whose classification is changed by a payee rule
+type PayeeRuleMatch struct {
+	LedgerID int32
+	RuleID   int32
+	Note     string
+	Before   LedgerClassification
+	After    LedgerClassification
+}
+
+// PayeeRepository represents a payee and payee rule repository interface
+type PayeeRepository interface {
+	CreatePayee(CreatePayeeRequest) (int32, error)
+	GetPayeeByID(int32) (*Payee, error)
+	GetPayeesByUserID(int32) ([]*Payee, error)
+	UpdatePayee(UpdatePayeeRequest) error
+	DeletePayee(int32) error
+
+	CreatePayeeRule(CreatePayeeRuleRequest) (int32, error)
+	GetPayeeRuleByID(int32) (*PayeeRule, error)
+	GetPayeeRulesByUserID(int32) ([]*PayeeRule, error)
+	UpdatePayeeRule(UpdatePayeeRuleRequest) error
+	DeletePayeeRule(int32) error
+}
@@ -17,27 +17,46 @@ type RecurrenceType string
 // ENUM(active, paused, completed, cancelled)
 type RecurrenceStatus string
 
+// CatchUpPolicy represents how the occurrences of a recurring transaction missed while they were
+// not processed are booked. A single due occurrence is always booked.
+// ENUM(book_all, latest_only, skip)
+type CatchUpPolicy string
+
+// PostMode represents how the occurrences of a recurring transaction are posted. Occurrences of
+// confirm recurrences are drafted as pending ledgers the user confirms or skips.
+// ENUM(auto, confirm)
+type PostMode string
+
+// RecurringExecutionStatus represents the outcome of a processed occurrence of a recurring transaction
+// ENUM(booked, pending, skipped)
+type RecurringExecutionStatus string
+
 // RecurringTransaction represents a recurring transaction configuration
 type RecurringTransaction struct {
-	ID           int32
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	UserID       int32
-	AccountID    int32
-	Name         string
-	Type         LedgerType
-	Amount       decimal.Decimal
-	Note         string
-	StartDate    time.Time
-	EndDate      *time.Time
-	RecurType    RecurrenceType
-	Status       RecurrenceStatus
-	Frequency    int        // How often the recurrence happens (e.g., every 2 weeks)
-	DayOfWeek    *int       // 0-6 (Sunday-Saturday) for weekly recurrences
-	DayOfMonth   *int       // 1-31 for monthly recurrences
-	MonthOfYear  *int       // 1-12 for yearly recurrences
-	LastExecuted *time.Time // When the transaction was last created
-	NextDue      time.Time  // When the next transaction is due
+	ID              int32
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	UserID          int32
+	AccountID       int32
+	Name            string
+	Type            LedgerType
+	Amount          decimal.Decimal
+	Note            string
+	StartDate       time.Time
+	EndDate         *time.Time
+	RecurType       RecurrenceType
+	Status          RecurrenceStatus
+	Frequency       int           // How often the recurrence happens (e.g., every 2 weeks)
+	DayOfWeek       *int          // 0-6 (Sunday-Saturday) for weekly recurrences
+	DayOfMonth      *int          // 1-31 for monthly recurrences
+	MonthOfYear     *int          // 1-12 for yearly recurrences
+	RRule           string        // RFC 5545 recurrence rule of custom recurrences
+	CatchUpPolicy   CatchUpPolicy // How occurrences missed while not processed are booked
+	PostMode        PostMode      // Whether occurrences are posted or drafted for confirmation
+	ReminderOffsets []int         // Days before a due date reminders are created, the owner's defaults when nil
+	LastExecuted    *time.Time    // When the transaction was last created
+	NextDue         time.Time     // When the next transaction is due
+	WorkspaceID     *int32        // Set when the transaction is owned by a workspace
 }
 
 // Reminder represents a reminder for a recurring transaction
@@ -49,50 +68,107 @@ type Reminder struct {
 	ReminderDate           time.Time
 	IsRead                 bool
 	ReadAt                 *time.Time
+	LedgerID               *int32 // Pending ledger the reminder asks to confirm
+}
+
+// DefaultReminderOffsets are the days before a due date reminders are created when neither the
+// recurring transaction nor its owner set them
+var DefaultReminderOffsets = []int{3}
+
+// ReminderSettings represents the reminder preferences of a user
+type ReminderSettings struct {
+	UserID         int32
+	DefaultOffsets []int // Days before a due date, for recurring transactions without their own offsets
+}
+
+// RecurringTransactionExecution records a processed occurrence of a recurring transaction
+type RecurringTransactionExecution struct {
+	ID                     int32
+	CreatedAt              time.Time
+	RecurringTransactionID int32
+	ScheduledFor           time.Time
+	Status                 RecurringExecutionStatus
+	LedgerID               *int32  // Ledger booked or drafted for the occurrence
+	Ledger                 *Ledger // Loaded along with the history of a recurring transaction
 }
 
 // CreateRecurringTransactionRequest defines the request to create a recurring transaction
 type CreateRecurringTransactionRequest struct {
-	UserID      int32
-	AccountID   int32
-	Name        string
-	Type        LedgerType
-	Amount      decimal.Decimal
-	Note        string
-	StartDate   time.Time
-	EndDate     *time.Time
-	RecurType   RecurrenceType
-	Frequency   int
-	DayOfWeek   *int
-	DayOfMonth  *int
-	MonthOfYear *int
+	UserID          int32
+	AccountID       int32
+	Name            string
+	Type            LedgerType
+	Amount          decimal.Decimal
+	Note            string
+	StartDate       time.Time
+	EndDate         *time.Time
+	RecurType       RecurrenceType
+	Frequency       int
+	DayOfWeek       *int
+	DayOfMonth      *int
+	MonthOfYear     *int
+	RRule           string
+	CatchUpPolicy   CatchUpPolicy
+	PostMode        PostMode
+	ReminderOffsets []int
+	NextDue         time.Time // First due date, computed by the service from the schedule
+	WorkspaceID     *int32
+	Actor           AuditActor
 }
 
 // UpdateRecurringTransactionRequest defines the request to update a recurring transaction
 type UpdateRecurringTransactionRequest struct {
-	ID          int32
-	Name        *string
-	Type        *LedgerType
-	Amount      *decimal.Decimal
-	Note        *string
-	EndDate     *time.Time
-	RecurType   *RecurrenceType
-	Status      *RecurrenceStatus
-	Frequency   *int
-	DayOfWeek   *int
-	DayOfMonth  *int
-	MonthOfYear *int
+	ID              int32
+	Name            *string
+	Type            *LedgerType
+	Amount          *decimal.Decimal
+	Note            *string
+	EndDate         *time.Time
+	RecurType       *RecurrenceType
+	Status          *RecurrenceStatus
+	Frequency       *int
+	DayOfWeek       *int
+	DayOfMonth      *int
+	MonthOfYear     *int
+	RRule           *string
+	CatchUpPolicy   *CatchUpPolicy
+	PostMode        *PostMode
+	ReminderOffsets []int      // Replaces the offsets when not nil, empty to fall back to the owner's defaults
+	NextDue         *time.Time // Set by the service when the schedule changes
+	Actor           AuditActor
+}
+
+// ExecuteRecurringOccurrenceRequest defines the request to process one occurrence of a recurring
+// transaction. The occurrence is recorded, its ledger created and the transaction moved past it
+// atomically, and only while the transaction is still due at ScheduledFor, so that every occurrence
+// is processed exactly once. A pending ledger is drafted along with a reminder to confirm it.
+type ExecuteRecurringOccurrenceRequest struct {
+	ID           int32
+	ScheduledFor time.Time
+	Ledger       *CreateLedgerRequest // Booked for the occurrence, nil when it is skipped
+	LastExecuted *time.Time           // Set when the occurrence was booked
+	NextDue      time.Time
+	Status       RecurrenceStatus
+	Actor        AuditActor
 }
 
 // RecurringTransactionRepository represents a recurring transaction repository
 type RecurringTransactionRepository interface {
 	CreateRecurringTransaction(ctx context.Context, req CreateRecurringTransactionRequest) (*RecurringTransaction, error)
 	GetRecurringTransactionByID(ctx context.Context, id int32) (*RecurringTransaction, error)
+	// GetRecurringTransactionIncludingDeleted also finds deleted transactions, whose audit history
+	// stays readable
+	GetRecurringTransactionIncludingDeleted(ctx context.Context, id int32) (*RecurringTransaction, error)
 	GetRecurringTransactionsByUserID(ctx context.Context, userID int32) ([]*RecurringTransaction, error)
+	GetRecurringTransactionsByWorkspaceID(ctx context.Context, workspaceID int32) ([]*RecurringTransaction, error)
+	// GetActiveRecurringTransactionsDue lists the transactions due before a time, without claiming them
 	GetActiveRecurringTransactionsDue(ctx context.Context, before time.Time) ([]*RecurringTransaction, error)
 	UpdateRecurringTransaction(ctx context.Context, req UpdateRecurringTransactionRequest) (*RecurringTransaction, error)
-	UpdateRecurringTransactionExecution(ctx context.Context, id int32, lastExecuted, nextDue time.Time) (*RecurringTransaction, error)
-	DeleteRecurringTransaction(ctx context.Context, id int32) error
+	// ExecuteRecurringOccurrence returns ErrNotFound when the occurrence was already processed or
+	// is being processed by another run
+	ExecuteRecurringOccurrence(ctx context.Context, req ExecuteRecurringOccurrenceRequest) (*RecurringTransaction, error)
+	GetRecurringTransactionExecutions(ctx context.Context, recurringTransactionID int32) ([]*RecurringTransactionExecution, error)
+	DeleteRecurringTransaction(ctx context.Context, id int32, actor AuditActor) error
 }
 
 // ReminderRepository represents a reminder repository
@@ -100,7 +176,15 @@ type ReminderRepository interface {
 	CreateReminder(ctx context.Context, recurringTransactionID int32, reminderDate time.Time) (*Reminder, error)
 	GetRemindersByRecurringTransactionID(ctx context.Context, recurringTransactionID int32) ([]*Reminder, error)
 	GetActiveRemindersByUserID(ctx context.Context, userID int32, before time.Time) ([]*Reminder, error)
+	GetUpcomingReminders(ctx context.Context, userID int32, start, end time.Time) ([]*Reminder, error)
 	GetReminderByID(ctx context.Context, id int32) (*Reminder, error)
 	MarkReminderAsRead(ctx context.Context, id int32) (*Reminder, error)
+	// SnoozeReminder returns ErrNotFound when the reminder was already read
+	SnoozeReminder(ctx context.Context, id int32, until time.Time) (*Reminder, error)
 	DeleteReminder(ctx context.Context, id int32) error
+	// DeleteUpcomingReminders deletes the unread reminders of a recurring transaction dated after a
+	// time, except the ones asking to confirm a pending ledger
+	DeleteUpcomingReminders(ctx context.Context, recurringTransactionID int32, after time.Time) error
+	GetReminderSettings(ctx context.Context, userID int32) (*ReminderSettings, error)
+	UpdateReminderSettings(ctx context.Context, settings ReminderSettings) (*ReminderSettings, error)
 }
@@ -11,6 +11,77 @@ import (
 	"fmt"
 )
 
+const (
+	// CatchUpPolicyBookAll is a CatchUpPolicy of type book_all.
+	CatchUpPolicyBookAll CatchUpPolicy = "book_all"
+	// CatchUpPolicyLatestOnly is a CatchUpPolicy of type latest_only.
+	CatchUpPolicyLatestOnly CatchUpPolicy = "latest_only"
+	// CatchUpPolicySkip is a CatchUpPolicy of type skip.
+	CatchUpPolicySkip CatchUpPolicy = "skip"
+)
+
+var ErrInvalidCatchUpPolicy = errors.New("not a valid CatchUpPolicy")
+
+// String implements the Stringer interface.
+func (x CatchUpPolicy) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x CatchUpPolicy) IsValid() bool {
+	_, err := ParseCatchUpPolicy(string(x))
+	return err == nil
+}
+
+var _CatchUpPolicyValue = map[string]CatchUpPolicy{
+	"book_all":    CatchUpPolicyBookAll,
+	"latest_only": CatchUpPolicyLatestOnly,
+	"skip":        CatchUpPolicySkip,
+}
+
+// ParseCatchUpPolicy attempts to convert a string to a CatchUpPolicy.
+func ParseCatchUpPolicy(name string) (CatchUpPolicy, error) {
+	if x, ok := _CatchUpPolicyValue[name]; ok {
+		return x, nil
+	}
+	return CatchUpPolicy(""), fmt.Errorf("%s is %w", name, ErrInvalidCatchUpPolicy)
+}
+
+const (
+	// PostModeAuto is a PostMode of type auto.
+	PostModeAuto PostMode = "auto"
+	// PostModeConfirm is a PostMode of type confirm.
+	PostModeConfirm PostMode = "confirm"
+)
+
+var ErrInvalidPostMode = errors.New("not a valid PostMode")
+
+// String implements the Stringer interface.
+func (x PostMode) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x PostMode) IsValid() bool {
+	_, err := ParsePostMode(string(x))
+	return err == nil
+}
+
+var _PostModeValue = map[string]PostMode{
+	"auto":    PostModeAuto,
+	"confirm": PostModeConfirm,
+}
+
+// ParsePostMode attempts to convert a string to a PostMode.
+func ParsePostMode(name string) (PostMode, error) {
+	if x, ok := _PostModeValue[name]; ok {
+		return x, nil
+	}
+	return PostMode(""), fmt.Errorf("%s is %w", name, ErrInvalidPostMode)
+}
+
 const (
 	// RecurrenceStatusActive is a RecurrenceStatus of type active.
 	RecurrenceStatusActive RecurrenceStatus = "active"
@@ -99,3 +170,40 @@ func ParseRecurrenceType(name string) (RecurrenceType, error) {
 	}
 	return RecurrenceType(""), fmt.Errorf("%s is %w", name, ErrInvalidRecurrenceType)
 }
+
+const (
+	// RecurringExecutionStatusBooked is a RecurringExecutionStatus of type booked.
+	RecurringExecutionStatusBooked RecurringExecutionStatus = "booked"
+	// RecurringExecutionStatusPending is a RecurringExecutionStatus of type pending.
+	RecurringExecutionStatusPending RecurringExecutionStatus = "pending"
+	// RecurringExecutionStatusSkipped is a RecurringExecutionStatus of type skipped.
+	RecurringExecutionStatusSkipped RecurringExecutionStatus = "skipped"
+)
+
+var ErrInvalidRecurringExecutionStatus = errors.New("not a valid RecurringExecutionStatus")
+
+// String implements the Stringer interface.
+func (x RecurringExecutionStatus) String() string {
+	return string(x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x RecurringExecutionStatus) IsValid() bool {
+	_, err := ParseRecurringExecutionStatus(string(x))
+	return err == nil
+}
+
+var _RecurringExecutionStatusValue = map[string]RecurringExecutionStatus{
+	"booked":  RecurringExecutionStatusBooked,
+	"pending": RecurringExecutionStatusPending,
+	"skipped": RecurringExecutionStatusSkipped,
+}
+
+// ParseRecurringExecutionStatus attempts to convert a string to a RecurringExecutionStatus.
+func ParseRecurringExecutionStatus(name string) (RecurringExecutionStatus, error) {
+	if x, ok := _RecurringExecutionStatusValue[name]; ok {
+		return x, nil
+	}
+	return RecurringExecutionStatus(""), fmt.Errorf("%s is %w", name, ErrInvalidRecurringExecutionStatus)
+}
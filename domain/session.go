@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+const (
+	// AccessTokenTTL is how long an access token is accepted after it is issued
+	AccessTokenTTL = 15 * time.Minute
+	// SessionTTL is how long a session survives without its refresh token being used
+	SessionTTL = 30 * 24 * time.Hour
+)
+
+// Session represents a signed-in device. Its refresh token is stored hashed
+// and rotated every time it is exchanged for a new access token.
+type Session struct {
+	ID                       int32
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+	UserID                   int32
+	Provider                 IdentityProvider
+	RefreshTokenHash         string
+	PreviousRefreshTokenHash string
+	UserAgent                string
+	IPAddress                string
+	LastUsedAt               time.Time
+	ExpiresAt                time.Time
+	RevokedAt                *time.Time
+}
+
+// IsActive reports whether the session can still be used at the given time.
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// CreateSessionRequest defines the request to create a session
+type CreateSessionRequest struct {
+	UserID           int32
+	Provider         IdentityProvider
+	RefreshTokenHash string
+	UserAgent        string
+	IPAddress        string
+	ExpiresAt        time.Time
+}
+
+// RotateSessionRequest defines the request to replace the refresh token of a session
+type RotateSessionRequest struct {
+	ID                      int32
+	CurrentRefreshTokenHash string
+	RefreshTokenHash        string
+	ExpiresAt               time.Time
+}
+
+// SessionRepository represents a session repository interface
+type SessionRepository interface {
+	CreateSession(CreateSessionRequest) (*Session, error)
+	GetSessionByID(int32) (*Session, error)
+	GetSessionByRefreshTokenHash(string) (*Session, error)
+	GetActiveSessionsByUserID(int32) ([]*Session, error)
+	RotateSessionRefreshToken(RotateSessionRequest) (*Session, error)
+	RevokeSession(int32) error
+	RevokeSessionsByUserID(userID, exceptSessionID int32) error
+}
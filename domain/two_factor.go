@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+const (
+	// TwoFactorChallengeTTL is how long a user has to enter the second factor after the password
+	TwoFactorChallengeTTL = 5 * time.Minute
+	// TwoFactorChallengeMaxAttempts is how many codes can be tried against a challenge before it is rejected
+	TwoFactorChallengeMaxAttempts = 5
+	// RecoveryCodeCount is how many recovery codes are issued at a time
+	RecoveryCodeCount = 10
+)
+
+// TOTPSecret represents the shared secret of a user's authenticator app. It only
+// protects logins once the user confirmed it with a first code.
+type TOTPSecret struct {
+	UserID       int32
+	CreatedAt    time.Time
+	Secret       string // base32 encoded
+	ConfirmedAt  *time.Time
+	LastUsedStep int64 // the time step of the last accepted code, codes cannot be replayed
+}
+
+// TwoFactorChallenge represents a login whose password has been verified and which
+// waits for a second factor. Only the hash of the challenge token is stored.
+type TwoFactorChallenge struct {
+	ID        int32
+	CreatedAt time.Time
+	UserID    int32
+	Provider  IdentityProvider
+	// Identifier is the one the user logged in with, wrong second factors count towards its lockout
+	Identifier string
+	TokenHash  string
+	Attempts   int32
+	ExpiresAt  time.Time
+	UsedAt     *time.Time
+}
+
+// CreateTwoFactorChallengeRequest defines the request to create a two-factor challenge
+type CreateTwoFactorChallengeRequest struct {
+	UserID     int32
+	Provider   IdentityProvider
+	Identifier string
+	TokenHash  string
+	ExpiresAt  time.Time
+}
+
+// TwoFactorRepository represents a two-factor authentication repository interface
+type TwoFactorRepository interface {
+	// UpsertTOTPSecret stores a new unconfirmed secret, replacing any previous one
+	UpsertTOTPSecret(userID int32, secret string) error
+	GetTOTPSecretByUserID(userID int32) (*TOTPSecret, error)
+	ConfirmTOTPSecret(userID int32) error
+	// UpdateTOTPLastUsedStep records an accepted time step, failing with ErrNotFound
+	// when the step is not newer than the last accepted one
+	UpdateTOTPLastUsedStep(userID int32, step int64) error
+	// DisableTwoFactor removes the TOTP secret and recovery codes of a user
+	DisableTwoFactor(userID int32) error
+
+	// ReplaceRecoveryCodes drops the recovery codes of a user and stores the given hashes instead
+	ReplaceRecoveryCodes(userID int32, codeHashes []string) error
+	// UseRecoveryCode marks an unused recovery code as used, failing with ErrNotFound otherwise
+	UseRecoveryCode(userID int32, codeHash string) error
+	CountUnusedRecoveryCodes(userID int32) (int, error)
+
+	CreateTwoFactorChallenge(CreateTwoFactorChallengeRequest) (*TwoFactorChallenge, error)
+	// GetTwoFactorChallengeByTokenHash returns an unused and unexpired challenge
+	GetTwoFactorChallengeByTokenHash(tokenHash string) (*TwoFactorChallenge, error)
+	// IncrementTwoFactorChallengeAttempts counts an attempt at a challenge, failing with
+	// ErrNotFound once maxAttempts were made
+	IncrementTwoFactorChallengeAttempts(id, maxAttempts int32) error
+	// UseTwoFactorChallenge marks a challenge as used, failing with ErrNotFound if it already was
+	UseTwoFactorChallenge(id int32) error
+}
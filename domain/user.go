@@ -1,7 +1,13 @@
+//go:generate go-enum
+
 package domain
 
 import "time"
 
+// UserRole represents the role of a user
+// ENUM(admin, user)
+type UserRole string
+
 // User represents a user
 type User struct {
 	ID         int32
@@ -10,13 +16,20 @@ type User struct {
 	Disabled   bool
 	Name       string
 	Nickname   string
+	Role       UserRole
 	Identities []Identity
 }
 
+// IsAdmin reports whether the user has the admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == UserRoleAdmin
+}
+
 // CreateUserRequest defines the request to create a user
 type CreateUserRequest struct {
 	Name     string
 	Nickname string
+	Role     UserRole
 }
 
 // UpdateUserRequest defines the request to update a user
@@ -25,6 +38,7 @@ type UpdateUserRequest struct {
 	Name     *string
 	Nickname *string
 	Disabled *bool
+	Role     *UserRole
 }
 
 // UserRepository represents a user repository interface
@@ -37,6 +51,10 @@ type UserRepository interface {
 	DeleteUser(id int32) error
 	GetUserByIdentity(provider IdentityProvider, identifier string) (*User, *Identity, error)
 	AddIdentity(userID int32, provider Identity) error
+	GetIdentitiesByUserID(userID int32) ([]*Identity, error)
+	UpdateIdentityCredential(provider IdentityProvider, identifier, credential string) error
+	UpdateIdentityLastUsed(provider IdentityProvider, identifier string) error
+	VerifyIdentity(provider IdentityProvider, identifier string) error
 }
 
 // IdentityProvider represents an identity provider
@@ -45,6 +63,8 @@ type IdentityProvider string
 const (
 	// IdentityProviderPassword is a IdentityProvider of type password.
 	IdentityProviderPassword IdentityProvider = "password"
+	// IdentityProviderOIDC is a IdentityProvider of type oidc, an external OpenID Connect provider.
+	IdentityProviderOIDC IdentityProvider = "oidc"
 )
 
 // Identity represents an identity
@@ -53,4 +73,5 @@ type Identity struct {
 	Identifier string // like email, or google id or telegram id etc
 	Credential string // like password hash or token etc
 	LastUsedAt time.Time
+	VerifiedAt *time.Time // when the owner proved control of the identifier, like an email address
 }
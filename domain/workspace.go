@@ -0,0 +1,88 @@
+//go:generate go-enum
+
+package domain
+
+import "time"
+
+// WorkspaceType represents the kind of a workspace
+// ENUM(household, business)
+type WorkspaceType string
+
+// WorkspaceRole represents the permission level of a user in a workspace
+// ENUM(owner, editor, viewer)
+type WorkspaceRole string
+
+// AccountRole returns the role a workspace member holds on the accounts owned by the workspace.
+func (x WorkspaceRole) AccountRole() AccountMemberRole {
+	switch x {
+	case WorkspaceRoleOwner:
+		return AccountMemberRoleOwner
+	case WorkspaceRoleEditor:
+		return AccountMemberRoleEditor
+	case WorkspaceRoleViewer:
+		return AccountMemberRoleViewer
+	}
+
+	return ""
+}
+
+// Allows reports whether the role grants at least the required permission.
+func (x WorkspaceRole) Allows(required WorkspaceRole) bool {
+	return x.AccountRole().Allows(required.AccountRole())
+}
+
+// Workspace represents a shared space, such as a household or a business,
+// that owns a set of accounts and recurring transactions
+type Workspace struct {
+	ID        int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Name      string
+	Type      WorkspaceType
+	DeletedAt *time.Time
+}
+
+// WorkspaceMember represents a user belonging to a workspace
+type WorkspaceMember struct {
+	ID          int32
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	WorkspaceID int32
+	UserID      int32
+	Role        WorkspaceRole
+}
+
+// MemberWorkspace represents a workspace and the role a user holds in it
+type MemberWorkspace struct {
+	Workspace *Workspace
+	Role      WorkspaceRole
+}
+
+// CreateWorkspaceRequest defines the request to create a workspace
+type CreateWorkspaceRequest struct {
+	OwnerID int32
+	Name    string
+	Type    WorkspaceType
+}
+
+// UpdateWorkspaceRequest defines the request to update a workspace
+type UpdateWorkspaceRequest struct {
+	ID   int32
+	Name *string
+	Type *WorkspaceType
+}
+
+// WorkspaceRepository represents a workspace repository interface
+type WorkspaceRepository interface {
+	CreateWorkspace(CreateWorkspaceRequest) (*Workspace, error)
+	GetWorkspaceByID(int32) (*Workspace, error)
+	GetWorkspacesByUserID(int32) ([]*MemberWorkspace, error)
+	UpdateWorkspace(UpdateWorkspaceRequest) (*Workspace, error)
+	DeleteWorkspace(int32) error
+
+	GetWorkspaceMember(workspaceID, userID int32) (*WorkspaceMember, error)
+	GetWorkspaceMembersByWorkspaceID(int32) ([]*WorkspaceMember, error)
+	AddWorkspaceMemberByEmail(workspaceID int32, email string, role WorkspaceRole) (*WorkspaceMember, error)
+	UpsertWorkspaceMember(workspaceID, userID int32, role WorkspaceRole) error
+	DeleteWorkspaceMember(workspaceID, userID int32) error
+}
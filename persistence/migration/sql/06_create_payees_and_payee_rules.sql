@@ -0,0 +1,37 @@
+-- Payees Table
+CREATE TABLE payees (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    user_id INT NOT NULL REFERENCES users(id),
+    name VARCHAR(255) NOT NULL
+);
+
+-- Payee Rules Table
+CREATE TABLE payee_rules (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    user_id INT NOT NULL REFERENCES users(id),
+    payee_id INT REFERENCES payees(id),
+    match_type VARCHAR(20) NOT NULL,
+    pattern VARCHAR(255) NOT NULL,
+    category VARCHAR(255),
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    priority INT NOT NULL DEFAULT 0
+);
+
+-- Ledger payee, category and tags
+ALTER TABLE ledgers
+    ADD COLUMN payee_id INT REFERENCES payees(id),
+    ADD COLUMN category VARCHAR(255),
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Payees and Payee Rules Indexes
+CREATE INDEX idx_payees_user_id ON payees(user_id);
+CREATE INDEX idx_payees_deleted_at ON payees(deleted_at);
+CREATE INDEX idx_payee_rules_user_id ON payee_rules(user_id);
+CREATE INDEX idx_payee_rules_deleted_at ON payee_rules(deleted_at);
+CREATE INDEX idx_ledgers_payee_id ON ledgers(payee_id);
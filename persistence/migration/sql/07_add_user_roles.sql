@@ -0,0 +1,5 @@
+-- Add role column to users
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user';
+
+-- Users Role Index
+CREATE INDEX idx_users_role ON users (role);
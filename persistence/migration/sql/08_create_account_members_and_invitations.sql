@@ -0,0 +1,29 @@
+-- Account Members Table
+CREATE TABLE account_members (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    account_id INT NOT NULL REFERENCES accounts(id),
+    user_id INT NOT NULL REFERENCES users(id),
+    role VARCHAR(20) NOT NULL,
+    UNIQUE (account_id, user_id)
+);
+
+-- Account Invitations Table
+CREATE TABLE account_invitations (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    account_id INT NOT NULL REFERENCES accounts(id),
+    inviter_id INT NOT NULL REFERENCES users(id),
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Account Members and Invitations Indexes
+CREATE INDEX idx_account_members_user_id ON account_members (user_id);
+CREATE INDEX idx_account_invitations_account_id ON account_invitations (account_id);
+CREATE INDEX idx_account_invitations_email ON account_invitations (email);
@@ -0,0 +1,30 @@
+-- Workspaces Table
+CREATE TABLE workspaces (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL
+);
+
+-- Workspace Members Table
+CREATE TABLE workspace_members (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    workspace_id INT NOT NULL REFERENCES workspaces(id),
+    user_id INT NOT NULL REFERENCES users(id),
+    role VARCHAR(20) NOT NULL,
+    UNIQUE (workspace_id, user_id)
+);
+
+-- Workspace ownership of accounts and recurring transactions
+ALTER TABLE accounts ADD COLUMN workspace_id INT REFERENCES workspaces(id);
+ALTER TABLE recurring_transactions ADD COLUMN workspace_id INT REFERENCES workspaces(id);
+
+-- Workspaces Indexes
+CREATE INDEX idx_workspaces_deleted_at ON workspaces (deleted_at);
+CREATE INDEX idx_workspace_members_user_id ON workspace_members (user_id);
+CREATE INDEX idx_accounts_workspace_id ON accounts (workspace_id);
+CREATE INDEX idx_recurring_transactions_workspace_id ON recurring_transactions (workspace_id);
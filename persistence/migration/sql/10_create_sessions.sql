@@ -0,0 +1,19 @@
+-- Sessions Table
+CREATE TABLE sessions (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    user_id INT NOT NULL REFERENCES users(id),
+    provider VARCHAR(50) NOT NULL,
+    refresh_token_hash VARCHAR(64) NOT NULL UNIQUE,
+    previous_refresh_token_hash VARCHAR(64),
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Sessions Indexes
+CREATE INDEX idx_sessions_user_id ON sessions (user_id);
+CREATE INDEX idx_sessions_previous_refresh_token_hash ON sessions (previous_refresh_token_hash);
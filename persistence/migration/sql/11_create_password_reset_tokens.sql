@@ -0,0 +1,13 @@
+-- Password Reset Tokens Table
+CREATE TABLE password_reset_tokens (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    user_id INT NOT NULL REFERENCES users(id),
+    identifier VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Password Reset Tokens Indexes
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
@@ -0,0 +1,17 @@
+-- Add verified_at column to identities, identities created before verification existed are trusted
+ALTER TABLE identities ADD COLUMN verified_at TIMESTAMP WITH TIME ZONE;
+UPDATE identities SET verified_at = last_used_at;
+
+-- Email Verification Tokens Table
+CREATE TABLE email_verification_tokens (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    user_id INT NOT NULL REFERENCES users(id),
+    identifier VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Email Verification Tokens Indexes
+CREATE INDEX idx_email_verification_tokens_user_id ON email_verification_tokens (user_id);
@@ -0,0 +1,35 @@
+-- TOTP Secrets Table, a secret only protects logins once confirmed
+CREATE TABLE totp_secrets (
+    user_id INT PRIMARY KEY REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    secret VARCHAR(64) NOT NULL,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    last_used_step BIGINT NOT NULL DEFAULT 0
+);
+
+-- Recovery Codes Table
+CREATE TABLE recovery_codes (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    user_id INT NOT NULL REFERENCES users(id),
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Recovery Codes Indexes
+CREATE INDEX idx_recovery_codes_user_id ON recovery_codes (user_id);
+
+-- Two Factor Challenges Table
+CREATE TABLE two_factor_challenges (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    user_id INT NOT NULL REFERENCES users(id),
+    provider VARCHAR(50) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Two Factor Challenges Indexes
+CREATE INDEX idx_two_factor_challenges_user_id ON two_factor_challenges (user_id);
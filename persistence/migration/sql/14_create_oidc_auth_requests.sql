@@ -0,0 +1,11 @@
+-- OIDC Auth Requests Table, one row per authorization redirect to the OIDC provider
+CREATE TABLE oidc_auth_requests (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    state_hash VARCHAR(64) NOT NULL UNIQUE,
+    nonce VARCHAR(64) NOT NULL,
+    code_verifier VARCHAR(128) NOT NULL,
+    link_user_id INT REFERENCES users(id),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE
+);
@@ -0,0 +1,24 @@
+-- Login Attempts Table, tracks consecutive failed logins of an identifier whether or not it exists
+CREATE TABLE login_attempts (
+    provider VARCHAR(50) NOT NULL,
+    identifier VARCHAR(255) NOT NULL,
+    failed_count INT NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (provider, identifier)
+);
+
+-- Auth Events Table
+CREATE TABLE auth_events (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    user_id INT REFERENCES users(id),
+    type VARCHAR(50) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    identifier VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT ''
+);
+
+-- Auth Events Indexes
+CREATE INDEX idx_auth_events_user_id ON auth_events (user_id, created_at);
@@ -0,0 +1,15 @@
+-- Audit Logs Table, append-only record of every financial mutation
+CREATE TABLE audit_logs (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    actor_user_id INT REFERENCES users(id),
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id INT NOT NULL,
+    before JSONB,
+    after JSONB,
+    ip_address VARCHAR(64) NOT NULL DEFAULT ''
+);
+
+-- Audit Logs Indexes
+CREATE INDEX idx_audit_logs_entity ON audit_logs (entity_type, entity_id, created_at);
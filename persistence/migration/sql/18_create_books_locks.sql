@@ -0,0 +1,13 @@
+-- Books Locks Table, closes the books of a user or of a single account through a date
+CREATE TABLE books_locks (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    user_id INT UNIQUE REFERENCES users(id),
+    account_id INT UNIQUE REFERENCES accounts(id),
+    closed_through DATE NOT NULL,
+    CHECK ((user_id IS NULL) <> (account_id IS NULL))
+);
+
+-- Records writes of admins into closed periods
+ALTER TABLE audit_logs ADD COLUMN books_lock_override BOOLEAN NOT NULL DEFAULT FALSE;
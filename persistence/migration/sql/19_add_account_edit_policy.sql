@@ -0,0 +1,4 @@
+-- Account edit policy, how long ledgers stay editable before they can only be corrected by adjustments
+ALTER TABLE accounts
+    ADD COLUMN edit_mode VARCHAR(20) NOT NULL DEFAULT 'window',
+    ADD COLUMN edit_window_minutes INT NOT NULL DEFAULT 15;
@@ -0,0 +1,2 @@
+-- Custom schedules of recurring transactions, as RFC 5545 recurrence rules
+ALTER TABLE recurring_transactions ADD COLUMN rrule TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- How occurrences missed while crond was down are booked
+ALTER TABLE recurring_transactions ADD COLUMN catch_up_policy VARCHAR(20) NOT NULL DEFAULT 'book_all';
@@ -0,0 +1,10 @@
+-- Recurring Transaction Executions Table, records every processed occurrence once
+CREATE TABLE recurring_transaction_executions (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    recurring_transaction_id INT NOT NULL REFERENCES recurring_transactions(id),
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    ledger_id INT REFERENCES ledgers(id),
+    UNIQUE (recurring_transaction_id, scheduled_for)
+);
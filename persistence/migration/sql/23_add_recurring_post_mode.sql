@@ -0,0 +1,8 @@
+-- Whether occurrences are posted automatically or drafted for the user to confirm
+ALTER TABLE recurring_transactions ADD COLUMN post_mode VARCHAR(20) NOT NULL DEFAULT 'auto';
+
+-- Drafted ledgers wait for confirmation and do not count towards the account balance
+ALTER TABLE ledgers ADD COLUMN is_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The drafted ledger a reminder asks to confirm
+ALTER TABLE reminders ADD COLUMN ledger_id INT REFERENCES ledgers(id);
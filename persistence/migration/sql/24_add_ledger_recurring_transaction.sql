@@ -0,0 +1,9 @@
+-- The recurring transaction a ledger was booked for
+ALTER TABLE ledgers ADD COLUMN recurring_transaction_id INT REFERENCES recurring_transactions(id);
+
+UPDATE ledgers
+SET recurring_transaction_id = e.recurring_transaction_id
+FROM recurring_transaction_executions e
+WHERE e.ledger_id = ledgers.id;
+
+CREATE INDEX idx_ledgers_recurring_transaction_id ON ledgers (recurring_transaction_id);
@@ -0,0 +1,12 @@
+-- Days before a due date the reminders of a recurring transaction are created, the owner's
+-- defaults when NULL
+ALTER TABLE recurring_transactions ADD COLUMN reminder_offsets INT[];
+
+-- Reminder Settings Table, the default reminder offsets of a user
+CREATE TABLE reminder_settings (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    user_id INT NOT NULL UNIQUE REFERENCES users(id),
+    default_offsets INT[] NOT NULL
+);
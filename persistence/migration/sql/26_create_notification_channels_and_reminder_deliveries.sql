@@ -0,0 +1,30 @@
+-- Notification Channels Table, where a user wants reminders delivered
+CREATE TABLE notification_channels (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    user_id INT NOT NULL REFERENCES users(id),
+    channel VARCHAR(20) NOT NULL,
+    target TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    UNIQUE (user_id, channel)
+);
+
+-- Reminder Deliveries Table, sends a reminder through a channel once per reminder date
+CREATE TABLE reminder_deliveries (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reminder_id INT NOT NULL REFERENCES reminders(id),
+    reminder_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    target TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_error TEXT NOT NULL DEFAULT '',
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (reminder_id, channel, reminder_date)
+);
+
+CREATE INDEX idx_reminder_deliveries_next_attempt_at ON reminder_deliveries (next_attempt_at) WHERE status = 'pending';
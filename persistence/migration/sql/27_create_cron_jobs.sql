@@ -0,0 +1,26 @@
+-- Cron Jobs Table, the jobs registered by crond and their configuration
+CREATE TABLE cron_jobs (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    schedule VARCHAR(100) NOT NULL,
+    timeout_seconds INT NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    trigger_requested_at TIMESTAMP WITH TIME ZONE -- Set when an admin asks for a run
+);
+
+-- Cron Job Runs Table, the history of the runs of the jobs
+CREATE TABLE cron_job_runs (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    job_name VARCHAR(100) NOT NULL REFERENCES cron_jobs(name),
+    trigger VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    items_processed INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_cron_job_runs_job_name_started_at ON cron_job_runs (job_name, started_at DESC);
@@ -0,0 +1,14 @@
+-- Digest Settings Table, the weekly or monthly report emails users opted in to
+CREATE TABLE digest_settings (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    user_id INT NOT NULL UNIQUE REFERENCES users(id),
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    frequency VARCHAR(20) NOT NULL DEFAULT 'weekly',
+    weekday SMALLINT NOT NULL DEFAULT 1, -- Day weekly digests are sent, 0 is Sunday
+    day_of_month SMALLINT NOT NULL DEFAULT 1, -- Day monthly digests are sent
+    last_period_end TIMESTAMP WITH TIME ZONE -- End of the latest period a digest was sent for
+);
+
+CREATE INDEX idx_digest_settings_enabled ON digest_settings (user_id) WHERE enabled;
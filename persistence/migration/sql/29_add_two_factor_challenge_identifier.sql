@@ -0,0 +1,3 @@
+-- Identifier the password or OIDC step of a challenge logged in with, so that wrong second
+-- factors count towards its lockout
+ALTER TABLE two_factor_challenges ADD COLUMN identifier VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+-- Email targets only receive reminders once their owner opens the confirmation link sent to them,
+-- other channels are confirmed when set
+ALTER TABLE notification_channels ADD COLUMN confirmed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE notification_channels ADD COLUMN confirmation_token_hash VARCHAR(64) UNIQUE;
+ALTER TABLE notification_channels ADD COLUMN confirmation_expires_at TIMESTAMP WITH TIME ZONE;
+UPDATE notification_channels SET confirmed_at = updated_at WHERE channel <> 'email';
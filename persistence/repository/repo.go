@@ -21,9 +21,25 @@ func NewSQLCRepository(db *pgxpool.Pool) *SQLCRepository {
 
 // Ensure SQLCRepository implements all required interfaces
 var (
-	_ domain.AccountRepository              = (*SQLCRepository)(nil)
-	_ domain.LedgerRepository               = (*SQLCRepository)(nil)
-	_ domain.UserRepository                 = (*SQLCRepository)(nil)
-	_ domain.RecurringTransactionRepository = (*SQLCRepository)(nil)
-	_ domain.ReminderRepository             = (*SQLCRepository)(nil)
+	_ domain.AccountRepository                = (*SQLCRepository)(nil)
+	_ domain.LedgerRepository                 = (*SQLCRepository)(nil)
+	_ domain.UserRepository                   = (*SQLCRepository)(nil)
+	_ domain.RecurringTransactionRepository   = (*SQLCRepository)(nil)
+	_ domain.ReminderRepository               = (*SQLCRepository)(nil)
+	_ domain.PayeeRepository                  = (*SQLCRepository)(nil)
+	_ domain.AccountMemberRepository          = (*SQLCRepository)(nil)
+	_ domain.WorkspaceRepository              = (*SQLCRepository)(nil)
+	_ domain.SessionRepository                = (*SQLCRepository)(nil)
+	_ domain.PasswordResetTokenRepository     = (*SQLCRepository)(nil)
+	_ domain.EmailVerificationTokenRepository = (*SQLCRepository)(nil)
+	_ domain.TwoFactorRepository              = (*SQLCRepository)(nil)
+	_ domain.OIDCAuthRequestRepository        = (*SQLCRepository)(nil)
+	_ domain.APIKeyRepository                 = (*SQLCRepository)(nil)
+	_ domain.LoginAttemptRepository           = (*SQLCRepository)(nil)
+	_ domain.AuthEventRepository              = (*SQLCRepository)(nil)
+	_ domain.AuditLogRepository               = (*SQLCRepository)(nil)
+	_ domain.BooksLockRepository              = (*SQLCRepository)(nil)
+	_ domain.NotificationRepository           = (*SQLCRepository)(nil)
+	_ domain.CronJobRepository                = (*SQLCRepository)(nil)
+	_ domain.DigestRepository                 = (*SQLCRepository)(nil)
 )
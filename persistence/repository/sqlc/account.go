@@ -15,18 +15,28 @@ import (
 // CreateAccount implements the domain.AccountRepository interface
 func (r *Repository) CreateAccount(req domain.CreateAccountRequest) error {
 	params := sqlcgen.CreateAccountParams{
-		UserID:   req.UserID,
-		Name:     req.Name,
-		Currency: req.Currency,
-		Status:   domain.AccountStatusActive.String(),
-		Balance:  decimal.Zero,
+		UserID:      req.UserID,
+		Name:        req.Name,
+		Currency:    req.Currency,
+		Status:      domain.AccountStatusActive.String(),
+		Balance:     decimal.Zero,
+		WorkspaceID: int4FromPtr(req.WorkspaceID),
 	}
 
-	_, err := r.querier.CreateAccount(r.ctx, params)
-	if err != nil {
-		return fmt.Errorf("failed to create account: %w", err)
-	}
-	return nil
+	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
+		account, err := repo.querier.CreateAccount(repo.ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to create account: %w", err)
+		}
+
+		return repo.createAuditLog(repo.ctx, auditEntry{
+			Actor:      req.Actor,
+			Action:     domain.AuditActionCreate,
+			EntityType: domain.AuditEntityTypeAccount,
+			EntityID:   account.ID,
+			After:      newAccountAuditState(mapToAccount(account)),
+		})
+	})
 }
 
 // GetAccountByID implements the domain.AccountRepository interface
@@ -53,15 +63,17 @@ func (r *Repository) GetAccountByID(id int32) (*domain.Account, error) {
 	}
 
 	return &domain.Account{
-		ID:        account.ID,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
-		DeletedAt: deletedAt,
-		UserID:    account.UserID,
-		Name:      account.Name,
-		Status:    domain.AccountStatus(account.Status),
-		Currency:  account.Currency,
-		Balance:   account.Balance,
+		ID:          account.ID,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		DeletedAt:   deletedAt,
+		UserID:      account.UserID,
+		Name:        account.Name,
+		Status:      domain.AccountStatus(account.Status),
+		Currency:    account.Currency,
+		Balance:     account.Balance,
+		WorkspaceID: int4ToPtr(account.WorkspaceID),
+		EditPolicy:  mapToEditPolicy(account.EditMode, account.EditWindowMinutes),
 	}, nil
 }
 
@@ -99,37 +111,90 @@ func (r *Repository) UpdateAccount(req domain.UpdateAccountRequest) error {
 		}
 	}
 
-	_, err := r.querier.UpdateAccount(r.ctx, params)
-	if err != nil {
-		return fmt.Errorf("failed to update account: %w", err)
+	if req.EditPolicy != nil {
+		params.EditMode = pgtype.Text{
+			String: req.EditPolicy.Mode.String(),
+			Valid:  true,
+		}
+		params.EditWindowMinutes = pgtype.Int4{
+			Int32: int32(req.EditPolicy.Window / time.Minute),
+			Valid: true,
+		}
 	}
 
-	return nil
+	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
+		before, err := repo.GetAccountByID(req.ID)
+		if err != nil {
+			return err
+		}
+
+		account, err := repo.querier.UpdateAccount(repo.ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to update account: %w", err)
+		}
+
+		return repo.createAuditLog(repo.ctx, auditEntry{
+			Actor:      req.Actor,
+			Action:     domain.AuditActionUpdate,
+			EntityType: domain.AuditEntityTypeAccount,
+			EntityID:   req.ID,
+			Before:     newAccountAuditState(before),
+			After:      newAccountAuditState(mapToAccount(account)),
+		})
+	})
 }
 
 // DeactivateAccountByID implements the domain.AccountRepository interface
 // This method now performs a soft delete by setting the deleted_at timestamp.
-func (r *Repository) DeactivateAccountByID(id int32) error {
+func (r *Repository) DeactivateAccountByID(id int32, actor domain.AuditActor) error {
 	params := sqlcgen.DeactivateAccountByIDParams{
 		Status: domain.AccountStatusClosed.String(),
 		ID:     id,
 	}
 
-	_, err := r.querier.DeactivateAccountByID(r.ctx, params)
-	if err != nil {
-		return fmt.Errorf("failed to deactivate account: %w", err)
-	}
-	return nil
+	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
+		before, err := repo.GetAccountByID(id)
+		if err != nil {
+			return err
+		}
+
+		account, err := repo.querier.DeactivateAccountByID(repo.ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to deactivate account: %w", err)
+		}
+
+		return repo.createAuditLog(repo.ctx, auditEntry{
+			Actor:      actor,
+			Action:     domain.AuditActionDeactivate,
+			EntityType: domain.AuditEntityTypeAccount,
+			EntityID:   id,
+			Before:     newAccountAuditState(before),
+			After:      newAccountAuditState(mapToAccount(account)),
+		})
+	})
 }
 
 // DeleteAccount implements the domain.AccountRepository interface
 // This method performs a soft delete by setting the deleted_at timestamp.
-func (r *Repository) DeleteAccount(id int32) error {
-	_, err := r.querier.DeleteAccount(r.ctx, id)
-	if err != nil {
-		return fmt.Errorf("failed to soft delete account: %w", err)
-	}
-	return nil
+func (r *Repository) DeleteAccount(id int32, actor domain.AuditActor) error {
+	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
+		before, err := repo.GetAccountByID(id)
+		if err != nil {
+			return err
+		}
+
+		if _, err := repo.querier.DeleteAccount(repo.ctx, id); err != nil {
+			return fmt.Errorf("failed to soft delete account: %w", err)
+		}
+
+		return repo.createAuditLog(repo.ctx, auditEntry{
+			Actor:      actor,
+			Action:     domain.AuditActionDelete,
+			EntityType: domain.AuditEntityTypeAccount,
+			EntityID:   id,
+			Before:     newAccountAuditState(before),
+		})
+	})
 }
 
 // GetAllAccounts implements the domain.AccountRepository interface
@@ -155,15 +220,17 @@ func (r *Repository) GetAllAccounts() ([]*domain.Account, error) {
 		}
 
 		domainAccounts[i] = &domain.Account{
-			ID:        account.ID,
-			CreatedAt: createdAt,
-			UpdatedAt: updatedAt,
-			UserID:    account.UserID,
-			Name:      account.Name,
-			Status:    domain.AccountStatus(account.Status),
-			Currency:  account.Currency,
-			Balance:   account.Balance,
-			DeletedAt: deletedAt,
+			ID:          account.ID,
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+			UserID:      account.UserID,
+			Name:        account.Name,
+			Status:      domain.AccountStatus(account.Status),
+			Currency:    account.Currency,
+			Balance:     account.Balance,
+			DeletedAt:   deletedAt,
+			WorkspaceID: int4ToPtr(account.WorkspaceID),
+			EditPolicy:  mapToEditPolicy(account.EditMode, account.EditWindowMinutes),
 		}
 	}
 
@@ -188,16 +255,74 @@ func (r *Repository) GetAccountsByUserID(userID int32) ([]*domain.Account, error
 		}
 
 		domainAccounts[i] = &domain.Account{
-			ID:        account.ID,
-			CreatedAt: createdAt,
-			UpdatedAt: updatedAt,
-			UserID:    account.UserID,
-			Name:      account.Name,
-			Status:    domain.AccountStatus(account.Status),
-			Currency:  account.Currency,
-			Balance:   account.Balance,
+			ID:          account.ID,
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+			UserID:      account.UserID,
+			Name:        account.Name,
+			Status:      domain.AccountStatus(account.Status),
+			Currency:    account.Currency,
+			Balance:     account.Balance,
+			WorkspaceID: int4ToPtr(account.WorkspaceID),
+			EditPolicy:  mapToEditPolicy(account.EditMode, account.EditWindowMinutes),
 		}
 	}
 
 	return domainAccounts, nil
 }
+
+// GetAccountsByWorkspaceID implements the domain.AccountRepository interface
+func (r *Repository) GetAccountsByWorkspaceID(workspaceID int32) ([]*domain.Account, error) {
+	accounts, err := r.querier.GetAccountsByWorkspaceID(r.ctx, pgtype.Int4{Int32: workspaceID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts by workspace: %w", err)
+	}
+
+	domainAccounts := make([]*domain.Account, len(accounts))
+	for i, account := range accounts {
+		domainAccounts[i] = &domain.Account{
+			ID:          account.ID,
+			CreatedAt:   account.CreatedAt.Time,
+			UpdatedAt:   account.UpdatedAt.Time,
+			UserID:      account.UserID,
+			Name:        account.Name,
+			Status:      domain.AccountStatus(account.Status),
+			Currency:    account.Currency,
+			Balance:     account.Balance,
+			WorkspaceID: int4ToPtr(account.WorkspaceID),
+			EditPolicy:  mapToEditPolicy(account.EditMode, account.EditWindowMinutes),
+		}
+	}
+
+	return domainAccounts, nil
+}
+
+// mapToAccount converts an account row to a domain account.
+func mapToAccount(account sqlcgen.Account) *domain.Account {
+	var deletedAt *time.Time
+	if account.DeletedAt.Valid {
+		deletedAt = &account.DeletedAt.Time
+	}
+
+	return &domain.Account{
+		ID:          account.ID,
+		CreatedAt:   account.CreatedAt.Time,
+		UpdatedAt:   account.UpdatedAt.Time,
+		DeletedAt:   deletedAt,
+		UserID:      account.UserID,
+		Name:        account.Name,
+		Status:      domain.AccountStatus(account.Status),
+		Currency:    account.Currency,
+		Balance:     account.Balance,
+		WorkspaceID: int4ToPtr(account.WorkspaceID),
+		EditPolicy:  mapToEditPolicy(account.EditMode, account.EditWindowMinutes),
+	}
+}
+
+// mapToEditPolicy converts the edit policy columns of an account row to a domain edit policy.
+func mapToEditPolicy(mode string, windowMinutes int32) domain.EditPolicy {
+	return domain.EditPolicy{
+		Mode:   domain.LedgerEditMode(mode),
+		Window: time.Duration(windowMinutes) * time.Minute,
+	}
+}
@@ -0,0 +1,237 @@
+package sqlc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// GetAccountMember implements the domain.AccountMemberRepository interface
+func (r *Repository) GetAccountMember(accountID, userID int32) (*domain.AccountMember, error) {
+	member, err := r.querier.GetAccountMember(r.ctx, sqlcgen.GetAccountMemberParams{
+		AccountID: accountID,
+		UserID:    userID,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get account member: %w", err)
+	}
+
+	return mapToAccountMember(member), nil
+}
+
+// GetAccountMembersByAccountID implements the domain.AccountMemberRepository interface
+func (r *Repository) GetAccountMembersByAccountID(accountID int32) ([]*domain.AccountMember, error) {
+	members, err := r.querier.GetAccountMembersByAccountID(r.ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account members: %w", err)
+	}
+
+	domainMembers := make([]*domain.AccountMember, len(members))
+	for i, member := range members {
+		domainMembers[i] = mapToAccountMember(member)
+	}
+
+	return domainMembers, nil
+}
+
+// GetSharedAccountsByUserID implements the domain.AccountMemberRepository interface
+func (r *Repository) GetSharedAccountsByUserID(userID int32) ([]*domain.SharedAccount, error) {
+	rows, err := r.querier.GetSharedAccountsByUserID(r.ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared accounts: %w", err)
+	}
+
+	sharedAccounts := make([]*domain.SharedAccount, len(rows))
+	for i, row := range rows {
+		sharedAccounts[i] = &domain.SharedAccount{
+			Account: &domain.Account{
+				ID:          row.ID,
+				CreatedAt:   row.CreatedAt.Time,
+				UpdatedAt:   row.UpdatedAt.Time,
+				UserID:      row.UserID,
+				Name:        row.Name,
+				Status:      domain.AccountStatus(row.Status),
+				Currency:    row.Currency,
+				Balance:     row.Balance,
+				WorkspaceID: int4ToPtr(row.WorkspaceID),
+				EditPolicy:  mapToEditPolicy(row.EditMode, row.EditWindowMinutes),
+			},
+			Role: domain.AccountMemberRole(row.MemberRole),
+		}
+	}
+
+	return sharedAccounts, nil
+}
+
+// UpdateAccountMemberRole implements the domain.AccountMemberRepository interface
+func (r *Repository) UpdateAccountMemberRole(accountID, userID int32, role domain.AccountMemberRole) error {
+	_, err := r.querier.UpdateAccountMemberRole(r.ctx, sqlcgen.UpdateAccountMemberRoleParams{
+		Role:      role.String(),
+		AccountID: accountID,
+		UserID:    userID,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to update account member role: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAccountMember implements the domain.AccountMemberRepository interface
+func (r *Repository) DeleteAccountMember(accountID, userID int32) error {
+	affected, err := r.querier.DeleteAccountMember(r.ctx, sqlcgen.DeleteAccountMemberParams{
+		AccountID: accountID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete account member: %w", err)
+	}
+
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateAccountInvitation implements the domain.AccountMemberRepository interface
+func (r *Repository) CreateAccountInvitation(req domain.CreateAccountInvitationRequest) (*domain.AccountInvitation, error) {
+	invitation, err := r.querier.CreateAccountInvitation(r.ctx, sqlcgen.CreateAccountInvitationParams{
+		AccountID: req.AccountID,
+		InviterID: req.InviterID,
+		Email:     req.Email,
+		Role:      req.Role.String(),
+		ExpiresAt: pgtype.Timestamptz{Time: req.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account invitation: %w", err)
+	}
+
+	return mapToAccountInvitation(invitation), nil
+}
+
+// GetAccountInvitationByID implements the domain.AccountMemberRepository interface
+func (r *Repository) GetAccountInvitationByID(id int32) (*domain.AccountInvitation, error) {
+	invitation, err := r.querier.GetAccountInvitationByID(r.ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get account invitation: %w", err)
+	}
+
+	return mapToAccountInvitation(invitation), nil
+}
+
+// GetAccountInvitationsByAccountID implements the domain.AccountMemberRepository interface
+func (r *Repository) GetAccountInvitationsByAccountID(accountID int32) ([]*domain.AccountInvitation, error) {
+	invitations, err := r.querier.GetAccountInvitationsByAccountID(r.ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account invitations: %w", err)
+	}
+
+	domainInvitations := make([]*domain.AccountInvitation, len(invitations))
+	for i, invitation := range invitations {
+		domainInvitations[i] = mapToAccountInvitation(invitation)
+	}
+
+	return domainInvitations, nil
+}
+
+// GetPendingAccountInvitationsByUserID implements the domain.AccountMemberRepository interface
+func (r *Repository) GetPendingAccountInvitationsByUserID(userID int32) ([]*domain.AccountInvitation, error) {
+	invitations, err := r.querier.GetPendingAccountInvitationsByUserID(r.ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending account invitations: %w", err)
+	}
+
+	domainInvitations := make([]*domain.AccountInvitation, len(invitations))
+	for i, invitation := range invitations {
+		domainInvitations[i] = mapToAccountInvitation(invitation)
+	}
+
+	return domainInvitations, nil
+}
+
+// UpdateAccountInvitationStatus implements the domain.AccountMemberRepository interface
+func (r *Repository) UpdateAccountInvitationStatus(id int32, status domain.AccountInvitationStatus) error {
+	_, err := r.querier.UpdateAccountInvitationStatus(r.ctx, sqlcgen.UpdateAccountInvitationStatusParams{
+		Status: status.String(),
+		ID:     id,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to update account invitation status: %w", err)
+	}
+
+	return nil
+}
+
+// AcceptAccountInvitation implements the domain.AccountMemberRepository interface.
+// The invitation is marked accepted and the user becomes a member in a single transaction.
+func (r *Repository) AcceptAccountInvitation(invitationID, userID int32) error {
+	return r.ExecuteTx(context.Background(), func(repo *Repository) error {
+		invitation, err := repo.querier.UpdateAccountInvitationStatus(repo.ctx, sqlcgen.UpdateAccountInvitationStatusParams{
+			Status: domain.AccountInvitationStatusAccepted.String(),
+			ID:     invitationID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to accept account invitation: %w", err)
+		}
+
+		if _, err := repo.querier.UpsertAccountMember(repo.ctx, sqlcgen.UpsertAccountMemberParams{
+			AccountID: invitation.AccountID,
+			UserID:    userID,
+			Role:      invitation.Role,
+		}); err != nil {
+			return fmt.Errorf("failed to add account member: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func mapToAccountMember(member sqlcgen.AccountMember) *domain.AccountMember {
+	return &domain.AccountMember{
+		ID:        member.ID,
+		CreatedAt: member.CreatedAt.Time,
+		UpdatedAt: member.UpdatedAt.Time,
+		AccountID: member.AccountID,
+		UserID:    member.UserID,
+		Role:      domain.AccountMemberRole(member.Role),
+	}
+}
+
+func mapToAccountInvitation(invitation sqlcgen.AccountInvitation) *domain.AccountInvitation {
+	var respondedAt *time.Time
+	if invitation.RespondedAt.Valid {
+		respondedAt = &invitation.RespondedAt.Time
+	}
+
+	return &domain.AccountInvitation{
+		ID:          invitation.ID,
+		CreatedAt:   invitation.CreatedAt.Time,
+		UpdatedAt:   invitation.UpdatedAt.Time,
+		AccountID:   invitation.AccountID,
+		InviterID:   invitation.InviterID,
+		Email:       invitation.Email,
+		Role:        domain.AccountMemberRole(invitation.Role),
+		Status:      domain.AccountInvitationStatus(invitation.Status),
+		ExpiresAt:   invitation.ExpiresAt.Time,
+		RespondedAt: respondedAt,
+	}
+}
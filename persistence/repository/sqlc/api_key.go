@@ -0,0 +1,133 @@
+package sqlc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// CreateAPIKey implements the domain.APIKeyRepository interface
+func (r *Repository) CreateAPIKey(req domain.CreateAPIKeyRequest) (*domain.APIKey, error) {
+	var expiresAt pgtype.Timestamptz
+	if req.ExpiresAt != nil {
+		expiresAt.Time = *req.ExpiresAt
+		expiresAt.Valid = true
+	}
+
+	scopes := make([]string, len(req.Scopes))
+	for i, scope := range req.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	apiKey, err := r.querier.CreateAPIKey(r.ctx, sqlcgen.CreateAPIKeyParams{
+		UserID:    req.UserID,
+		Name:      req.Name,
+		Prefix:    req.Prefix,
+		KeyHash:   req.KeyHash,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return mapToAPIKey(apiKey), nil
+}
+
+// GetAPIKeyByHash implements the domain.APIKeyRepository interface
+func (r *Repository) GetAPIKeyByHash(hash string) (*domain.APIKey, error) {
+	apiKey, err := r.querier.GetAPIKeyByHash(r.ctx, hash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return mapToAPIKey(apiKey), nil
+}
+
+// GetAPIKeysByUserID implements the domain.APIKeyRepository interface.
+// Revoked keys are left out.
+func (r *Repository) GetAPIKeysByUserID(userID int32) ([]*domain.APIKey, error) {
+	apiKeys, err := r.querier.GetAPIKeysByUserID(r.ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	domainAPIKeys := make([]*domain.APIKey, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		domainAPIKeys[i] = mapToAPIKey(apiKey)
+	}
+
+	return domainAPIKeys, nil
+}
+
+// TouchAPIKey implements the domain.APIKeyRepository interface
+func (r *Repository) TouchAPIKey(id int32, usedAt time.Time) error {
+	if err := r.querier.TouchAPIKey(r.ctx, sqlcgen.TouchAPIKeyParams{
+		LastUsedAt: pgtype.Timestamptz{Time: usedAt, Valid: true},
+		ID:         id,
+	}); err != nil {
+		return fmt.Errorf("failed to touch api key: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAPIKey implements the domain.APIKeyRepository interface
+func (r *Repository) RevokeAPIKey(id, userID int32) error {
+	affected, err := r.querier.RevokeAPIKey(r.ctx, sqlcgen.RevokeAPIKeyParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func mapToAPIKey(apiKey sqlcgen.ApiKey) *domain.APIKey {
+	var expiresAt *time.Time
+	if apiKey.ExpiresAt.Valid {
+		expiresAt = &apiKey.ExpiresAt.Time
+	}
+
+	var lastUsedAt *time.Time
+	if apiKey.LastUsedAt.Valid {
+		lastUsedAt = &apiKey.LastUsedAt.Time
+	}
+
+	var revokedAt *time.Time
+	if apiKey.RevokedAt.Valid {
+		revokedAt = &apiKey.RevokedAt.Time
+	}
+
+	scopes := make([]domain.APIKeyScope, len(apiKey.Scopes))
+	for i, scope := range apiKey.Scopes {
+		scopes[i] = domain.APIKeyScope(scope)
+	}
+
+	return &domain.APIKey{
+		ID:         apiKey.ID,
+		CreatedAt:  apiKey.CreatedAt.Time,
+		UserID:     apiKey.UserID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		KeyHash:    apiKey.KeyHash,
+		Scopes:     scopes,
+		ExpiresAt:  expiresAt,
+		LastUsedAt: lastUsedAt,
+		RevokedAt:  revokedAt,
+	}
+}
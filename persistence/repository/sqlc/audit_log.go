@@ -0,0 +1,207 @@
+package sqlc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// GetAuditLogs implements the domain.AuditLogRepository interface.
+// The most recent logs come first.
+func (r *Repository) GetAuditLogs(entityType domain.AuditEntityType, entityID int32) ([]*domain.AuditLog, error) {
+	logs, err := r.querier.GetAuditLogsByEntity(r.ctx, sqlcgen.GetAuditLogsByEntityParams{
+		EntityType: entityType.String(),
+		EntityID:   entityID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit logs: %w", err)
+	}
+
+	domainLogs := make([]*domain.AuditLog, len(logs))
+	for i, log := range logs {
+		domainLogs[i] = &domain.AuditLog{
+			ID:                log.ID,
+			CreatedAt:         log.CreatedAt.Time,
+			ActorUserID:       int4ToPtr(log.ActorUserID),
+			Action:            domain.AuditAction(log.Action),
+			EntityType:        domain.AuditEntityType(log.EntityType),
+			EntityID:          log.EntityID,
+			Before:            log.Before,
+			After:             log.After,
+			IPAddress:         log.IpAddress,
+			BooksLockOverride: log.BooksLockOverride,
+		}
+	}
+
+	return domainLogs, nil
+}
+
+// auditEntry describes a mutation to be recorded in the audit log.
+// Before and After are left nil when the entity did not exist before or after the mutation.
+type auditEntry struct {
+	Actor      domain.AuditActor
+	Action     domain.AuditAction
+	EntityType domain.AuditEntityType
+	EntityID   int32
+	Before     any
+	After      any
+}
+
+// createAuditLog records a mutation. It must be called on the repository of the
+// transaction performing the mutation, so that both commit or roll back together.
+func (r *Repository) createAuditLog(ctx context.Context, entry auditEntry) error {
+	var actorUserID pgtype.Int4
+	if entry.Actor.UserID != 0 {
+		actorUserID = pgtype.Int4{Int32: entry.Actor.UserID, Valid: true}
+	}
+
+	before, err := marshalAuditState(entry.Before)
+	if err != nil {
+		return err
+	}
+
+	after, err := marshalAuditState(entry.After)
+	if err != nil {
+		return err
+	}
+
+	if err := r.querier.CreateAuditLog(ctx, sqlcgen.CreateAuditLogParams{
+		ActorUserID:       actorUserID,
+		Action:            entry.Action.String(),
+		EntityType:        entry.EntityType.String(),
+		EntityID:          entry.EntityID,
+		Before:            before,
+		After:             after,
+		IpAddress:         entry.Actor.IPAddress,
+		BooksLockOverride: entry.Actor.OverrideBooksLock,
+	}); err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+
+	return nil
+}
+
+func marshalAuditState(state any) ([]byte, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit state: %w", err)
+	}
+
+	return b, nil
+}
+
+// ledgerAuditState is the audited representation of a ledger.
+type ledgerAuditState struct {
+	AccountID    int32           `json:"account_id"`
+	Date         time.Time       `json:"date"`
+	Type         string          `json:"type"`
+	Amount       decimal.Decimal `json:"amount"`
+	Note         string          `json:"note"`
+	PayeeID      *int32          `json:"payee_id"`
+	Category     string          `json:"category"`
+	Tags         []string        `json:"tags"`
+	IsAdjustment bool            `json:"is_adjustment"`
+	AdjustedFrom *int32          `json:"adjusted_from"`
+	IsVoided     bool            `json:"is_voided"`
+	IsPending    bool            `json:"is_pending"`
+}
+
+func newLedgerAuditState(ledger *domain.Ledger) ledgerAuditState {
+	return ledgerAuditState{
+		AccountID:    ledger.AccountID,
+		Date:         ledger.Date,
+		Type:         ledger.Type.String(),
+		Amount:       ledger.Amount,
+		Note:         ledger.Note,
+		PayeeID:      ledger.PayeeID,
+		Category:     ledger.Category,
+		Tags:         ledger.Tags,
+		IsAdjustment: ledger.IsAdjustment,
+		AdjustedFrom: ledger.AdjustedFrom,
+		IsVoided:     ledger.IsVoided,
+		IsPending:    ledger.IsPending,
+	}
+}
+
+// accountAuditState is the audited representation of an account.
+type accountAuditState struct {
+	UserID            int32           `json:"user_id"`
+	Name              string          `json:"name"`
+	Status            string          `json:"status"`
+	Currency          string          `json:"currency"`
+	Balance           decimal.Decimal `json:"balance"`
+	WorkspaceID       *int32          `json:"workspace_id"`
+	EditMode          string          `json:"edit_mode"`
+	EditWindowMinutes int             `json:"edit_window_minutes"`
+}
+
+func newAccountAuditState(account *domain.Account) accountAuditState {
+	return accountAuditState{
+		UserID:            account.UserID,
+		Name:              account.Name,
+		Status:            account.Status.String(),
+		Currency:          account.Currency,
+		Balance:           account.Balance,
+		WorkspaceID:       account.WorkspaceID,
+		EditMode:          account.EditPolicy.Mode.String(),
+		EditWindowMinutes: int(account.EditPolicy.Window / time.Minute),
+	}
+}
+
+// recurringTransactionAuditState is the audited representation of a recurring transaction.
+type recurringTransactionAuditState struct {
+	AccountID       int32           `json:"account_id"`
+	Name            string          `json:"name"`
+	Type            string          `json:"type"`
+	Amount          decimal.Decimal `json:"amount"`
+	Note            string          `json:"note"`
+	StartDate       time.Time       `json:"start_date"`
+	EndDate         *time.Time      `json:"end_date"`
+	RecurType       string          `json:"recur_type"`
+	Status          string          `json:"status"`
+	Frequency       int             `json:"frequency"`
+	DayOfWeek       *int            `json:"day_of_week"`
+	DayOfMonth      *int            `json:"day_of_month"`
+	MonthOfYear     *int            `json:"month_of_year"`
+	RRule           string          `json:"rrule"`
+	CatchUpPolicy   string          `json:"catch_up_policy"`
+	PostMode        string          `json:"post_mode"`
+	ReminderOffsets []int           `json:"reminder_offsets"`
+	NextDue         time.Time       `json:"next_due"`
+	WorkspaceID     *int32          `json:"workspace_id"`
+}
+
+func newRecurringTransactionAuditState(rt *domain.RecurringTransaction) recurringTransactionAuditState {
+	return recurringTransactionAuditState{
+		AccountID:       rt.AccountID,
+		Name:            rt.Name,
+		Type:            rt.Type.String(),
+		Amount:          rt.Amount,
+		Note:            rt.Note,
+		StartDate:       rt.StartDate,
+		EndDate:         rt.EndDate,
+		RecurType:       rt.RecurType.String(),
+		Status:          rt.Status.String(),
+		Frequency:       rt.Frequency,
+		DayOfWeek:       rt.DayOfWeek,
+		DayOfMonth:      rt.DayOfMonth,
+		MonthOfYear:     rt.MonthOfYear,
+		RRule:           rt.RRule,
+		CatchUpPolicy:   rt.CatchUpPolicy.String(),
+		PostMode:        rt.PostMode.String(),
+		ReminderOffsets: rt.ReminderOffsets,
+		NextDue:         rt.NextDue,
+		WorkspaceID:     rt.WorkspaceID,
+	}
+}
@@ -0,0 +1,54 @@
+package sqlc
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// CreateAuthEvent implements the domain.AuthEventRepository interface
+func (r *Repository) CreateAuthEvent(req domain.CreateAuthEventRequest) error {
+	if err := r.querier.CreateAuthEvent(r.ctx, sqlcgen.CreateAuthEventParams{
+		UserID:     int4FromPtr(req.UserID),
+		Type:       req.Type.String(),
+		Provider:   string(req.Provider),
+		Identifier: req.Identifier,
+		IpAddress:  req.IPAddress,
+		UserAgent:  req.UserAgent,
+	}); err != nil {
+		return fmt.Errorf("failed to create auth event: %w", err)
+	}
+
+	return nil
+}
+
+// GetAuthEventsByUserID implements the domain.AuthEventRepository interface.
+// The most recent events come first.
+func (r *Repository) GetAuthEventsByUserID(userID, limit int32) ([]*domain.AuthEvent, error) {
+	events, err := r.querier.GetAuthEventsByUserID(r.ctx, sqlcgen.GetAuthEventsByUserIDParams{
+		UserID: pgtype.Int4{Int32: userID, Valid: true},
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth events: %w", err)
+	}
+
+	domainEvents := make([]*domain.AuthEvent, len(events))
+	for i, event := range events {
+		domainEvents[i] = &domain.AuthEvent{
+			ID:         event.ID,
+			CreatedAt:  event.CreatedAt.Time,
+			UserID:     int4ToPtr(event.UserID),
+			Type:       domain.AuthEventType(event.Type),
+			Provider:   domain.IdentityProvider(event.Provider),
+			Identifier: event.Identifier,
+			IPAddress:  event.IpAddress,
+			UserAgent:  event.UserAgent,
+		}
+	}
+
+	return domainEvents, nil
+}
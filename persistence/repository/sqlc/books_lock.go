@@ -0,0 +1,134 @@
+package sqlc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// GetUserBooksLock implements the domain.BooksLockRepository interface
+func (r *Repository) GetUserBooksLock(userID int32) (*domain.BooksLock, error) {
+	lock, err := r.querier.GetBooksLockByUserID(r.ctx, pgtype.Int4{Int32: userID, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user books lock: %w", err)
+	}
+
+	return mapToBooksLock(lock), nil
+}
+
+// GetAccountBooksLock implements the domain.BooksLockRepository interface
+func (r *Repository) GetAccountBooksLock(accountID int32) (*domain.BooksLock, error) {
+	lock, err := r.querier.GetBooksLockByAccountID(r.ctx, pgtype.Int4{Int32: accountID, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get account books lock: %w", err)
+	}
+
+	return mapToBooksLock(lock), nil
+}
+
+// SetUserBooksLock implements the domain.BooksLockRepository interface
+func (r *Repository) SetUserBooksLock(userID int32, closedThrough *time.Time, actor domain.AuditActor) error {
+	id := pgtype.Int4{Int32: userID, Valid: true}
+
+	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
+		before, err := repo.GetUserBooksLock(userID)
+		if err != nil && err != domain.ErrNotFound {
+			return err
+		}
+
+		if closedThrough == nil {
+			if err := repo.querier.DeleteUserBooksLock(repo.ctx, id); err != nil {
+				return fmt.Errorf("failed to delete user books lock: %w", err)
+			}
+		} else if err := repo.querier.UpsertUserBooksLock(repo.ctx, sqlcgen.UpsertUserBooksLockParams{
+			UserID:        id,
+			ClosedThrough: pgtype.Date{Time: *closedThrough, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to set user books lock: %w", err)
+		}
+
+		return repo.createBooksLockAuditLog(actor, domain.AuditEntityTypeUserBooksLock, userID, before, closedThrough)
+	})
+}
+
+// SetAccountBooksLock implements the domain.BooksLockRepository interface
+func (r *Repository) SetAccountBooksLock(accountID int32, closedThrough *time.Time, actor domain.AuditActor) error {
+	id := pgtype.Int4{Int32: accountID, Valid: true}
+
+	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
+		before, err := repo.GetAccountBooksLock(accountID)
+		if err != nil && err != domain.ErrNotFound {
+			return err
+		}
+
+		if closedThrough == nil {
+			if err := repo.querier.DeleteAccountBooksLock(repo.ctx, id); err != nil {
+				return fmt.Errorf("failed to delete account books lock: %w", err)
+			}
+		} else if err := repo.querier.UpsertAccountBooksLock(repo.ctx, sqlcgen.UpsertAccountBooksLockParams{
+			AccountID:     id,
+			ClosedThrough: pgtype.Date{Time: *closedThrough, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to set account books lock: %w", err)
+		}
+
+		return repo.createBooksLockAuditLog(actor, domain.AuditEntityTypeAccountBooksLock, accountID, before, closedThrough)
+	})
+}
+
+// createBooksLockAuditLog records closing, moving or reopening the books of a user or an account.
+// Locks are audited against the user or account they close the books of.
+func (r *Repository) createBooksLockAuditLog(actor domain.AuditActor, entityType domain.AuditEntityType, entityID int32, before *domain.BooksLock, closedThrough *time.Time) error {
+	entry := auditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionUpdate,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+
+	switch {
+	case before == nil && closedThrough == nil:
+		// The books were already open
+		return nil
+	case before == nil:
+		entry.Action = domain.AuditActionCreate
+	case closedThrough == nil:
+		entry.Action = domain.AuditActionDelete
+	}
+
+	if before != nil {
+		entry.Before = booksLockAuditState{ClosedThrough: before.ClosedThrough.Format(time.DateOnly)}
+	}
+	if closedThrough != nil {
+		entry.After = booksLockAuditState{ClosedThrough: closedThrough.Format(time.DateOnly)}
+	}
+
+	return r.createAuditLog(r.ctx, entry)
+}
+
+// booksLockAuditState is the audited representation of a books lock.
+type booksLockAuditState struct {
+	ClosedThrough string `json:"closed_through"`
+}
+
+func mapToBooksLock(lock sqlcgen.BooksLock) *domain.BooksLock {
+	return &domain.BooksLock{
+		ID:            lock.ID,
+		CreatedAt:     lock.CreatedAt.Time,
+		UpdatedAt:     lock.UpdatedAt.Time,
+		UserID:        int4ToPtr(lock.UserID),
+		AccountID:     int4ToPtr(lock.AccountID),
+		ClosedThrough: lock.ClosedThrough.Time,
+	}
+}
@@ -0,0 +1,46 @@
+package sqlc
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// int4FromPtr converts an optional int32 to a nullable pgtype.Int4.
+func int4FromPtr(v *int32) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *v, Valid: true}
+}
+
+// int4ToPtr converts a nullable pgtype.Int4 to an optional int32.
+func int4ToPtr(v pgtype.Int4) *int32 {
+	if !v.Valid {
+		return nil
+	}
+	i := v.Int32
+	return &i
+}
+
+// int32sFromInts converts ints to an int array, keeping a nil slice NULL.
+func int32sFromInts(v []int) []int32 {
+	if v == nil {
+		return nil
+	}
+	out := make([]int32, len(v))
+	for i, n := range v {
+		out[i] = int32(n)
+	}
+	return out
+}
+
+// int32sToInts converts an int array to ints, keeping NULL a nil slice.
+func int32sToInts(v []int32) []int {
+	if v == nil {
+		return nil
+	}
+	out := make([]int, len(v))
+	for i, n := range v {
+		out[i] = int(n)
+	}
+	return out
+}
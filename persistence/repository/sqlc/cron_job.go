@@ -0,0 +1,164 @@
+package sqlc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// RegisterCronJob creates a cron job or updates its configuration
+func (r *Repository) RegisterCronJob(ctx context.Context, job domain.CronJob) (*domain.CronJob, error) {
+	result, err := r.querier.UpsertCronJob(ctx, sqlcgen.UpsertCronJobParams{
+		Name:           job.Name,
+		Schedule:       job.Schedule,
+		TimeoutSeconds: int32(job.Timeout / time.Second),
+		Enabled:        job.Enabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register cron job: %w", err)
+	}
+
+	return mapToCronJob(result), nil
+}
+
+// GetCronJobs gets all cron jobs ordered by name
+func (r *Repository) GetCronJobs(ctx context.Context) ([]*domain.CronJob, error) {
+	results, err := r.querier.GetCronJobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cron jobs: %w", err)
+	}
+
+	jobs := make([]*domain.CronJob, len(results))
+	for i, result := range results {
+		jobs[i] = mapToCronJob(result)
+	}
+
+	return jobs, nil
+}
+
+// GetCronJobByName gets a cron job by its name
+func (r *Repository) GetCronJobByName(ctx context.Context, name string) (*domain.CronJob, error) {
+	result, err := r.querier.GetCronJobByName(ctx, name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get cron job: %w", err)
+	}
+
+	return mapToCronJob(result), nil
+}
+
+// RequestCronJobTrigger asks crond to run a job
+func (r *Repository) RequestCronJobTrigger(ctx context.Context, name string) (*domain.CronJob, error) {
+	result, err := r.querier.RequestCronJobTrigger(ctx, name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to trigger cron job: %w", err)
+	}
+
+	return mapToCronJob(result), nil
+}
+
+// ClaimCronJobTrigger clears the trigger request of a job and reports whether there was one
+func (r *Repository) ClaimCronJobTrigger(ctx context.Context, name string) (bool, error) {
+	if _, err := r.querier.ClaimCronJobTrigger(ctx, name); err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim cron job trigger: %w", err)
+	}
+
+	return true, nil
+}
+
+// CreateCronJobRun records the start of a run of a cron job
+func (r *Repository) CreateCronJobRun(ctx context.Context, jobName string, trigger domain.CronJobTrigger, startedAt time.Time) (*domain.CronJobRun, error) {
+	result, err := r.querier.CreateCronJobRun(ctx, sqlcgen.CreateCronJobRunParams{
+		JobName:   jobName,
+		Trigger:   trigger.String(),
+		StartedAt: pgtype.Timestamptz{Time: startedAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cron job run: %w", err)
+	}
+
+	return mapToCronJobRun(result), nil
+}
+
+// FinishCronJobRun records the outcome of a run of a cron job
+func (r *Repository) FinishCronJobRun(ctx context.Context, req domain.FinishCronJobRunRequest) error {
+	if err := r.querier.FinishCronJobRun(ctx, sqlcgen.FinishCronJobRunParams{
+		ID:             req.ID,
+		Status:         req.Status.String(),
+		FinishedAt:     pgtype.Timestamptz{Time: req.FinishedAt, Valid: true},
+		ItemsProcessed: int32(req.ItemsProcessed),
+		Error:          req.Error,
+	}); err != nil {
+		return fmt.Errorf("failed to finish cron job run: %w", err)
+	}
+
+	return nil
+}
+
+// GetCronJobRuns gets the latest runs of a cron job
+func (r *Repository) GetCronJobRuns(ctx context.Context, jobName string, limit int) ([]*domain.CronJobRun, error) {
+	results, err := r.querier.GetCronJobRunsByJobName(ctx, sqlcgen.GetCronJobRunsByJobNameParams{
+		JobName: jobName,
+		Limit:   int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cron job runs: %w", err)
+	}
+
+	runs := make([]*domain.CronJobRun, len(results))
+	for i, result := range results {
+		runs[i] = mapToCronJobRun(result)
+	}
+
+	return runs, nil
+}
+
+func mapToCronJob(j sqlcgen.CronJob) *domain.CronJob {
+	var triggerRequestedAt *time.Time
+	if j.TriggerRequestedAt.Valid {
+		triggerRequestedAt = &j.TriggerRequestedAt.Time
+	}
+
+	return &domain.CronJob{
+		ID:                 j.ID,
+		CreatedAt:          j.CreatedAt.Time,
+		UpdatedAt:          j.UpdatedAt.Time,
+		Name:               j.Name,
+		Schedule:           j.Schedule,
+		Timeout:            time.Duration(j.TimeoutSeconds) * time.Second,
+		Enabled:            j.Enabled,
+		TriggerRequestedAt: triggerRequestedAt,
+	}
+}
+
+func mapToCronJobRun(r sqlcgen.CronJobRun) *domain.CronJobRun {
+	var finishedAt *time.Time
+	if r.FinishedAt.Valid {
+		finishedAt = &r.FinishedAt.Time
+	}
+
+	return &domain.CronJobRun{
+		ID:             r.ID,
+		JobName:        r.JobName,
+		Trigger:        domain.CronJobTrigger(r.Trigger),
+		Status:         domain.CronJobRunStatus(r.Status),
+		StartedAt:      r.StartedAt.Time,
+		FinishedAt:     finishedAt,
+		ItemsProcessed: int(r.ItemsProcessed),
+		Error:          r.Error,
+	}
+}
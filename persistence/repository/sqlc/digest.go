@@ -0,0 +1,131 @@
+package sqlc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// GetDigestSettings gets the digest preferences of a user
+func (r *Repository) GetDigestSettings(ctx context.Context, userID int32) (*domain.DigestSettings, error) {
+	result, err := r.querier.GetDigestSettingsByUserID(ctx, userID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get digest settings: %w", err)
+	}
+
+	return mapToDigestSettings(result), nil
+}
+
+// UpdateDigestSettings sets the digest preferences of a user
+func (r *Repository) UpdateDigestSettings(ctx context.Context, settings domain.DigestSettings) (*domain.DigestSettings, error) {
+	result, err := r.querier.UpsertDigestSettings(ctx, sqlcgen.UpsertDigestSettingsParams{
+		UserID:     settings.UserID,
+		Enabled:    settings.Enabled,
+		Frequency:  settings.Frequency.String(),
+		Weekday:    int16(settings.Weekday),
+		DayOfMonth: int16(settings.DayOfMonth),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update digest settings: %w", err)
+	}
+
+	return mapToDigestSettings(result), nil
+}
+
+// GetEnabledDigestSettings gets the digest preferences of the users who opted in
+func (r *Repository) GetEnabledDigestSettings(ctx context.Context) ([]*domain.DigestSettings, error) {
+	results, err := r.querier.GetEnabledDigestSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enabled digest settings: %w", err)
+	}
+
+	settings := make([]*domain.DigestSettings, len(results))
+	for i, result := range results {
+		settings[i] = mapToDigestSettings(result)
+	}
+
+	return settings, nil
+}
+
+// ClaimDigestPeriod records that the digest of a period is being sent
+func (r *Repository) ClaimDigestPeriod(ctx context.Context, userID int32, periodEnd time.Time) error {
+	if _, err := r.querier.ClaimDigestPeriod(ctx, sqlcgen.ClaimDigestPeriodParams{
+		PeriodEnd: pgtype.Timestamptz{Time: periodEnd, Valid: true},
+		UserID:    userID,
+	}); err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to claim digest period: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseDigestPeriod restores the previous period end of a claimed digest
+func (r *Repository) ReleaseDigestPeriod(ctx context.Context, userID int32, periodEnd time.Time, previous *time.Time) error {
+	var previousPeriodEnd pgtype.Timestamptz
+	if previous != nil {
+		previousPeriodEnd = pgtype.Timestamptz{Time: *previous, Valid: true}
+	}
+
+	if err := r.querier.ReleaseDigestPeriod(ctx, sqlcgen.ReleaseDigestPeriodParams{
+		PreviousPeriodEnd: previousPeriodEnd,
+		UserID:            userID,
+		PeriodEnd:         pgtype.Timestamptz{Time: periodEnd, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to release digest period: %w", err)
+	}
+
+	return nil
+}
+
+// GetSpendingByCategory sums the posted expenses of the accounts of a user by category and currency
+func (r *Repository) GetSpendingByCategory(ctx context.Context, userID int32, since, until time.Time) ([]*domain.CategorySpending, error) {
+	results, err := r.querier.GetSpendingByCategory(ctx, sqlcgen.GetSpendingByCategoryParams{
+		UserID: userID,
+		Since:  pgtype.Timestamptz{Time: since, Valid: true},
+		Until:  pgtype.Timestamptz{Time: until, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spending by category: %w", err)
+	}
+
+	spending := make([]*domain.CategorySpending, len(results))
+	for i, result := range results {
+		spending[i] = &domain.CategorySpending{
+			Category:    result.Category,
+			Currency:    result.Currency,
+			Amount:      result.Amount,
+			LedgerCount: int(result.LedgerCount),
+		}
+	}
+
+	return spending, nil
+}
+
+func mapToDigestSettings(s sqlcgen.DigestSetting) *domain.DigestSettings {
+	var lastPeriodEnd *time.Time
+	if s.LastPeriodEnd.Valid {
+		lastPeriodEnd = &s.LastPeriodEnd.Time
+	}
+
+	return &domain.DigestSettings{
+		UserID:        s.UserID,
+		UpdatedAt:     s.UpdatedAt.Time,
+		Enabled:       s.Enabled,
+		Frequency:     domain.DigestFrequency(s.Frequency),
+		Weekday:       time.Weekday(s.Weekday),
+		DayOfMonth:    int(s.DayOfMonth),
+		LastPeriodEnd: lastPeriodEnd,
+	}
+}
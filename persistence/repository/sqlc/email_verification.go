@@ -0,0 +1,58 @@
+package sqlc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// CreateEmailVerificationToken implements the domain.EmailVerificationTokenRepository interface
+func (r *Repository) CreateEmailVerificationToken(req domain.CreateEmailVerificationTokenRequest) (*domain.EmailVerificationToken, error) {
+	token, err := r.querier.CreateEmailVerificationToken(r.ctx, sqlcgen.CreateEmailVerificationTokenParams{
+		UserID:     req.UserID,
+		Identifier: req.Identifier,
+		TokenHash:  req.TokenHash,
+		ExpiresAt:  pgtype.Timestamptz{Time: req.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email verification token: %w", err)
+	}
+
+	return mapToEmailVerificationToken(token), nil
+}
+
+// UseEmailVerificationToken implements the domain.EmailVerificationTokenRepository interface.
+// Used and expired tokens are reported as not found.
+func (r *Repository) UseEmailVerificationToken(tokenHash string) (*domain.EmailVerificationToken, error) {
+	token, err := r.querier.UseEmailVerificationToken(r.ctx, tokenHash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to use email verification token: %w", err)
+	}
+
+	return mapToEmailVerificationToken(token), nil
+}
+
+func mapToEmailVerificationToken(token sqlcgen.EmailVerificationToken) *domain.EmailVerificationToken {
+	var usedAt *time.Time
+	if token.UsedAt.Valid {
+		usedAt = &token.UsedAt.Time
+	}
+
+	return &domain.EmailVerificationToken{
+		ID:         token.ID,
+		CreatedAt:  token.CreatedAt.Time,
+		UserID:     token.UserID,
+		Identifier: token.Identifier,
+		TokenHash:  token.TokenHash,
+		ExpiresAt:  token.ExpiresAt.Time,
+		UsedAt:     usedAt,
+	}
+}
@@ -17,36 +17,60 @@ import (
 func (r *Repository) CreateLedger(req domain.CreateLedgerRequest) (int32, error) {
 	var ledgerID int32
 	err := r.ExecuteTx(r.ctx, func(repo *Repository) error {
-		// Create the ledger entry
 		var err error
-		ledger, err := repo.querier.CreateLedger(repo.ctx, sqlcgen.CreateLedgerParams{
-			AccountID:    req.AccountID,
-			Date:         pgtype.Timestamptz{Time: req.Date, Valid: true},
-			Type:         string(req.Type),
-			Amount:       req.Amount,
-			Note:         pgtype.Text{String: req.Note, Valid: true},
-			IsAdjustment: false, // isAdjustment
-			AdjustedFrom: pgtype.Int4{},
-		})
-		if err != nil {
-			return fmt.Errorf("failed to create ledger: %w", err)
-		}
+		ledgerID, err = repo.createLedger(req)
+		return err
+	})
 
-		ledgerID = ledger.ID
+	return ledgerID, err
+}
 
-		// Update account balance
-		_, err = repo.querier.IncreaseAccountBalance(repo.ctx, sqlcgen.IncreaseAccountBalanceParams{
+// createLedger creates a ledger entry and updates the account balance, unless the ledger is
+// pending. It must run within a transaction.
+func (r *Repository) createLedger(req domain.CreateLedgerRequest) (int32, error) {
+	// Create the ledger entry
+	ledger, err := r.querier.CreateLedger(r.ctx, sqlcgen.CreateLedgerParams{
+		AccountID:    req.AccountID,
+		Date:         pgtype.Timestamptz{Time: req.Date, Valid: true},
+		Type:         string(req.Type),
+		Amount:       req.Amount,
+		Note:         pgtype.Text{String: req.Note, Valid: true},
+		IsAdjustment: false, // isAdjustment
+		AdjustedFrom: pgtype.Int4{},
+		PayeeID:      int4FromPtr(req.PayeeID),
+		Category:     pgtype.Text{String: req.Category, Valid: req.Category != ""},
+		Tags:         nonNilTags(req.Tags),
+		IsPending:    req.IsPending,
+
+		RecurringTransactionID: int4FromPtr(req.RecurringTransactionID),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create ledger: %w", err)
+	}
+
+	// Pending ledgers count towards the balance once they are posted
+	if !req.IsPending {
+		_, err = r.querier.IncreaseAccountBalance(r.ctx, sqlcgen.IncreaseAccountBalanceParams{
 			Balance: req.Amount,
 			ID:      req.AccountID,
 		})
 		if err != nil {
-			return fmt.Errorf("failed to update account balance: %w", err)
+			return 0, fmt.Errorf("failed to update account balance: %w", err)
 		}
+	}
 
-		return nil
+	err = r.createAuditLog(r.ctx, auditEntry{
+		Actor:      req.Actor,
+		Action:     domain.AuditActionCreate,
+		EntityType: domain.AuditEntityTypeLedger,
+		EntityID:   ledger.ID,
+		After:      newLedgerAuditState(mapToLedger(ledger)),
 	})
+	if err != nil {
+		return 0, err
+	}
 
-	return ledgerID, err
+	return ledger.ID, nil
 }
 
 // GetLedgerByID implements the domain.LedgerRepository interface
@@ -81,8 +105,14 @@ func (r *Repository) GetLedgerByID(id int32) (*domain.Ledger, error) {
 			}
 			return nil
 		}(),
-		IsVoided: ledger.IsVoided,
-		VoidedAt: voidedAt,
+		IsVoided:  ledger.IsVoided,
+		VoidedAt:  voidedAt,
+		IsPending: ledger.IsPending,
+		PayeeID:   int4ToPtr(ledger.PayeeID),
+		Category:  ledger.Category.String,
+		Tags:      ledger.Tags,
+
+		RecurringTransactionID: int4ToPtr(ledger.RecurringTransactionID),
 	}, nil
 }
 
@@ -117,8 +147,94 @@ func (r *Repository) GetLedgersByAccountID(accountID int32) ([]*domain.Ledger, e
 				}
 				return nil
 			}(),
-			IsVoided: ledger.IsVoided,
-			VoidedAt: voidedAt,
+			IsVoided:  ledger.IsVoided,
+			VoidedAt:  voidedAt,
+			IsPending: ledger.IsPending,
+			PayeeID:   int4ToPtr(ledger.PayeeID),
+			Category:  ledger.Category.String,
+			Tags:      ledger.Tags,
+
+			RecurringTransactionID: int4ToPtr(ledger.RecurringTransactionID),
+		}
+	}
+
+	return domainLedgers, nil
+}
+
+// GetLedgersByUserID implements the domain.LedgerRepository interface
+func (r *Repository) GetLedgersByUserID(userID int32) ([]*domain.Ledger, error) {
+	ledgers, err := r.querier.GetLedgersByUserID(r.ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledgers for user: %w", err)
+	}
+
+	domainLedgers := make([]*domain.Ledger, len(ledgers))
+	for i, ledger := range ledgers {
+		var voidedAt *time.Time
+		if ledger.VoidedAt.Valid {
+			voidedAt = &ledger.VoidedAt.Time
+		}
+
+		domainLedgers[i] = &domain.Ledger{
+			ID:           ledger.ID,
+			CreatedAt:    ledger.CreatedAt.Time,
+			UpdatedAt:    ledger.UpdatedAt.Time,
+			AccountID:    ledger.AccountID,
+			Date:         ledger.Date.Time,
+			Type:         domain.LedgerType(ledger.Type),
+			Currency:     ledger.Currency,
+			Amount:       ledger.Amount,
+			Note:         ledger.Note.String,
+			IsAdjustment: ledger.IsAdjustment,
+			AdjustedFrom: int4ToPtr(ledger.AdjustedFrom),
+			IsVoided:     ledger.IsVoided,
+			VoidedAt:     voidedAt,
+			IsPending:    ledger.IsPending,
+			PayeeID:      int4ToPtr(ledger.PayeeID),
+			Category:     ledger.Category.String,
+			Tags:         ledger.Tags,
+
+			RecurringTransactionID: int4ToPtr(ledger.RecurringTransactionID),
+		}
+	}
+
+	return domainLedgers, nil
+}
+
+// GetLedgersByRecurringTransactionID implements the domain.LedgerRepository interface
+func (r *Repository) GetLedgersByRecurringTransactionID(recurringTransactionID int32) ([]*domain.Ledger, error) {
+	ledgers, err := r.querier.GetLedgersByRecurringTransactionID(r.ctx, pgtype.Int4{Int32: recurringTransactionID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledgers for recurring transaction: %w", err)
+	}
+
+	domainLedgers := make([]*domain.Ledger, len(ledgers))
+	for i, ledger := range ledgers {
+		var voidedAt *time.Time
+		if ledger.VoidedAt.Valid {
+			voidedAt = &ledger.VoidedAt.Time
+		}
+
+		domainLedgers[i] = &domain.Ledger{
+			ID:           ledger.ID,
+			CreatedAt:    ledger.CreatedAt.Time,
+			UpdatedAt:    ledger.UpdatedAt.Time,
+			AccountID:    ledger.AccountID,
+			Date:         ledger.Date.Time,
+			Type:         domain.LedgerType(ledger.Type),
+			Currency:     ledger.Currency,
+			Amount:       ledger.Amount,
+			Note:         ledger.Note.String,
+			IsAdjustment: ledger.IsAdjustment,
+			AdjustedFrom: int4ToPtr(ledger.AdjustedFrom),
+			IsVoided:     ledger.IsVoided,
+			VoidedAt:     voidedAt,
+			IsPending:    ledger.IsPending,
+			PayeeID:      int4ToPtr(ledger.PayeeID),
+			Category:     ledger.Category.String,
+			Tags:         ledger.Tags,
+
+			RecurringTransactionID: int4ToPtr(ledger.RecurringTransactionID),
 		}
 	}
 
@@ -128,14 +244,10 @@ func (r *Repository) GetLedgersByAccountID(accountID int32) ([]*domain.Ledger, e
 // UpdateLedger implements the domain.LedgerRepository interface
 func (r *Repository) UpdateLedger(req domain.UpdateLedgerRequest) error {
 	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
-		// Get the old amount for balance adjustment
-		var oldAmount decimal.Decimal
-		if req.Amount != nil {
-			amount, err := repo.querier.GetLedgerAmount(repo.ctx, req.ID)
-			if err != nil {
-				return fmt.Errorf("failed to get ledger amount: %w", err)
-			}
-			oldAmount = amount
+		// Get the old ledger for balance adjustment and the audit log
+		before, err := repo.GetLedgerByID(req.ID)
+		if err != nil {
+			return err
 		}
 
 		// Prepare update params
@@ -173,48 +285,69 @@ func (r *Repository) UpdateLedger(req domain.UpdateLedgerRequest) error {
 			}
 		}
 
+		if req.PayeeID != nil {
+			updateParams.PayeeID = pgtype.Int4{
+				Int32: *req.PayeeID,
+				Valid: true,
+			}
+		}
+
+		if req.Category != nil {
+			updateParams.Category = pgtype.Text{
+				String: *req.Category,
+				Valid:  true,
+			}
+		}
+
+		if req.Tags != nil {
+			updateParams.Tags = nonNilTags(*req.Tags)
+		}
+
 		// Update the ledger
-		if _, err := repo.querier.UpdateLedger(repo.ctx, updateParams); err != nil {
+		ledger, err := repo.querier.UpdateLedger(repo.ctx, updateParams)
+		if err != nil {
 			return fmt.Errorf("failed to update ledger: %w", err)
 		}
 
 		// If amount has changed, update account balance
 		if req.Amount != nil {
-			// Get the ledger to find the account ID
-			ledger, err := repo.querier.GetLedgerByID(repo.ctx, req.ID)
-			if err != nil {
-				return fmt.Errorf("failed to get ledger account for balance update: %w", err)
-			}
-
 			// Calculate balance adjustment
-			adjustment := req.Amount.Sub(oldAmount)
+			adjustment := req.Amount.Sub(before.Amount)
 			if !adjustment.IsZero() {
 				if _, err := repo.querier.IncreaseAccountBalance(repo.ctx, sqlcgen.IncreaseAccountBalanceParams{
 					Balance: adjustment,
-					ID:      ledger.AccountID,
+					ID:      before.AccountID,
 				}); err != nil {
 					return fmt.Errorf("failed to adjust account balance: %w", err)
 				}
 			}
 		}
 
-		return nil
+		return repo.createAuditLog(repo.ctx, auditEntry{
+			Actor:      req.Actor,
+			Action:     domain.AuditActionUpdate,
+			EntityType: domain.AuditEntityTypeLedger,
+			EntityID:   req.ID,
+			Before:     newLedgerAuditState(before),
+			After:      newLedgerAuditState(mapToLedger(ledger)),
+		})
 	})
 }
 
 // VoidLedger implements the domain.LedgerRepository interface
 // This method now also performs a soft delete by setting the deleted_at timestamp.
-func (r *Repository) VoidLedger(id int32) error {
+func (r *Repository) VoidLedger(id int32, actor domain.AuditActor) error {
 	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
 		// Get the ledger to find the amount and account ID
 		// The GetLedgerByID query already filters out soft-deleted records.
-		ledger, err := repo.querier.GetLedgerByID(repo.ctx, id)
+		ledger, err := repo.GetLedgerByID(id)
 		if err != nil {
 			return fmt.Errorf("failed to get ledger for voiding: %w", err)
 		}
 
 		// Void the ledger (SQL query now also sets deleted_at)
-		if _, err := repo.querier.VoidLedger(repo.ctx, id); err != nil {
+		voided, err := repo.querier.VoidLedger(repo.ctx, id)
+		if err != nil {
 			return fmt.Errorf("failed to void ledger: %w", err)
 		}
 
@@ -227,17 +360,24 @@ func (r *Repository) VoidLedger(id int32) error {
 			return fmt.Errorf("failed to update account balance for voided ledger: %w", err)
 		}
 
-		return nil
+		return repo.createAuditLog(repo.ctx, auditEntry{
+			Actor:      actor,
+			Action:     domain.AuditActionVoid,
+			EntityType: domain.AuditEntityTypeLedger,
+			EntityID:   id,
+			Before:     newLedgerAuditState(ledger),
+			After:      newLedgerAuditState(mapToLedger(voided)),
+		})
 	})
 }
 
 // DeleteLedger implements the domain.LedgerRepository interface for soft delete
-func (r *Repository) DeleteLedger(id int32) error {
+func (r *Repository) DeleteLedger(id int32, actor domain.AuditActor) error {
 	// We need to get the ledger first to reverse the balance impact before soft deleting.
 	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
 		// Get the ledger to find the amount and account ID
 		// The GetLedgerByID query already filters out soft-deleted records.
-		ledger, err := repo.querier.GetLedgerByID(repo.ctx, id)
+		ledger, err := repo.GetLedgerByID(id)
 		if err != nil {
 			return fmt.Errorf("failed to get ledger for soft deletion: %w", err)
 		}
@@ -256,15 +396,26 @@ func (r *Repository) DeleteLedger(id int32) error {
 			return fmt.Errorf("failed to update account balance for soft deleted ledger: %w", err)
 		}
 
-		return nil
+		return repo.createAuditLog(repo.ctx, auditEntry{
+			Actor:      actor,
+			Action:     domain.AuditActionDelete,
+			EntityType: domain.AuditEntityTypeLedger,
+			EntityID:   id,
+			Before:     newLedgerAuditState(ledger),
+		})
 	})
 }
 
 // AdjustLedger adjusts a ledger by its original ID.
 func (r *Repository) AdjustLedger(originalID int32, adjustment domain.CreateLedgerRequest) error {
 	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
+		original, err := repo.GetLedgerByID(originalID)
+		if err != nil {
+			return err
+		}
+
 		// Create a new ledger entry marked as an adjustment
-		_, err := repo.querier.CreateLedger(repo.ctx, sqlcgen.CreateLedgerParams{
+		ledger, err := repo.querier.CreateLedger(repo.ctx, sqlcgen.CreateLedgerParams{
 			AccountID:    adjustment.AccountID,
 			Date:         pgtype.Timestamptz{Time: adjustment.Date, Valid: true},
 			Type:         string(adjustment.Type),
@@ -272,6 +423,9 @@ func (r *Repository) AdjustLedger(originalID int32, adjustment domain.CreateLedg
 			Note:         pgtype.Text{String: adjustment.Note, Valid: true},
 			IsAdjustment: true,
 			AdjustedFrom: pgtype.Int4{Int32: originalID, Valid: true},
+			PayeeID:      int4FromPtr(adjustment.PayeeID),
+			Category:     pgtype.Text{String: adjustment.Category, Valid: adjustment.Category != ""},
+			Tags:         nonNilTags(adjustment.Tags),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create adjustment ledger: %w", err)
@@ -285,6 +439,161 @@ func (r *Repository) AdjustLedger(originalID int32, adjustment domain.CreateLedg
 			return fmt.Errorf("failed to update account balance for adjustment: %w", err)
 		}
 
+		// The adjustment is logged against the original ledger, with the adjusting entry as its after state
+		return repo.createAuditLog(repo.ctx, auditEntry{
+			Actor:      adjustment.Actor,
+			Action:     domain.AuditActionAdjust,
+			EntityType: domain.AuditEntityTypeLedger,
+			EntityID:   originalID,
+			Before:     newLedgerAuditState(original),
+			After:      newLedgerAuditState(mapToLedger(ledger)),
+		})
+	})
+}
+
+// PostPendingLedger implements the domain.LedgerRepository interface
+func (r *Repository) PostPendingLedger(id int32, amount *decimal.Decimal, actor domain.AuditActor) error {
+	params := sqlcgen.PostPendingLedgerParams{
+		ID: id,
+	}
+
+	if amount != nil {
+		params.Amount = pgtype.Numeric{Valid: true}
+		params.Amount.InfinityModifier = pgtype.Finite
+		params.Amount.NaN = false
+		params.Amount.Int = amount.Coefficient()
+		params.Amount.Exp = amount.Exponent()
+	}
+
+	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
+		before, err := repo.GetLedgerByID(id)
+		if err != nil {
+			return err
+		}
+
+		ledger, err := repo.querier.PostPendingLedger(repo.ctx, params)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return domain.ErrNotFound
+			}
+			return fmt.Errorf("failed to post pending ledger: %w", err)
+		}
+
+		if _, err := repo.querier.IncreaseAccountBalance(repo.ctx, sqlcgen.IncreaseAccountBalanceParams{
+			Balance: ledger.Amount,
+			ID:      ledger.AccountID,
+		}); err != nil {
+			return fmt.Errorf("failed to update account balance for posted ledger: %w", err)
+		}
+
+		if err := repo.querier.UpdateRecurringTransactionExecutionStatus(repo.ctx, sqlcgen.UpdateRecurringTransactionExecutionStatusParams{
+			Status:   domain.RecurringExecutionStatusBooked.String(),
+			LedgerID: pgtype.Int4{Int32: id, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to update recurring transaction execution: %w", err)
+		}
+
+		return repo.createAuditLog(repo.ctx, auditEntry{
+			Actor:      actor,
+			Action:     domain.AuditActionUpdate,
+			EntityType: domain.AuditEntityTypeLedger,
+			EntityID:   id,
+			Before:     newLedgerAuditState(before),
+			After:      newLedgerAuditState(mapToLedger(ledger)),
+		})
+	})
+}
+
+// DiscardPendingLedger implements the domain.LedgerRepository interface
+func (r *Repository) DiscardPendingLedger(id int32, actor domain.AuditActor) error {
+	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
+		ledger, err := repo.GetLedgerByID(id)
+		if err != nil {
+			return err
+		}
+
+		if !ledger.IsPending {
+			return domain.ErrNotFound
+		}
+
+		// Pending ledgers never counted towards the balance, so there is nothing to reverse
+		if _, err := repo.querier.DeleteLedger(repo.ctx, id); err != nil {
+			return fmt.Errorf("failed to discard pending ledger: %w", err)
+		}
+
+		if err := repo.querier.UpdateRecurringTransactionExecutionStatus(repo.ctx, sqlcgen.UpdateRecurringTransactionExecutionStatusParams{
+			Status:   domain.RecurringExecutionStatusSkipped.String(),
+			LedgerID: pgtype.Int4{Int32: id, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to update recurring transaction execution: %w", err)
+		}
+
+		return repo.createAuditLog(repo.ctx, auditEntry{
+			Actor:      actor,
+			Action:     domain.AuditActionDelete,
+			EntityType: domain.AuditEntityTypeLedger,
+			EntityID:   id,
+			Before:     newLedgerAuditState(ledger),
+		})
+	})
+}
+
+// ClassifyLedgers implements the domain.LedgerRepository interface.
+// It leaves updated_at untouched so that classification does not reopen the edit window.
+func (r *Repository) ClassifyLedgers(matches []domain.PayeeRuleMatch) error {
+	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
+		for _, match := range matches {
+			_, err := repo.querier.ClassifyLedger(repo.ctx, sqlcgen.ClassifyLedgerParams{
+				PayeeID:  int4FromPtr(match.After.PayeeID),
+				Category: pgtype.Text{String: match.After.Category, Valid: match.After.Category != ""},
+				Tags:     nonNilTags(match.After.Tags),
+				ID:       match.LedgerID,
+			})
+			if err != nil {
+				if err == pgx.ErrNoRows {
+					return domain.ErrNotFound
+				}
+				return fmt.Errorf("failed to classify ledger %d: %w", match.LedgerID, err)
+			}
+		}
+
 		return nil
 	})
 }
+
+// mapToLedger converts a ledger row to a domain ledger. The row carries no currency.
+func mapToLedger(ledger sqlcgen.Ledger) *domain.Ledger {
+	var voidedAt *time.Time
+	if ledger.VoidedAt.Valid {
+		voidedAt = &ledger.VoidedAt.Time
+	}
+
+	return &domain.Ledger{
+		ID:           ledger.ID,
+		CreatedAt:    ledger.CreatedAt.Time,
+		UpdatedAt:    ledger.UpdatedAt.Time,
+		AccountID:    ledger.AccountID,
+		Date:         ledger.Date.Time,
+		Type:         domain.LedgerType(ledger.Type),
+		Amount:       ledger.Amount,
+		Note:         ledger.Note.String,
+		IsAdjustment: ledger.IsAdjustment,
+		AdjustedFrom: int4ToPtr(ledger.AdjustedFrom),
+		IsVoided:     ledger.IsVoided,
+		VoidedAt:     voidedAt,
+		IsPending:    ledger.IsPending,
+		PayeeID:      int4ToPtr(ledger.PayeeID),
+		Category:     ledger.Category.String,
+		Tags:         ledger.Tags,
+
+		RecurringTransactionID: int4ToPtr(ledger.RecurringTransactionID),
+	}
+}
+
+// nonNilTags keeps empty tag lists from being encoded as NULL.
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
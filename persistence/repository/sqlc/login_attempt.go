@@ -0,0 +1,83 @@
+package sqlc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// GetLoginAttempt implements the domain.LoginAttemptRepository interface
+func (r *Repository) GetLoginAttempt(provider domain.IdentityProvider, identifier string) (*domain.LoginAttempt, error) {
+	attempt, err := r.querier.GetLoginAttempt(r.ctx, sqlcgen.GetLoginAttemptParams{
+		Provider:   string(provider),
+		Identifier: identifier,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get login attempt: %w", err)
+	}
+
+	return mapToLoginAttempt(attempt), nil
+}
+
+// RecordFailedLogin implements the domain.LoginAttemptRepository interface
+func (r *Repository) RecordFailedLogin(req domain.RecordFailedLoginRequest) (*domain.LoginAttempt, error) {
+	attempt, err := r.querier.RecordFailedLogin(r.ctx, sqlcgen.RecordFailedLoginParams{
+		Provider:    string(req.Provider),
+		Identifier:  req.Identifier,
+		FailedAt:    pgtype.Timestamptz{Time: req.FailedAt, Valid: true},
+		ResetBefore: pgtype.Timestamptz{Time: req.ResetBefore, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record failed login: %w", err)
+	}
+
+	return mapToLoginAttempt(attempt), nil
+}
+
+// LockLogin implements the domain.LoginAttemptRepository interface
+func (r *Repository) LockLogin(provider domain.IdentityProvider, identifier string, until time.Time) error {
+	if err := r.querier.LockLogin(r.ctx, sqlcgen.LockLoginParams{
+		Provider:    string(provider),
+		Identifier:  identifier,
+		LockedUntil: pgtype.Timestamptz{Time: until, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+
+	return nil
+}
+
+// ClearLoginAttempts implements the domain.LoginAttemptRepository interface
+func (r *Repository) ClearLoginAttempts(provider domain.IdentityProvider, identifier string) error {
+	if err := r.querier.ClearLoginAttempts(r.ctx, sqlcgen.ClearLoginAttemptsParams{
+		Provider:   string(provider),
+		Identifier: identifier,
+	}); err != nil {
+		return fmt.Errorf("failed to clear login attempts: %w", err)
+	}
+
+	return nil
+}
+
+func mapToLoginAttempt(attempt sqlcgen.LoginAttempt) *domain.LoginAttempt {
+	var lockedUntil *time.Time
+	if attempt.LockedUntil.Valid {
+		lockedUntil = &attempt.LockedUntil.Time
+	}
+
+	return &domain.LoginAttempt{
+		Provider:     domain.IdentityProvider(attempt.Provider),
+		Identifier:   attempt.Identifier,
+		FailedCount:  attempt.FailedCount,
+		LastFailedAt: attempt.LastFailedAt.Time,
+		LockedUntil:  lockedUntil,
+	}
+}
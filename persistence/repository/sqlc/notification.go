@@ -0,0 +1,188 @@
+package sqlc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// GetNotificationPreferences gets the notification channels of a user
+func (r *Repository) GetNotificationPreferences(ctx context.Context, userID int32) ([]*domain.NotificationPreference, error) {
+	results, err := r.querier.GetNotificationChannelsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification channels: %w", err)
+	}
+
+	preferences := make([]*domain.NotificationPreference, len(results))
+	for i, result := range results {
+		preferences[i] = mapToNotificationPreference(result)
+	}
+
+	return preferences, nil
+}
+
+// UpsertNotificationPreference sets the target of a notification channel of a user
+func (r *Repository) UpsertNotificationPreference(ctx context.Context, preference domain.NotificationPreference) (*domain.NotificationPreference, error) {
+	params := sqlcgen.UpsertNotificationChannelParams{
+		UserID:  preference.UserID,
+		Channel: preference.Channel.String(),
+		Target:  preference.Target,
+		Enabled: preference.Enabled,
+		ConfirmationTokenHash: pgtype.Text{
+			String: preference.ConfirmationTokenHash,
+			Valid:  preference.ConfirmationTokenHash != "",
+		},
+	}
+	if preference.ConfirmedAt != nil {
+		params.ConfirmedAt = pgtype.Timestamptz{Time: *preference.ConfirmedAt, Valid: true}
+	}
+	if preference.ConfirmationExpiresAt != nil {
+		params.ConfirmationExpiresAt = pgtype.Timestamptz{Time: *preference.ConfirmationExpiresAt, Valid: true}
+	}
+
+	result, err := r.querier.UpsertNotificationChannel(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set notification channel: %w", err)
+	}
+
+	return mapToNotificationPreference(result), nil
+}
+
+// ConfirmNotificationPreference confirms the target of a notification channel using the hash of
+// its unexpired confirmation token
+func (r *Repository) ConfirmNotificationPreference(ctx context.Context, tokenHash string) (*domain.NotificationPreference, error) {
+	result, err := r.querier.ConfirmNotificationChannel(ctx, pgtype.Text{String: tokenHash, Valid: true})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to confirm notification channel: %w", err)
+	}
+
+	return mapToNotificationPreference(result), nil
+}
+
+// DeleteNotificationPreference removes a notification channel of a user
+func (r *Repository) DeleteNotificationPreference(ctx context.Context, userID int32, channel domain.NotificationChannel) error {
+	if err := r.querier.DeleteNotificationChannel(ctx, sqlcgen.DeleteNotificationChannelParams{
+		UserID:  userID,
+		Channel: channel.String(),
+	}); err != nil {
+		return fmt.Errorf("failed to delete notification channel: %w", err)
+	}
+
+	return nil
+}
+
+// QueueReminderDeliveries queues the deliveries of the unread reminders dated between since and until
+func (r *Repository) QueueReminderDeliveries(ctx context.Context, since, until time.Time) ([]*domain.ReminderDelivery, error) {
+	results, err := r.querier.QueueReminderDeliveries(ctx, sqlcgen.QueueReminderDeliveriesParams{
+		Since: pgtype.Timestamptz{Time: since, Valid: true},
+		Until: pgtype.Timestamptz{Time: until, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue reminder deliveries: %w", err)
+	}
+
+	return mapToReminderDeliveries(results), nil
+}
+
+// ClaimReminderDeliveries claims the pending deliveries due at now until leaseUntil
+func (r *Repository) ClaimReminderDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.ReminderDelivery, error) {
+	results, err := r.querier.ClaimReminderDeliveries(ctx, sqlcgen.ClaimReminderDeliveriesParams{
+		LeaseUntil: pgtype.Timestamptz{Time: leaseUntil, Valid: true},
+		Now:        pgtype.Timestamptz{Time: now, Valid: true},
+		BatchSize:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim reminder deliveries: %w", err)
+	}
+
+	return mapToReminderDeliveries(results), nil
+}
+
+// UpdateReminderDelivery records the outcome of a delivery attempt
+func (r *Repository) UpdateReminderDelivery(ctx context.Context, req domain.UpdateReminderDeliveryRequest) error {
+	var deliveredAt pgtype.Timestamptz
+	if req.DeliveredAt != nil {
+		deliveredAt = pgtype.Timestamptz{Time: *req.DeliveredAt, Valid: true}
+	}
+
+	if err := r.querier.UpdateReminderDeliveryStatus(ctx, sqlcgen.UpdateReminderDeliveryStatusParams{
+		Status:        req.Status.String(),
+		NextAttemptAt: pgtype.Timestamptz{Time: req.NextAttemptAt, Valid: true},
+		LastError:     req.LastError,
+		DeliveredAt:   deliveredAt,
+		ID:            req.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to update reminder delivery: %w", err)
+	}
+
+	return nil
+}
+
+// GetReminderDeliveries gets the deliveries of a reminder, the latest first
+func (r *Repository) GetReminderDeliveries(ctx context.Context, reminderID int32) ([]*domain.ReminderDelivery, error) {
+	results, err := r.querier.GetReminderDeliveriesByReminderID(ctx, reminderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reminder deliveries: %w", err)
+	}
+
+	return mapToReminderDeliveries(results), nil
+}
+
+func mapToNotificationPreference(c sqlcgen.NotificationChannel) *domain.NotificationPreference {
+	var confirmedAt, confirmationExpiresAt *time.Time
+	if c.ConfirmedAt.Valid {
+		confirmedAt = &c.ConfirmedAt.Time
+	}
+	if c.ConfirmationExpiresAt.Valid {
+		confirmationExpiresAt = &c.ConfirmationExpiresAt.Time
+	}
+
+	return &domain.NotificationPreference{
+		ID:                    c.ID,
+		CreatedAt:             c.CreatedAt.Time,
+		UpdatedAt:             c.UpdatedAt.Time,
+		UserID:                c.UserID,
+		Channel:               domain.NotificationChannel(c.Channel),
+		Target:                c.Target,
+		Enabled:               c.Enabled,
+		ConfirmedAt:           confirmedAt,
+		ConfirmationTokenHash: c.ConfirmationTokenHash.String,
+		ConfirmationExpiresAt: confirmationExpiresAt,
+	}
+}
+
+func mapToReminderDeliveries(results []sqlcgen.ReminderDelivery) []*domain.ReminderDelivery {
+	deliveries := make([]*domain.ReminderDelivery, len(results))
+	for i, d := range results {
+		var deliveredAt *time.Time
+		if d.DeliveredAt.Valid {
+			deliveredAt = &d.DeliveredAt.Time
+		}
+
+		deliveries[i] = &domain.ReminderDelivery{
+			ID:            d.ID,
+			CreatedAt:     d.CreatedAt.Time,
+			UpdatedAt:     d.UpdatedAt.Time,
+			ReminderID:    d.ReminderID,
+			ReminderDate:  d.ReminderDate.Time,
+			Channel:       domain.NotificationChannel(d.Channel),
+			Target:        d.Target,
+			Status:        domain.ReminderDeliveryStatus(d.Status),
+			Attempts:      int(d.Attempts),
+			NextAttemptAt: d.NextAttemptAt.Time,
+			LastError:     d.LastError,
+			DeliveredAt:   deliveredAt,
+		}
+	}
+
+	return deliveries
+}
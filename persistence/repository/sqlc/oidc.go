@@ -0,0 +1,60 @@
+package sqlc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// CreateOIDCAuthRequest implements the domain.OIDCAuthRequestRepository interface
+func (r *Repository) CreateOIDCAuthRequest(req domain.CreateOIDCAuthRequestRequest) (*domain.OIDCAuthRequest, error) {
+	authRequest, err := r.querier.CreateOIDCAuthRequest(r.ctx, sqlcgen.CreateOIDCAuthRequestParams{
+		StateHash:    req.StateHash,
+		Nonce:        req.Nonce,
+		CodeVerifier: req.CodeVerifier,
+		LinkUserID:   int4FromPtr(req.LinkUserID),
+		ExpiresAt:    pgtype.Timestamptz{Time: req.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oidc auth request: %w", err)
+	}
+
+	return mapToOIDCAuthRequest(authRequest), nil
+}
+
+// UseOIDCAuthRequest implements the domain.OIDCAuthRequestRepository interface.
+// Used and expired requests are reported as not found.
+func (r *Repository) UseOIDCAuthRequest(stateHash string) (*domain.OIDCAuthRequest, error) {
+	authRequest, err := r.querier.UseOIDCAuthRequest(r.ctx, stateHash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to use oidc auth request: %w", err)
+	}
+
+	return mapToOIDCAuthRequest(authRequest), nil
+}
+
+func mapToOIDCAuthRequest(authRequest sqlcgen.OidcAuthRequest) *domain.OIDCAuthRequest {
+	var usedAt *time.Time
+	if authRequest.UsedAt.Valid {
+		usedAt = &authRequest.UsedAt.Time
+	}
+
+	return &domain.OIDCAuthRequest{
+		ID:           authRequest.ID,
+		CreatedAt:    authRequest.CreatedAt.Time,
+		StateHash:    authRequest.StateHash,
+		Nonce:        authRequest.Nonce,
+		CodeVerifier: authRequest.CodeVerifier,
+		LinkUserID:   int4ToPtr(authRequest.LinkUserID),
+		ExpiresAt:    authRequest.ExpiresAt.Time,
+		UsedAt:       usedAt,
+	}
+}
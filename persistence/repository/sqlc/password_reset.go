@@ -0,0 +1,67 @@
+package sqlc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// CreatePasswordResetToken implements the domain.PasswordResetTokenRepository interface
+func (r *Repository) CreatePasswordResetToken(req domain.CreatePasswordResetTokenRequest) (*domain.PasswordResetToken, error) {
+	token, err := r.querier.CreatePasswordResetToken(r.ctx, sqlcgen.CreatePasswordResetTokenParams{
+		UserID:     req.UserID,
+		Identifier: req.Identifier,
+		TokenHash:  req.TokenHash,
+		ExpiresAt:  pgtype.Timestamptz{Time: req.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return mapToPasswordResetToken(token), nil
+}
+
+// UsePasswordResetToken implements the domain.PasswordResetTokenRepository interface.
+// Used and expired tokens are reported as not found.
+func (r *Repository) UsePasswordResetToken(tokenHash string) (*domain.PasswordResetToken, error) {
+	token, err := r.querier.UsePasswordResetToken(r.ctx, tokenHash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to use password reset token: %w", err)
+	}
+
+	return mapToPasswordResetToken(token), nil
+}
+
+// InvalidatePasswordResetTokensByUserID implements the domain.PasswordResetTokenRepository interface
+func (r *Repository) InvalidatePasswordResetTokensByUserID(userID int32) error {
+	if err := r.querier.InvalidatePasswordResetTokensByUserID(r.ctx, userID); err != nil {
+		return fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+
+	return nil
+}
+
+func mapToPasswordResetToken(token sqlcgen.PasswordResetToken) *domain.PasswordResetToken {
+	var usedAt *time.Time
+	if token.UsedAt.Valid {
+		usedAt = &token.UsedAt.Time
+	}
+
+	return &domain.PasswordResetToken{
+		ID:         token.ID,
+		CreatedAt:  token.CreatedAt.Time,
+		UserID:     token.UserID,
+		Identifier: token.Identifier,
+		TokenHash:  token.TokenHash,
+		ExpiresAt:  token.ExpiresAt.Time,
+		UsedAt:     usedAt,
+	}
+}
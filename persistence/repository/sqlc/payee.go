@@ -0,0 +1,211 @@
+package sqlc
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// CreatePayee implements the domain.PayeeRepository interface
+func (r *Repository) CreatePayee(req domain.CreatePayeeRequest) (int32, error) {
+	payee, err := r.querier.CreatePayee(r.ctx, sqlcgen.CreatePayeeParams{
+		UserID: req.UserID,
+		Name:   req.Name,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create payee: %w", err)
+	}
+
+	return payee.ID, nil
+}
+
+// GetPayeeByID implements the domain.PayeeRepository interface
+func (r *Repository) GetPayeeByID(id int32) (*domain.Payee, error) {
+	payee, err := r.querier.GetPayeeByID(r.ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get payee: %w", err)
+	}
+
+	return mapToPayee(payee), nil
+}
+
+// GetPayeesByUserID implements the domain.PayeeRepository interface
+func (r *Repository) GetPayeesByUserID(userID int32) ([]*domain.Payee, error) {
+	payees, err := r.querier.GetPayeesByUserID(r.ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payees: %w", err)
+	}
+
+	domainPayees := make([]*domain.Payee, len(payees))
+	for i, payee := range payees {
+		domainPayees[i] = mapToPayee(payee)
+	}
+
+	return domainPayees, nil
+}
+
+// UpdatePayee implements the domain.PayeeRepository interface
+func (r *Repository) UpdatePayee(req domain.UpdatePayeeRequest) error {
+	params := sqlcgen.UpdatePayeeParams{
+		ID: req.ID,
+	}
+
+	if req.Name != nil {
+		params.Name = pgtype.Text{String: *req.Name, Valid: true}
+	}
+
+	if _, err := r.querier.UpdatePayee(r.ctx, params); err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to update payee: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePayee implements the domain.PayeeRepository interface
+func (r *Repository) DeletePayee(id int32) error {
+	if _, err := r.querier.DeletePayee(r.ctx, id); err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to delete payee: %w", err)
+	}
+
+	return nil
+}
+
+// CreatePayeeRule implements the domain.PayeeRepository interface
+func (r *Repository) CreatePayeeRule(req domain.CreatePayeeRuleRequest) (int32, error) {
+	rule, err := r.querier.CreatePayeeRule(r.ctx, sqlcgen.CreatePayeeRuleParams{
+		UserID:    req.UserID,
+		PayeeID:   int4FromPtr(req.PayeeID),
+		MatchType: string(req.MatchType),
+		Pattern:   req.Pattern,
+		Category:  pgtype.Text{String: req.Category, Valid: req.Category != ""},
+		Tags:      nonNilTags(req.Tags),
+		Priority:  req.Priority,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create payee rule: %w", err)
+	}
+
+	return rule.ID, nil
+}
+
+// GetPayeeRuleByID implements the domain.PayeeRepository interface
+func (r *Repository) GetPayeeRuleByID(id int32) (*domain.PayeeRule, error) {
+	rule, err := r.querier.GetPayeeRuleByID(r.ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get payee rule: %w", err)
+	}
+
+	return mapToPayeeRule(rule), nil
+}
+
+// GetPayeeRulesByUserID implements the domain.PayeeRepository interface.
+// Rules are ordered by priority, highest first.
+func (r *Repository) GetPayeeRulesByUserID(userID int32) ([]*domain.PayeeRule, error) {
+	rules, err := r.querier.GetPayeeRulesByUserID(r.ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payee rules: %w", err)
+	}
+
+	domainRules := make([]*domain.PayeeRule, len(rules))
+	for i, rule := range rules {
+		domainRules[i] = mapToPayeeRule(rule)
+	}
+
+	return domainRules, nil
+}
+
+// UpdatePayeeRule implements the domain.PayeeRepository interface
+func (r *Repository) UpdatePayeeRule(req domain.UpdatePayeeRuleRequest) error {
+	params := sqlcgen.UpdatePayeeRuleParams{
+		ID:      req.ID,
+		PayeeID: int4FromPtr(req.PayeeID),
+	}
+
+	if req.MatchType != nil {
+		params.MatchType = pgtype.Text{String: string(*req.MatchType), Valid: true}
+	}
+
+	if req.Pattern != nil {
+		params.Pattern = pgtype.Text{String: *req.Pattern, Valid: true}
+	}
+
+	if req.Category != nil {
+		params.Category = pgtype.Text{String: *req.Category, Valid: true}
+	}
+
+	if req.Tags != nil {
+		params.Tags = nonNilTags(*req.Tags)
+	}
+
+	if req.Priority != nil {
+		params.Priority = pgtype.Int4{Int32: *req.Priority, Valid: true}
+	}
+
+	if _, err := r.querier.UpdatePayeeRule(r.ctx, params); err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to update payee rule: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePayeeRule implements the domain.PayeeRepository interface
+func (r *Repository) DeletePayeeRule(id int32) error {
+	if _, err := r.querier.DeletePayeeRule(r.ctx, id); err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to delete payee rule: %w", err)
+	}
+
+	return nil
+}
+
+// Helper functions
+func mapToPayee(payee sqlcgen.Payee) *domain.Payee {
+	return &domain.Payee{
+		ID:        payee.ID,
+		CreatedAt: payee.CreatedAt.Time,
+		UpdatedAt: payee.UpdatedAt.Time,
+		UserID:    payee.UserID,
+		Name:      payee.Name,
+	}
+}
+
+func mapToPayeeRule(rule sqlcgen.PayeeRule) *domain.PayeeRule {
+	payeeRule := &domain.PayeeRule{
+		ID:        rule.ID,
+		CreatedAt: rule.CreatedAt.Time,
+		UpdatedAt: rule.UpdatedAt.Time,
+		UserID:    rule.UserID,
+		PayeeID:   int4ToPtr(rule.PayeeID),
+		MatchType: domain.PayeeRuleMatchType(rule.MatchType),
+		Pattern:   rule.Pattern,
+		Category:  rule.Category.String,
+		Tags:      rule.Tags,
+		Priority:  rule.Priority,
+	}
+
+	// Patterns are validated when saved, a rule whose pattern does not compile never matches
+	_ = payeeRule.Compile()
+
+	return payeeRule
+}
@@ -36,32 +36,51 @@ func (r *Repository) CreateRecurringTransaction(ctx context.Context, req domain.
 		endDate.Valid = true
 	}
 
-	nextDue := calculateNextDueDate(req.StartDate, req.RecurType, req.Frequency, req.DayOfWeek, req.DayOfMonth, req.MonthOfYear)
-
 	params := sqlcgen.CreateRecurringTransactionParams{
-		UserID:      req.UserID,
-		AccountID:   req.AccountID,
-		Name:        req.Name,
-		Type:        string(req.Type),
-		Amount:      req.Amount,
-		Note:        pgtype.Text{String: req.Note, Valid: true},
-		StartDate:   pgtype.Timestamptz{Time: req.StartDate, Valid: true},
-		EndDate:     endDate,
-		RecurType:   string(req.RecurType),
-		Status:      string(domain.RecurrenceStatusActive),
-		Frequency:   int32(req.Frequency),
-		DayOfWeek:   dayOfWeek,
-		DayOfMonth:  dayOfMonth,
-		MonthOfYear: monthOfYear,
-		NextDue:     pgtype.Timestamptz{Time: nextDue, Valid: true},
-	}
-
-	result, err := r.querier.CreateRecurringTransaction(ctx, params)
+		UserID:          req.UserID,
+		AccountID:       req.AccountID,
+		Name:            req.Name,
+		Type:            string(req.Type),
+		Amount:          req.Amount,
+		Note:            pgtype.Text{String: req.Note, Valid: true},
+		StartDate:       pgtype.Timestamptz{Time: req.StartDate, Valid: true},
+		EndDate:         endDate,
+		RecurType:       string(req.RecurType),
+		Status:          string(domain.RecurrenceStatusActive),
+		Frequency:       int32(req.Frequency),
+		DayOfWeek:       dayOfWeek,
+		DayOfMonth:      dayOfMonth,
+		MonthOfYear:     monthOfYear,
+		NextDue:         pgtype.Timestamptz{Time: req.NextDue, Valid: true},
+		WorkspaceID:     int4FromPtr(req.WorkspaceID),
+		Rrule:           req.RRule,
+		CatchUpPolicy:   string(req.CatchUpPolicy),
+		PostMode:        string(req.PostMode),
+		ReminderOffsets: int32sFromInts(req.ReminderOffsets),
+	}
+
+	var transaction *domain.RecurringTransaction
+	err := r.ExecuteTx(ctx, func(repo *Repository) error {
+		result, err := repo.querier.CreateRecurringTransaction(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		transaction = mapToRecurringTransaction(result)
+
+		return repo.createAuditLog(ctx, auditEntry{
+			Actor:      req.Actor,
+			Action:     domain.AuditActionCreate,
+			EntityType: domain.AuditEntityTypeRecurringTransaction,
+			EntityID:   transaction.ID,
+			After:      newRecurringTransactionAuditState(transaction),
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return mapToRecurringTransaction(result), nil
+	return transaction, nil
 }
 
 // GetRecurringTransactionByID gets a recurring transaction by ID
@@ -74,6 +93,19 @@ func (r *Repository) GetRecurringTransactionByID(ctx context.Context, id int32)
 	return mapToRecurringTransaction(result), nil
 }
 
+// GetRecurringTransactionIncludingDeleted gets a recurring transaction by ID, even if it was deleted
+func (r *Repository) GetRecurringTransactionIncludingDeleted(ctx context.Context, id int32) (*domain.RecurringTransaction, error) {
+	result, err := r.querier.GetRecurringTransactionIncludingDeleted(ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get recurring transaction: %w", err)
+	}
+
+	return mapToRecurringTransaction(result), nil
+}
+
 // GetRecurringTransactionsByUserID gets all recurring transactions for a user
 func (r *Repository) GetRecurringTransactionsByUserID(ctx context.Context, userID int32) ([]*domain.RecurringTransaction, error) {
 	results, err := r.querier.GetRecurringTransactionsByUserID(ctx, userID)
@@ -89,7 +121,24 @@ func (r *Repository) GetRecurringTransactionsByUserID(ctx context.Context, userI
 	return transactions, nil
 }
 
-// GetActiveRecurringTransactionsDue gets all active recurring transactions due before a specified time
+// GetRecurringTransactionsByWorkspaceID gets all recurring transactions owned by a workspace
+func (r *Repository) GetRecurringTransactionsByWorkspaceID(ctx context.Context, workspaceID int32) ([]*domain.RecurringTransaction, error) {
+	results, err := r.querier.GetRecurringTransactionsByWorkspaceID(ctx, pgtype.Int4{Int32: workspaceID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*domain.RecurringTransaction, len(results))
+	for i, result := range results {
+		transactions[i] = mapToRecurringTransaction(result)
+	}
+
+	return transactions, nil
+}
+
+// GetActiveRecurringTransactionsDue gets all active recurring transactions due before a specified
+// time. The batch is not locked: concurrent runs may list the same transactions, and only the one
+// whose ExecuteRecurringOccurrence claims an occurrence processes it.
 func (r *Repository) GetActiveRecurringTransactionsDue(ctx context.Context, before time.Time) ([]*domain.RecurringTransaction, error) {
 	results, err := r.querier.GetActiveRecurringTransactionsDue(ctx, pgtype.Timestamptz{Time: before, Valid: true})
 	if err != nil {
@@ -188,46 +237,216 @@ func (r *Repository) UpdateRecurringTransaction(ctx context.Context, req domain.
 		}
 	}
 
-	result, err := r.querier.UpdateRecurringTransaction(ctx, params)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, domain.ErrNotFound
+	if req.RRule != nil {
+		params.Rrule = pgtype.Text{
+			String: *req.RRule,
+			Valid:  true,
+		}
+	}
+
+	if req.CatchUpPolicy != nil {
+		params.CatchUpPolicy = pgtype.Text{
+			String: string(*req.CatchUpPolicy),
+			Valid:  true,
+		}
+	}
+
+	if req.PostMode != nil {
+		params.PostMode = pgtype.Text{
+			String: string(*req.PostMode),
+			Valid:  true,
 		}
+	}
+
+	if req.ReminderOffsets != nil {
+		params.ReminderOffsets = int32sFromInts(req.ReminderOffsets)
+	}
+
+	if req.NextDue != nil {
+		params.NextDue = pgtype.Timestamptz{
+			Time:  *req.NextDue,
+			Valid: true,
+		}
+	}
+
+	var transaction *domain.RecurringTransaction
+	err := r.ExecuteTx(ctx, func(repo *Repository) error {
+		before, err := repo.querier.GetRecurringTransactionByID(ctx, req.ID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return domain.ErrNotFound
+			}
+			return err
+		}
+
+		result, err := repo.querier.UpdateRecurringTransaction(ctx, params)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return domain.ErrNotFound
+			}
+			return err
+		}
+
+		transaction = mapToRecurringTransaction(result)
+
+		return repo.createAuditLog(ctx, auditEntry{
+			Actor:      req.Actor,
+			Action:     domain.AuditActionUpdate,
+			EntityType: domain.AuditEntityTypeRecurringTransaction,
+			EntityID:   req.ID,
+			Before:     newRecurringTransactionAuditState(mapToRecurringTransaction(before)),
+			After:      newRecurringTransactionAuditState(transaction),
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return mapToRecurringTransaction(result), nil
+	return transaction, nil
 }
 
-// UpdateRecurringTransactionExecution updates the last executed and next due dates
-func (r *Repository) UpdateRecurringTransactionExecution(ctx context.Context, id int32, lastExecuted, nextDue time.Time) (*domain.RecurringTransaction, error) {
+// ExecuteRecurringOccurrence processes one occurrence of a recurring transaction in a single
+// transaction. The recurring transaction is locked while it is still due at the occurrence, and
+// locked rows are skipped, so that concurrent runs never process the same occurrence twice.
+func (r *Repository) ExecuteRecurringOccurrence(ctx context.Context, req domain.ExecuteRecurringOccurrenceRequest) (*domain.RecurringTransaction, error) {
+	scheduledFor := pgtype.Timestamptz{Time: req.ScheduledFor, Valid: true}
+
 	params := sqlcgen.UpdateRecurringTransactionExecutionParams{
-		ID:           id,
-		LastExecuted: pgtype.Timestamptz{Time: lastExecuted, Valid: true},
-		NextDue:      pgtype.Timestamptz{Time: nextDue, Valid: true},
+		ID:           req.ID,
+		ScheduledFor: scheduledFor,
+		NextDue:      pgtype.Timestamptz{Time: req.NextDue, Valid: true},
+		Status:       string(req.Status),
 	}
 
-	result, err := r.querier.UpdateRecurringTransactionExecution(ctx, params)
-	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, domain.ErrNotFound
+	if req.LastExecuted != nil {
+		params.LastExecuted = pgtype.Timestamptz{Time: *req.LastExecuted, Valid: true}
+	}
+
+	var transaction *domain.RecurringTransaction
+	err := r.ExecuteTx(ctx, func(repo *Repository) error {
+		before, err := repo.querier.ClaimRecurringTransactionOccurrence(ctx, sqlcgen.ClaimRecurringTransactionOccurrenceParams{
+			ID:           req.ID,
+			ScheduledFor: scheduledFor,
+		})
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return domain.ErrNotFound
+			}
+			return fmt.Errorf("failed to claim recurring transaction occurrence: %w", err)
 		}
+
+		status := domain.RecurringExecutionStatusSkipped
+		var ledgerID pgtype.Int4
+		if req.Ledger != nil {
+			id, err := repo.createLedger(*req.Ledger)
+			if err != nil {
+				return err
+			}
+
+			status = domain.RecurringExecutionStatusBooked
+			ledgerID = pgtype.Int4{Int32: id, Valid: true}
+
+			if req.Ledger.IsPending {
+				status = domain.RecurringExecutionStatusPending
+
+				// The user is reminded to confirm the drafted ledger
+				if _, err := repo.querier.CreateReminder(ctx, sqlcgen.CreateReminderParams{
+					RecurringTransactionID: req.ID,
+					ReminderDate:           scheduledFor,
+					LedgerID:               ledgerID,
+				}); err != nil {
+					return fmt.Errorf("failed to create reminder for pending ledger: %w", err)
+				}
+			}
+		}
+
+		_, err = repo.querier.CreateRecurringTransactionExecution(ctx, sqlcgen.CreateRecurringTransactionExecutionParams{
+			RecurringTransactionID: req.ID,
+			ScheduledFor:           scheduledFor,
+			Status:                 string(status),
+			LedgerID:               ledgerID,
+		})
+		if err != nil {
+			// The occurrence was recorded before, its ledger is rolled back
+			if err == pgx.ErrNoRows {
+				return domain.ErrNotFound
+			}
+			return fmt.Errorf("failed to record recurring transaction execution: %w", err)
+		}
+
+		result, err := repo.querier.UpdateRecurringTransactionExecution(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to update recurring transaction execution: %w", err)
+		}
+
+		transaction = mapToRecurringTransaction(result)
+
+		return repo.createAuditLog(ctx, auditEntry{
+			Actor:      req.Actor,
+			Action:     domain.AuditActionUpdate,
+			EntityType: domain.AuditEntityTypeRecurringTransaction,
+			EntityID:   req.ID,
+			Before:     newRecurringTransactionAuditState(mapToRecurringTransaction(before)),
+			After:      newRecurringTransactionAuditState(transaction),
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return mapToRecurringTransaction(result), nil
+	return transaction, nil
 }
 
-// DeleteRecurringTransaction implements the domain.RecurringTransactionRepository interface
-// This method performs a soft delete by setting the deleted_at timestamp and status to cancelled.
-func (r *Repository) DeleteRecurringTransaction(ctx context.Context, id int32) error {
-	// The SQL query now sets deleted_at and status = 'cancelled'.
-	_, err := r.querier.DeleteRecurringTransaction(ctx, id)
+// GetRecurringTransactionExecutions gets the processed occurrences of a recurring transaction, newest first
+func (r *Repository) GetRecurringTransactionExecutions(ctx context.Context, recurringTransactionID int32) ([]*domain.RecurringTransactionExecution, error) {
+	results, err := r.querier.GetRecurringTransactionExecutions(ctx, recurringTransactionID)
 	if err != nil {
-		// It's good practice to wrap errors for context
-		return fmt.Errorf("failed to soft delete recurring transaction: %w", err)
+		return nil, err
 	}
-	return nil
+
+	executions := make([]*domain.RecurringTransactionExecution, len(results))
+	for i, result := range results {
+		executions[i] = &domain.RecurringTransactionExecution{
+			ID:                     result.ID,
+			CreatedAt:              result.CreatedAt.Time,
+			RecurringTransactionID: result.RecurringTransactionID,
+			ScheduledFor:           result.ScheduledFor.Time,
+			Status:                 domain.RecurringExecutionStatus(result.Status),
+			LedgerID:               int4ToPtr(result.LedgerID),
+		}
+	}
+
+	return executions, nil
+}
+
+// DeleteRecurringTransaction implements the domain.RecurringTransactionRepository interface
+// This method performs a soft delete by setting the deleted_at timestamp and status to cancelled.
+func (r *Repository) DeleteRecurringTransaction(ctx context.Context, id int32, actor domain.AuditActor) error {
+	return r.ExecuteTx(ctx, func(repo *Repository) error {
+		before, err := repo.querier.GetRecurringTransactionByID(ctx, id)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return domain.ErrNotFound
+			}
+			return fmt.Errorf("failed to get recurring transaction for soft deletion: %w", err)
+		}
+
+		// The SQL query now sets deleted_at and status = 'cancelled'.
+		result, err := repo.querier.DeleteRecurringTransaction(ctx, id)
+		if err != nil {
+			// It's good practice to wrap errors for context
+			return fmt.Errorf("failed to soft delete recurring transaction: %w", err)
+		}
+
+		return repo.createAuditLog(ctx, auditEntry{
+			Actor:      actor,
+			Action:     domain.AuditActionDelete,
+			EntityType: domain.AuditEntityTypeRecurringTransaction,
+			EntityID:   id,
+			Before:     newRecurringTransactionAuditState(mapToRecurringTransaction(before)),
+			After:      newRecurringTransactionAuditState(mapToRecurringTransaction(result)),
+		})
+	})
 }
 
 // Helper functions
@@ -259,122 +478,29 @@ func mapToRecurringTransaction(rt sqlcgen.RecurringTransaction) *domain.Recurrin
 	}
 
 	return &domain.RecurringTransaction{
-		ID:           rt.ID,
-		CreatedAt:    rt.CreatedAt.Time,
-		UpdatedAt:    rt.UpdatedAt.Time,
-		UserID:       rt.UserID,
-		AccountID:    rt.AccountID,
-		Name:         rt.Name,
-		Type:         domain.LedgerType(rt.Type),
-		Amount:       rt.Amount,
-		Note:         rt.Note.String,
-		StartDate:    rt.StartDate.Time,
-		EndDate:      endDate,
-		RecurType:    domain.RecurrenceType(rt.RecurType),
-		Status:       domain.RecurrenceStatus(rt.Status),
-		Frequency:    int(rt.Frequency),
-		DayOfWeek:    dayOfWeek,
-		DayOfMonth:   dayOfMonth,
-		MonthOfYear:  monthOfYear,
-		LastExecuted: lastExecuted,
-		NextDue:      rt.NextDue.Time,
-	}
-}
-
-func calculateNextDueDate(startDate time.Time, recurType domain.RecurrenceType, frequency int, dayOfWeek, dayOfMonth, monthOfYear *int) time.Time {
-	now := time.Now()
-
-	if startDate.After(now) {
-		return startDate
+		ID:              rt.ID,
+		CreatedAt:       rt.CreatedAt.Time,
+		UpdatedAt:       rt.UpdatedAt.Time,
+		UserID:          rt.UserID,
+		AccountID:       rt.AccountID,
+		Name:            rt.Name,
+		Type:            domain.LedgerType(rt.Type),
+		Amount:          rt.Amount,
+		Note:            rt.Note.String,
+		StartDate:       rt.StartDate.Time,
+		EndDate:         endDate,
+		RecurType:       domain.RecurrenceType(rt.RecurType),
+		Status:          domain.RecurrenceStatus(rt.Status),
+		Frequency:       int(rt.Frequency),
+		DayOfWeek:       dayOfWeek,
+		DayOfMonth:      dayOfMonth,
+		MonthOfYear:     monthOfYear,
+		RRule:           rt.Rrule,
+		CatchUpPolicy:   domain.CatchUpPolicy(rt.CatchUpPolicy),
+		PostMode:        domain.PostMode(rt.PostMode),
+		ReminderOffsets: int32sToInts(rt.ReminderOffsets),
+		LastExecuted:    lastExecuted,
+		NextDue:         rt.NextDue.Time,
+		WorkspaceID:     int4ToPtr(rt.WorkspaceID),
 	}
-
-	switch recurType {
-	case domain.RecurrenceTypeDaily:
-		return now.AddDate(0, 0, frequency)
-
-	case domain.RecurrenceTypeWeekly:
-		daysToAdd := frequency * 7
-		if dayOfWeek != nil {
-			currentDOW := int(now.Weekday())
-			targetDOW := *dayOfWeek
-
-			daysToAdd = (targetDOW - currentDOW + 7) % 7
-			if daysToAdd == 0 && frequency > 0 {
-				daysToAdd = 7 * frequency
-			}
-		}
-		return now.AddDate(0, 0, daysToAdd)
-
-	case domain.RecurrenceTypeBiweekly:
-		return now.AddDate(0, 0, 14*frequency)
-
-	case domain.RecurrenceTypeMonthly:
-		nextDate := now.AddDate(0, frequency, 0)
-
-		if dayOfMonth != nil {
-			targetDay := *dayOfMonth
-			if targetDay > 28 {
-				daysInMonth := daysInMonth(nextDate.Year(), int(nextDate.Month()))
-				if targetDay > daysInMonth {
-					targetDay = daysInMonth
-				}
-			}
-
-			nextDate = time.Date(nextDate.Year(), nextDate.Month(), targetDay,
-				nextDate.Hour(), nextDate.Minute(), nextDate.Second(),
-				nextDate.Nanosecond(), nextDate.Location())
-		}
-
-		return nextDate
-
-	case domain.RecurrenceTypeQuarterly:
-		nextDate := now.AddDate(0, 3*frequency, 0)
-
-		if dayOfMonth != nil {
-			targetDay := *dayOfMonth
-			if targetDay > 28 {
-				daysInMonth := daysInMonth(nextDate.Year(), int(nextDate.Month()))
-				if targetDay > daysInMonth {
-					targetDay = daysInMonth
-				}
-			}
-
-			nextDate = time.Date(nextDate.Year(), nextDate.Month(), targetDay,
-				nextDate.Hour(), nextDate.Minute(), nextDate.Second(),
-				nextDate.Nanosecond(), nextDate.Location())
-		}
-
-		return nextDate
-
-	case domain.RecurrenceTypeYearly:
-		nextDate := now.AddDate(frequency, 0, 0)
-
-		if monthOfYear != nil && dayOfMonth != nil {
-			targetMonth := time.Month(*monthOfYear)
-			targetDay := *dayOfMonth
-
-			if targetDay > 28 {
-				daysInMonth := daysInMonth(nextDate.Year(), *monthOfYear)
-				if targetDay > daysInMonth {
-					targetDay = daysInMonth
-				}
-			}
-
-			nextDate = time.Date(nextDate.Year(), targetMonth, targetDay,
-				nextDate.Hour(), nextDate.Minute(), nextDate.Second(),
-				nextDate.Nanosecond(), nextDate.Location())
-		}
-
-		return nextDate
-
-	case domain.RecurrenceTypeCustom:
-		return now.AddDate(0, 0, frequency)
-
-	default:
-		return now.AddDate(0, 1, 0)
-	}
-}
-
-func daysInMonth(year, month int) int {
-	return time.Date(year, time.Month(month+1), 0, 0, 0, 0, 0, time.UTC).Day()
 }
@@ -83,6 +83,22 @@ func (r *Repository) MarkReminderAsRead(ctx context.Context, id int32) (*domain.
 	return mapToReminder(result), nil
 }
 
+// SnoozeReminder moves an unread reminder to a later date
+func (r *Repository) SnoozeReminder(ctx context.Context, id int32, until time.Time) (*domain.Reminder, error) {
+	result, err := r.querier.SnoozeReminder(ctx, sqlcgen.SnoozeReminderParams{
+		ID:           id,
+		ReminderDate: pgtype.Timestamptz{Time: until, Valid: true},
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to snooze reminder: %w", err)
+	}
+
+	return mapToReminder(result), nil
+}
+
 // DeleteReminder implements the domain.ReminderRepository interface for soft delete
 func (r *Repository) DeleteReminder(ctx context.Context, id int32) error {
 	if _, err := r.querier.DeleteReminder(ctx, id); err != nil {
@@ -91,6 +107,43 @@ func (r *Repository) DeleteReminder(ctx context.Context, id int32) error {
 	return nil
 }
 
+// DeleteUpcomingReminders implements the domain.ReminderRepository interface
+func (r *Repository) DeleteUpcomingReminders(ctx context.Context, recurringTransactionID int32, after time.Time) error {
+	if err := r.querier.DeleteUpcomingReminders(ctx, sqlcgen.DeleteUpcomingRemindersParams{
+		RecurringTransactionID: recurringTransactionID,
+		ReminderDate:           pgtype.Timestamptz{Time: after, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to delete upcoming reminders: %w", err)
+	}
+	return nil
+}
+
+// GetReminderSettings gets the reminder preferences of a user
+func (r *Repository) GetReminderSettings(ctx context.Context, userID int32) (*domain.ReminderSettings, error) {
+	result, err := r.querier.GetReminderSettingsByUserID(ctx, userID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get reminder settings: %w", err)
+	}
+
+	return mapToReminderSettings(result), nil
+}
+
+// UpdateReminderSettings sets the reminder preferences of a user
+func (r *Repository) UpdateReminderSettings(ctx context.Context, settings domain.ReminderSettings) (*domain.ReminderSettings, error) {
+	result, err := r.querier.UpsertReminderSettings(ctx, sqlcgen.UpsertReminderSettingsParams{
+		UserID:         settings.UserID,
+		DefaultOffsets: int32sFromInts(settings.DefaultOffsets),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reminder settings: %w", err)
+	}
+
+	return mapToReminderSettings(result), nil
+}
+
 // GetUpcomingReminders gets upcoming reminders for a user within a date range
 func (r *Repository) GetUpcomingReminders(ctx context.Context, userID int32, start, end time.Time) ([]*domain.Reminder, error) {
 	results, err := r.querier.GetUpcomingReminders(ctx, sqlcgen.GetUpcomingRemindersParams{
@@ -117,6 +170,7 @@ func (r *Repository) GetUpcomingReminders(ctx context.Context, userID int32, sta
 			ReminderDate:           result.ReminderDate.Time,
 			IsRead:                 result.IsRead,
 			ReadAt:                 readAt,
+			LedgerID:               int4ToPtr(result.LedgerID),
 		}
 	}
 
@@ -138,5 +192,13 @@ func mapToReminder(r sqlcgen.Reminder) *domain.Reminder {
 		ReminderDate:           r.ReminderDate.Time,
 		IsRead:                 r.IsRead,
 		ReadAt:                 readAt,
+		LedgerID:               int4ToPtr(r.LedgerID),
+	}
+}
+
+func mapToReminderSettings(s sqlcgen.ReminderSetting) *domain.ReminderSettings {
+	return &domain.ReminderSettings{
+		UserID:         s.UserID,
+		DefaultOffsets: int32sToInts(s.DefaultOffsets),
 	}
 }
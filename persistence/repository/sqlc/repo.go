@@ -12,10 +12,26 @@ import (
 )
 
 var (
-	_ domain.AccountRepository     = (*Repository)(nil)
-	_ domain.LedgerRepository      = (*Repository)(nil)
-	_ domain.UserRepository        = (*Repository)(nil)
-	_ domain.BankAccountRepository = (*Repository)(nil)
+	_ domain.AccountRepository                = (*Repository)(nil)
+	_ domain.LedgerRepository                 = (*Repository)(nil)
+	_ domain.UserRepository                   = (*Repository)(nil)
+	_ domain.BankAccountRepository            = (*Repository)(nil)
+	_ domain.PayeeRepository                  = (*Repository)(nil)
+	_ domain.AccountMemberRepository          = (*Repository)(nil)
+	_ domain.WorkspaceRepository              = (*Repository)(nil)
+	_ domain.SessionRepository                = (*Repository)(nil)
+	_ domain.PasswordResetTokenRepository     = (*Repository)(nil)
+	_ domain.EmailVerificationTokenRepository = (*Repository)(nil)
+	_ domain.TwoFactorRepository              = (*Repository)(nil)
+	_ domain.OIDCAuthRequestRepository        = (*Repository)(nil)
+	_ domain.APIKeyRepository                 = (*Repository)(nil)
+	_ domain.LoginAttemptRepository           = (*Repository)(nil)
+	_ domain.AuthEventRepository              = (*Repository)(nil)
+	_ domain.AuditLogRepository               = (*Repository)(nil)
+	_ domain.BooksLockRepository              = (*Repository)(nil)
+	_ domain.NotificationRepository           = (*Repository)(nil)
+	_ domain.CronJobRepository                = (*Repository)(nil)
+	_ domain.DigestRepository                 = (*Repository)(nil)
 )
 
 // Repository implements repository interfaces using SQLC-generated code
@@ -51,7 +67,7 @@ func (r *Repository) ExecuteTx(ctx context.Context, fn func(repo *Repository) er
 	}
 
 	txRepo := r.WithTx(tx)
-	
+
 	if err := fn(txRepo); err != nil {
 		if rbErr := tx.Rollback(ctx); rbErr != nil {
 			return fmt.Errorf("tx failed: %v, rollback failed: %v", err, rbErr)
@@ -64,4 +80,4 @@ func (r *Repository) ExecuteTx(ctx context.Context, fn func(repo *Repository) er
 	}
 
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,139 @@
+package sqlc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// CreateSession implements the domain.SessionRepository interface
+func (r *Repository) CreateSession(req domain.CreateSessionRequest) (*domain.Session, error) {
+	session, err := r.querier.CreateSession(r.ctx, sqlcgen.CreateSessionParams{
+		UserID:           req.UserID,
+		Provider:         string(req.Provider),
+		RefreshTokenHash: req.RefreshTokenHash,
+		UserAgent:        req.UserAgent,
+		IpAddress:        req.IPAddress,
+		ExpiresAt:        pgtype.Timestamptz{Time: req.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return mapToSession(session), nil
+}
+
+// GetSessionByID implements the domain.SessionRepository interface
+func (r *Repository) GetSessionByID(id int32) (*domain.Session, error) {
+	session, err := r.querier.GetSessionByID(r.ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return mapToSession(session), nil
+}
+
+// GetSessionByRefreshTokenHash implements the domain.SessionRepository interface.
+// It matches both the current and the previously rotated refresh token.
+func (r *Repository) GetSessionByRefreshTokenHash(hash string) (*domain.Session, error) {
+	session, err := r.querier.GetSessionByRefreshTokenHash(r.ctx, hash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get session by refresh token: %w", err)
+	}
+
+	return mapToSession(session), nil
+}
+
+// GetActiveSessionsByUserID implements the domain.SessionRepository interface
+func (r *Repository) GetActiveSessionsByUserID(userID int32) ([]*domain.Session, error) {
+	sessions, err := r.querier.GetActiveSessionsByUserID(r.ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	domainSessions := make([]*domain.Session, len(sessions))
+	for i, session := range sessions {
+		domainSessions[i] = mapToSession(session)
+	}
+
+	return domainSessions, nil
+}
+
+// RotateSessionRefreshToken implements the domain.SessionRepository interface.
+// It returns domain.ErrNotFound when the current refresh token has already been rotated.
+func (r *Repository) RotateSessionRefreshToken(req domain.RotateSessionRequest) (*domain.Session, error) {
+	session, err := r.querier.RotateSessionRefreshToken(r.ctx, sqlcgen.RotateSessionRefreshTokenParams{
+		RefreshTokenHash:        req.RefreshTokenHash,
+		ExpiresAt:               pgtype.Timestamptz{Time: req.ExpiresAt, Valid: true},
+		ID:                      req.ID,
+		CurrentRefreshTokenHash: req.CurrentRefreshTokenHash,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to rotate session refresh token: %w", err)
+	}
+
+	return mapToSession(session), nil
+}
+
+// RevokeSession implements the domain.SessionRepository interface
+func (r *Repository) RevokeSession(id int32) error {
+	affected, err := r.querier.RevokeSession(r.ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// RevokeSessionsByUserID implements the domain.SessionRepository interface.
+// A zero exceptSessionID revokes every session of the user.
+func (r *Repository) RevokeSessionsByUserID(userID, exceptSessionID int32) error {
+	if err := r.querier.RevokeSessionsByUserID(r.ctx, sqlcgen.RevokeSessionsByUserIDParams{
+		UserID:   userID,
+		ExceptID: exceptSessionID,
+	}); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
+
+func mapToSession(session sqlcgen.Session) *domain.Session {
+	var revokedAt *time.Time
+	if session.RevokedAt.Valid {
+		revokedAt = &session.RevokedAt.Time
+	}
+
+	return &domain.Session{
+		ID:                       session.ID,
+		CreatedAt:                session.CreatedAt.Time,
+		UpdatedAt:                session.UpdatedAt.Time,
+		UserID:                   session.UserID,
+		Provider:                 domain.IdentityProvider(session.Provider),
+		RefreshTokenHash:         session.RefreshTokenHash,
+		PreviousRefreshTokenHash: session.PreviousRefreshTokenHash.String,
+		UserAgent:                session.UserAgent,
+		IPAddress:                session.IpAddress,
+		LastUsedAt:               session.LastUsedAt.Time,
+		ExpiresAt:                session.ExpiresAt.Time,
+		RevokedAt:                revokedAt,
+	}
+}
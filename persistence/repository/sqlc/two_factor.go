@@ -0,0 +1,213 @@
+package sqlc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// UpsertTOTPSecret implements the domain.TwoFactorRepository interface
+func (r *Repository) UpsertTOTPSecret(userID int32, secret string) error {
+	if err := r.querier.UpsertTOTPSecret(r.ctx, sqlcgen.UpsertTOTPSecretParams{
+		UserID: userID,
+		Secret: secret,
+	}); err != nil {
+		return fmt.Errorf("failed to upsert totp secret: %w", err)
+	}
+
+	return nil
+}
+
+// GetTOTPSecretByUserID implements the domain.TwoFactorRepository interface
+func (r *Repository) GetTOTPSecretByUserID(userID int32) (*domain.TOTPSecret, error) {
+	secret, err := r.querier.GetTOTPSecretByUserID(r.ctx, userID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get totp secret: %w", err)
+	}
+
+	var confirmedAt *time.Time
+	if secret.ConfirmedAt.Valid {
+		confirmedAt = &secret.ConfirmedAt.Time
+	}
+
+	return &domain.TOTPSecret{
+		UserID:       secret.UserID,
+		CreatedAt:    secret.CreatedAt.Time,
+		Secret:       secret.Secret,
+		ConfirmedAt:  confirmedAt,
+		LastUsedStep: secret.LastUsedStep,
+	}, nil
+}
+
+// ConfirmTOTPSecret implements the domain.TwoFactorRepository interface
+func (r *Repository) ConfirmTOTPSecret(userID int32) error {
+	rows, err := r.querier.ConfirmTOTPSecret(r.ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to confirm totp secret: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateTOTPLastUsedStep implements the domain.TwoFactorRepository interface
+func (r *Repository) UpdateTOTPLastUsedStep(userID int32, step int64) error {
+	rows, err := r.querier.UpdateTOTPLastUsedStep(r.ctx, sqlcgen.UpdateTOTPLastUsedStepParams{
+		UserID:       userID,
+		LastUsedStep: step,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update totp last used step: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// DisableTwoFactor implements the domain.TwoFactorRepository interface
+func (r *Repository) DisableTwoFactor(userID int32) error {
+	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
+		if err := repo.querier.DeleteTOTPSecret(repo.ctx, userID); err != nil {
+			return fmt.Errorf("failed to delete totp secret: %w", err)
+		}
+
+		if err := repo.querier.DeleteRecoveryCodesByUserID(repo.ctx, userID); err != nil {
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ReplaceRecoveryCodes implements the domain.TwoFactorRepository interface
+func (r *Repository) ReplaceRecoveryCodes(userID int32, codeHashes []string) error {
+	return r.ExecuteTx(r.ctx, func(repo *Repository) error {
+		if err := repo.querier.DeleteRecoveryCodesByUserID(repo.ctx, userID); err != nil {
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+
+		for _, codeHash := range codeHashes {
+			if err := repo.querier.CreateRecoveryCode(repo.ctx, sqlcgen.CreateRecoveryCodeParams{
+				UserID:   userID,
+				CodeHash: codeHash,
+			}); err != nil {
+				return fmt.Errorf("failed to create recovery code: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// UseRecoveryCode implements the domain.TwoFactorRepository interface
+func (r *Repository) UseRecoveryCode(userID int32, codeHash string) error {
+	rows, err := r.querier.UseRecoveryCode(r.ctx, sqlcgen.UseRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// CountUnusedRecoveryCodes implements the domain.TwoFactorRepository interface
+func (r *Repository) CountUnusedRecoveryCodes(userID int32) (int, error) {
+	count, err := r.querier.CountUnusedRecoveryCodes(r.ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+
+	return int(count), nil
+}
+
+// CreateTwoFactorChallenge implements the domain.TwoFactorRepository interface
+func (r *Repository) CreateTwoFactorChallenge(req domain.CreateTwoFactorChallengeRequest) (*domain.TwoFactorChallenge, error) {
+	challenge, err := r.querier.CreateTwoFactorChallenge(r.ctx, sqlcgen.CreateTwoFactorChallengeParams{
+		UserID:     req.UserID,
+		Provider:   string(req.Provider),
+		Identifier: req.Identifier,
+		TokenHash:  req.TokenHash,
+		ExpiresAt:  pgtype.Timestamptz{Time: req.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create two-factor challenge: %w", err)
+	}
+
+	return mapToTwoFactorChallenge(challenge), nil
+}
+
+// GetTwoFactorChallengeByTokenHash implements the domain.TwoFactorRepository interface.
+// Used and expired challenges are reported as not found.
+func (r *Repository) GetTwoFactorChallengeByTokenHash(tokenHash string) (*domain.TwoFactorChallenge, error) {
+	challenge, err := r.querier.GetTwoFactorChallengeByTokenHash(r.ctx, tokenHash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get two-factor challenge: %w", err)
+	}
+
+	return mapToTwoFactorChallenge(challenge), nil
+}
+
+// IncrementTwoFactorChallengeAttempts implements the domain.TwoFactorRepository interface.
+// The attempt is counted in the same statement that checks the limit, so concurrent attempts
+// cannot exceed it.
+func (r *Repository) IncrementTwoFactorChallengeAttempts(id, maxAttempts int32) error {
+	if _, err := r.querier.IncrementTwoFactorChallengeAttempts(r.ctx, sqlcgen.IncrementTwoFactorChallengeAttemptsParams{
+		ID:          id,
+		MaxAttempts: maxAttempts,
+	}); err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to increment two-factor challenge attempts: %w", err)
+	}
+
+	return nil
+}
+
+// UseTwoFactorChallenge implements the domain.TwoFactorRepository interface
+func (r *Repository) UseTwoFactorChallenge(id int32) error {
+	rows, err := r.querier.UseTwoFactorChallenge(r.ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to use two-factor challenge: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func mapToTwoFactorChallenge(challenge sqlcgen.TwoFactorChallenge) *domain.TwoFactorChallenge {
+	var usedAt *time.Time
+	if challenge.UsedAt.Valid {
+		usedAt = &challenge.UsedAt.Time
+	}
+
+	return &domain.TwoFactorChallenge{
+		ID:         challenge.ID,
+		CreatedAt:  challenge.CreatedAt.Time,
+		UserID:     challenge.UserID,
+		Provider:   domain.IdentityProvider(challenge.Provider),
+		Identifier: challenge.Identifier,
+		TokenHash:  challenge.TokenHash,
+		Attempts:   challenge.Attempts,
+		ExpiresAt:  challenge.ExpiresAt.Time,
+		UsedAt:     usedAt,
+	}
+}
@@ -2,6 +2,7 @@ package sqlc
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -17,14 +18,21 @@ func convertToDomainUser(user sqlcgen.User) *domain.User {
 		Disabled:  user.Disabled,
 		Name:      user.Name,
 		Nickname:  user.Nickname.String,
+		Role:      domain.UserRole(user.Role),
 	}
 }
 
 // CreateUser implements the domain.UserRepository interface
 func (r *Repository) CreateUser(req domain.CreateUserRequest) (int32, error) {
+	role := req.Role
+	if role == "" {
+		role = domain.UserRoleUser
+	}
+
 	user, err := r.querier.CreateUser(r.ctx, sqlcgen.CreateUserParams{
 		Name:     req.Name,
 		Nickname: pgtype.Text{String: req.Nickname, Valid: req.Nickname != ""},
+		Role:     role.String(),
 	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to create user: %w", err)
@@ -57,14 +65,7 @@ func (r *Repository) GetUserByID(id int32) (*domain.User, error) {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	return &domain.User{
-		ID:        user.ID,
-		CreatedAt: user.CreatedAt.Time,
-		UpdatedAt: user.UpdatedAt.Time,
-		Disabled:  user.Disabled,
-		Name:      user.Name,
-		Nickname:  user.Nickname.String,
-	}, nil
+	return convertToDomainUser(user), nil
 }
 
 // UpdateUser implements the domain.UserRepository interface
@@ -93,6 +94,13 @@ func (r *Repository) UpdateUser(req domain.UpdateUserRequest) error {
 		}
 	}
 
+	if req.Role != nil {
+		params.Role = pgtype.Text{
+			String: req.Role.String(),
+			Valid:  true,
+		}
+	}
+
 	if _, err := r.querier.UpdateUser(r.ctx, params); err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
@@ -113,17 +121,94 @@ func (r *Repository) DeactivateUserByID(id int32) error {
 
 // AddIdentity implements the domain.UserRepository interface
 func (r *Repository) AddIdentity(userID int32, provider domain.Identity) error {
+	var verifiedAt pgtype.Timestamptz
+	if provider.VerifiedAt != nil {
+		verifiedAt = pgtype.Timestamptz{Time: *provider.VerifiedAt, Valid: true}
+	}
+
 	if _, err := r.querier.AddIdentity(r.ctx, sqlcgen.AddIdentityParams{
 		UserID:     userID,
 		Provider:   string(provider.Provider),
 		Identifier: provider.Identifier,
 		Credential: provider.Credential,
+		VerifiedAt: verifiedAt,
 	}); err != nil {
 		return fmt.Errorf("failed to add identity: %w", err)
 	}
 	return nil
 }
 
+// GetIdentitiesByUserID implements the domain.UserRepository interface
+func (r *Repository) GetIdentitiesByUserID(userID int32) ([]*domain.Identity, error) {
+	identities, err := r.querier.GetIdentitiesByUserID(r.ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identities: %w", err)
+	}
+
+	result := make([]*domain.Identity, len(identities))
+	for i, identity := range identities {
+		var verifiedAt *time.Time
+		if identity.VerifiedAt.Valid {
+			verifiedAt = &identity.VerifiedAt.Time
+		}
+
+		result[i] = &domain.Identity{
+			Provider:   domain.IdentityProvider(identity.Provider),
+			Identifier: identity.Identifier,
+			Credential: identity.Credential,
+			LastUsedAt: identity.LastUsedAt.Time,
+			VerifiedAt: verifiedAt,
+		}
+	}
+
+	return result, nil
+}
+
+// UpdateIdentityCredential implements the domain.UserRepository interface
+func (r *Repository) UpdateIdentityCredential(provider domain.IdentityProvider, identifier, credential string) error {
+	if _, err := r.querier.UpdateIdentityCredential(r.ctx, sqlcgen.UpdateIdentityCredentialParams{
+		Provider:   string(provider),
+		Identifier: identifier,
+		Credential: credential,
+	}); err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to update identity credential: %w", err)
+	}
+	return nil
+}
+
+// UpdateIdentityLastUsed implements the domain.UserRepository interface
+func (r *Repository) UpdateIdentityLastUsed(provider domain.IdentityProvider, identifier string) error {
+	if _, err := r.querier.UpdateIdentityLastUsed(r.ctx, sqlcgen.UpdateIdentityLastUsedParams{
+		Provider:   string(provider),
+		Identifier: identifier,
+	}); err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to update identity last used: %w", err)
+	}
+	return nil
+}
+
+// VerifyIdentity implements the domain.UserRepository interface.
+// Identities that are already verified are reported as not found.
+func (r *Repository) VerifyIdentity(provider domain.IdentityProvider, identifier string) error {
+	rows, err := r.querier.VerifyIdentity(r.ctx, sqlcgen.VerifyIdentityParams{
+		Provider:   string(provider),
+		Identifier: identifier,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to verify identity: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
 // DeleteUser implements the domain.UserRepository interface for soft delete
 func (r *Repository) DeleteUser(id int32) error {
 	if _, err := r.querier.DeleteUser(r.ctx, id); err != nil {
@@ -140,7 +225,7 @@ func (r *Repository) GetUserByIdentity(provider domain.IdentityProvider, identif
 	})
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, nil, fmt.Errorf("user or identity not found: %w", err)
+			return nil, nil, domain.ErrNotFound
 		}
 		return nil, nil, fmt.Errorf("failed to get user by identity: %w", err)
 	}
@@ -152,6 +237,12 @@ func (r *Repository) GetUserByIdentity(provider domain.IdentityProvider, identif
 		Disabled:  row.UserDisabled,
 		Name:      row.UserName,
 		Nickname:  row.UserNickname.String,
+		Role:      domain.UserRole(row.UserRole),
+	}
+
+	var verifiedAt *time.Time
+	if row.IdentityVerifiedAt.Valid {
+		verifiedAt = &row.IdentityVerifiedAt.Time
 	}
 
 	identity := &domain.Identity{
@@ -159,6 +250,7 @@ func (r *Repository) GetUserByIdentity(provider domain.IdentityProvider, identif
 		Identifier: row.IdentityIdentifier,
 		Credential: row.IdentityCredential,
 		LastUsedAt: row.IdentityLastUsedAt.Time,
+		VerifiedAt: verifiedAt,
 	}
 
 	return user, identity, nil
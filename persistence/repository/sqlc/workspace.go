@@ -0,0 +1,227 @@
+package sqlc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/persistence/sqlcgen"
+)
+
+// CreateWorkspace implements the domain.WorkspaceRepository interface.
+// The workspace and its owner membership are created in a single transaction.
+func (r *Repository) CreateWorkspace(req domain.CreateWorkspaceRequest) (*domain.Workspace, error) {
+	var workspace sqlcgen.Workspace
+	err := r.ExecuteTx(context.Background(), func(repo *Repository) error {
+		var err error
+		workspace, err = repo.querier.CreateWorkspace(repo.ctx, sqlcgen.CreateWorkspaceParams{
+			Name: req.Name,
+			Type: req.Type.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create workspace: %w", err)
+		}
+
+		if _, err := repo.querier.UpsertWorkspaceMember(repo.ctx, sqlcgen.UpsertWorkspaceMemberParams{
+			WorkspaceID: workspace.ID,
+			UserID:      req.OwnerID,
+			Role:        domain.WorkspaceRoleOwner.String(),
+		}); err != nil {
+			return fmt.Errorf("failed to add workspace owner: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToWorkspace(workspace), nil
+}
+
+// GetWorkspaceByID implements the domain.WorkspaceRepository interface
+func (r *Repository) GetWorkspaceByID(id int32) (*domain.Workspace, error) {
+	workspace, err := r.querier.GetWorkspaceByID(r.ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	return mapToWorkspace(workspace), nil
+}
+
+// GetWorkspacesByUserID implements the domain.WorkspaceRepository interface
+func (r *Repository) GetWorkspacesByUserID(userID int32) ([]*domain.MemberWorkspace, error) {
+	rows, err := r.querier.GetWorkspacesByUserID(r.ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspaces by user: %w", err)
+	}
+
+	workspaces := make([]*domain.MemberWorkspace, len(rows))
+	for i, row := range rows {
+		workspaces[i] = &domain.MemberWorkspace{
+			Workspace: &domain.Workspace{
+				ID:        row.ID,
+				CreatedAt: row.CreatedAt.Time,
+				UpdatedAt: row.UpdatedAt.Time,
+				Name:      row.Name,
+				Type:      domain.WorkspaceType(row.Type),
+			},
+			Role: domain.WorkspaceRole(row.MemberRole),
+		}
+	}
+
+	return workspaces, nil
+}
+
+// UpdateWorkspace implements the domain.WorkspaceRepository interface
+func (r *Repository) UpdateWorkspace(req domain.UpdateWorkspaceRequest) (*domain.Workspace, error) {
+	params := sqlcgen.UpdateWorkspaceParams{
+		ID: req.ID,
+	}
+
+	if req.Name != nil {
+		params.Name = pgtype.Text{String: *req.Name, Valid: true}
+	}
+
+	if req.Type != nil {
+		params.Type = pgtype.Text{String: req.Type.String(), Valid: true}
+	}
+
+	workspace, err := r.querier.UpdateWorkspace(r.ctx, params)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update workspace: %w", err)
+	}
+
+	return mapToWorkspace(workspace), nil
+}
+
+// DeleteWorkspace implements the domain.WorkspaceRepository interface.
+// This method performs a soft delete by setting the deleted_at timestamp.
+func (r *Repository) DeleteWorkspace(id int32) error {
+	_, err := r.querier.DeleteWorkspace(r.ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("failed to delete workspace: %w", err)
+	}
+
+	return nil
+}
+
+// GetWorkspaceMember implements the domain.WorkspaceRepository interface
+func (r *Repository) GetWorkspaceMember(workspaceID, userID int32) (*domain.WorkspaceMember, error) {
+	member, err := r.querier.GetWorkspaceMember(r.ctx, sqlcgen.GetWorkspaceMemberParams{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get workspace member: %w", err)
+	}
+
+	return mapToWorkspaceMember(member), nil
+}
+
+// GetWorkspaceMembersByWorkspaceID implements the domain.WorkspaceRepository interface
+func (r *Repository) GetWorkspaceMembersByWorkspaceID(workspaceID int32) ([]*domain.WorkspaceMember, error) {
+	members, err := r.querier.GetWorkspaceMembersByWorkspaceID(r.ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace members: %w", err)
+	}
+
+	domainMembers := make([]*domain.WorkspaceMember, len(members))
+	for i, member := range members {
+		domainMembers[i] = mapToWorkspaceMember(member)
+	}
+
+	return domainMembers, nil
+}
+
+// AddWorkspaceMemberByEmail implements the domain.WorkspaceRepository interface.
+// It returns domain.ErrNotFound when no user has an identity with the email.
+func (r *Repository) AddWorkspaceMemberByEmail(workspaceID int32, email string, role domain.WorkspaceRole) (*domain.WorkspaceMember, error) {
+	member, err := r.querier.AddWorkspaceMemberByEmail(r.ctx, sqlcgen.AddWorkspaceMemberByEmailParams{
+		WorkspaceID: workspaceID,
+		Role:        role.String(),
+		Email:       email,
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to add workspace member: %w", err)
+	}
+
+	return mapToWorkspaceMember(member), nil
+}
+
+// UpsertWorkspaceMember implements the domain.WorkspaceRepository interface
+func (r *Repository) UpsertWorkspaceMember(workspaceID, userID int32, role domain.WorkspaceRole) error {
+	_, err := r.querier.UpsertWorkspaceMember(r.ctx, sqlcgen.UpsertWorkspaceMemberParams{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Role:        role.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert workspace member: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteWorkspaceMember implements the domain.WorkspaceRepository interface
+func (r *Repository) DeleteWorkspaceMember(workspaceID, userID int32) error {
+	affected, err := r.querier.DeleteWorkspaceMember(r.ctx, sqlcgen.DeleteWorkspaceMemberParams{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace member: %w", err)
+	}
+
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func mapToWorkspace(workspace sqlcgen.Workspace) *domain.Workspace {
+	var deletedAt *time.Time
+	if workspace.DeletedAt.Valid {
+		deletedAt = &workspace.DeletedAt.Time
+	}
+
+	return &domain.Workspace{
+		ID:        workspace.ID,
+		CreatedAt: workspace.CreatedAt.Time,
+		UpdatedAt: workspace.UpdatedAt.Time,
+		Name:      workspace.Name,
+		Type:      domain.WorkspaceType(workspace.Type),
+		DeletedAt: deletedAt,
+	}
+}
+
+func mapToWorkspaceMember(member sqlcgen.WorkspaceMember) *domain.WorkspaceMember {
+	return &domain.WorkspaceMember{
+		ID:          member.ID,
+		CreatedAt:   member.CreatedAt.Time,
+		UpdatedAt:   member.UpdatedAt.Time,
+		WorkspaceID: member.WorkspaceID,
+		UserID:      member.UserID,
+		Role:        domain.WorkspaceRole(member.Role),
+	}
+}
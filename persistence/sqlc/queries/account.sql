@@ -4,9 +4,10 @@ INSERT INTO accounts (
     name,
     currency,
     status,
-    balance
+    balance,
+    workspace_id
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetAccountByID :one
@@ -21,6 +22,8 @@ SET
     name = CASE WHEN sqlc.narg('name')::text IS NULL THEN name ELSE sqlc.narg('name') END,
     currency = CASE WHEN sqlc.narg('currency')::text IS NULL THEN currency ELSE sqlc.narg('currency') END,
     status = CASE WHEN sqlc.narg('status')::text IS NULL THEN status ELSE sqlc.narg('status') END,
+    edit_mode = CASE WHEN sqlc.narg('edit_mode')::text IS NULL THEN edit_mode ELSE sqlc.narg('edit_mode') END,
+    edit_window_minutes = CASE WHEN sqlc.narg('edit_window_minutes')::int IS NULL THEN edit_window_minutes ELSE sqlc.narg('edit_window_minutes') END,
     updated_at = NOW()
 WHERE id = sqlc.arg('id') AND deleted_at IS NULL
 RETURNING *;
@@ -40,7 +43,7 @@ ORDER BY created_at;
 
 -- name: GetAccountsByUserID :many
 SELECT * FROM accounts
-WHERE user_id = $1 AND deleted_at IS NULL
+WHERE user_id = $1 AND workspace_id IS NULL AND deleted_at IS NULL
 ORDER BY created_at;
 
 -- name: IncreaseAccountBalance :one
@@ -58,3 +61,8 @@ SET
     updated_at = NOW()
 WHERE id = sqlc.arg('id') AND deleted_at IS NULL
 RETURNING *;
+
+-- name: GetAccountsByWorkspaceID :many
+SELECT * FROM accounts
+WHERE workspace_id = $1 AND deleted_at IS NULL
+ORDER BY created_at;
@@ -0,0 +1,81 @@
+-- name: UpsertAccountMember :one
+INSERT INTO account_members (
+    account_id,
+    user_id,
+    role
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (account_id, user_id) DO UPDATE
+SET
+    role = EXCLUDED.role,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetAccountMember :one
+SELECT * FROM account_members
+WHERE account_id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: GetAccountMembersByAccountID :many
+SELECT * FROM account_members
+WHERE account_id = $1
+ORDER BY id;
+
+-- name: UpdateAccountMemberRole :one
+UPDATE account_members
+SET
+    role = sqlc.arg('role'),
+    updated_at = NOW()
+WHERE account_id = sqlc.arg('account_id') AND user_id = sqlc.arg('user_id')
+RETURNING *;
+
+-- name: DeleteAccountMember :execrows
+DELETE FROM account_members
+WHERE account_id = $1 AND user_id = $2;
+
+-- name: GetSharedAccountsByUserID :many
+SELECT a.*, m.role AS member_role
+FROM accounts a
+JOIN account_members m ON m.account_id = a.id
+WHERE m.user_id = $1 AND a.deleted_at IS NULL
+ORDER BY a.created_at;
+
+-- name: CreateAccountInvitation :one
+INSERT INTO account_invitations (
+    account_id,
+    inviter_id,
+    email,
+    role,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetAccountInvitationByID :one
+SELECT * FROM account_invitations
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetAccountInvitationsByAccountID :many
+SELECT * FROM account_invitations
+WHERE account_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetPendingAccountInvitationsByUserID :many
+SELECT ai.*
+FROM account_invitations ai
+JOIN identities i ON LOWER(i.identifier) = LOWER(ai.email)
+WHERE i.user_id = $1 AND i.verified_at IS NOT NULL
+    AND ai.status = 'pending' AND ai.expires_at > NOW()
+ORDER BY ai.created_at DESC;
+
+-- name: UpdateAccountInvitationStatus :one
+UPDATE account_invitations
+SET
+    status = sqlc.arg('status'),
+    responded_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id')
+RETURNING *;
@@ -0,0 +1,32 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (
+    user_id,
+    name,
+    prefix,
+    key_hash,
+    scopes,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetAPIKeyByHash :one
+SELECT * FROM api_keys
+WHERE key_hash = $1
+LIMIT 1;
+
+-- name: GetAPIKeysByUserID :many
+SELECT * FROM api_keys
+WHERE user_id = $1 AND revoked_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: TouchAPIKey :exec
+UPDATE api_keys
+SET last_used_at = sqlc.arg('last_used_at')
+WHERE id = sqlc.arg('id');
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = NOW()
+WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;
@@ -0,0 +1,18 @@
+-- name: CreateAuditLog :exec
+INSERT INTO audit_logs (
+    actor_user_id,
+    action,
+    entity_type,
+    entity_id,
+    before,
+    after,
+    ip_address,
+    books_lock_override
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+);
+
+-- name: GetAuditLogsByEntity :many
+SELECT * FROM audit_logs
+WHERE entity_type = $1 AND entity_id = $2
+ORDER BY created_at DESC, id DESC;
@@ -0,0 +1,17 @@
+-- name: CreateAuthEvent :exec
+INSERT INTO auth_events (
+    user_id,
+    type,
+    provider,
+    identifier,
+    ip_address,
+    user_agent
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+);
+
+-- name: GetAuthEventsByUserID :many
+SELECT * FROM auth_events
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
@@ -0,0 +1,37 @@
+-- name: GetBooksLockByUserID :one
+SELECT * FROM books_locks
+WHERE user_id = $1
+LIMIT 1;
+
+-- name: GetBooksLockByAccountID :one
+SELECT * FROM books_locks
+WHERE account_id = $1
+LIMIT 1;
+
+-- name: UpsertUserBooksLock :exec
+INSERT INTO books_locks (
+    user_id,
+    closed_through
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE
+SET closed_through = EXCLUDED.closed_through, updated_at = NOW();
+
+-- name: UpsertAccountBooksLock :exec
+INSERT INTO books_locks (
+    account_id,
+    closed_through
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (account_id) DO UPDATE
+SET closed_through = EXCLUDED.closed_through, updated_at = NOW();
+
+-- name: DeleteUserBooksLock :exec
+DELETE FROM books_locks
+WHERE user_id = $1;
+
+-- name: DeleteAccountBooksLock :exec
+DELETE FROM books_locks
+WHERE account_id = $1;
@@ -0,0 +1,65 @@
+-- name: UpsertCronJob :one
+INSERT INTO cron_jobs (
+    name,
+    schedule,
+    timeout_seconds,
+    enabled
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (name) DO UPDATE
+SET
+    schedule = EXCLUDED.schedule,
+    timeout_seconds = EXCLUDED.timeout_seconds,
+    enabled = EXCLUDED.enabled,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetCronJobs :many
+SELECT * FROM cron_jobs
+ORDER BY name ASC;
+
+-- name: GetCronJobByName :one
+SELECT * FROM cron_jobs
+WHERE name = $1;
+
+-- name: RequestCronJobTrigger :one
+UPDATE cron_jobs
+SET
+    trigger_requested_at = NOW(),
+    updated_at = NOW()
+WHERE name = $1
+RETURNING *;
+
+-- name: ClaimCronJobTrigger :one
+UPDATE cron_jobs
+SET
+    trigger_requested_at = NULL,
+    updated_at = NOW()
+WHERE name = $1 AND trigger_requested_at IS NOT NULL
+RETURNING *;
+
+-- name: CreateCronJobRun :one
+INSERT INTO cron_job_runs (
+    job_name,
+    trigger,
+    started_at
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: FinishCronJobRun :exec
+UPDATE cron_job_runs
+SET
+    status = $2,
+    finished_at = $3,
+    items_processed = $4,
+    error = $5
+WHERE id = $1;
+
+-- name: GetCronJobRunsByJobName :many
+SELECT * FROM cron_job_runs
+WHERE job_name = $1
+ORDER BY started_at DESC
+LIMIT $2;
@@ -0,0 +1,65 @@
+-- name: GetDigestSettingsByUserID :one
+SELECT * FROM digest_settings
+WHERE user_id = $1
+LIMIT 1;
+
+-- name: UpsertDigestSettings :one
+INSERT INTO digest_settings (
+    user_id,
+    enabled,
+    frequency,
+    weekday,
+    day_of_month
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (user_id) DO UPDATE
+SET
+    enabled = EXCLUDED.enabled,
+    frequency = EXCLUDED.frequency,
+    weekday = EXCLUDED.weekday,
+    day_of_month = EXCLUDED.day_of_month,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetEnabledDigestSettings :many
+SELECT * FROM digest_settings
+WHERE enabled = TRUE
+ORDER BY user_id ASC;
+
+-- name: ClaimDigestPeriod :one
+UPDATE digest_settings
+SET
+    last_period_end = sqlc.arg('period_end'),
+    updated_at = NOW()
+WHERE user_id = sqlc.arg('user_id')
+  AND enabled = TRUE
+  AND (last_period_end IS NULL OR last_period_end < sqlc.arg('period_end'))
+RETURNING *;
+
+-- name: ReleaseDigestPeriod :exec
+UPDATE digest_settings
+SET
+    last_period_end = sqlc.narg('previous_period_end'),
+    updated_at = NOW()
+WHERE user_id = sqlc.arg('user_id')
+  AND last_period_end = sqlc.arg('period_end');
+
+-- name: GetSpendingByCategory :many
+SELECT
+    COALESCE(l.category, '')::text AS category,
+    a.currency,
+    SUM(ABS(l.amount))::decimal AS amount,
+    COUNT(*) AS ledger_count
+FROM ledgers l
+JOIN accounts a ON l.account_id = a.id
+WHERE a.user_id = sqlc.arg('user_id')
+  AND l.type = 'expense'
+  AND l.date >= sqlc.arg('since')
+  AND l.date < sqlc.arg('until')
+  AND l.is_voided = FALSE
+  AND l.is_pending = FALSE
+  AND l.deleted_at IS NULL
+  AND a.deleted_at IS NULL
+GROUP BY COALESCE(l.category, ''), a.currency
+ORDER BY a.currency ASC, amount DESC;
@@ -0,0 +1,17 @@
+-- name: CreateEmailVerificationToken :one
+INSERT INTO email_verification_tokens (
+    user_id,
+    identifier,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: UseEmailVerificationToken :one
+UPDATE email_verification_tokens
+SET
+    used_at = NOW()
+WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+RETURNING *;
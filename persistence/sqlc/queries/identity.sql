@@ -26,3 +26,9 @@ RETURNING *;
 DELETE FROM identities
 WHERE provider = $1 AND identifier = $2
 RETURNING *;
+
+-- name: VerifyIdentity :execrows
+UPDATE identities
+SET
+    verified_at = NOW()
+WHERE provider = $1 AND identifier = $2 AND verified_at IS NULL;
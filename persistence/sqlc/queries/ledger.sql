@@ -6,9 +6,14 @@ INSERT INTO ledgers (
     amount,
     note,
     is_adjustment,
-    adjusted_from
+    adjusted_from,
+    payee_id,
+    category,
+    tags,
+    is_pending,
+    recurring_transaction_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 ) RETURNING *;
 
 -- name: GetLedgerByID :one
@@ -37,6 +42,15 @@ JOIN accounts a ON l.account_id = a.id
 WHERE l.account_id = $1 AND l.deleted_at IS NULL AND a.deleted_at IS NULL
 ORDER BY l.date DESC, l.updated_at DESC;
 
+-- name: GetLedgersByRecurringTransactionID :many
+SELECT
+    l.*,
+    a.currency
+FROM ledgers l
+JOIN accounts a ON l.account_id = a.id
+WHERE l.recurring_transaction_id = $1 AND l.deleted_at IS NULL AND a.deleted_at IS NULL
+ORDER BY l.date DESC, l.id DESC;
+
 -- name: UpdateLedger :one
 UPDATE ledgers
 SET
@@ -44,6 +58,9 @@ SET
     type = CASE WHEN sqlc.narg('type')::text IS NULL THEN type ELSE sqlc.narg('type') END,
     amount = CASE WHEN sqlc.narg('amount')::decimal IS NULL THEN amount ELSE sqlc.narg('amount') END,
     note = CASE WHEN sqlc.narg('note')::text IS NULL THEN note ELSE sqlc.narg('note') END,
+    payee_id = CASE WHEN sqlc.narg('payee_id')::int IS NULL THEN payee_id ELSE sqlc.narg('payee_id') END,
+    category = CASE WHEN sqlc.narg('category')::text IS NULL THEN category ELSE sqlc.narg('category') END,
+    tags = CASE WHEN sqlc.narg('tags')::text[] IS NULL THEN tags ELSE sqlc.narg('tags') END,
     updated_at = NOW()
 WHERE id = sqlc.arg('id') AND deleted_at IS NULL
 RETURNING *;
@@ -57,7 +74,34 @@ SET
 WHERE id = sqlc.arg('id') AND deleted_at IS NULL
 RETURNING *;
 
+-- name: PostPendingLedger :one
+UPDATE ledgers
+SET
+    is_pending = FALSE,
+    amount = CASE WHEN sqlc.narg('amount')::decimal IS NULL THEN amount ELSE sqlc.narg('amount') END,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND is_pending AND deleted_at IS NULL
+RETURNING *;
+
 -- name: GetLedgerAmount :one
 SELECT amount FROM ledgers
 WHERE id = sqlc.arg('id') AND deleted_at IS NULL
 LIMIT 1;
+
+-- name: GetLedgersByUserID :many
+SELECT
+    l.*,
+    a.currency
+FROM ledgers l
+JOIN accounts a ON l.account_id = a.id
+WHERE a.user_id = $1 AND l.is_voided = FALSE AND l.is_pending = FALSE AND l.deleted_at IS NULL AND a.deleted_at IS NULL
+ORDER BY l.date DESC, l.id DESC;
+
+-- name: ClassifyLedger :one
+UPDATE ledgers
+SET
+    payee_id = sqlc.narg('payee_id'),
+    category = sqlc.narg('category'),
+    tags = sqlc.arg('tags')
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
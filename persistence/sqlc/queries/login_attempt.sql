@@ -0,0 +1,31 @@
+-- name: GetLoginAttempt :one
+SELECT * FROM login_attempts
+WHERE provider = $1 AND identifier = $2
+LIMIT 1;
+
+-- name: RecordFailedLogin :one
+INSERT INTO login_attempts (
+    provider,
+    identifier,
+    failed_count,
+    last_failed_at
+) VALUES (
+    sqlc.arg('provider'), sqlc.arg('identifier'), 1, sqlc.arg('failed_at')
+)
+ON CONFLICT (provider, identifier) DO UPDATE
+SET
+    failed_count = CASE
+        WHEN login_attempts.last_failed_at < sqlc.arg('reset_before') THEN 1
+        ELSE login_attempts.failed_count + 1
+    END,
+    last_failed_at = EXCLUDED.last_failed_at
+RETURNING *;
+
+-- name: LockLogin :exec
+UPDATE login_attempts
+SET locked_until = $3
+WHERE provider = $1 AND identifier = $2;
+
+-- name: ClearLoginAttempts :exec
+DELETE FROM login_attempts
+WHERE provider = $1 AND identifier = $2;
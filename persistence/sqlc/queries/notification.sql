@@ -0,0 +1,85 @@
+-- name: GetNotificationChannelsByUserID :many
+SELECT * FROM notification_channels
+WHERE user_id = $1
+ORDER BY channel ASC;
+
+-- name: UpsertNotificationChannel :one
+INSERT INTO notification_channels (
+    user_id,
+    channel,
+    target,
+    enabled,
+    confirmed_at,
+    confirmation_token_hash,
+    confirmation_expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (user_id, channel) DO UPDATE
+SET
+    target = EXCLUDED.target,
+    enabled = EXCLUDED.enabled,
+    confirmed_at = EXCLUDED.confirmed_at,
+    confirmation_token_hash = EXCLUDED.confirmation_token_hash,
+    confirmation_expires_at = EXCLUDED.confirmation_expires_at,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: ConfirmNotificationChannel :one
+UPDATE notification_channels
+SET
+    confirmed_at = NOW(),
+    confirmation_token_hash = NULL,
+    confirmation_expires_at = NULL,
+    updated_at = NOW()
+WHERE confirmation_token_hash = $1 AND confirmation_expires_at > NOW()
+RETURNING *;
+
+-- name: DeleteNotificationChannel :exec
+DELETE FROM notification_channels
+WHERE user_id = $1 AND channel = $2;
+
+-- name: QueueReminderDeliveries :many
+INSERT INTO reminder_deliveries (
+    reminder_id, reminder_date, channel, target, next_attempt_at
+)
+SELECT r.id, r.reminder_date, nc.channel, nc.target, sqlc.arg('until')::timestamptz
+FROM reminders r
+JOIN recurring_transactions rt ON r.recurring_transaction_id = rt.id
+JOIN notification_channels nc ON nc.user_id = rt.user_id AND nc.enabled = TRUE AND nc.confirmed_at IS NOT NULL
+WHERE r.reminder_date BETWEEN sqlc.arg('since') AND sqlc.arg('until')
+  AND r.is_read = FALSE
+  AND r.deleted_at IS NULL
+  AND rt.deleted_at IS NULL
+ON CONFLICT (reminder_id, channel, reminder_date) DO NOTHING
+RETURNING *;
+
+-- name: ClaimReminderDeliveries :many
+UPDATE reminder_deliveries
+SET
+    attempts = attempts + 1,
+    next_attempt_at = sqlc.arg('lease_until'),
+    updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM reminder_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= sqlc.arg('now')
+    ORDER BY next_attempt_at ASC
+    LIMIT sqlc.arg('batch_size')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpdateReminderDeliveryStatus :exec
+UPDATE reminder_deliveries
+SET
+    status = sqlc.arg('status'),
+    next_attempt_at = sqlc.arg('next_attempt_at'),
+    last_error = sqlc.arg('last_error'),
+    delivered_at = sqlc.narg('delivered_at'),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id');
+
+-- name: GetReminderDeliveriesByReminderID :many
+SELECT * FROM reminder_deliveries
+WHERE reminder_id = $1
+ORDER BY created_at DESC, channel ASC;
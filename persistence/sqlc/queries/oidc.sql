@@ -0,0 +1,18 @@
+-- name: CreateOIDCAuthRequest :one
+INSERT INTO oidc_auth_requests (
+    state_hash,
+    nonce,
+    code_verifier,
+    link_user_id,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: UseOIDCAuthRequest :one
+UPDATE oidc_auth_requests
+SET
+    used_at = NOW()
+WHERE state_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+RETURNING *;
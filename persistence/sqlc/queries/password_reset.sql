@@ -0,0 +1,23 @@
+-- name: CreatePasswordResetToken :one
+INSERT INTO password_reset_tokens (
+    user_id,
+    identifier,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: UsePasswordResetToken :one
+UPDATE password_reset_tokens
+SET
+    used_at = NOW()
+WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+RETURNING *;
+
+-- name: InvalidatePasswordResetTokensByUserID :exec
+UPDATE password_reset_tokens
+SET
+    used_at = NOW()
+WHERE user_id = $1 AND used_at IS NULL;
@@ -0,0 +1,33 @@
+-- name: CreatePayee :one
+INSERT INTO payees (
+    user_id,
+    name
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetPayeeByID :one
+SELECT * FROM payees
+WHERE id = $1 AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetPayeesByUserID :many
+SELECT * FROM payees
+WHERE user_id = $1 AND deleted_at IS NULL
+ORDER BY name;
+
+-- name: UpdatePayee :one
+UPDATE payees
+SET
+    name = CASE WHEN sqlc.narg('name')::text IS NULL THEN name ELSE sqlc.narg('name') END,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeletePayee :one
+UPDATE payees
+SET
+    deleted_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
@@ -0,0 +1,43 @@
+-- name: CreatePayeeRule :one
+INSERT INTO payee_rules (
+    user_id,
+    payee_id,
+    match_type,
+    pattern,
+    category,
+    tags,
+    priority
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetPayeeRuleByID :one
+SELECT * FROM payee_rules
+WHERE id = $1 AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetPayeeRulesByUserID :many
+SELECT * FROM payee_rules
+WHERE user_id = $1 AND deleted_at IS NULL
+ORDER BY priority DESC, id ASC;
+
+-- name: UpdatePayeeRule :one
+UPDATE payee_rules
+SET
+    payee_id = CASE WHEN sqlc.narg('payee_id')::int IS NULL THEN payee_id ELSE sqlc.narg('payee_id') END,
+    match_type = CASE WHEN sqlc.narg('match_type')::text IS NULL THEN match_type ELSE sqlc.narg('match_type') END,
+    pattern = CASE WHEN sqlc.narg('pattern')::text IS NULL THEN pattern ELSE sqlc.narg('pattern') END,
+    category = CASE WHEN sqlc.narg('category')::text IS NULL THEN category ELSE sqlc.narg('category') END,
+    tags = CASE WHEN sqlc.narg('tags')::text[] IS NULL THEN tags ELSE sqlc.narg('tags') END,
+    priority = CASE WHEN sqlc.narg('priority')::int IS NULL THEN priority ELSE sqlc.narg('priority') END,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeletePayeeRule :one
+UPDATE payee_rules
+SET
+    deleted_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
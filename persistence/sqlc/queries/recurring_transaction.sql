@@ -2,18 +2,28 @@
 INSERT INTO recurring_transactions (
     user_id, account_id, name, type, amount, note,
     start_date, end_date, recur_type, status, frequency,
-    day_of_week, day_of_month, month_of_year, next_due
+    day_of_week, day_of_month, month_of_year, next_due, workspace_id, rrule,
+    catch_up_policy, post_mode, reminder_offsets
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
 ) RETURNING *;
 
 -- name: GetRecurringTransactionByID :one
 SELECT * FROM recurring_transactions
 WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
 
+-- name: GetRecurringTransactionIncludingDeleted :one
+SELECT * FROM recurring_transactions
+WHERE id = $1 LIMIT 1;
+
 -- name: GetRecurringTransactionsByUserID :many
 SELECT * FROM recurring_transactions
-WHERE user_id = $1 AND deleted_at IS NULL
+WHERE user_id = $1 AND workspace_id IS NULL AND deleted_at IS NULL
+ORDER BY next_due ASC;
+
+-- name: GetRecurringTransactionsByWorkspaceID :many
+SELECT * FROM recurring_transactions
+WHERE workspace_id = $1 AND deleted_at IS NULL
 ORDER BY next_due ASC;
 
 -- name: GetActiveRecurringTransactionsDue :many
@@ -35,7 +45,12 @@ SET
     frequency = CASE WHEN sqlc.narg('frequency')::int IS NULL THEN frequency ELSE sqlc.narg('frequency') END,
     day_of_week = CASE WHEN sqlc.narg('day_of_week')::int IS NULL THEN day_of_week ELSE sqlc.narg('day_of_week') END,
     day_of_month = CASE WHEN sqlc.narg('day_of_month')::int IS NULL THEN day_of_month ELSE sqlc.narg('day_of_month') END,
-    month_of_year = CASE WHEN sqlc.narg('month_of_year')::int IS NULL THEN month_of_year ELSE sqlc.narg('month_of_year') END
+    month_of_year = CASE WHEN sqlc.narg('month_of_year')::int IS NULL THEN month_of_year ELSE sqlc.narg('month_of_year') END,
+    rrule = CASE WHEN sqlc.narg('rrule')::text IS NULL THEN rrule ELSE sqlc.narg('rrule') END,
+    catch_up_policy = CASE WHEN sqlc.narg('catch_up_policy')::text IS NULL THEN catch_up_policy ELSE sqlc.narg('catch_up_policy') END,
+    post_mode = CASE WHEN sqlc.narg('post_mode')::text IS NULL THEN post_mode ELSE sqlc.narg('post_mode') END,
+    reminder_offsets = CASE WHEN sqlc.narg('reminder_offsets')::int[] IS NULL THEN reminder_offsets ELSE NULLIF(sqlc.narg('reminder_offsets')::int[], '{}') END,
+    next_due = CASE WHEN sqlc.narg('next_due')::timestamptz IS NULL THEN next_due ELSE sqlc.narg('next_due') END
 WHERE id = sqlc.arg('id') AND deleted_at IS NULL
 RETURNING *;
 
@@ -43,11 +58,38 @@ RETURNING *;
 UPDATE recurring_transactions
 SET
     updated_at = NOW(),
-    last_executed = sqlc.arg('last_executed'),
-    next_due = sqlc.arg('next_due')
-WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+    last_executed = CASE WHEN sqlc.narg('last_executed')::timestamptz IS NULL THEN last_executed ELSE sqlc.narg('last_executed') END,
+    next_due = sqlc.arg('next_due'),
+    status = sqlc.arg('status')
+WHERE id = sqlc.arg('id') AND next_due = sqlc.arg('scheduled_for')
+    AND status = 'active' AND deleted_at IS NULL
 RETURNING *;
 
+-- name: ClaimRecurringTransactionOccurrence :one
+SELECT * FROM recurring_transactions
+WHERE id = sqlc.arg('id') AND next_due = sqlc.arg('scheduled_for')
+    AND status = 'active' AND deleted_at IS NULL
+FOR UPDATE SKIP LOCKED;
+
+-- name: CreateRecurringTransactionExecution :one
+INSERT INTO recurring_transaction_executions (
+    recurring_transaction_id, scheduled_for, status, ledger_id
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (recurring_transaction_id, scheduled_for) DO NOTHING
+RETURNING *;
+
+-- name: GetRecurringTransactionExecutions :many
+SELECT * FROM recurring_transaction_executions
+WHERE recurring_transaction_id = $1
+ORDER BY scheduled_for DESC;
+
+-- name: UpdateRecurringTransactionExecutionStatus :exec
+UPDATE recurring_transaction_executions
+SET status = sqlc.arg('status')
+WHERE ledger_id = sqlc.arg('ledger_id');
+
 -- name: DeleteRecurringTransaction :one
 UPDATE recurring_transactions
 SET
@@ -1,8 +1,8 @@
 -- name: CreateReminder :one
 INSERT INTO reminders (
-    recurring_transaction_id, reminder_date
+    recurring_transaction_id, reminder_date, ledger_id
 ) VALUES (
-    $1, $2
+    $1, $2, $3
 ) RETURNING *;
 
 -- name: DeleteReminder :one
@@ -48,3 +48,38 @@ WHERE rt.user_id = $1
   AND r.deleted_at IS NULL
   AND rt.deleted_at IS NULL
 ORDER BY r.reminder_date ASC;
+
+-- name: SnoozeReminder :one
+UPDATE reminders
+SET
+    reminder_date = sqlc.arg('reminder_date'),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND is_read = FALSE AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteUpcomingReminders :exec
+UPDATE reminders
+SET
+    deleted_at = NOW(),
+    updated_at = NOW()
+WHERE recurring_transaction_id = $1
+  AND reminder_date > $2
+  AND is_read = FALSE
+  AND ledger_id IS NULL
+  AND deleted_at IS NULL;
+
+-- name: GetReminderSettingsByUserID :one
+SELECT * FROM reminder_settings
+WHERE user_id = $1
+LIMIT 1;
+
+-- name: UpsertReminderSettings :one
+INSERT INTO reminder_settings (
+    user_id,
+    default_offsets
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE
+SET default_offsets = EXCLUDED.default_offsets, updated_at = NOW()
+RETURNING *;
@@ -0,0 +1,52 @@
+-- name: CreateSession :one
+INSERT INTO sessions (
+    user_id,
+    provider,
+    refresh_token_hash,
+    user_agent,
+    ip_address,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetSessionByID :one
+SELECT * FROM sessions
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetSessionByRefreshTokenHash :one
+SELECT * FROM sessions
+WHERE refresh_token_hash = sqlc.arg('hash') OR previous_refresh_token_hash = sqlc.arg('hash')
+LIMIT 1;
+
+-- name: GetActiveSessionsByUserID :many
+SELECT * FROM sessions
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+ORDER BY last_used_at DESC;
+
+-- name: RotateSessionRefreshToken :one
+UPDATE sessions
+SET
+    previous_refresh_token_hash = refresh_token_hash,
+    refresh_token_hash = sqlc.arg('refresh_token_hash'),
+    expires_at = sqlc.arg('expires_at'),
+    last_used_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND refresh_token_hash = sqlc.arg('current_refresh_token_hash') AND revoked_at IS NULL
+RETURNING *;
+
+-- name: RevokeSession :execrows
+UPDATE sessions
+SET
+    revoked_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeSessionsByUserID :exec
+UPDATE sessions
+SET
+    revoked_at = NOW(),
+    updated_at = NOW()
+WHERE user_id = sqlc.arg('user_id') AND id <> sqlc.arg('except_id') AND revoked_at IS NULL;
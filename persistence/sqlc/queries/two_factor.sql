@@ -0,0 +1,84 @@
+-- name: UpsertTOTPSecret :exec
+INSERT INTO totp_secrets (
+    user_id,
+    secret
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE
+SET
+    created_at = NOW(),
+    secret = EXCLUDED.secret,
+    confirmed_at = NULL,
+    last_used_step = 0;
+
+-- name: GetTOTPSecretByUserID :one
+SELECT * FROM totp_secrets
+WHERE user_id = $1;
+
+-- name: ConfirmTOTPSecret :execrows
+UPDATE totp_secrets
+SET
+    confirmed_at = NOW()
+WHERE user_id = $1 AND confirmed_at IS NULL;
+
+-- name: UpdateTOTPLastUsedStep :execrows
+UPDATE totp_secrets
+SET
+    last_used_step = $2
+WHERE user_id = $1 AND last_used_step < $2;
+
+-- name: DeleteTOTPSecret :exec
+DELETE FROM totp_secrets
+WHERE user_id = $1;
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO recovery_codes (
+    user_id,
+    code_hash
+) VALUES (
+    $1, $2
+);
+
+-- name: DeleteRecoveryCodesByUserID :exec
+DELETE FROM recovery_codes
+WHERE user_id = $1;
+
+-- name: UseRecoveryCode :execrows
+UPDATE recovery_codes
+SET
+    used_at = NOW()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: CountUnusedRecoveryCodes :one
+SELECT COUNT(*) FROM recovery_codes
+WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: CreateTwoFactorChallenge :one
+INSERT INTO two_factor_challenges (
+    user_id,
+    provider,
+    identifier,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetTwoFactorChallengeByTokenHash :one
+SELECT * FROM two_factor_challenges
+WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW();
+
+-- name: IncrementTwoFactorChallengeAttempts :one
+UPDATE two_factor_challenges
+SET
+    attempts = attempts + 1
+WHERE id = sqlc.arg('id') AND attempts < sqlc.arg('max_attempts')
+RETURNING attempts;
+
+-- name: UseTwoFactorChallenge :execrows
+UPDATE two_factor_challenges
+SET
+    used_at = NOW()
+WHERE id = $1 AND used_at IS NULL;
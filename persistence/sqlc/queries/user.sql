@@ -1,9 +1,10 @@
 -- name: CreateUser :one
 INSERT INTO users (
     name,
-    nickname
+    nickname,
+    role
 ) VALUES (
-    $1, $2
+    $1, $2, $3
 )
 RETURNING *;
 
@@ -23,6 +24,7 @@ SET
     name = CASE WHEN sqlc.narg('name')::text IS NULL THEN name ELSE sqlc.narg('name') END,
     nickname = CASE WHEN sqlc.narg('nickname')::text IS NULL THEN nickname ELSE sqlc.narg('nickname') END,
     disabled = CASE WHEN sqlc.narg('disabled')::boolean IS NULL THEN disabled ELSE sqlc.narg('disabled') END,
+    role = CASE WHEN sqlc.narg('role')::text IS NULL THEN role ELSE sqlc.narg('role') END,
     updated_at = NOW()
 WHERE id = sqlc.arg('id') AND deleted_at IS NULL
 RETURNING *;
@@ -49,9 +51,10 @@ INSERT INTO identities (
     provider,
     identifier,
     credential,
-    last_used_at
+    last_used_at,
+    verified_at
 ) VALUES (
-    $1, $2, $3, $4, NOW()
+    $1, $2, $3, $4, NOW(), $5
 )
 RETURNING *;
 
@@ -63,12 +66,14 @@ SELECT
     u.disabled AS user_disabled,
     u.name AS user_name,
     u.nickname AS user_nickname,
+    u.role AS user_role,
     i.id AS identity_id,
     i.user_id AS identity_user_id,
     i.provider AS identity_provider,
     i.identifier AS identity_identifier,
     i.credential AS identity_credential,
-    i.last_used_at AS identity_last_used_at
+    i.last_used_at AS identity_last_used_at,
+    i.verified_at AS identity_verified_at
 FROM users u
 JOIN identities i ON u.id = i.user_id
 WHERE i.provider = $1 AND i.identifier = $2 AND u.deleted_at IS NULL
@@ -0,0 +1,83 @@
+-- name: CreateWorkspace :one
+INSERT INTO workspaces (
+    name,
+    type
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: GetWorkspaceByID :one
+SELECT * FROM workspaces
+WHERE id = $1 AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetWorkspacesByUserID :many
+SELECT w.*, m.role AS member_role
+FROM workspaces w
+JOIN workspace_members m ON m.workspace_id = w.id
+WHERE m.user_id = $1 AND w.deleted_at IS NULL
+ORDER BY w.created_at;
+
+-- name: UpdateWorkspace :one
+UPDATE workspaces
+SET
+    name = CASE WHEN sqlc.narg('name')::text IS NULL THEN name ELSE sqlc.narg('name') END,
+    type = CASE WHEN sqlc.narg('type')::text IS NULL THEN type ELSE sqlc.narg('type') END,
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteWorkspace :one
+UPDATE workspaces
+SET
+    deleted_at = NOW(),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: UpsertWorkspaceMember :one
+INSERT INTO workspace_members (
+    workspace_id,
+    user_id,
+    role
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (workspace_id, user_id) DO UPDATE
+SET
+    role = EXCLUDED.role,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetWorkspaceMember :one
+SELECT m.*
+FROM workspace_members m
+JOIN workspaces w ON w.id = m.workspace_id
+WHERE m.workspace_id = $1 AND m.user_id = $2 AND w.deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetWorkspaceMembersByWorkspaceID :many
+SELECT * FROM workspace_members
+WHERE workspace_id = $1
+ORDER BY id;
+
+-- name: DeleteWorkspaceMember :execrows
+DELETE FROM workspace_members
+WHERE workspace_id = $1 AND user_id = $2;
+
+-- name: AddWorkspaceMemberByEmail :one
+INSERT INTO workspace_members (
+    workspace_id,
+    user_id,
+    role
+)
+SELECT sqlc.arg('workspace_id')::int, i.user_id, sqlc.arg('role')::text
+FROM identities i
+WHERE LOWER(i.identifier) = LOWER(sqlc.arg('email')::text)
+LIMIT 1
+ON CONFLICT (workspace_id, user_id) DO UPDATE
+SET
+    role = EXCLUDED.role,
+    updated_at = NOW()
+RETURNING *;
@@ -199,3 +199,573 @@ CREATE TABLE bank_accounts (
 -- Bank Accounts Table Indexes
 CREATE INDEX idx_bank_accounts_account_id ON bank_accounts (account_id);
 CREATE INDEX idx_bank_accounts_deleted_at ON bank_accounts (deleted_at);
+
+-- Payees Table
+CREATE TABLE payees (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        deleted_at TIMESTAMP
+    WITH
+        TIME ZONE,
+        user_id INT NOT NULL REFERENCES users (id),
+        name VARCHAR(255) NOT NULL
+);
+
+-- Payee Rules Table
+CREATE TABLE payee_rules (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        deleted_at TIMESTAMP
+    WITH
+        TIME ZONE,
+        user_id INT NOT NULL REFERENCES users (id),
+        payee_id INT REFERENCES payees (id),
+        match_type VARCHAR(20) NOT NULL,
+        pattern VARCHAR(255) NOT NULL,
+        category VARCHAR(255),
+        tags TEXT[] NOT NULL DEFAULT '{}',
+        priority INT NOT NULL DEFAULT 0
+);
+
+-- Ledger payee, category and tags
+ALTER TABLE ledgers
+ADD COLUMN payee_id INT REFERENCES payees (id),
+ADD COLUMN category VARCHAR(255),
+ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Payees and Payee Rules Indexes
+CREATE INDEX idx_payees_user_id ON payees (user_id);
+
+CREATE INDEX idx_payees_deleted_at ON payees (deleted_at);
+
+CREATE INDEX idx_payee_rules_user_id ON payee_rules (user_id);
+
+CREATE INDEX idx_payee_rules_deleted_at ON payee_rules (deleted_at);
+
+CREATE INDEX idx_ledgers_payee_id ON ledgers (payee_id);
+
+-- User roles
+ALTER TABLE users
+ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user';
+
+CREATE INDEX idx_users_role ON users (role);
+
+-- Account Members Table
+CREATE TABLE account_members (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        account_id INT NOT NULL REFERENCES accounts (id),
+        user_id INT NOT NULL REFERENCES users (id),
+        role VARCHAR(20) NOT NULL,
+        UNIQUE (account_id, user_id)
+);
+
+-- Account Invitations Table
+CREATE TABLE account_invitations (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        account_id INT NOT NULL REFERENCES accounts (id),
+        inviter_id INT NOT NULL REFERENCES users (id),
+        email VARCHAR(255) NOT NULL,
+        role VARCHAR(20) NOT NULL,
+        status VARCHAR(20) NOT NULL DEFAULT 'pending',
+        expires_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        responded_at TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+-- Account Members and Invitations Indexes
+CREATE INDEX idx_account_members_user_id ON account_members (user_id);
+
+CREATE INDEX idx_account_invitations_account_id ON account_invitations (account_id);
+
+CREATE INDEX idx_account_invitations_email ON account_invitations (email);
+
+-- Workspaces Table
+CREATE TABLE workspaces (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        deleted_at TIMESTAMP
+    WITH
+        TIME ZONE,
+        name VARCHAR(255) NOT NULL,
+        type VARCHAR(20) NOT NULL
+);
+
+-- Workspace Members Table
+CREATE TABLE workspace_members (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        workspace_id INT NOT NULL REFERENCES workspaces (id),
+        user_id INT NOT NULL REFERENCES users (id),
+        role VARCHAR(20) NOT NULL,
+        UNIQUE (workspace_id, user_id)
+);
+
+-- Workspace ownership of accounts and recurring transactions
+ALTER TABLE accounts
+ADD COLUMN workspace_id INT REFERENCES workspaces (id);
+
+ALTER TABLE recurring_transactions
+ADD COLUMN workspace_id INT REFERENCES workspaces (id);
+
+-- Workspaces Indexes
+CREATE INDEX idx_workspaces_deleted_at ON workspaces (deleted_at);
+
+CREATE INDEX idx_workspace_members_user_id ON workspace_members (user_id);
+
+CREATE INDEX idx_accounts_workspace_id ON accounts (workspace_id);
+
+CREATE INDEX idx_recurring_transactions_workspace_id ON recurring_transactions (workspace_id);
+
+-- Sessions Table
+CREATE TABLE sessions (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        user_id INT NOT NULL REFERENCES users (id),
+        provider VARCHAR(50) NOT NULL,
+        refresh_token_hash VARCHAR(64) NOT NULL UNIQUE,
+        previous_refresh_token_hash VARCHAR(64),
+        user_agent TEXT NOT NULL DEFAULT '',
+        ip_address VARCHAR(64) NOT NULL DEFAULT '',
+        last_used_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        expires_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        revoked_at TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+-- Sessions Indexes
+CREATE INDEX idx_sessions_user_id ON sessions (user_id);
+
+CREATE INDEX idx_sessions_previous_refresh_token_hash ON sessions (previous_refresh_token_hash);
+
+-- Password Reset Tokens Table
+CREATE TABLE password_reset_tokens (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        user_id INT NOT NULL REFERENCES users (id),
+        identifier VARCHAR(255) NOT NULL,
+        token_hash VARCHAR(64) NOT NULL UNIQUE,
+        expires_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        used_at TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+-- Password Reset Tokens Indexes
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
+
+-- Identity verification
+ALTER TABLE identities
+ADD COLUMN verified_at TIMESTAMP
+WITH
+    TIME ZONE;
+
+-- Email Verification Tokens Table
+CREATE TABLE email_verification_tokens (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        user_id INT NOT NULL REFERENCES users (id),
+        identifier VARCHAR(255) NOT NULL,
+        token_hash VARCHAR(64) NOT NULL UNIQUE,
+        expires_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        used_at TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+-- Email Verification Tokens Indexes
+CREATE INDEX idx_email_verification_tokens_user_id ON email_verification_tokens (user_id);
+
+-- TOTP Secrets Table
+CREATE TABLE totp_secrets (
+    user_id INT PRIMARY KEY REFERENCES users (id),
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        secret VARCHAR(64) NOT NULL,
+        confirmed_at TIMESTAMP
+    WITH
+        TIME ZONE,
+        last_used_step BIGINT NOT NULL DEFAULT 0
+);
+
+-- Recovery Codes Table
+CREATE TABLE recovery_codes (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        user_id INT NOT NULL REFERENCES users (id),
+        code_hash VARCHAR(64) NOT NULL,
+        used_at TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+-- Recovery Codes Indexes
+CREATE INDEX idx_recovery_codes_user_id ON recovery_codes (user_id);
+
+-- Two Factor Challenges Table
+CREATE TABLE two_factor_challenges (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        user_id INT NOT NULL REFERENCES users (id),
+        provider VARCHAR(50) NOT NULL,
+        token_hash VARCHAR(64) NOT NULL UNIQUE,
+        attempts INT NOT NULL DEFAULT 0,
+        expires_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        used_at TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+-- Two Factor Challenges Indexes
+CREATE INDEX idx_two_factor_challenges_user_id ON two_factor_challenges (user_id);
+
+-- OIDC Auth Requests Table
+CREATE TABLE oidc_auth_requests (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        state_hash VARCHAR(64) NOT NULL UNIQUE,
+        nonce VARCHAR(64) NOT NULL,
+        code_verifier VARCHAR(128) NOT NULL,
+        link_user_id INT REFERENCES users (id),
+        expires_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        used_at TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+-- API Keys Table
+CREATE TABLE api_keys (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        user_id INT NOT NULL REFERENCES users (id),
+        name VARCHAR(100) NOT NULL,
+        prefix VARCHAR(16) NOT NULL,
+        key_hash VARCHAR(64) NOT NULL UNIQUE,
+        scopes TEXT[] NOT NULL,
+        expires_at TIMESTAMP
+    WITH
+        TIME ZONE,
+        last_used_at TIMESTAMP
+    WITH
+        TIME ZONE,
+        revoked_at TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+-- API Keys Indexes
+CREATE INDEX idx_api_keys_user_id ON api_keys (user_id);
+
+-- Login Attempts Table
+CREATE TABLE login_attempts (
+    provider VARCHAR(50) NOT NULL,
+    identifier VARCHAR(255) NOT NULL,
+    failed_count INT NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        locked_until TIMESTAMP
+    WITH
+        TIME ZONE,
+        PRIMARY KEY (provider, identifier)
+);
+
+-- Auth Events Table
+CREATE TABLE auth_events (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        user_id INT REFERENCES users (id),
+        type VARCHAR(50) NOT NULL,
+        provider VARCHAR(50) NOT NULL,
+        identifier VARCHAR(255) NOT NULL DEFAULT '',
+        ip_address VARCHAR(64) NOT NULL DEFAULT '',
+        user_agent TEXT NOT NULL DEFAULT ''
+);
+
+-- Auth Events Indexes
+CREATE INDEX idx_auth_events_user_id ON auth_events (user_id, created_at);
+
+-- Audit Logs Table
+CREATE TABLE audit_logs (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        actor_user_id INT REFERENCES users (id),
+        action VARCHAR(50) NOT NULL,
+        entity_type VARCHAR(50) NOT NULL,
+        entity_id INT NOT NULL,
+        before JSONB,
+        after JSONB,
+        ip_address VARCHAR(64) NOT NULL DEFAULT '',
+        books_lock_override BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- Audit Logs Indexes
+CREATE INDEX idx_audit_logs_entity ON audit_logs (entity_type, entity_id, created_at);
+
+-- Books Locks Table
+CREATE TABLE books_locks (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        user_id INT UNIQUE REFERENCES users (id),
+        account_id INT UNIQUE REFERENCES accounts (id),
+        closed_through DATE NOT NULL,
+        CHECK (
+            (user_id IS NULL) <> (account_id IS NULL)
+        )
+);
+
+-- Account Edit Policy
+ALTER TABLE accounts
+ADD COLUMN edit_mode VARCHAR(20) NOT NULL DEFAULT 'window',
+ADD COLUMN edit_window_minutes INT NOT NULL DEFAULT 15;
+
+-- Recurring Transactions Custom Schedule
+ALTER TABLE recurring_transactions
+ADD COLUMN rrule TEXT NOT NULL DEFAULT '';
+
+-- Recurring Transactions Catch-up Policy
+ALTER TABLE recurring_transactions
+ADD COLUMN catch_up_policy VARCHAR(20) NOT NULL DEFAULT 'book_all';
+
+-- Recurring Transaction Executions Table
+CREATE TABLE recurring_transaction_executions (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        recurring_transaction_id INT NOT NULL REFERENCES recurring_transactions (id),
+        scheduled_for TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        status VARCHAR(20) NOT NULL,
+        ledger_id INT REFERENCES ledgers (id),
+        UNIQUE (recurring_transaction_id, scheduled_for)
+);
+
+-- Recurring Transactions Post Mode
+ALTER TABLE recurring_transactions
+ADD COLUMN post_mode VARCHAR(20) NOT NULL DEFAULT 'auto';
+
+ALTER TABLE ledgers
+ADD COLUMN is_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE reminders
+ADD COLUMN ledger_id INT REFERENCES ledgers (id);
+
+-- Ledger Recurring Transaction
+ALTER TABLE ledgers
+ADD COLUMN recurring_transaction_id INT REFERENCES recurring_transactions (id);
+
+-- Recurring Transactions Reminder Offsets
+ALTER TABLE recurring_transactions
+ADD COLUMN reminder_offsets INT[];
+
+-- Reminder Settings Table
+CREATE TABLE reminder_settings (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        user_id INT NOT NULL UNIQUE REFERENCES users (id),
+        default_offsets INT[] NOT NULL
+);
+
+-- Notification Channels Table
+CREATE TABLE notification_channels (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        user_id INT NOT NULL REFERENCES users (id),
+        channel VARCHAR(20) NOT NULL,
+        target TEXT NOT NULL,
+        enabled BOOLEAN NOT NULL DEFAULT TRUE,
+        UNIQUE (user_id, channel)
+);
+
+-- Reminder Deliveries Table
+CREATE TABLE reminder_deliveries (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        reminder_id INT NOT NULL REFERENCES reminders (id),
+        reminder_date TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        channel VARCHAR(20) NOT NULL,
+        target TEXT NOT NULL,
+        status VARCHAR(20) NOT NULL DEFAULT 'pending',
+        attempts INT NOT NULL DEFAULT 0,
+        next_attempt_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        last_error TEXT NOT NULL DEFAULT '',
+        delivered_at TIMESTAMP
+    WITH
+        TIME ZONE,
+        UNIQUE (reminder_id, channel, reminder_date)
+);
+
+CREATE INDEX idx_reminder_deliveries_next_attempt_at ON reminder_deliveries (next_attempt_at)
+WHERE
+    status = 'pending';
+
+-- Cron Jobs Table
+CREATE TABLE cron_jobs (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        name VARCHAR(100) NOT NULL UNIQUE,
+        schedule VARCHAR(100) NOT NULL,
+        timeout_seconds INT NOT NULL,
+        enabled BOOLEAN NOT NULL,
+        trigger_requested_at TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+-- Cron Job Runs Table
+CREATE TABLE cron_job_runs (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        job_name VARCHAR(100) NOT NULL REFERENCES cron_jobs (name),
+        trigger VARCHAR(20) NOT NULL,
+        status VARCHAR(20) NOT NULL DEFAULT 'running',
+        started_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL,
+        finished_at TIMESTAMP
+    WITH
+        TIME ZONE,
+        items_processed INT NOT NULL DEFAULT 0,
+        error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_cron_job_runs_job_name_started_at ON cron_job_runs (job_name, started_at DESC);
+
+-- Digest Settings Table
+CREATE TABLE digest_settings (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        updated_at TIMESTAMP
+    WITH
+        TIME ZONE NOT NULL DEFAULT NOW (),
+        user_id INT NOT NULL UNIQUE REFERENCES users (id),
+        enabled BOOLEAN NOT NULL DEFAULT FALSE,
+        frequency VARCHAR(20) NOT NULL DEFAULT 'weekly',
+        weekday SMALLINT NOT NULL DEFAULT 1,
+        day_of_month SMALLINT NOT NULL DEFAULT 1,
+        last_period_end TIMESTAMP
+    WITH
+        TIME ZONE
+);
+
+CREATE INDEX idx_digest_settings_enabled ON digest_settings (user_id)
+WHERE
+    enabled;
+
+-- Two Factor Challenges Identifier
+ALTER TABLE two_factor_challenges
+ADD COLUMN identifier VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Notification Channels Confirmation
+ALTER TABLE notification_channels
+ADD COLUMN confirmed_at TIMESTAMP
+WITH
+    TIME ZONE,
+ADD COLUMN confirmation_token_hash VARCHAR(64) UNIQUE,
+ADD COLUMN confirmation_expires_at TIMESTAMP
+WITH
+    TIME ZONE;
@@ -18,18 +18,20 @@ INSERT INTO accounts (
     name,
     currency,
     status,
-    balance
+    balance,
+    workspace_id
 ) VALUES (
-    $1, $2, $3, $4, $5
-) RETURNING id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance, workspace_id, edit_mode, edit_window_minutes
 `
 
 type CreateAccountParams struct {
-	UserID   int32
-	Name     string
-	Currency string
-	Status   string
-	Balance  decimal.Decimal
+	UserID      int32
+	Name        string
+	Currency    string
+	Status      string
+	Balance     decimal.Decimal
+	WorkspaceID pgtype.Int4
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
@@ -39,6 +41,7 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		arg.Currency,
 		arg.Status,
 		arg.Balance,
+		arg.WorkspaceID,
 	)
 	var i Account
 	err := row.Scan(
@@ -51,6 +54,9 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Status,
 		&i.Currency,
 		&i.Balance,
+		&i.WorkspaceID,
+		&i.EditMode,
+		&i.EditWindowMinutes,
 	)
 	return i, err
 }
@@ -61,7 +67,7 @@ SET
     status = $1,
     updated_at = NOW()
 WHERE id = $2 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance
+RETURNING id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance, workspace_id, edit_mode, edit_window_minutes
 `
 
 type DeactivateAccountByIDParams struct {
@@ -82,6 +88,9 @@ func (q *Queries) DeactivateAccountByID(ctx context.Context, arg DeactivateAccou
 		&i.Status,
 		&i.Currency,
 		&i.Balance,
+		&i.WorkspaceID,
+		&i.EditMode,
+		&i.EditWindowMinutes,
 	)
 	return i, err
 }
@@ -92,7 +101,7 @@ SET
     deleted_at = NOW(),
     updated_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance
+RETURNING id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance, workspace_id, edit_mode, edit_window_minutes
 `
 
 func (q *Queries) DeleteAccount(ctx context.Context, id int32) (Account, error) {
@@ -108,12 +117,15 @@ func (q *Queries) DeleteAccount(ctx context.Context, id int32) (Account, error)
 		&i.Status,
 		&i.Currency,
 		&i.Balance,
+		&i.WorkspaceID,
+		&i.EditMode,
+		&i.EditWindowMinutes,
 	)
 	return i, err
 }
 
 const getAccountByID = `-- name: GetAccountByID :one
-SELECT id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance FROM accounts
+SELECT id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance, workspace_id, edit_mode, edit_window_minutes FROM accounts
 WHERE id = $1 AND deleted_at IS NULL
 LIMIT 1
 `
@@ -131,13 +143,16 @@ func (q *Queries) GetAccountByID(ctx context.Context, id int32) (Account, error)
 		&i.Status,
 		&i.Currency,
 		&i.Balance,
+		&i.WorkspaceID,
+		&i.EditMode,
+		&i.EditWindowMinutes,
 	)
 	return i, err
 }
 
 const getAccountsByUserID = `-- name: GetAccountsByUserID :many
-SELECT id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance FROM accounts
-WHERE user_id = $1 AND deleted_at IS NULL
+SELECT id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance, workspace_id, edit_mode, edit_window_minutes FROM accounts
+WHERE user_id = $1 AND workspace_id IS NULL AND deleted_at IS NULL
 ORDER BY created_at
 `
 
@@ -160,6 +175,48 @@ func (q *Queries) GetAccountsByUserID(ctx context.Context, userID int32) ([]Acco
 			&i.Status,
 			&i.Currency,
 			&i.Balance,
+			&i.WorkspaceID,
+			&i.EditMode,
+			&i.EditWindowMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAccountsByWorkspaceID = `-- name: GetAccountsByWorkspaceID :many
+SELECT id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance, workspace_id, edit_mode, edit_window_minutes FROM accounts
+WHERE workspace_id = $1 AND deleted_at IS NULL
+ORDER BY created_at
+`
+
+func (q *Queries) GetAccountsByWorkspaceID(ctx context.Context, workspaceID pgtype.Int4) ([]Account, error) {
+	rows, err := q.db.Query(ctx, getAccountsByWorkspaceID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.UserID,
+			&i.Name,
+			&i.Status,
+			&i.Currency,
+			&i.Balance,
+			&i.WorkspaceID,
+			&i.EditMode,
+			&i.EditWindowMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -172,7 +229,7 @@ func (q *Queries) GetAccountsByUserID(ctx context.Context, userID int32) ([]Acco
 }
 
 const getAllAccounts = `-- name: GetAllAccounts :many
-SELECT id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance FROM accounts
+SELECT id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance, workspace_id, edit_mode, edit_window_minutes FROM accounts
 WHERE deleted_at IS NULL
 ORDER BY created_at
 `
@@ -196,6 +253,9 @@ func (q *Queries) GetAllAccounts(ctx context.Context) ([]Account, error) {
 			&i.Status,
 			&i.Currency,
 			&i.Balance,
+			&i.WorkspaceID,
+			&i.EditMode,
+			&i.EditWindowMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -213,7 +273,7 @@ SET
     balance = balance + $1,
     updated_at = NOW()
 WHERE id = $2 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance
+RETURNING id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance, workspace_id, edit_mode, edit_window_minutes
 `
 
 type IncreaseAccountBalanceParams struct {
@@ -234,6 +294,9 @@ func (q *Queries) IncreaseAccountBalance(ctx context.Context, arg IncreaseAccoun
 		&i.Status,
 		&i.Currency,
 		&i.Balance,
+		&i.WorkspaceID,
+		&i.EditMode,
+		&i.EditWindowMinutes,
 	)
 	return i, err
 }
@@ -245,17 +308,21 @@ SET
     name = CASE WHEN $2::text IS NULL THEN name ELSE $2 END,
     currency = CASE WHEN $3::text IS NULL THEN currency ELSE $3 END,
     status = CASE WHEN $4::text IS NULL THEN status ELSE $4 END,
+    edit_mode = CASE WHEN $5::text IS NULL THEN edit_mode ELSE $5 END,
+    edit_window_minutes = CASE WHEN $6::int IS NULL THEN edit_window_minutes ELSE $6 END,
     updated_at = NOW()
-WHERE id = $5 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance
+WHERE id = $7 AND deleted_at IS NULL
+RETURNING id, created_at, updated_at, deleted_at, user_id, name, status, currency, balance, workspace_id, edit_mode, edit_window_minutes
 `
 
 type UpdateAccountParams struct {
-	UserID   pgtype.Int4
-	Name     pgtype.Text
-	Currency pgtype.Text
-	Status   pgtype.Text
-	ID       int32
+	UserID            pgtype.Int4
+	Name              pgtype.Text
+	Currency          pgtype.Text
+	Status            pgtype.Text
+	EditMode          pgtype.Text
+	EditWindowMinutes pgtype.Int4
+	ID                int32
 }
 
 func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error) {
@@ -264,6 +331,8 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		arg.Name,
 		arg.Currency,
 		arg.Status,
+		arg.EditMode,
+		arg.EditWindowMinutes,
 		arg.ID,
 	)
 	var i Account
@@ -277,6 +346,9 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 		&i.Status,
 		&i.Currency,
 		&i.Balance,
+		&i.WorkspaceID,
+		&i.EditMode,
+		&i.EditWindowMinutes,
 	)
 	return i, err
 }
@@ -12,7 +12,7 @@ import (
 const deleteIdentity = `-- name: DeleteIdentity :one
 DELETE FROM identities
 WHERE provider = $1 AND identifier = $2
-RETURNING id, user_id, provider, identifier, credential, last_used_at
+RETURNING id, user_id, provider, identifier, credential, last_used_at, verified_at
 `
 
 type DeleteIdentityParams struct {
@@ -30,12 +30,13 @@ func (q *Queries) DeleteIdentity(ctx context.Context, arg DeleteIdentityParams)
 		&i.Identifier,
 		&i.Credential,
 		&i.LastUsedAt,
+		&i.VerifiedAt,
 	)
 	return i, err
 }
 
 const getIdentitiesByUserID = `-- name: GetIdentitiesByUserID :many
-SELECT id, user_id, provider, identifier, credential, last_used_at FROM identities
+SELECT id, user_id, provider, identifier, credential, last_used_at, verified_at FROM identities
 WHERE user_id = $1
 `
 
@@ -55,6 +56,7 @@ func (q *Queries) GetIdentitiesByUserID(ctx context.Context, userID int32) ([]Id
 			&i.Identifier,
 			&i.Credential,
 			&i.LastUsedAt,
+			&i.VerifiedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -67,7 +69,7 @@ func (q *Queries) GetIdentitiesByUserID(ctx context.Context, userID int32) ([]Id
 }
 
 const getIdentityByProviderAndIdentifier = `-- name: GetIdentityByProviderAndIdentifier :one
-SELECT id, user_id, provider, identifier, credential, last_used_at FROM identities
+SELECT id, user_id, provider, identifier, credential, last_used_at, verified_at FROM identities
 WHERE provider = $1 AND identifier = $2
 LIMIT 1
 `
@@ -87,6 +89,7 @@ func (q *Queries) GetIdentityByProviderAndIdentifier(ctx context.Context, arg Ge
 		&i.Identifier,
 		&i.Credential,
 		&i.LastUsedAt,
+		&i.VerifiedAt,
 	)
 	return i, err
 }
@@ -97,7 +100,7 @@ SET
     credential = $3,
     last_used_at = NOW()
 WHERE provider = $1 AND identifier = $2
-RETURNING id, user_id, provider, identifier, credential, last_used_at
+RETURNING id, user_id, provider, identifier, credential, last_used_at, verified_at
 `
 
 type UpdateIdentityCredentialParams struct {
@@ -116,6 +119,7 @@ func (q *Queries) UpdateIdentityCredential(ctx context.Context, arg UpdateIdenti
 		&i.Identifier,
 		&i.Credential,
 		&i.LastUsedAt,
+		&i.VerifiedAt,
 	)
 	return i, err
 }
@@ -125,7 +129,7 @@ UPDATE identities
 SET
     last_used_at = NOW()
 WHERE provider = $1 AND identifier = $2
-RETURNING id, user_id, provider, identifier, credential, last_used_at
+RETURNING id, user_id, provider, identifier, credential, last_used_at, verified_at
 `
 
 type UpdateIdentityLastUsedParams struct {
@@ -143,6 +147,27 @@ func (q *Queries) UpdateIdentityLastUsed(ctx context.Context, arg UpdateIdentity
 		&i.Identifier,
 		&i.Credential,
 		&i.LastUsedAt,
+		&i.VerifiedAt,
 	)
 	return i, err
 }
+
+const verifyIdentity = `-- name: VerifyIdentity :execrows
+UPDATE identities
+SET
+    verified_at = NOW()
+WHERE provider = $1 AND identifier = $2 AND verified_at IS NULL
+`
+
+type VerifyIdentityParams struct {
+	Provider   string
+	Identifier string
+}
+
+func (q *Queries) VerifyIdentity(ctx context.Context, arg VerifyIdentityParams) (int64, error) {
+	result, err := q.db.Exec(ctx, verifyIdentity, arg.Provider, arg.Identifier)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
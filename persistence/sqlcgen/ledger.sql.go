@@ -12,6 +12,54 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const classifyLedger = `-- name: ClassifyLedger :one
+UPDATE ledgers
+SET
+    payee_id = $1,
+    category = $2,
+    tags = $3
+WHERE id = $4 AND deleted_at IS NULL
+RETURNING id, created_at, updated_at, deleted_at, account_id, date, type, amount, note, is_adjustment, adjusted_from, is_voided, voided_at, payee_id, category, tags, is_pending, recurring_transaction_id
+`
+
+type ClassifyLedgerParams struct {
+	PayeeID  pgtype.Int4
+	Category pgtype.Text
+	Tags     []string
+	ID       int32
+}
+
+func (q *Queries) ClassifyLedger(ctx context.Context, arg ClassifyLedgerParams) (Ledger, error) {
+	row := q.db.QueryRow(ctx, classifyLedger,
+		arg.PayeeID,
+		arg.Category,
+		arg.Tags,
+		arg.ID,
+	)
+	var i Ledger
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.AccountID,
+		&i.Date,
+		&i.Type,
+		&i.Amount,
+		&i.Note,
+		&i.IsAdjustment,
+		&i.AdjustedFrom,
+		&i.IsVoided,
+		&i.VoidedAt,
+		&i.PayeeID,
+		&i.Category,
+		&i.Tags,
+		&i.IsPending,
+		&i.RecurringTransactionID,
+	)
+	return i, err
+}
+
 const createLedger = `-- name: CreateLedger :one
 INSERT INTO ledgers (
     account_id,
@@ -20,20 +68,30 @@ INSERT INTO ledgers (
     amount,
     note,
     is_adjustment,
-    adjusted_from
+    adjusted_from,
+    payee_id,
+    category,
+    tags,
+    is_pending,
+    recurring_transaction_id
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7
-) RETURNING id, created_at, updated_at, deleted_at, account_id, date, type, amount, note, is_adjustment, adjusted_from, is_voided, voided_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING id, created_at, updated_at, deleted_at, account_id, date, type, amount, note, is_adjustment, adjusted_from, is_voided, voided_at, payee_id, category, tags, is_pending, recurring_transaction_id
 `
 
 type CreateLedgerParams struct {
-	AccountID    int32
-	Date         pgtype.Timestamptz
-	Type         string
-	Amount       decimal.Decimal
-	Note         pgtype.Text
-	IsAdjustment bool
-	AdjustedFrom pgtype.Int4
+	AccountID              int32
+	Date                   pgtype.Timestamptz
+	Type                   string
+	Amount                 decimal.Decimal
+	Note                   pgtype.Text
+	IsAdjustment           bool
+	AdjustedFrom           pgtype.Int4
+	PayeeID                pgtype.Int4
+	Category               pgtype.Text
+	Tags                   []string
+	IsPending              bool
+	RecurringTransactionID pgtype.Int4
 }
 
 func (q *Queries) CreateLedger(ctx context.Context, arg CreateLedgerParams) (Ledger, error) {
@@ -45,6 +103,11 @@ func (q *Queries) CreateLedger(ctx context.Context, arg CreateLedgerParams) (Led
 		arg.Note,
 		arg.IsAdjustment,
 		arg.AdjustedFrom,
+		arg.PayeeID,
+		arg.Category,
+		arg.Tags,
+		arg.IsPending,
+		arg.RecurringTransactionID,
 	)
 	var i Ledger
 	err := row.Scan(
@@ -61,6 +124,11 @@ func (q *Queries) CreateLedger(ctx context.Context, arg CreateLedgerParams) (Led
 		&i.AdjustedFrom,
 		&i.IsVoided,
 		&i.VoidedAt,
+		&i.PayeeID,
+		&i.Category,
+		&i.Tags,
+		&i.IsPending,
+		&i.RecurringTransactionID,
 	)
 	return i, err
 }
@@ -71,7 +139,7 @@ SET
     deleted_at = NOW(),
     updated_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, account_id, date, type, amount, note, is_adjustment, adjusted_from, is_voided, voided_at
+RETURNING id, created_at, updated_at, deleted_at, account_id, date, type, amount, note, is_adjustment, adjusted_from, is_voided, voided_at, payee_id, category, tags, is_pending, recurring_transaction_id
 `
 
 func (q *Queries) DeleteLedger(ctx context.Context, id int32) (Ledger, error) {
@@ -91,6 +159,11 @@ func (q *Queries) DeleteLedger(ctx context.Context, id int32) (Ledger, error) {
 		&i.AdjustedFrom,
 		&i.IsVoided,
 		&i.VoidedAt,
+		&i.PayeeID,
+		&i.Category,
+		&i.Tags,
+		&i.IsPending,
+		&i.RecurringTransactionID,
 	)
 	return i, err
 }
@@ -110,7 +183,7 @@ func (q *Queries) GetLedgerAmount(ctx context.Context, id int32) (decimal.Decima
 
 const getLedgerByID = `-- name: GetLedgerByID :one
 SELECT
-    l.id, l.created_at, l.updated_at, l.deleted_at, l.account_id, l.date, l.type, l.amount, l.note, l.is_adjustment, l.adjusted_from, l.is_voided, l.voided_at,
+    l.id, l.created_at, l.updated_at, l.deleted_at, l.account_id, l.date, l.type, l.amount, l.note, l.is_adjustment, l.adjusted_from, l.is_voided, l.voided_at, l.payee_id, l.category, l.tags, l.is_pending, l.recurring_transaction_id,
     a.currency
 FROM ledgers l
 JOIN accounts a ON l.account_id = a.id
@@ -119,20 +192,25 @@ LIMIT 1
 `
 
 type GetLedgerByIDRow struct {
-	ID           int32
-	CreatedAt    pgtype.Timestamptz
-	UpdatedAt    pgtype.Timestamptz
-	DeletedAt    pgtype.Timestamptz
-	AccountID    int32
-	Date         pgtype.Timestamptz
-	Type         string
-	Amount       decimal.Decimal
-	Note         pgtype.Text
-	IsAdjustment bool
-	AdjustedFrom pgtype.Int4
-	IsVoided     bool
-	VoidedAt     pgtype.Timestamptz
-	Currency     string
+	ID                     int32
+	CreatedAt              pgtype.Timestamptz
+	UpdatedAt              pgtype.Timestamptz
+	DeletedAt              pgtype.Timestamptz
+	AccountID              int32
+	Date                   pgtype.Timestamptz
+	Type                   string
+	Amount                 decimal.Decimal
+	Note                   pgtype.Text
+	IsAdjustment           bool
+	AdjustedFrom           pgtype.Int4
+	IsVoided               bool
+	VoidedAt               pgtype.Timestamptz
+	PayeeID                pgtype.Int4
+	Category               pgtype.Text
+	Tags                   []string
+	IsPending              bool
+	RecurringTransactionID pgtype.Int4
+	Currency               string
 }
 
 func (q *Queries) GetLedgerByID(ctx context.Context, id int32) (GetLedgerByIDRow, error) {
@@ -152,6 +230,11 @@ func (q *Queries) GetLedgerByID(ctx context.Context, id int32) (GetLedgerByIDRow
 		&i.AdjustedFrom,
 		&i.IsVoided,
 		&i.VoidedAt,
+		&i.PayeeID,
+		&i.Category,
+		&i.Tags,
+		&i.IsPending,
+		&i.RecurringTransactionID,
 		&i.Currency,
 	)
 	return i, err
@@ -159,7 +242,7 @@ func (q *Queries) GetLedgerByID(ctx context.Context, id int32) (GetLedgerByIDRow
 
 const getLedgersByAccountID = `-- name: GetLedgersByAccountID :many
 SELECT
-    l.id, l.created_at, l.updated_at, l.deleted_at, l.account_id, l.date, l.type, l.amount, l.note, l.is_adjustment, l.adjusted_from, l.is_voided, l.voided_at,
+    l.id, l.created_at, l.updated_at, l.deleted_at, l.account_id, l.date, l.type, l.amount, l.note, l.is_adjustment, l.adjusted_from, l.is_voided, l.voided_at, l.payee_id, l.category, l.tags, l.is_pending, l.recurring_transaction_id,
     a.currency
 FROM ledgers l
 JOIN accounts a ON l.account_id = a.id
@@ -168,20 +251,25 @@ ORDER BY l.date DESC, l.updated_at DESC
 `
 
 type GetLedgersByAccountIDRow struct {
-	ID           int32
-	CreatedAt    pgtype.Timestamptz
-	UpdatedAt    pgtype.Timestamptz
-	DeletedAt    pgtype.Timestamptz
-	AccountID    int32
-	Date         pgtype.Timestamptz
-	Type         string
-	Amount       decimal.Decimal
-	Note         pgtype.Text
-	IsAdjustment bool
-	AdjustedFrom pgtype.Int4
-	IsVoided     bool
-	VoidedAt     pgtype.Timestamptz
-	Currency     string
+	ID                     int32
+	CreatedAt              pgtype.Timestamptz
+	UpdatedAt              pgtype.Timestamptz
+	DeletedAt              pgtype.Timestamptz
+	AccountID              int32
+	Date                   pgtype.Timestamptz
+	Type                   string
+	Amount                 decimal.Decimal
+	Note                   pgtype.Text
+	IsAdjustment           bool
+	AdjustedFrom           pgtype.Int4
+	IsVoided               bool
+	VoidedAt               pgtype.Timestamptz
+	PayeeID                pgtype.Int4
+	Category               pgtype.Text
+	Tags                   []string
+	IsPending              bool
+	RecurringTransactionID pgtype.Int4
+	Currency               string
 }
 
 func (q *Queries) GetLedgersByAccountID(ctx context.Context, accountID int32) ([]GetLedgersByAccountIDRow, error) {
@@ -207,6 +295,83 @@ func (q *Queries) GetLedgersByAccountID(ctx context.Context, accountID int32) ([
 			&i.AdjustedFrom,
 			&i.IsVoided,
 			&i.VoidedAt,
+			&i.PayeeID,
+			&i.Category,
+			&i.Tags,
+			&i.IsPending,
+			&i.RecurringTransactionID,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLedgersByRecurringTransactionID = `-- name: GetLedgersByRecurringTransactionID :many
+SELECT
+    l.id, l.created_at, l.updated_at, l.deleted_at, l.account_id, l.date, l.type, l.amount, l.note, l.is_adjustment, l.adjusted_from, l.is_voided, l.voided_at, l.payee_id, l.category, l.tags, l.is_pending, l.recurring_transaction_id,
+    a.currency
+FROM ledgers l
+JOIN accounts a ON l.account_id = a.id
+WHERE l.recurring_transaction_id = $1 AND l.deleted_at IS NULL AND a.deleted_at IS NULL
+ORDER BY l.date DESC, l.id DESC
+`
+
+type GetLedgersByRecurringTransactionIDRow struct {
+	ID                     int32
+	CreatedAt              pgtype.Timestamptz
+	UpdatedAt              pgtype.Timestamptz
+	DeletedAt              pgtype.Timestamptz
+	AccountID              int32
+	Date                   pgtype.Timestamptz
+	Type                   string
+	Amount                 decimal.Decimal
+	Note                   pgtype.Text
+	IsAdjustment           bool
+	AdjustedFrom           pgtype.Int4
+	IsVoided               bool
+	VoidedAt               pgtype.Timestamptz
+	PayeeID                pgtype.Int4
+	Category               pgtype.Text
+	Tags                   []string
+	IsPending              bool
+	RecurringTransactionID pgtype.Int4
+	Currency               string
+}
+
+func (q *Queries) GetLedgersByRecurringTransactionID(ctx context.Context, recurringTransactionID pgtype.Int4) ([]GetLedgersByRecurringTransactionIDRow, error) {
+	rows, err := q.db.Query(ctx, getLedgersByRecurringTransactionID, recurringTransactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetLedgersByRecurringTransactionIDRow{}
+	for rows.Next() {
+		var i GetLedgersByRecurringTransactionIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.AccountID,
+			&i.Date,
+			&i.Type,
+			&i.Amount,
+			&i.Note,
+			&i.IsAdjustment,
+			&i.AdjustedFrom,
+			&i.IsVoided,
+			&i.VoidedAt,
+			&i.PayeeID,
+			&i.Category,
+			&i.Tags,
+			&i.IsPending,
+			&i.RecurringTransactionID,
 			&i.Currency,
 		); err != nil {
 			return nil, err
@@ -219,6 +384,119 @@ func (q *Queries) GetLedgersByAccountID(ctx context.Context, accountID int32) ([
 	return items, nil
 }
 
+const getLedgersByUserID = `-- name: GetLedgersByUserID :many
+SELECT
+    l.id, l.created_at, l.updated_at, l.deleted_at, l.account_id, l.date, l.type, l.amount, l.note, l.is_adjustment, l.adjusted_from, l.is_voided, l.voided_at, l.payee_id, l.category, l.tags, l.is_pending, l.recurring_transaction_id,
+    a.currency
+FROM ledgers l
+JOIN accounts a ON l.account_id = a.id
+WHERE a.user_id = $1 AND l.is_voided = FALSE AND l.is_pending = FALSE AND l.deleted_at IS NULL AND a.deleted_at IS NULL
+ORDER BY l.date DESC, l.id DESC
+`
+
+type GetLedgersByUserIDRow struct {
+	ID                     int32
+	CreatedAt              pgtype.Timestamptz
+	UpdatedAt              pgtype.Timestamptz
+	DeletedAt              pgtype.Timestamptz
+	AccountID              int32
+	Date                   pgtype.Timestamptz
+	Type                   string
+	Amount                 decimal.Decimal
+	Note                   pgtype.Text
+	IsAdjustment           bool
+	AdjustedFrom           pgtype.Int4
+	IsVoided               bool
+	VoidedAt               pgtype.Timestamptz
+	PayeeID                pgtype.Int4
+	Category               pgtype.Text
+	Tags                   []string
+	IsPending              bool
+	RecurringTransactionID pgtype.Int4
+	Currency               string
+}
+
+func (q *Queries) GetLedgersByUserID(ctx context.Context, userID int32) ([]GetLedgersByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, getLedgersByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetLedgersByUserIDRow{}
+	for rows.Next() {
+		var i GetLedgersByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.AccountID,
+			&i.Date,
+			&i.Type,
+			&i.Amount,
+			&i.Note,
+			&i.IsAdjustment,
+			&i.AdjustedFrom,
+			&i.IsVoided,
+			&i.VoidedAt,
+			&i.PayeeID,
+			&i.Category,
+			&i.Tags,
+			&i.IsPending,
+			&i.RecurringTransactionID,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const postPendingLedger = `-- name: PostPendingLedger :one
+UPDATE ledgers
+SET
+    is_pending = FALSE,
+    amount = CASE WHEN $1::decimal IS NULL THEN amount ELSE $1 END,
+    updated_at = NOW()
+WHERE id = $2 AND is_pending AND deleted_at IS NULL
+RETURNING id, created_at, updated_at, deleted_at, account_id, date, type, amount, note, is_adjustment, adjusted_from, is_voided, voided_at, payee_id, category, tags, is_pending, recurring_transaction_id
+`
+
+type PostPendingLedgerParams struct {
+	Amount pgtype.Numeric
+	ID     int32
+}
+
+func (q *Queries) PostPendingLedger(ctx context.Context, arg PostPendingLedgerParams) (Ledger, error) {
+	row := q.db.QueryRow(ctx, postPendingLedger, arg.Amount, arg.ID)
+	var i Ledger
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.AccountID,
+		&i.Date,
+		&i.Type,
+		&i.Amount,
+		&i.Note,
+		&i.IsAdjustment,
+		&i.AdjustedFrom,
+		&i.IsVoided,
+		&i.VoidedAt,
+		&i.PayeeID,
+		&i.Category,
+		&i.Tags,
+		&i.IsPending,
+		&i.RecurringTransactionID,
+	)
+	return i, err
+}
+
 const updateLedger = `-- name: UpdateLedger :one
 UPDATE ledgers
 SET
@@ -226,17 +504,23 @@ SET
     type = CASE WHEN $2::text IS NULL THEN type ELSE $2 END,
     amount = CASE WHEN $3::decimal IS NULL THEN amount ELSE $3 END,
     note = CASE WHEN $4::text IS NULL THEN note ELSE $4 END,
+    payee_id = CASE WHEN $5::int IS NULL THEN payee_id ELSE $5 END,
+    category = CASE WHEN $6::text IS NULL THEN category ELSE $6 END,
+    tags = CASE WHEN $7::text[] IS NULL THEN tags ELSE $7 END,
     updated_at = NOW()
-WHERE id = $5 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, account_id, date, type, amount, note, is_adjustment, adjusted_from, is_voided, voided_at
+WHERE id = $8 AND deleted_at IS NULL
+RETURNING id, created_at, updated_at, deleted_at, account_id, date, type, amount, note, is_adjustment, adjusted_from, is_voided, voided_at, payee_id, category, tags, is_pending, recurring_transaction_id
 `
 
 type UpdateLedgerParams struct {
-	Date   pgtype.Timestamptz
-	Type   pgtype.Text
-	Amount pgtype.Numeric
-	Note   pgtype.Text
-	ID     int32
+	Date     pgtype.Timestamptz
+	Type     pgtype.Text
+	Amount   pgtype.Numeric
+	Note     pgtype.Text
+	PayeeID  pgtype.Int4
+	Category pgtype.Text
+	Tags     []string
+	ID       int32
 }
 
 func (q *Queries) UpdateLedger(ctx context.Context, arg UpdateLedgerParams) (Ledger, error) {
@@ -245,6 +529,9 @@ func (q *Queries) UpdateLedger(ctx context.Context, arg UpdateLedgerParams) (Led
 		arg.Type,
 		arg.Amount,
 		arg.Note,
+		arg.PayeeID,
+		arg.Category,
+		arg.Tags,
 		arg.ID,
 	)
 	var i Ledger
@@ -262,6 +549,11 @@ func (q *Queries) UpdateLedger(ctx context.Context, arg UpdateLedgerParams) (Led
 		&i.AdjustedFrom,
 		&i.IsVoided,
 		&i.VoidedAt,
+		&i.PayeeID,
+		&i.Category,
+		&i.Tags,
+		&i.IsPending,
+		&i.RecurringTransactionID,
 	)
 	return i, err
 }
@@ -273,7 +565,7 @@ SET
     voided_at = NOW(),
     updated_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, account_id, date, type, amount, note, is_adjustment, adjusted_from, is_voided, voided_at
+RETURNING id, created_at, updated_at, deleted_at, account_id, date, type, amount, note, is_adjustment, adjusted_from, is_voided, voided_at, payee_id, category, tags, is_pending, recurring_transaction_id
 `
 
 func (q *Queries) VoidLedger(ctx context.Context, id int32) (Ledger, error) {
@@ -293,6 +585,11 @@ func (q *Queries) VoidLedger(ctx context.Context, id int32) (Ledger, error) {
 		&i.AdjustedFrom,
 		&i.IsVoided,
 		&i.VoidedAt,
+		&i.PayeeID,
+		&i.Category,
+		&i.Tags,
+		&i.IsPending,
+		&i.RecurringTransactionID,
 	)
 	return i, err
 }
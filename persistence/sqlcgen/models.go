@@ -9,16 +9,78 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-type Account struct {
+type AccountInvitation struct {
+	ID          int32
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+	AccountID   int32
+	InviterID   int32
+	Email       string
+	Role        string
+	Status      string
+	ExpiresAt   pgtype.Timestamptz
+	RespondedAt pgtype.Timestamptz
+}
+
+type AccountMember struct {
 	ID        int32
 	CreatedAt pgtype.Timestamptz
 	UpdatedAt pgtype.Timestamptz
-	DeletedAt pgtype.Timestamptz
+	AccountID int32
 	UserID    int32
-	Name      string
-	Status    string
-	Currency  string
-	Balance   decimal.Decimal
+	Role      string
+}
+
+type Account struct {
+	ID                int32
+	CreatedAt         pgtype.Timestamptz
+	UpdatedAt         pgtype.Timestamptz
+	DeletedAt         pgtype.Timestamptz
+	UserID            int32
+	Name              string
+	Status            string
+	Currency          string
+	Balance           decimal.Decimal
+	WorkspaceID       pgtype.Int4
+	EditMode          string
+	EditWindowMinutes int32
+}
+
+type ApiKey struct {
+	ID         int32
+	CreatedAt  pgtype.Timestamptz
+	UserID     int32
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	ExpiresAt  pgtype.Timestamptz
+	LastUsedAt pgtype.Timestamptz
+	RevokedAt  pgtype.Timestamptz
+}
+
+type AuditLog struct {
+	ID                int32
+	CreatedAt         pgtype.Timestamptz
+	ActorUserID       pgtype.Int4
+	Action            string
+	EntityType        string
+	EntityID          int32
+	Before            []byte
+	After             []byte
+	IpAddress         string
+	BooksLockOverride bool
+}
+
+type AuthEvent struct {
+	ID         int32
+	CreatedAt  pgtype.Timestamptz
+	UserID     pgtype.Int4
+	Type       string
+	Provider   string
+	Identifier string
+	IpAddress  string
+	UserAgent  string
 }
 
 type BankAccount struct {
@@ -33,6 +95,60 @@ type BankAccount struct {
 	SwiftCode     pgtype.Text
 }
 
+type BooksLock struct {
+	ID            int32
+	CreatedAt     pgtype.Timestamptz
+	UpdatedAt     pgtype.Timestamptz
+	UserID        pgtype.Int4
+	AccountID     pgtype.Int4
+	ClosedThrough pgtype.Date
+}
+
+type CronJobRun struct {
+	ID             int32
+	CreatedAt      pgtype.Timestamptz
+	JobName        string
+	Trigger        string
+	Status         string
+	StartedAt      pgtype.Timestamptz
+	FinishedAt     pgtype.Timestamptz
+	ItemsProcessed int32
+	Error          string
+}
+
+type CronJob struct {
+	ID                 int32
+	CreatedAt          pgtype.Timestamptz
+	UpdatedAt          pgtype.Timestamptz
+	Name               string
+	Schedule           string
+	TimeoutSeconds     int32
+	Enabled            bool
+	TriggerRequestedAt pgtype.Timestamptz
+}
+
+type DigestSetting struct {
+	ID            int32
+	CreatedAt     pgtype.Timestamptz
+	UpdatedAt     pgtype.Timestamptz
+	UserID        int32
+	Enabled       bool
+	Frequency     string
+	Weekday       int16
+	DayOfMonth    int16
+	LastPeriodEnd pgtype.Timestamptz
+}
+
+type EmailVerificationToken struct {
+	ID         int32
+	CreatedAt  pgtype.Timestamptz
+	UserID     int32
+	Identifier string
+	TokenHash  string
+	ExpiresAt  pgtype.Timestamptz
+	UsedAt     pgtype.Timestamptz
+}
+
 type Identity struct {
 	ID         int32
 	UserID     int32
@@ -40,45 +156,161 @@ type Identity struct {
 	Identifier string
 	Credential string
 	LastUsedAt pgtype.Timestamptz
+	VerifiedAt pgtype.Timestamptz
 }
 
 type Ledger struct {
+	ID                     int32
+	CreatedAt              pgtype.Timestamptz
+	UpdatedAt              pgtype.Timestamptz
+	DeletedAt              pgtype.Timestamptz
+	AccountID              int32
+	Date                   pgtype.Timestamptz
+	Type                   string
+	Amount                 decimal.Decimal
+	Note                   pgtype.Text
+	IsAdjustment           bool
+	AdjustedFrom           pgtype.Int4
+	IsVoided               bool
+	VoidedAt               pgtype.Timestamptz
+	PayeeID                pgtype.Int4
+	Category               pgtype.Text
+	Tags                   []string
+	IsPending              bool
+	RecurringTransactionID pgtype.Int4
+}
+
+type LoginAttempt struct {
+	Provider     string
+	Identifier   string
+	FailedCount  int32
+	LastFailedAt pgtype.Timestamptz
+	LockedUntil  pgtype.Timestamptz
+}
+
+type NotificationChannel struct {
+	ID                    int32
+	CreatedAt             pgtype.Timestamptz
+	UpdatedAt             pgtype.Timestamptz
+	UserID                int32
+	Channel               string
+	Target                string
+	Enabled               bool
+	ConfirmedAt           pgtype.Timestamptz
+	ConfirmationTokenHash pgtype.Text
+	ConfirmationExpiresAt pgtype.Timestamptz
+}
+
+type OidcAuthRequest struct {
 	ID           int32
 	CreatedAt    pgtype.Timestamptz
-	UpdatedAt    pgtype.Timestamptz
-	DeletedAt    pgtype.Timestamptz
-	AccountID    int32
-	Date         pgtype.Timestamptz
-	Type         string
-	Amount       decimal.Decimal
-	Note         pgtype.Text
-	IsAdjustment bool
-	AdjustedFrom pgtype.Int4
-	IsVoided     bool
-	VoidedAt     pgtype.Timestamptz
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	LinkUserID   pgtype.Int4
+	ExpiresAt    pgtype.Timestamptz
+	UsedAt       pgtype.Timestamptz
+}
+
+type PasswordResetToken struct {
+	ID         int32
+	CreatedAt  pgtype.Timestamptz
+	UserID     int32
+	Identifier string
+	TokenHash  string
+	ExpiresAt  pgtype.Timestamptz
+	UsedAt     pgtype.Timestamptz
+}
+
+type PayeeRule struct {
+	ID        int32
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+	DeletedAt pgtype.Timestamptz
+	UserID    int32
+	PayeeID   pgtype.Int4
+	MatchType string
+	Pattern   string
+	Category  pgtype.Text
+	Tags      []string
+	Priority  int32
+}
+
+type Payee struct {
+	ID        int32
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+	DeletedAt pgtype.Timestamptz
+	UserID    int32
+	Name      string
+}
+
+type RecoveryCode struct {
+	ID        int32
+	CreatedAt pgtype.Timestamptz
+	UserID    int32
+	CodeHash  string
+	UsedAt    pgtype.Timestamptz
+}
+
+type RecurringTransactionExecution struct {
+	ID                     int32
+	CreatedAt              pgtype.Timestamptz
+	RecurringTransactionID int32
+	ScheduledFor           pgtype.Timestamptz
+	Status                 string
+	LedgerID               pgtype.Int4
 }
 
 type RecurringTransaction struct {
-	ID           int32
-	CreatedAt    pgtype.Timestamptz
-	UpdatedAt    pgtype.Timestamptz
-	DeletedAt    pgtype.Timestamptz
-	UserID       int32
-	AccountID    int32
-	Name         string
-	Type         string
-	Amount       decimal.Decimal
-	Note         pgtype.Text
-	StartDate    pgtype.Timestamptz
-	EndDate      pgtype.Timestamptz
-	RecurType    string
-	Status       string
-	Frequency    int32
-	DayOfWeek    pgtype.Int4
-	DayOfMonth   pgtype.Int4
-	MonthOfYear  pgtype.Int4
-	LastExecuted pgtype.Timestamptz
-	NextDue      pgtype.Timestamptz
+	ID              int32
+	CreatedAt       pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+	DeletedAt       pgtype.Timestamptz
+	UserID          int32
+	AccountID       int32
+	Name            string
+	Type            string
+	Amount          decimal.Decimal
+	Note            pgtype.Text
+	StartDate       pgtype.Timestamptz
+	EndDate         pgtype.Timestamptz
+	RecurType       string
+	Status          string
+	Frequency       int32
+	DayOfWeek       pgtype.Int4
+	DayOfMonth      pgtype.Int4
+	MonthOfYear     pgtype.Int4
+	LastExecuted    pgtype.Timestamptz
+	NextDue         pgtype.Timestamptz
+	WorkspaceID     pgtype.Int4
+	Rrule           string
+	CatchUpPolicy   string
+	PostMode        string
+	ReminderOffsets []int32
+}
+
+type ReminderDelivery struct {
+	ID            int32
+	CreatedAt     pgtype.Timestamptz
+	UpdatedAt     pgtype.Timestamptz
+	ReminderID    int32
+	ReminderDate  pgtype.Timestamptz
+	Channel       string
+	Target        string
+	Status        string
+	Attempts      int32
+	NextAttemptAt pgtype.Timestamptz
+	LastError     string
+	DeliveredAt   pgtype.Timestamptz
+}
+
+type ReminderSetting struct {
+	ID             int32
+	CreatedAt      pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+	UserID         int32
+	DefaultOffsets []int32
 }
 
 type Reminder struct {
@@ -90,6 +322,42 @@ type Reminder struct {
 	ReminderDate           pgtype.Timestamptz
 	IsRead                 bool
 	ReadAt                 pgtype.Timestamptz
+	LedgerID               pgtype.Int4
+}
+
+type Session struct {
+	ID                       int32
+	CreatedAt                pgtype.Timestamptz
+	UpdatedAt                pgtype.Timestamptz
+	UserID                   int32
+	Provider                 string
+	RefreshTokenHash         string
+	PreviousRefreshTokenHash pgtype.Text
+	UserAgent                string
+	IpAddress                string
+	LastUsedAt               pgtype.Timestamptz
+	ExpiresAt                pgtype.Timestamptz
+	RevokedAt                pgtype.Timestamptz
+}
+
+type TotpSecret struct {
+	UserID       int32
+	CreatedAt    pgtype.Timestamptz
+	Secret       string
+	ConfirmedAt  pgtype.Timestamptz
+	LastUsedStep int64
+}
+
+type TwoFactorChallenge struct {
+	ID         int32
+	CreatedAt  pgtype.Timestamptz
+	UserID     int32
+	Provider   string
+	TokenHash  string
+	Attempts   int32
+	ExpiresAt  pgtype.Timestamptz
+	UsedAt     pgtype.Timestamptz
+	Identifier string
 }
 
 type User struct {
@@ -100,4 +368,23 @@ type User struct {
 	Disabled  bool
 	Name      string
 	Nickname  pgtype.Text
+	Role      string
+}
+
+type WorkspaceMember struct {
+	ID          int32
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+	WorkspaceID int32
+	UserID      int32
+	Role        string
+}
+
+type Workspace struct {
+	ID        int32
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+	DeletedAt pgtype.Timestamptz
+	Name      string
+	Type      string
 }
@@ -13,51 +13,166 @@ import (
 
 type Querier interface {
 	AddIdentity(ctx context.Context, arg AddIdentityParams) (Identity, error)
+	AddWorkspaceMemberByEmail(ctx context.Context, arg AddWorkspaceMemberByEmailParams) (WorkspaceMember, error)
+	ClaimCronJobTrigger(ctx context.Context, name string) (CronJob, error)
+	ClaimDigestPeriod(ctx context.Context, arg ClaimDigestPeriodParams) (DigestSetting, error)
+	ClaimRecurringTransactionOccurrence(ctx context.Context, arg ClaimRecurringTransactionOccurrenceParams) (RecurringTransaction, error)
+	ClaimReminderDeliveries(ctx context.Context, arg ClaimReminderDeliveriesParams) ([]ReminderDelivery, error)
+	ClassifyLedger(ctx context.Context, arg ClassifyLedgerParams) (Ledger, error)
+	ClearLoginAttempts(ctx context.Context, arg ClearLoginAttemptsParams) error
+	ConfirmNotificationChannel(ctx context.Context, confirmationTokenHash pgtype.Text) (NotificationChannel, error)
+	ConfirmTOTPSecret(ctx context.Context, userID int32) (int64, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID int32) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAccountInvitation(ctx context.Context, arg CreateAccountInvitationParams) (AccountInvitation, error)
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error
+	CreateAuthEvent(ctx context.Context, arg CreateAuthEventParams) error
 	CreateBankAccount(ctx context.Context, arg CreateBankAccountParams) (BankAccount, error)
+	CreateCronJobRun(ctx context.Context, arg CreateCronJobRunParams) (CronJobRun, error)
+	CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) (EmailVerificationToken, error)
 	CreateLedger(ctx context.Context, arg CreateLedgerParams) (Ledger, error)
+	CreateOIDCAuthRequest(ctx context.Context, arg CreateOIDCAuthRequestParams) (OidcAuthRequest, error)
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) (PasswordResetToken, error)
+	CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error)
+	CreatePayeeRule(ctx context.Context, arg CreatePayeeRuleParams) (PayeeRule, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
 	CreateRecurringTransaction(ctx context.Context, arg CreateRecurringTransactionParams) (RecurringTransaction, error)
+	CreateRecurringTransactionExecution(ctx context.Context, arg CreateRecurringTransactionExecutionParams) (RecurringTransactionExecution, error)
 	CreateReminder(ctx context.Context, arg CreateReminderParams) (Reminder, error)
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateTwoFactorChallenge(ctx context.Context, arg CreateTwoFactorChallengeParams) (TwoFactorChallenge, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWorkspace(ctx context.Context, arg CreateWorkspaceParams) (Workspace, error)
 	DeactivateAccountByID(ctx context.Context, arg DeactivateAccountByIDParams) (Account, error)
 	DeactivateUserByID(ctx context.Context, id int32) (User, error)
 	DeleteAccount(ctx context.Context, id int32) (Account, error)
+	DeleteAccountBooksLock(ctx context.Context, accountID pgtype.Int4) error
+	DeleteAccountMember(ctx context.Context, arg DeleteAccountMemberParams) (int64, error)
 	DeleteBankAccount(ctx context.Context, id int32) (BankAccount, error)
 	DeleteIdentity(ctx context.Context, arg DeleteIdentityParams) (Identity, error)
 	DeleteLedger(ctx context.Context, id int32) (Ledger, error)
+	DeleteNotificationChannel(ctx context.Context, arg DeleteNotificationChannelParams) error
+	DeletePayee(ctx context.Context, id int32) (Payee, error)
+	DeletePayeeRule(ctx context.Context, id int32) (PayeeRule, error)
+	DeleteRecoveryCodesByUserID(ctx context.Context, userID int32) error
 	DeleteRecurringTransaction(ctx context.Context, id int32) (RecurringTransaction, error)
 	DeleteReminder(ctx context.Context, id int32) (Reminder, error)
+	DeleteTOTPSecret(ctx context.Context, userID int32) error
+	DeleteUpcomingReminders(ctx context.Context, arg DeleteUpcomingRemindersParams) error
 	DeleteUser(ctx context.Context, id int32) (User, error)
+	DeleteUserBooksLock(ctx context.Context, userID pgtype.Int4) error
+	DeleteWorkspace(ctx context.Context, id int32) (Workspace, error)
+	DeleteWorkspaceMember(ctx context.Context, arg DeleteWorkspaceMemberParams) (int64, error)
+	FinishCronJobRun(ctx context.Context, arg FinishCronJobRunParams) error
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
+	GetAPIKeysByUserID(ctx context.Context, userID int32) ([]ApiKey, error)
 	GetAccountByID(ctx context.Context, id int32) (Account, error)
+	GetAccountInvitationByID(ctx context.Context, id int32) (AccountInvitation, error)
+	GetAccountInvitationsByAccountID(ctx context.Context, accountID int32) ([]AccountInvitation, error)
+	GetAccountMember(ctx context.Context, arg GetAccountMemberParams) (AccountMember, error)
+	GetAccountMembersByAccountID(ctx context.Context, accountID int32) ([]AccountMember, error)
 	GetAccountsByUserID(ctx context.Context, userID int32) ([]Account, error)
+	GetAccountsByWorkspaceID(ctx context.Context, workspaceID pgtype.Int4) ([]Account, error)
 	GetActiveRecurringTransactionsDue(ctx context.Context, nextDue pgtype.Timestamptz) ([]RecurringTransaction, error)
 	GetActiveRemindersByUserID(ctx context.Context, arg GetActiveRemindersByUserIDParams) ([]Reminder, error)
+	GetActiveSessionsByUserID(ctx context.Context, userID int32) ([]Session, error)
 	GetAllAccounts(ctx context.Context) ([]Account, error)
 	GetAllUsers(ctx context.Context) ([]User, error)
+	GetAuditLogsByEntity(ctx context.Context, arg GetAuditLogsByEntityParams) ([]AuditLog, error)
+	GetAuthEventsByUserID(ctx context.Context, arg GetAuthEventsByUserIDParams) ([]AuthEvent, error)
 	GetBankAccountByAccountID(ctx context.Context, accountID int32) (BankAccount, error)
 	GetBankAccountByID(ctx context.Context, id int32) (BankAccount, error)
+	GetBooksLockByAccountID(ctx context.Context, accountID pgtype.Int4) (BooksLock, error)
+	GetBooksLockByUserID(ctx context.Context, userID pgtype.Int4) (BooksLock, error)
+	GetCronJobByName(ctx context.Context, name string) (CronJob, error)
+	GetCronJobRunsByJobName(ctx context.Context, arg GetCronJobRunsByJobNameParams) ([]CronJobRun, error)
+	GetCronJobs(ctx context.Context) ([]CronJob, error)
+	GetDigestSettingsByUserID(ctx context.Context, userID int32) (DigestSetting, error)
+	GetEnabledDigestSettings(ctx context.Context) ([]DigestSetting, error)
 	GetIdentitiesByUserID(ctx context.Context, userID int32) ([]Identity, error)
 	GetIdentityByProviderAndIdentifier(ctx context.Context, arg GetIdentityByProviderAndIdentifierParams) (Identity, error)
 	GetLedgerAmount(ctx context.Context, id int32) (decimal.Decimal, error)
 	GetLedgerByID(ctx context.Context, id int32) (GetLedgerByIDRow, error)
 	GetLedgersByAccountID(ctx context.Context, accountID int32) ([]GetLedgersByAccountIDRow, error)
+	GetLedgersByRecurringTransactionID(ctx context.Context, recurringTransactionID pgtype.Int4) ([]GetLedgersByRecurringTransactionIDRow, error)
+	GetLedgersByUserID(ctx context.Context, userID int32) ([]GetLedgersByUserIDRow, error)
+	GetLoginAttempt(ctx context.Context, arg GetLoginAttemptParams) (LoginAttempt, error)
+	GetNotificationChannelsByUserID(ctx context.Context, userID int32) ([]NotificationChannel, error)
+	GetPayeeByID(ctx context.Context, id int32) (Payee, error)
+	GetPayeeRuleByID(ctx context.Context, id int32) (PayeeRule, error)
+	GetPayeeRulesByUserID(ctx context.Context, userID int32) ([]PayeeRule, error)
+	GetPayeesByUserID(ctx context.Context, userID int32) ([]Payee, error)
+	GetPendingAccountInvitationsByUserID(ctx context.Context, userID int32) ([]AccountInvitation, error)
 	GetRecurringTransactionByID(ctx context.Context, id int32) (RecurringTransaction, error)
+	GetRecurringTransactionExecutions(ctx context.Context, recurringTransactionID int32) ([]RecurringTransactionExecution, error)
+	GetRecurringTransactionIncludingDeleted(ctx context.Context, id int32) (RecurringTransaction, error)
 	GetRecurringTransactionsByUserID(ctx context.Context, userID int32) ([]RecurringTransaction, error)
+	GetRecurringTransactionsByWorkspaceID(ctx context.Context, workspaceID pgtype.Int4) ([]RecurringTransaction, error)
 	GetReminderByID(ctx context.Context, id int32) (Reminder, error)
+	GetReminderDeliveriesByReminderID(ctx context.Context, reminderID int32) ([]ReminderDelivery, error)
+	GetReminderSettingsByUserID(ctx context.Context, userID int32) (ReminderSetting, error)
 	GetRemindersByRecurringTransactionID(ctx context.Context, recurringTransactionID int32) ([]Reminder, error)
+	GetSessionByID(ctx context.Context, id int32) (Session, error)
+	GetSessionByRefreshTokenHash(ctx context.Context, hash string) (Session, error)
+	GetSharedAccountsByUserID(ctx context.Context, userID int32) ([]GetSharedAccountsByUserIDRow, error)
+	GetSpendingByCategory(ctx context.Context, arg GetSpendingByCategoryParams) ([]GetSpendingByCategoryRow, error)
+	GetTOTPSecretByUserID(ctx context.Context, userID int32) (TotpSecret, error)
+	GetTwoFactorChallengeByTokenHash(ctx context.Context, tokenHash string) (TwoFactorChallenge, error)
 	GetUpcomingReminders(ctx context.Context, arg GetUpcomingRemindersParams) ([]GetUpcomingRemindersRow, error)
 	GetUserByID(ctx context.Context, id int32) (User, error)
 	GetUserByIdentity(ctx context.Context, arg GetUserByIdentityParams) (GetUserByIdentityRow, error)
+	GetWorkspaceByID(ctx context.Context, id int32) (Workspace, error)
+	GetWorkspaceMember(ctx context.Context, arg GetWorkspaceMemberParams) (WorkspaceMember, error)
+	GetWorkspaceMembersByWorkspaceID(ctx context.Context, workspaceID int32) ([]WorkspaceMember, error)
+	GetWorkspacesByUserID(ctx context.Context, userID int32) ([]GetWorkspacesByUserIDRow, error)
 	IncreaseAccountBalance(ctx context.Context, arg IncreaseAccountBalanceParams) (Account, error)
+	IncrementTwoFactorChallengeAttempts(ctx context.Context, arg IncrementTwoFactorChallengeAttemptsParams) (int32, error)
+	InvalidatePasswordResetTokensByUserID(ctx context.Context, userID int32) error
+	LockLogin(ctx context.Context, arg LockLoginParams) error
 	MarkReminderAsRead(ctx context.Context, id int32) (Reminder, error)
+	PostPendingLedger(ctx context.Context, arg PostPendingLedgerParams) (Ledger, error)
+	QueueReminderDeliveries(ctx context.Context, arg QueueReminderDeliveriesParams) ([]ReminderDelivery, error)
+	RecordFailedLogin(ctx context.Context, arg RecordFailedLoginParams) (LoginAttempt, error)
+	ReleaseDigestPeriod(ctx context.Context, arg ReleaseDigestPeriodParams) error
+	RequestCronJobTrigger(ctx context.Context, name string) (CronJob, error)
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
+	RevokeSession(ctx context.Context, id int32) (int64, error)
+	RevokeSessionsByUserID(ctx context.Context, arg RevokeSessionsByUserIDParams) error
+	RotateSessionRefreshToken(ctx context.Context, arg RotateSessionRefreshTokenParams) (Session, error)
+	SnoozeReminder(ctx context.Context, arg SnoozeReminderParams) (Reminder, error)
+	TouchAPIKey(ctx context.Context, arg TouchAPIKeyParams) error
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountInvitationStatus(ctx context.Context, arg UpdateAccountInvitationStatusParams) (AccountInvitation, error)
+	UpdateAccountMemberRole(ctx context.Context, arg UpdateAccountMemberRoleParams) (AccountMember, error)
 	UpdateBankAccount(ctx context.Context, arg UpdateBankAccountParams) (BankAccount, error)
 	UpdateIdentityCredential(ctx context.Context, arg UpdateIdentityCredentialParams) (Identity, error)
 	UpdateIdentityLastUsed(ctx context.Context, arg UpdateIdentityLastUsedParams) (Identity, error)
 	UpdateLedger(ctx context.Context, arg UpdateLedgerParams) (Ledger, error)
+	UpdatePayee(ctx context.Context, arg UpdatePayeeParams) (Payee, error)
+	UpdatePayeeRule(ctx context.Context, arg UpdatePayeeRuleParams) (PayeeRule, error)
 	UpdateRecurringTransaction(ctx context.Context, arg UpdateRecurringTransactionParams) (RecurringTransaction, error)
 	UpdateRecurringTransactionExecution(ctx context.Context, arg UpdateRecurringTransactionExecutionParams) (RecurringTransaction, error)
+	UpdateRecurringTransactionExecutionStatus(ctx context.Context, arg UpdateRecurringTransactionExecutionStatusParams) error
+	UpdateReminderDeliveryStatus(ctx context.Context, arg UpdateReminderDeliveryStatusParams) error
+	UpdateTOTPLastUsedStep(ctx context.Context, arg UpdateTOTPLastUsedStepParams) (int64, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateWorkspace(ctx context.Context, arg UpdateWorkspaceParams) (Workspace, error)
+	UpsertAccountBooksLock(ctx context.Context, arg UpsertAccountBooksLockParams) error
+	UpsertAccountMember(ctx context.Context, arg UpsertAccountMemberParams) (AccountMember, error)
+	UpsertCronJob(ctx context.Context, arg UpsertCronJobParams) (CronJob, error)
+	UpsertDigestSettings(ctx context.Context, arg UpsertDigestSettingsParams) (DigestSetting, error)
+	UpsertNotificationChannel(ctx context.Context, arg UpsertNotificationChannelParams) (NotificationChannel, error)
+	UpsertReminderSettings(ctx context.Context, arg UpsertReminderSettingsParams) (ReminderSetting, error)
+	UpsertTOTPSecret(ctx context.Context, arg UpsertTOTPSecretParams) error
+	UpsertUserBooksLock(ctx context.Context, arg UpsertUserBooksLockParams) error
+	UpsertWorkspaceMember(ctx context.Context, arg UpsertWorkspaceMemberParams) (WorkspaceMember, error)
+	UseEmailVerificationToken(ctx context.Context, tokenHash string) (EmailVerificationToken, error)
+	UseOIDCAuthRequest(ctx context.Context, stateHash string) (OidcAuthRequest, error)
+	UsePasswordResetToken(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
+	UseTwoFactorChallenge(ctx context.Context, id int32) (int64, error)
+	VerifyIdentity(ctx context.Context, arg VerifyIdentityParams) (int64, error)
 	VoidLedger(ctx context.Context, id int32) (Ledger, error)
 }
 
@@ -12,32 +12,83 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const claimRecurringTransactionOccurrence = `-- name: ClaimRecurringTransactionOccurrence :one
+SELECT id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due, workspace_id, rrule, catch_up_policy, post_mode, reminder_offsets FROM recurring_transactions
+WHERE id = $1 AND next_due = $2
+    AND status = 'active' AND deleted_at IS NULL
+FOR UPDATE SKIP LOCKED
+`
+
+type ClaimRecurringTransactionOccurrenceParams struct {
+	ID           int32
+	ScheduledFor pgtype.Timestamptz
+}
+
+func (q *Queries) ClaimRecurringTransactionOccurrence(ctx context.Context, arg ClaimRecurringTransactionOccurrenceParams) (RecurringTransaction, error) {
+	row := q.db.QueryRow(ctx, claimRecurringTransactionOccurrence, arg.ID, arg.ScheduledFor)
+	var i RecurringTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.UserID,
+		&i.AccountID,
+		&i.Name,
+		&i.Type,
+		&i.Amount,
+		&i.Note,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RecurType,
+		&i.Status,
+		&i.Frequency,
+		&i.DayOfWeek,
+		&i.DayOfMonth,
+		&i.MonthOfYear,
+		&i.LastExecuted,
+		&i.NextDue,
+		&i.WorkspaceID,
+		&i.Rrule,
+		&i.CatchUpPolicy,
+		&i.PostMode,
+		&i.ReminderOffsets,
+	)
+	return i, err
+}
+
 const createRecurringTransaction = `-- name: CreateRecurringTransaction :one
 INSERT INTO recurring_transactions (
     user_id, account_id, name, type, amount, note,
     start_date, end_date, recur_type, status, frequency,
-    day_of_week, day_of_month, month_of_year, next_due
+    day_of_week, day_of_month, month_of_year, next_due, workspace_id, rrule,
+    catch_up_policy, post_mode, reminder_offsets
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
-) RETURNING id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
+) RETURNING id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due, workspace_id, rrule, catch_up_policy, post_mode, reminder_offsets
 `
 
 type CreateRecurringTransactionParams struct {
-	UserID      int32
-	AccountID   int32
-	Name        string
-	Type        string
-	Amount      decimal.Decimal
-	Note        pgtype.Text
-	StartDate   pgtype.Timestamptz
-	EndDate     pgtype.Timestamptz
-	RecurType   string
-	Status      string
-	Frequency   int32
-	DayOfWeek   pgtype.Int4
-	DayOfMonth  pgtype.Int4
-	MonthOfYear pgtype.Int4
-	NextDue     pgtype.Timestamptz
+	UserID          int32
+	AccountID       int32
+	Name            string
+	Type            string
+	Amount          decimal.Decimal
+	Note            pgtype.Text
+	StartDate       pgtype.Timestamptz
+	EndDate         pgtype.Timestamptz
+	RecurType       string
+	Status          string
+	Frequency       int32
+	DayOfWeek       pgtype.Int4
+	DayOfMonth      pgtype.Int4
+	MonthOfYear     pgtype.Int4
+	NextDue         pgtype.Timestamptz
+	WorkspaceID     pgtype.Int4
+	Rrule           string
+	CatchUpPolicy   string
+	PostMode        string
+	ReminderOffsets []int32
 }
 
 func (q *Queries) CreateRecurringTransaction(ctx context.Context, arg CreateRecurringTransactionParams) (RecurringTransaction, error) {
@@ -57,6 +108,11 @@ func (q *Queries) CreateRecurringTransaction(ctx context.Context, arg CreateRecu
 		arg.DayOfMonth,
 		arg.MonthOfYear,
 		arg.NextDue,
+		arg.WorkspaceID,
+		arg.Rrule,
+		arg.CatchUpPolicy,
+		arg.PostMode,
+		arg.ReminderOffsets,
 	)
 	var i RecurringTransaction
 	err := row.Scan(
@@ -80,6 +136,47 @@ func (q *Queries) CreateRecurringTransaction(ctx context.Context, arg CreateRecu
 		&i.MonthOfYear,
 		&i.LastExecuted,
 		&i.NextDue,
+		&i.WorkspaceID,
+		&i.Rrule,
+		&i.CatchUpPolicy,
+		&i.PostMode,
+		&i.ReminderOffsets,
+	)
+	return i, err
+}
+
+const createRecurringTransactionExecution = `-- name: CreateRecurringTransactionExecution :one
+INSERT INTO recurring_transaction_executions (
+    recurring_transaction_id, scheduled_for, status, ledger_id
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (recurring_transaction_id, scheduled_for) DO NOTHING
+RETURNING id, created_at, recurring_transaction_id, scheduled_for, status, ledger_id
+`
+
+type CreateRecurringTransactionExecutionParams struct {
+	RecurringTransactionID int32
+	ScheduledFor           pgtype.Timestamptz
+	Status                 string
+	LedgerID               pgtype.Int4
+}
+
+func (q *Queries) CreateRecurringTransactionExecution(ctx context.Context, arg CreateRecurringTransactionExecutionParams) (RecurringTransactionExecution, error) {
+	row := q.db.QueryRow(ctx, createRecurringTransactionExecution,
+		arg.RecurringTransactionID,
+		arg.ScheduledFor,
+		arg.Status,
+		arg.LedgerID,
+	)
+	var i RecurringTransactionExecution
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.RecurringTransactionID,
+		&i.ScheduledFor,
+		&i.Status,
+		&i.LedgerID,
 	)
 	return i, err
 }
@@ -91,7 +188,7 @@ SET
     status = 'cancelled',
     deleted_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due
+RETURNING id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due, workspace_id, rrule, catch_up_policy, post_mode, reminder_offsets
 `
 
 func (q *Queries) DeleteRecurringTransaction(ctx context.Context, id int32) (RecurringTransaction, error) {
@@ -118,12 +215,17 @@ func (q *Queries) DeleteRecurringTransaction(ctx context.Context, id int32) (Rec
 		&i.MonthOfYear,
 		&i.LastExecuted,
 		&i.NextDue,
+		&i.WorkspaceID,
+		&i.Rrule,
+		&i.CatchUpPolicy,
+		&i.PostMode,
+		&i.ReminderOffsets,
 	)
 	return i, err
 }
 
 const getActiveRecurringTransactionsDue = `-- name: GetActiveRecurringTransactionsDue :many
-SELECT id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due FROM recurring_transactions
+SELECT id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due, workspace_id, rrule, catch_up_policy, post_mode, reminder_offsets FROM recurring_transactions
 WHERE status = 'active' AND next_due <= $1 AND deleted_at IS NULL
 ORDER BY next_due ASC
 `
@@ -158,6 +260,11 @@ func (q *Queries) GetActiveRecurringTransactionsDue(ctx context.Context, nextDue
 			&i.MonthOfYear,
 			&i.LastExecuted,
 			&i.NextDue,
+			&i.WorkspaceID,
+			&i.Rrule,
+			&i.CatchUpPolicy,
+			&i.PostMode,
+			&i.ReminderOffsets,
 		); err != nil {
 			return nil, err
 		}
@@ -170,7 +277,7 @@ func (q *Queries) GetActiveRecurringTransactionsDue(ctx context.Context, nextDue
 }
 
 const getRecurringTransactionByID = `-- name: GetRecurringTransactionByID :one
-SELECT id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due FROM recurring_transactions
+SELECT id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due, workspace_id, rrule, catch_up_policy, post_mode, reminder_offsets FROM recurring_transactions
 WHERE id = $1 AND deleted_at IS NULL LIMIT 1
 `
 
@@ -198,13 +305,89 @@ func (q *Queries) GetRecurringTransactionByID(ctx context.Context, id int32) (Re
 		&i.MonthOfYear,
 		&i.LastExecuted,
 		&i.NextDue,
+		&i.WorkspaceID,
+		&i.Rrule,
+		&i.CatchUpPolicy,
+		&i.PostMode,
+		&i.ReminderOffsets,
+	)
+	return i, err
+}
+
+const getRecurringTransactionExecutions = `-- name: GetRecurringTransactionExecutions :many
+SELECT id, created_at, recurring_transaction_id, scheduled_for, status, ledger_id FROM recurring_transaction_executions
+WHERE recurring_transaction_id = $1
+ORDER BY scheduled_for DESC
+`
+
+func (q *Queries) GetRecurringTransactionExecutions(ctx context.Context, recurringTransactionID int32) ([]RecurringTransactionExecution, error) {
+	rows, err := q.db.Query(ctx, getRecurringTransactionExecutions, recurringTransactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RecurringTransactionExecution{}
+	for rows.Next() {
+		var i RecurringTransactionExecution
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.RecurringTransactionID,
+			&i.ScheduledFor,
+			&i.Status,
+			&i.LedgerID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRecurringTransactionIncludingDeleted = `-- name: GetRecurringTransactionIncludingDeleted :one
+SELECT id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due, workspace_id, rrule, catch_up_policy, post_mode, reminder_offsets FROM recurring_transactions
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetRecurringTransactionIncludingDeleted(ctx context.Context, id int32) (RecurringTransaction, error) {
+	row := q.db.QueryRow(ctx, getRecurringTransactionIncludingDeleted, id)
+	var i RecurringTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.UserID,
+		&i.AccountID,
+		&i.Name,
+		&i.Type,
+		&i.Amount,
+		&i.Note,
+		&i.StartDate,
+		&i.EndDate,
+		&i.RecurType,
+		&i.Status,
+		&i.Frequency,
+		&i.DayOfWeek,
+		&i.DayOfMonth,
+		&i.MonthOfYear,
+		&i.LastExecuted,
+		&i.NextDue,
+		&i.WorkspaceID,
+		&i.Rrule,
+		&i.CatchUpPolicy,
+		&i.PostMode,
+		&i.ReminderOffsets,
 	)
 	return i, err
 }
 
 const getRecurringTransactionsByUserID = `-- name: GetRecurringTransactionsByUserID :many
-SELECT id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due FROM recurring_transactions
-WHERE user_id = $1 AND deleted_at IS NULL
+SELECT id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due, workspace_id, rrule, catch_up_policy, post_mode, reminder_offsets FROM recurring_transactions
+WHERE user_id = $1 AND workspace_id IS NULL AND deleted_at IS NULL
 ORDER BY next_due ASC
 `
 
@@ -238,6 +421,63 @@ func (q *Queries) GetRecurringTransactionsByUserID(ctx context.Context, userID i
 			&i.MonthOfYear,
 			&i.LastExecuted,
 			&i.NextDue,
+			&i.WorkspaceID,
+			&i.Rrule,
+			&i.CatchUpPolicy,
+			&i.PostMode,
+			&i.ReminderOffsets,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRecurringTransactionsByWorkspaceID = `-- name: GetRecurringTransactionsByWorkspaceID :many
+SELECT id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due, workspace_id, rrule, catch_up_policy, post_mode, reminder_offsets FROM recurring_transactions
+WHERE workspace_id = $1 AND deleted_at IS NULL
+ORDER BY next_due ASC
+`
+
+func (q *Queries) GetRecurringTransactionsByWorkspaceID(ctx context.Context, workspaceID pgtype.Int4) ([]RecurringTransaction, error) {
+	rows, err := q.db.Query(ctx, getRecurringTransactionsByWorkspaceID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RecurringTransaction{}
+	for rows.Next() {
+		var i RecurringTransaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.UserID,
+			&i.AccountID,
+			&i.Name,
+			&i.Type,
+			&i.Amount,
+			&i.Note,
+			&i.StartDate,
+			&i.EndDate,
+			&i.RecurType,
+			&i.Status,
+			&i.Frequency,
+			&i.DayOfWeek,
+			&i.DayOfMonth,
+			&i.MonthOfYear,
+			&i.LastExecuted,
+			&i.NextDue,
+			&i.WorkspaceID,
+			&i.Rrule,
+			&i.CatchUpPolicy,
+			&i.PostMode,
+			&i.ReminderOffsets,
 		); err != nil {
 			return nil, err
 		}
@@ -263,24 +503,34 @@ SET
     frequency = CASE WHEN $8::int IS NULL THEN frequency ELSE $8 END,
     day_of_week = CASE WHEN $9::int IS NULL THEN day_of_week ELSE $9 END,
     day_of_month = CASE WHEN $10::int IS NULL THEN day_of_month ELSE $10 END,
-    month_of_year = CASE WHEN $11::int IS NULL THEN month_of_year ELSE $11 END
-WHERE id = $12 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due
+    month_of_year = CASE WHEN $11::int IS NULL THEN month_of_year ELSE $11 END,
+    rrule = CASE WHEN $12::text IS NULL THEN rrule ELSE $12 END,
+    catch_up_policy = CASE WHEN $13::text IS NULL THEN catch_up_policy ELSE $13 END,
+    post_mode = CASE WHEN $14::text IS NULL THEN post_mode ELSE $14 END,
+    reminder_offsets = CASE WHEN $15::int[] IS NULL THEN reminder_offsets ELSE NULLIF($15::int[], '{}') END,
+    next_due = CASE WHEN $16::timestamptz IS NULL THEN next_due ELSE $16 END
+WHERE id = $17 AND deleted_at IS NULL
+RETURNING id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due, workspace_id, rrule, catch_up_policy, post_mode, reminder_offsets
 `
 
 type UpdateRecurringTransactionParams struct {
-	Name        pgtype.Text
-	Type        pgtype.Text
-	Amount      pgtype.Numeric
-	Note        pgtype.Text
-	EndDate     pgtype.Timestamptz
-	RecurType   pgtype.Text
-	Status      pgtype.Text
-	Frequency   pgtype.Int4
-	DayOfWeek   pgtype.Int4
-	DayOfMonth  pgtype.Int4
-	MonthOfYear pgtype.Int4
-	ID          int32
+	Name            pgtype.Text
+	Type            pgtype.Text
+	Amount          pgtype.Numeric
+	Note            pgtype.Text
+	EndDate         pgtype.Timestamptz
+	RecurType       pgtype.Text
+	Status          pgtype.Text
+	Frequency       pgtype.Int4
+	DayOfWeek       pgtype.Int4
+	DayOfMonth      pgtype.Int4
+	MonthOfYear     pgtype.Int4
+	Rrule           pgtype.Text
+	CatchUpPolicy   pgtype.Text
+	PostMode        pgtype.Text
+	ReminderOffsets []int32
+	NextDue         pgtype.Timestamptz
+	ID              int32
 }
 
 func (q *Queries) UpdateRecurringTransaction(ctx context.Context, arg UpdateRecurringTransactionParams) (RecurringTransaction, error) {
@@ -296,6 +546,11 @@ func (q *Queries) UpdateRecurringTransaction(ctx context.Context, arg UpdateRecu
 		arg.DayOfWeek,
 		arg.DayOfMonth,
 		arg.MonthOfYear,
+		arg.Rrule,
+		arg.CatchUpPolicy,
+		arg.PostMode,
+		arg.ReminderOffsets,
+		arg.NextDue,
 		arg.ID,
 	)
 	var i RecurringTransaction
@@ -320,6 +575,11 @@ func (q *Queries) UpdateRecurringTransaction(ctx context.Context, arg UpdateRecu
 		&i.MonthOfYear,
 		&i.LastExecuted,
 		&i.NextDue,
+		&i.WorkspaceID,
+		&i.Rrule,
+		&i.CatchUpPolicy,
+		&i.PostMode,
+		&i.ReminderOffsets,
 	)
 	return i, err
 }
@@ -328,20 +588,30 @@ const updateRecurringTransactionExecution = `-- name: UpdateRecurringTransaction
 UPDATE recurring_transactions
 SET
     updated_at = NOW(),
-    last_executed = $1,
-    next_due = $2
-WHERE id = $3 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due
+    last_executed = CASE WHEN $1::timestamptz IS NULL THEN last_executed ELSE $1 END,
+    next_due = $2,
+    status = $3
+WHERE id = $4 AND next_due = $5
+    AND status = 'active' AND deleted_at IS NULL
+RETURNING id, created_at, updated_at, deleted_at, user_id, account_id, name, type, amount, note, start_date, end_date, recur_type, status, frequency, day_of_week, day_of_month, month_of_year, last_executed, next_due, workspace_id, rrule, catch_up_policy, post_mode, reminder_offsets
 `
 
 type UpdateRecurringTransactionExecutionParams struct {
 	LastExecuted pgtype.Timestamptz
 	NextDue      pgtype.Timestamptz
+	Status       string
 	ID           int32
+	ScheduledFor pgtype.Timestamptz
 }
 
 func (q *Queries) UpdateRecurringTransactionExecution(ctx context.Context, arg UpdateRecurringTransactionExecutionParams) (RecurringTransaction, error) {
-	row := q.db.QueryRow(ctx, updateRecurringTransactionExecution, arg.LastExecuted, arg.NextDue, arg.ID)
+	row := q.db.QueryRow(ctx, updateRecurringTransactionExecution,
+		arg.LastExecuted,
+		arg.NextDue,
+		arg.Status,
+		arg.ID,
+		arg.ScheduledFor,
+	)
 	var i RecurringTransaction
 	err := row.Scan(
 		&i.ID,
@@ -364,6 +634,27 @@ func (q *Queries) UpdateRecurringTransactionExecution(ctx context.Context, arg U
 		&i.MonthOfYear,
 		&i.LastExecuted,
 		&i.NextDue,
+		&i.WorkspaceID,
+		&i.Rrule,
+		&i.CatchUpPolicy,
+		&i.PostMode,
+		&i.ReminderOffsets,
 	)
 	return i, err
 }
+
+const updateRecurringTransactionExecutionStatus = `-- name: UpdateRecurringTransactionExecutionStatus :exec
+UPDATE recurring_transaction_executions
+SET status = $1
+WHERE ledger_id = $2
+`
+
+type UpdateRecurringTransactionExecutionStatusParams struct {
+	Status   string
+	LedgerID pgtype.Int4
+}
+
+func (q *Queries) UpdateRecurringTransactionExecutionStatus(ctx context.Context, arg UpdateRecurringTransactionExecutionStatusParams) error {
+	_, err := q.db.Exec(ctx, updateRecurringTransactionExecutionStatus, arg.Status, arg.LedgerID)
+	return err
+}
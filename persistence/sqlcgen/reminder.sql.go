@@ -14,19 +14,20 @@ import (
 
 const createReminder = `-- name: CreateReminder :one
 INSERT INTO reminders (
-    recurring_transaction_id, reminder_date
+    recurring_transaction_id, reminder_date, ledger_id
 ) VALUES (
-    $1, $2
-) RETURNING id, created_at, updated_at, deleted_at, recurring_transaction_id, reminder_date, is_read, read_at
+    $1, $2, $3
+) RETURNING id, created_at, updated_at, deleted_at, recurring_transaction_id, reminder_date, is_read, read_at, ledger_id
 `
 
 type CreateReminderParams struct {
 	RecurringTransactionID int32
 	ReminderDate           pgtype.Timestamptz
+	LedgerID               pgtype.Int4
 }
 
 func (q *Queries) CreateReminder(ctx context.Context, arg CreateReminderParams) (Reminder, error) {
-	row := q.db.QueryRow(ctx, createReminder, arg.RecurringTransactionID, arg.ReminderDate)
+	row := q.db.QueryRow(ctx, createReminder, arg.RecurringTransactionID, arg.ReminderDate, arg.LedgerID)
 	var i Reminder
 	err := row.Scan(
 		&i.ID,
@@ -37,6 +38,7 @@ func (q *Queries) CreateReminder(ctx context.Context, arg CreateReminderParams)
 		&i.ReminderDate,
 		&i.IsRead,
 		&i.ReadAt,
+		&i.LedgerID,
 	)
 	return i, err
 }
@@ -47,7 +49,7 @@ SET
     deleted_at = NOW(),
     updated_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, recurring_transaction_id, reminder_date, is_read, read_at
+RETURNING id, created_at, updated_at, deleted_at, recurring_transaction_id, reminder_date, is_read, read_at, ledger_id
 `
 
 func (q *Queries) DeleteReminder(ctx context.Context, id int32) (Reminder, error) {
@@ -62,12 +64,35 @@ func (q *Queries) DeleteReminder(ctx context.Context, id int32) (Reminder, error
 		&i.ReminderDate,
 		&i.IsRead,
 		&i.ReadAt,
+		&i.LedgerID,
 	)
 	return i, err
 }
 
+const deleteUpcomingReminders = `-- name: DeleteUpcomingReminders :exec
+UPDATE reminders
+SET
+    deleted_at = NOW(),
+    updated_at = NOW()
+WHERE recurring_transaction_id = $1
+  AND reminder_date > $2
+  AND is_read = FALSE
+  AND ledger_id IS NULL
+  AND deleted_at IS NULL
+`
+
+type DeleteUpcomingRemindersParams struct {
+	RecurringTransactionID int32
+	ReminderDate           pgtype.Timestamptz
+}
+
+func (q *Queries) DeleteUpcomingReminders(ctx context.Context, arg DeleteUpcomingRemindersParams) error {
+	_, err := q.db.Exec(ctx, deleteUpcomingReminders, arg.RecurringTransactionID, arg.ReminderDate)
+	return err
+}
+
 const getActiveRemindersByUserID = `-- name: GetActiveRemindersByUserID :many
-SELECT r.id, r.created_at, r.updated_at, r.deleted_at, r.recurring_transaction_id, r.reminder_date, r.is_read, r.read_at
+SELECT r.id, r.created_at, r.updated_at, r.deleted_at, r.recurring_transaction_id, r.reminder_date, r.is_read, r.read_at, r.ledger_id
 FROM reminders r
 JOIN recurring_transactions rt ON r.recurring_transaction_id = rt.id
 WHERE rt.user_id = $1 AND r.is_read = FALSE AND r.reminder_date <= $2 AND r.deleted_at IS NULL AND rt.deleted_at IS NULL
@@ -97,6 +122,7 @@ func (q *Queries) GetActiveRemindersByUserID(ctx context.Context, arg GetActiveR
 			&i.ReminderDate,
 			&i.IsRead,
 			&i.ReadAt,
+			&i.LedgerID,
 		); err != nil {
 			return nil, err
 		}
@@ -109,7 +135,7 @@ func (q *Queries) GetActiveRemindersByUserID(ctx context.Context, arg GetActiveR
 }
 
 const getReminderByID = `-- name: GetReminderByID :one
-SELECT id, created_at, updated_at, deleted_at, recurring_transaction_id, reminder_date, is_read, read_at FROM reminders
+SELECT id, created_at, updated_at, deleted_at, recurring_transaction_id, reminder_date, is_read, read_at, ledger_id FROM reminders
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -125,12 +151,32 @@ func (q *Queries) GetReminderByID(ctx context.Context, id int32) (Reminder, erro
 		&i.ReminderDate,
 		&i.IsRead,
 		&i.ReadAt,
+		&i.LedgerID,
+	)
+	return i, err
+}
+
+const getReminderSettingsByUserID = `-- name: GetReminderSettingsByUserID :one
+SELECT id, created_at, updated_at, user_id, default_offsets FROM reminder_settings
+WHERE user_id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetReminderSettingsByUserID(ctx context.Context, userID int32) (ReminderSetting, error) {
+	row := q.db.QueryRow(ctx, getReminderSettingsByUserID, userID)
+	var i ReminderSetting
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.DefaultOffsets,
 	)
 	return i, err
 }
 
 const getRemindersByRecurringTransactionID = `-- name: GetRemindersByRecurringTransactionID :many
-SELECT id, created_at, updated_at, deleted_at, recurring_transaction_id, reminder_date, is_read, read_at FROM reminders
+SELECT id, created_at, updated_at, deleted_at, recurring_transaction_id, reminder_date, is_read, read_at, ledger_id FROM reminders
 WHERE recurring_transaction_id = $1 AND deleted_at IS NULL
 ORDER BY reminder_date ASC
 `
@@ -153,6 +199,7 @@ func (q *Queries) GetRemindersByRecurringTransactionID(ctx context.Context, recu
 			&i.ReminderDate,
 			&i.IsRead,
 			&i.ReadAt,
+			&i.LedgerID,
 		); err != nil {
 			return nil, err
 		}
@@ -165,7 +212,7 @@ func (q *Queries) GetRemindersByRecurringTransactionID(ctx context.Context, recu
 }
 
 const getUpcomingReminders = `-- name: GetUpcomingReminders :many
-SELECT r.id, r.created_at, r.updated_at, r.deleted_at, r.recurring_transaction_id, r.reminder_date, r.is_read, r.read_at, rt.name AS transaction_name, rt.amount, rt.type
+SELECT r.id, r.created_at, r.updated_at, r.deleted_at, r.recurring_transaction_id, r.reminder_date, r.is_read, r.read_at, r.ledger_id, rt.name AS transaction_name, rt.amount, rt.type
 FROM reminders r
 JOIN recurring_transactions rt ON r.recurring_transaction_id = rt.id
 WHERE rt.user_id = $1
@@ -191,6 +238,7 @@ type GetUpcomingRemindersRow struct {
 	ReminderDate           pgtype.Timestamptz
 	IsRead                 bool
 	ReadAt                 pgtype.Timestamptz
+	LedgerID               pgtype.Int4
 	TransactionName        string
 	Amount                 decimal.Decimal
 	Type                   string
@@ -214,6 +262,7 @@ func (q *Queries) GetUpcomingReminders(ctx context.Context, arg GetUpcomingRemin
 			&i.ReminderDate,
 			&i.IsRead,
 			&i.ReadAt,
+			&i.LedgerID,
 			&i.TransactionName,
 			&i.Amount,
 			&i.Type,
@@ -235,7 +284,7 @@ SET
     read_at = NOW(),
     updated_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, recurring_transaction_id, reminder_date, is_read, read_at
+RETURNING id, created_at, updated_at, deleted_at, recurring_transaction_id, reminder_date, is_read, read_at, ledger_id
 `
 
 func (q *Queries) MarkReminderAsRead(ctx context.Context, id int32) (Reminder, error) {
@@ -250,6 +299,68 @@ func (q *Queries) MarkReminderAsRead(ctx context.Context, id int32) (Reminder, e
 		&i.ReminderDate,
 		&i.IsRead,
 		&i.ReadAt,
+		&i.LedgerID,
+	)
+	return i, err
+}
+
+const snoozeReminder = `-- name: SnoozeReminder :one
+UPDATE reminders
+SET
+    reminder_date = $1,
+    updated_at = NOW()
+WHERE id = $2 AND is_read = FALSE AND deleted_at IS NULL
+RETURNING id, created_at, updated_at, deleted_at, recurring_transaction_id, reminder_date, is_read, read_at, ledger_id
+`
+
+type SnoozeReminderParams struct {
+	ReminderDate pgtype.Timestamptz
+	ID           int32
+}
+
+func (q *Queries) SnoozeReminder(ctx context.Context, arg SnoozeReminderParams) (Reminder, error) {
+	row := q.db.QueryRow(ctx, snoozeReminder, arg.ReminderDate, arg.ID)
+	var i Reminder
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.RecurringTransactionID,
+		&i.ReminderDate,
+		&i.IsRead,
+		&i.ReadAt,
+		&i.LedgerID,
+	)
+	return i, err
+}
+
+const upsertReminderSettings = `-- name: UpsertReminderSettings :one
+INSERT INTO reminder_settings (
+    user_id,
+    default_offsets
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (user_id) DO UPDATE
+SET default_offsets = EXCLUDED.default_offsets, updated_at = NOW()
+RETURNING id, created_at, updated_at, user_id, default_offsets
+`
+
+type UpsertReminderSettingsParams struct {
+	UserID         int32
+	DefaultOffsets []int32
+}
+
+func (q *Queries) UpsertReminderSettings(ctx context.Context, arg UpsertReminderSettingsParams) (ReminderSetting, error) {
+	row := q.db.QueryRow(ctx, upsertReminderSettings, arg.UserID, arg.DefaultOffsets)
+	var i ReminderSetting
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.DefaultOffsets,
 	)
 	return i, err
 }
@@ -17,11 +17,12 @@ INSERT INTO identities (
     provider,
     identifier,
     credential,
-    last_used_at
+    last_used_at,
+    verified_at
 ) VALUES (
-    $1, $2, $3, $4, NOW()
+    $1, $2, $3, $4, NOW(), $5
 )
-RETURNING id, user_id, provider, identifier, credential, last_used_at
+RETURNING id, user_id, provider, identifier, credential, last_used_at, verified_at
 `
 
 type AddIdentityParams struct {
@@ -29,6 +30,7 @@ type AddIdentityParams struct {
 	Provider   string
 	Identifier string
 	Credential string
+	VerifiedAt pgtype.Timestamptz
 }
 
 func (q *Queries) AddIdentity(ctx context.Context, arg AddIdentityParams) (Identity, error) {
@@ -37,6 +39,7 @@ func (q *Queries) AddIdentity(ctx context.Context, arg AddIdentityParams) (Ident
 		arg.Provider,
 		arg.Identifier,
 		arg.Credential,
+		arg.VerifiedAt,
 	)
 	var i Identity
 	err := row.Scan(
@@ -46,6 +49,7 @@ func (q *Queries) AddIdentity(ctx context.Context, arg AddIdentityParams) (Ident
 		&i.Identifier,
 		&i.Credential,
 		&i.LastUsedAt,
+		&i.VerifiedAt,
 	)
 	return i, err
 }
@@ -53,20 +57,22 @@ func (q *Queries) AddIdentity(ctx context.Context, arg AddIdentityParams) (Ident
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     name,
-    nickname
+    nickname,
+    role
 ) VALUES (
-    $1, $2
+    $1, $2, $3
 )
-RETURNING id, created_at, updated_at, deleted_at, disabled, name, nickname
+RETURNING id, created_at, updated_at, deleted_at, disabled, name, nickname, role
 `
 
 type CreateUserParams struct {
 	Name     string
 	Nickname pgtype.Text
+	Role     string
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
-	row := q.db.QueryRow(ctx, createUser, arg.Name, arg.Nickname)
+	row := q.db.QueryRow(ctx, createUser, arg.Name, arg.Nickname, arg.Role)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -76,6 +82,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Disabled,
 		&i.Name,
 		&i.Nickname,
+		&i.Role,
 	)
 	return i, err
 }
@@ -86,7 +93,7 @@ SET
     disabled = true,
     updated_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, disabled, name, nickname
+RETURNING id, created_at, updated_at, deleted_at, disabled, name, nickname, role
 `
 
 func (q *Queries) DeactivateUserByID(ctx context.Context, id int32) (User, error) {
@@ -100,6 +107,7 @@ func (q *Queries) DeactivateUserByID(ctx context.Context, id int32) (User, error
 		&i.Disabled,
 		&i.Name,
 		&i.Nickname,
+		&i.Role,
 	)
 	return i, err
 }
@@ -110,7 +118,7 @@ SET
     deleted_at = NOW(),
     updated_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, disabled, name, nickname
+RETURNING id, created_at, updated_at, deleted_at, disabled, name, nickname, role
 `
 
 func (q *Queries) DeleteUser(ctx context.Context, id int32) (User, error) {
@@ -124,12 +132,13 @@ func (q *Queries) DeleteUser(ctx context.Context, id int32) (User, error) {
 		&i.Disabled,
 		&i.Name,
 		&i.Nickname,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getAllUsers = `-- name: GetAllUsers :many
-SELECT id, created_at, updated_at, deleted_at, disabled, name, nickname FROM users
+SELECT id, created_at, updated_at, deleted_at, disabled, name, nickname, role FROM users
 WHERE deleted_at IS NULL
 ORDER BY id
 `
@@ -151,6 +160,7 @@ func (q *Queries) GetAllUsers(ctx context.Context) ([]User, error) {
 			&i.Disabled,
 			&i.Name,
 			&i.Nickname,
+			&i.Role,
 		); err != nil {
 			return nil, err
 		}
@@ -163,7 +173,7 @@ func (q *Queries) GetAllUsers(ctx context.Context) ([]User, error) {
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, created_at, updated_at, deleted_at, disabled, name, nickname FROM users
+SELECT id, created_at, updated_at, deleted_at, disabled, name, nickname, role FROM users
 WHERE id = $1 AND deleted_at IS NULL
 LIMIT 1
 `
@@ -179,6 +189,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
 		&i.Disabled,
 		&i.Name,
 		&i.Nickname,
+		&i.Role,
 	)
 	return i, err
 }
@@ -191,12 +202,14 @@ SELECT
     u.disabled AS user_disabled,
     u.name AS user_name,
     u.nickname AS user_nickname,
+    u.role AS user_role,
     i.id AS identity_id,
     i.user_id AS identity_user_id,
     i.provider AS identity_provider,
     i.identifier AS identity_identifier,
     i.credential AS identity_credential,
-    i.last_used_at AS identity_last_used_at
+    i.last_used_at AS identity_last_used_at,
+    i.verified_at AS identity_verified_at
 FROM users u
 JOIN identities i ON u.id = i.user_id
 WHERE i.provider = $1 AND i.identifier = $2 AND u.deleted_at IS NULL
@@ -215,12 +228,14 @@ type GetUserByIdentityRow struct {
 	UserDisabled       bool
 	UserName           string
 	UserNickname       pgtype.Text
+	UserRole           string
 	IdentityID         int32
 	IdentityUserID     int32
 	IdentityProvider   string
 	IdentityIdentifier string
 	IdentityCredential string
 	IdentityLastUsedAt pgtype.Timestamptz
+	IdentityVerifiedAt pgtype.Timestamptz
 }
 
 func (q *Queries) GetUserByIdentity(ctx context.Context, arg GetUserByIdentityParams) (GetUserByIdentityRow, error) {
@@ -233,12 +248,14 @@ func (q *Queries) GetUserByIdentity(ctx context.Context, arg GetUserByIdentityPa
 		&i.UserDisabled,
 		&i.UserName,
 		&i.UserNickname,
+		&i.UserRole,
 		&i.IdentityID,
 		&i.IdentityUserID,
 		&i.IdentityProvider,
 		&i.IdentityIdentifier,
 		&i.IdentityCredential,
 		&i.IdentityLastUsedAt,
+		&i.IdentityVerifiedAt,
 	)
 	return i, err
 }
@@ -249,15 +266,17 @@ SET
     name = CASE WHEN $1::text IS NULL THEN name ELSE $1 END,
     nickname = CASE WHEN $2::text IS NULL THEN nickname ELSE $2 END,
     disabled = CASE WHEN $3::boolean IS NULL THEN disabled ELSE $3 END,
+    role = CASE WHEN $4::text IS NULL THEN role ELSE $4 END,
     updated_at = NOW()
-WHERE id = $4 AND deleted_at IS NULL
-RETURNING id, created_at, updated_at, deleted_at, disabled, name, nickname
+WHERE id = $5 AND deleted_at IS NULL
+RETURNING id, created_at, updated_at, deleted_at, disabled, name, nickname, role
 `
 
 type UpdateUserParams struct {
 	Name     pgtype.Text
 	Nickname pgtype.Text
 	Disabled pgtype.Bool
+	Role     pgtype.Text
 	ID       int32
 }
 
@@ -266,6 +285,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.Name,
 		arg.Nickname,
 		arg.Disabled,
+		arg.Role,
 		arg.ID,
 	)
 	var i User
@@ -277,6 +297,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Disabled,
 		&i.Name,
 		&i.Nickname,
+		&i.Role,
 	)
 	return i, err
 }
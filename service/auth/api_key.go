@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// apiKeyPrefix marks personal API keys, so they can be told apart from JWTs
+	apiKeyPrefix = "bk_"
+	apiKeyLen    = 32
+	// apiKeyDisplayLen is how much of a key is kept in clear to recognize it by
+	apiKeyDisplayLen = len(apiKeyPrefix) + 8
+)
+
+// GenerateAPIKey generates a random personal API key, along with the prefix shown to
+// recognize it and the hash it is stored as.
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	bs := make([]byte, apiKeyLen)
+	if _, err := rand.Read(bs); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(bs)
+	return key, key[:apiKeyDisplayLen], HashAPIKey(key), nil
+}
+
+// HashAPIKey hashes a personal API key for storage and lookup. Keys are random
+// enough that a plain SHA-256 cannot be brute forced.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIKey reports whether a bearer token is a personal API key rather than a JWT.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, apiKeyPrefix)
+}
@@ -0,0 +1,27 @@
+package auth_test
+
+import (
+	"strings"
+
+	"github.com/omegaatt36/bookly/service/auth"
+)
+
+func (s *testAuthSuite) TestGenerateAPIKey() {
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	s.NoError(err)
+	s.True(auth.IsAPIKey(key))
+	s.True(strings.HasPrefix(key, prefix))
+	s.Len(prefix, 11)
+	s.Equal(auth.HashAPIKey(key), hash)
+
+	other, _, otherHash, err := auth.GenerateAPIKey()
+	s.NoError(err)
+	s.NotEqual(key, other)
+	s.NotEqual(hash, otherHash)
+}
+
+func (s *testAuthSuite) TestIsAPIKey() {
+	s.True(auth.IsAPIKey("bk_abc"))
+	s.False(auth.IsAPIKey("eyJhbGciOiJIUzI1NiJ9.e30.sig"))
+	s.False(auth.IsAPIKey(""))
+}
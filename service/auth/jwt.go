@@ -1,12 +1,13 @@
 package auth
 
 import (
+	"crypto/subtle"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
-	"golang.org/x/crypto/argon2"
 
 	"github.com/omegaatt36/bookly/domain"
 )
@@ -15,7 +16,8 @@ var _ domain.Authenticator = (*JWTAuthenticator)(nil)
 
 // JWTAuthenticator represents a JWT authentication service, encrypts password by using argon2.
 type JWTAuthenticator struct {
-	ttl       time.Duration
+	ttl time.Duration
+	// salt is the global salt of legacy password hashes, new hashes carry their own salt
 	salt      string
 	secretKey string
 
@@ -26,7 +28,7 @@ type JWTAuthenticator struct {
 // Accept userRepo as the first parameter
 func NewJWTAuthorizator(salt, secretKey string, opts ...Option) *JWTAuthenticator {
 	authorizator := JWTAuthenticator{
-		ttl:       time.Hour * 24,
+		ttl:       domain.AccessTokenTTL,
 		salt:      salt,
 		secretKey: secretKey,
 		getNow:    time.Now,
@@ -57,10 +59,9 @@ func (o ttlOption) apply(a *JWTAuthenticator) {
 	a.ttl = o.ttl
 }
 
-// HashPassword hashes a password using argon2.
+// HashPassword hashes a password using argon2id with a random salt, encoded in the PHC string format.
 func (authenticator *JWTAuthenticator) HashPassword(password string) (string, error) {
-	hash := argon2.IDKey([]byte(password), []byte(authenticator.salt), 1, 64*1024, 4, 32)
-	return fmt.Sprintf("%x", hash), nil
+	return hashPHC(password)
 }
 
 // GenerateToken generates a token for a user.
@@ -74,6 +75,10 @@ func (authenticator *JWTAuthenticator) GenerateToken(req domain.GenerateTokenReq
 
 	claims["sub"] = req.UserID
 	claims["user_id"] = req.UserID
+	claims["role"] = req.Role.String()
+	if req.SessionID != 0 {
+		claims["sid"] = req.SessionID
+	}
 	claims["exp"] = authenticator.getNow().Add(authenticator.ttl).Unix()
 
 	tokenString, err := token.SignedString([]byte(authenticator.secretKey))
@@ -84,7 +89,7 @@ func (authenticator *JWTAuthenticator) GenerateToken(req domain.GenerateTokenReq
 	return tokenString, nil
 }
 
-// ValidateToken validates a token for a user and returns the associated user ID.
+// ValidateToken validates a token for a user and returns the associated user ID and role.
 func (authenticator *JWTAuthenticator) ValidateToken(req domain.ValidateTokenRequest) (domain.TokenValidationResponse, error) {
 	token, err := jwt.Parse(req.Token, func(token *jwt.Token) (any, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -112,28 +117,54 @@ func (authenticator *JWTAuthenticator) ValidateToken(req domain.ValidateTokenReq
 		return domain.TokenValidationResponse{Valid: false}, errors.New("user_id not found in token")
 	}
 
+	// Tokens issued before roles were introduced carry no role claim.
+	role := domain.UserRoleUser
+	if rawRole, ok := claims["role"].(string); ok && rawRole != "" {
+		parsedRole, err := domain.ParseUserRole(rawRole)
+		if err != nil {
+			return domain.TokenValidationResponse{Valid: false}, err
+		}
+		role = parsedRole
+	}
+
+	// Tokens issued outside of a session carry no session claim.
+	var sessionID int32
+	if rawSessionID, ok := claims["sid"].(float64); ok {
+		sessionID = int32(rawSessionID)
+	}
+
 	return domain.TokenValidationResponse{
-		Valid:  true,
-		UserID: int32(userID),
+		Valid:     true,
+		UserID:    int32(userID),
+		Role:      role,
+		SessionID: sessionID,
 	}, nil
 }
 
-// VerifyCredential verifies the provided credential against the stored identity credential.
-// It assumes the identity.Credential is the hex-encoded Argon2 hash and the salt is in authenticator.salt.
-
 // VerifyCredential verifies if the provided credential matches the stored identity credential.
-// It is used during authentication to validate user credentials.
+// It is used during authentication to validate user credentials, and accepts both PHC encoded
+// hashes and legacy hex hashes salted with the global salt.
 func (authenticator *JWTAuthenticator) VerifyCredential(credential string, identity *domain.Identity) (bool, error) {
 	if identity.Provider != domain.IdentityProviderPassword {
 		return false, fmt.Errorf("unsupported identity provider for credential verification: %s", identity.Provider)
 	}
 
-	providedCredentialHash := argon2.IDKey([]byte(credential), []byte(authenticator.salt), 1, 64*1024, 4, 32)
-	providedCredentialHashHex := fmt.Sprintf("%x", providedCredentialHash)
+	if strings.HasPrefix(identity.Credential, phcPrefix) {
+		return verifyPHC(credential, identity.Credential)
+	}
+
+	providedCredentialHash := hashLegacy(credential, authenticator.salt)
 
-	if providedCredentialHashHex != identity.Credential {
-		return false, nil
+	return subtle.ConstantTimeCompare([]byte(providedCredentialHash), []byte(identity.Credential)) == 1, nil
+}
+
+// NeedsRehash reports whether a stored password hash is in the legacy format or uses
+// outdated parameters, and should be replaced after the next successful verification.
+func (authenticator *JWTAuthenticator) NeedsRehash(hash string) bool {
+	params, _, _, err := decodePHC(hash)
+	if err != nil {
+		return true
 	}
 
-	return true, nil
+	return params != defaultArgon2Params
 }
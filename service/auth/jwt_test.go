@@ -1,10 +1,13 @@
 package auth_test
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/argon2"
 
 	"github.com/omegaatt36/bookly/domain"
 	"github.com/omegaatt36/bookly/service/auth"
@@ -36,11 +39,44 @@ func (s *testAuthSuite) TestHashPassword() {
 	s.NoError(err)
 	s.NotEmpty(hashedPassword)
 	s.NotEqual(password, hashedPassword)
+	s.True(strings.HasPrefix(hashedPassword, "$argon2id$v=19$m=65536,t=1,p=4$"))
+	s.False(authenticator.NeedsRehash(hashedPassword))
 
-	// Hash should be consistent
+	// Every hash has its own salt
 	hashedPassword2, err := authenticator.HashPassword(password)
 	s.NoError(err)
-	s.Equal(hashedPassword, hashedPassword2)
+	s.NotEqual(hashedPassword, hashedPassword2)
+
+	for _, hash := range []string{hashedPassword, hashedPassword2} {
+		identity := &domain.Identity{Provider: domain.IdentityProviderPassword, Credential: hash}
+
+		valid, err := authenticator.VerifyCredential(password, identity)
+		s.NoError(err)
+		s.True(valid)
+
+		valid, err = authenticator.VerifyCredential("wrong-password", identity)
+		s.NoError(err)
+		s.False(valid)
+	}
+}
+
+func (s *testAuthSuite) TestVerifyLegacyCredential() {
+	authenticator := auth.NewJWTAuthorizator(s.salt, s.secretKey)
+
+	legacyHash := legacyTestHash(s.salt, "test-password")
+	identity := &domain.Identity{Provider: domain.IdentityProviderPassword, Credential: legacyHash}
+
+	valid, err := authenticator.VerifyCredential("test-password", identity)
+	s.NoError(err)
+	s.True(valid)
+	s.True(authenticator.NeedsRehash(legacyHash))
+
+	valid, err = authenticator.VerifyCredential("wrong-password", identity)
+	s.NoError(err)
+	s.False(valid)
+
+	// Hashes with outdated parameters are rehashed too
+	s.True(authenticator.NeedsRehash("$argon2id$v=19$m=4096,t=3,p=1$c2FsdHNhbHRzYWx0c2FsdA$aGFzaGhhc2hoYXNoaGFzaGhhc2hoYXNoaGFzaGhhc2g"))
 }
 
 func (s *testAuthSuite) TestGenerateAndValidateToken() {
@@ -64,6 +100,27 @@ func (s *testAuthSuite) TestGenerateAndValidateToken() {
 	s.Empty(result.UserID)
 }
 
+func (s *testAuthSuite) TestTokenCarriesRole() {
+	authenticator := auth.NewJWTAuthorizator(s.salt, s.secretKey)
+	var userID int32 = 9999
+
+	token, err := authenticator.GenerateToken(domain.GenerateTokenRequest{UserID: userID, Role: domain.UserRoleAdmin})
+	s.NoError(err)
+
+	result, err := authenticator.ValidateToken(domain.ValidateTokenRequest{Token: token})
+	s.NoError(err)
+	s.True(result.Valid)
+	s.Equal(domain.UserRoleAdmin, result.Role)
+
+	// Tokens without a role default to the user role
+	token, err = authenticator.GenerateToken(domain.GenerateTokenRequest{UserID: userID})
+	s.NoError(err)
+
+	result, err = authenticator.ValidateToken(domain.ValidateTokenRequest{Token: token})
+	s.NoError(err)
+	s.Equal(domain.UserRoleUser, result.Role)
+}
+
 func (s *testAuthSuite) TestTokenExpiration() {
 	pastAuthenticator := auth.NewJWTAuthorizator(s.salt, s.secretKey, auth.WithTTL(time.Second), auth.WithGetNow(func() time.Time {
 		return time.Now().Add(-time.Hour)
@@ -83,3 +140,20 @@ func (s *testAuthSuite) TestTokenExpiration() {
 	s.False(result.Valid)
 	s.Empty(result.UserID)
 }
+
+func (s *testAuthSuite) TestTokenCarriesSessionID() {
+	authenticator := auth.NewJWTAuthorizator(s.salt, s.secretKey)
+
+	token, err := authenticator.GenerateToken(domain.GenerateTokenRequest{UserID: 9999, SessionID: 42})
+	s.NoError(err)
+
+	result, err := authenticator.ValidateToken(domain.ValidateTokenRequest{Token: token})
+	s.NoError(err)
+	s.True(result.Valid)
+	s.Equal(int32(42), result.SessionID)
+}
+
+// legacyTestHash reproduces the hashes stored before they carried their own salt.
+func legacyTestHash(salt, password string) string {
+	return fmt.Sprintf("%x", argon2.IDKey([]byte(password), []byte(salt), 1, 64*1024, 4, 32))
+}
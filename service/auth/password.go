@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2Params are the argon2id parameters of a password hash.
+type argon2Params struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	keyLen  uint32
+}
+
+// defaultArgon2Params are used for new hashes. Hashes created with other
+// parameters still verify, and are rehashed on the next successful login.
+var defaultArgon2Params = argon2Params{
+	memory:  64 * 1024,
+	time:    1,
+	threads: 4,
+	keyLen:  32,
+}
+
+const (
+	argon2SaltLen = 16
+	phcPrefix     = "$argon2id$"
+)
+
+var errInvalidHash = errors.New("invalid password hash")
+
+// encodePHC encodes an argon2id hash in the PHC string format:
+// $argon2id$v=19$m=65536,t=1,p=4$<salt>$<hash>
+func encodePHC(params argon2Params, salt, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.memory, params.time, params.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key))
+}
+
+// decodePHC parses an argon2id hash in the PHC string format.
+func decodePHC(encoded string) (params argon2Params, salt, key []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return argon2Params{}, nil, nil, errInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return argon2Params{}, nil, nil, errInvalidHash
+	}
+	if version != argon2.Version {
+		return argon2Params{}, nil, nil, fmt.Errorf("unsupported argon2 version: %d", version)
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return argon2Params{}, nil, nil, errInvalidHash
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return argon2Params{}, nil, nil, errInvalidHash
+	}
+
+	key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return argon2Params{}, nil, nil, errInvalidHash
+	}
+	params.keyLen = uint32(len(key))
+
+	return params, salt, key, nil
+}
+
+// hashPHC hashes a password with a random salt and the default parameters.
+func hashPHC(password string) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	params := defaultArgon2Params
+	key := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, params.keyLen)
+
+	return encodePHC(params, salt, key), nil
+}
+
+// verifyPHC verifies a password against a hash in the PHC string format.
+func verifyPHC(password, encoded string) (bool, error) {
+	params, salt, key, err := decodePHC(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	providedKey := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, params.keyLen)
+
+	return subtle.ConstantTimeCompare(providedKey, key) == 1, nil
+}
+
+// hashLegacy hashes a password the way it was stored before hashes were
+// self-describing: bare hex of argon2id with one global salt.
+func hashLegacy(password, salt string) string {
+	key := argon2.IDKey([]byte(password), []byte(salt), 1, 64*1024, 4, 32)
+	return fmt.Sprintf("%x", key)
+}
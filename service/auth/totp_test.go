@@ -0,0 +1,60 @@
+package auth_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/omegaatt36/bookly/service/auth"
+)
+
+// rfc6238Secret is the SHA1 test secret of RFC 6238, base32 encoded
+var rfc6238Secret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func (s *testAuthSuite) TestValidateTOTP() {
+	// The RFC 6238 test vectors, truncated to six digits
+	for _, tc := range []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	} {
+		step, ok := auth.ValidateTOTP(rfc6238Secret, tc.code, time.Unix(tc.unix, 0))
+		s.True(ok, tc.unix)
+		s.Equal(tc.unix/30, step)
+	}
+
+	_, ok := auth.ValidateTOTP(rfc6238Secret, "000000", time.Unix(59, 0))
+	s.False(ok)
+
+	_, ok = auth.ValidateTOTP(rfc6238Secret, "28708", time.Unix(59, 0))
+	s.False(ok)
+}
+
+func (s *testAuthSuite) TestValidateTOTPSkew() {
+	at := time.Unix(1111111109, 0)
+
+	// A code from the previous time step is still accepted
+	step, ok := auth.ValidateTOTP(rfc6238Secret, "081804", at.Add(30*time.Second))
+	s.True(ok)
+	s.Equal(at.Unix()/30, step)
+
+	// Codes further away are not
+	_, ok = auth.ValidateTOTP(rfc6238Secret, "081804", at.Add(90*time.Second))
+	s.False(ok)
+}
+
+func (s *testAuthSuite) TestGenerateTOTPSecret() {
+	secret, err := auth.GenerateTOTPSecret()
+	s.NoError(err)
+	s.Len(secret, 32)
+
+	uri := auth.TOTPProvisioningURI("Bookly", "user@example.com", secret)
+	s.True(strings.HasPrefix(uri, "otpauth://totp/Bookly:user@example.com?"))
+	s.Contains(uri, "secret="+secret)
+	s.Contains(uri, "issuer=Bookly")
+}
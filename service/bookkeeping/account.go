@@ -18,8 +18,8 @@ func (s *Service) UpdateAccount(req domain.UpdateAccountRequest) error {
 }
 
 // DeactivateAccountByID deactivates an account by its ID.
-func (s *Service) DeactivateAccountByID(id int32) error {
-	return s.accountRepo.DeactivateAccountByID(id)
+func (s *Service) DeactivateAccountByID(id int32, actor domain.AuditActor) error {
+	return s.accountRepo.DeactivateAccountByID(id, actor)
 }
 
 // GetAllAccounts retrieves all accounts.
@@ -31,3 +31,8 @@ func (s *Service) GetAllAccounts() ([]*domain.Account, error) {
 func (s *Service) GetAccountsByUserID(userID int32) ([]*domain.Account, error) {
 	return s.accountRepo.GetAccountsByUserID(userID)
 }
+
+// GetAccountsByWorkspaceID retrieves all accounts owned by a workspace.
+func (s *Service) GetAccountsByWorkspaceID(workspaceID int32) ([]*domain.Account, error) {
+	return s.accountRepo.GetAccountsByWorkspaceID(workspaceID)
+}
@@ -0,0 +1,226 @@
+package bookkeeping
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// ErrInvalidAccountMember is returned when a membership change is not allowed
+var ErrInvalidAccountMember = errors.New("invalid account member")
+
+// GetAccountAccess retrieves an account together with the role the user holds on it.
+// The owner of a personal account is always AccountMemberRoleOwner. Otherwise the user gets
+// the higher of their account membership role and, for workspace accounts, their current
+// workspace role. Workspace accounts belong to the workspace, so whoever created one has no
+// role of their own on it. An empty role means no access.
+func (s *Service) GetAccountAccess(accountID, userID int32) (*domain.Account, domain.AccountMemberRole, error) {
+	account, err := s.accountRepo.GetAccountByID(accountID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if account.WorkspaceID == nil && account.UserID == userID {
+		return account, domain.AccountMemberRoleOwner, nil
+	}
+
+	var role domain.AccountMemberRole
+	if s.accountMemberRepo != nil {
+		member, err := s.accountMemberRepo.GetAccountMember(accountID, userID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, "", err
+		}
+		if member != nil {
+			role = member.Role
+		}
+	}
+
+	if account.WorkspaceID != nil && s.workspaceRepo != nil {
+		member, err := s.workspaceRepo.GetWorkspaceMember(*account.WorkspaceID, userID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, "", err
+		}
+		if member != nil && !role.Allows(member.Role.AccountRole()) {
+			role = member.Role.AccountRole()
+		}
+	}
+
+	return account, role, nil
+}
+
+// GetSharedAccountsByUserID retrieves the accounts other users have shared with the user.
+func (s *Service) GetSharedAccountsByUserID(userID int32) ([]*domain.SharedAccount, error) {
+	if s.accountMemberRepo == nil {
+		return nil, nil
+	}
+
+	return s.accountMemberRepo.GetSharedAccountsByUserID(userID)
+}
+
+// GetAccountMembers retrieves the members of an account, excluding its owner.
+func (s *Service) GetAccountMembers(accountID int32) ([]*domain.AccountMember, error) {
+	return s.accountMemberRepo.GetAccountMembersByAccountID(accountID)
+}
+
+// UpdateAccountMemberRole changes the role of an account member.
+func (s *Service) UpdateAccountMemberRole(accountID, userID int32, role domain.AccountMemberRole) error {
+	if err := validateAccountMemberRole(role); err != nil {
+		return err
+	}
+
+	return s.accountMemberRepo.UpdateAccountMemberRole(accountID, userID, role)
+}
+
+// RemoveAccountMember revokes a member's access to an account.
+func (s *Service) RemoveAccountMember(accountID, userID int32) error {
+	return s.accountMemberRepo.DeleteAccountMember(accountID, userID)
+}
+
+// InviteAccountMember invites a user by email to join an account with the given role, and
+// emails the invitation.
+func (s *Service) InviteAccountMember(req domain.CreateAccountInvitationRequest) (*domain.AccountInvitation, error) {
+	if err := validateAccountMemberRole(req.Role); err != nil {
+		return nil, err
+	}
+
+	req.Email = strings.TrimSpace(req.Email)
+	if req.Email == "" {
+		return nil, fmt.Errorf("%w: email is required", ErrInvalidAccountMember)
+	}
+
+	if req.ExpiresAt.IsZero() {
+		req.ExpiresAt = time.Now().Add(domain.AccountInvitationTTL)
+	}
+
+	invitation, err := s.accountMemberRepo.CreateAccountInvitation(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The invitation stands even if the email fails, the invitee also finds it once signed in
+	if err := s.notifyAccountInvitation(invitation); err != nil {
+		slog.Error("failed to send account invitation",
+			"invitation_id", invitation.ID,
+			"error", err)
+	}
+
+	return invitation, nil
+}
+
+// notifyAccountInvitation emails an invitation to the address it was sent to
+func (s *Service) notifyAccountInvitation(invitation *domain.AccountInvitation) error {
+	if s.notifier == nil || s.userRepo == nil {
+		return nil
+	}
+
+	account, err := s.accountRepo.GetAccountByID(invitation.AccountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	inviter, err := s.userRepo.GetUserByID(invitation.InviterID)
+	if err != nil {
+		return fmt.Errorf("failed to get inviter: %w", err)
+	}
+
+	return s.notifier.Notify(domain.Notification{
+		Recipient: invitation.Email,
+		Subject:   fmt.Sprintf("%s invited you to %q on Bookly", cmp.Or(inviter.Nickname, inviter.Name), account.Name),
+		Body: fmt.Sprintf("%s invited you to join the account %q on Bookly as %s.\n\n"+
+			"Sign in or sign up at %s with this verified email address to accept the invitation "+
+			"before %s.\n\n"+
+			"If you do not know %[1]s, you can ignore this message.",
+			cmp.Or(inviter.Nickname, inviter.Name), account.Name, invitation.Role, s.webURL,
+			invitation.ExpiresAt.Format(time.DateOnly)),
+	})
+}
+
+// GetAccountInvitations retrieves all invitations of an account.
+func (s *Service) GetAccountInvitations(accountID int32) ([]*domain.AccountInvitation, error) {
+	return s.accountMemberRepo.GetAccountInvitationsByAccountID(accountID)
+}
+
+// GetAccountInvitationByID retrieves an account invitation by its ID.
+func (s *Service) GetAccountInvitationByID(id int32) (*domain.AccountInvitation, error) {
+	return s.accountMemberRepo.GetAccountInvitationByID(id)
+}
+
+// GetPendingAccountInvitations retrieves the pending invitations addressed to any verified email of
+// the user. Only these can be accepted, so that nobody joins an account with an address they do
+// not own.
+func (s *Service) GetPendingAccountInvitations(userID int32) ([]*domain.AccountInvitation, error) {
+	return s.accountMemberRepo.GetPendingAccountInvitationsByUserID(userID)
+}
+
+// AcceptAccountInvitation accepts an invitation addressed to the user and grants the invited role.
+func (s *Service) AcceptAccountInvitation(invitationID, userID int32) error {
+	invitation, err := s.getPendingInvitationForUser(invitationID, userID)
+	if err != nil {
+		return err
+	}
+
+	account, err := s.accountRepo.GetAccountByID(invitation.AccountID)
+	if err != nil {
+		return err
+	}
+	if account.WorkspaceID == nil && account.UserID == userID {
+		return fmt.Errorf("%w: owner cannot join their own account", ErrInvalidAccountMember)
+	}
+
+	return s.accountMemberRepo.AcceptAccountInvitation(invitation.ID, userID)
+}
+
+// DeclineAccountInvitation declines an invitation addressed to the user.
+func (s *Service) DeclineAccountInvitation(invitationID, userID int32) error {
+	invitation, err := s.getPendingInvitationForUser(invitationID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.accountMemberRepo.UpdateAccountInvitationStatus(invitation.ID, domain.AccountInvitationStatusDeclined)
+}
+
+// RevokeAccountInvitation revokes a pending invitation.
+func (s *Service) RevokeAccountInvitation(invitationID int32) error {
+	invitation, err := s.accountMemberRepo.GetAccountInvitationByID(invitationID)
+	if err != nil {
+		return err
+	}
+
+	if invitation.Status != domain.AccountInvitationStatusPending {
+		return fmt.Errorf("%w: invitation is already %s", ErrInvalidAccountMember, invitation.Status)
+	}
+
+	return s.accountMemberRepo.UpdateAccountInvitationStatus(invitationID, domain.AccountInvitationStatusRevoked)
+}
+
+func (s *Service) getPendingInvitationForUser(invitationID, userID int32) (*domain.AccountInvitation, error) {
+	invitations, err := s.accountMemberRepo.GetPendingAccountInvitationsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(invitations, func(invitation *domain.AccountInvitation) bool {
+		return invitation.ID == invitationID
+	})
+	if index < 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return invitations[index], nil
+}
+
+// validateAccountMemberRole ensures the role can be granted to a member; ownership cannot be shared.
+func validateAccountMemberRole(role domain.AccountMemberRole) error {
+	if role != domain.AccountMemberRoleEditor && role != domain.AccountMemberRoleViewer {
+		return fmt.Errorf("%w: role must be editor or viewer", ErrInvalidAccountMember)
+	}
+
+	return nil
+}
@@ -0,0 +1,8 @@
+package bookkeeping
+
+import "github.com/omegaatt36/bookly/domain"
+
+// GetAuditLogs retrieves the audit logs of an entity, most recent first.
+func (s *Service) GetAuditLogs(entityType domain.AuditEntityType, entityID int32) ([]*domain.AuditLog, error) {
+	return s.auditLogRepo.GetAuditLogs(entityType, entityID)
+}
@@ -0,0 +1,369 @@
+package bookkeeping
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log/slog"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// ErrDigestRepositoryNotSet is returned when using digests without their repository
+var ErrDigestRepositoryNotSet = errors.New("digest repository not set")
+
+// ErrMailerNotSet is returned when sending digests without a mailer
+var ErrMailerNotSet = errors.New("mailer not set")
+
+// ErrInvalidDigestSettings is returned when digest settings are out of range
+var ErrInvalidDigestSettings = errors.New("invalid digest settings")
+
+// maxDigestDayOfMonth bounds the day monthly digests are sent, so that every month has it.
+const maxDigestDayOfMonth = 28
+
+//go:embed templates/digest.txt templates/digest.html
+var digestTemplateFS embed.FS
+
+var digestFuncs = map[string]any{
+	"date": func(t time.Time) string { return t.Format(time.DateOnly) },
+	"money": func(amount decimal.Decimal) string {
+		return amount.StringFixed(2)
+	},
+	"category": func(category string) string {
+		if category == "" {
+			return "Uncategorized"
+		}
+		return category
+	},
+}
+
+var (
+	digestTextTemplate = texttemplate.Must(
+		texttemplate.New("digest.txt").Funcs(digestFuncs).ParseFS(digestTemplateFS, "templates/digest.txt"))
+	digestHTMLTemplate = htmltemplate.Must(
+		htmltemplate.New("digest.html").Funcs(digestFuncs).ParseFS(digestTemplateFS, "templates/digest.html"))
+)
+
+// digestReport is the content of a digest email
+type digestReport struct {
+	Subject      string
+	Name         string
+	Frequency    domain.DigestFrequency
+	Since        time.Time // Start of the reported period
+	Last         time.Time // Last day of the reported period
+	Spending     []*domain.CategorySpending
+	Totals       []digestTotal
+	Upcoming     []digestPayment
+	UpcomingLast time.Time // Last day upcoming payments are listed for
+	Accounts     []*domain.Account
+}
+
+// digestTotal is the spending of a period in a currency
+type digestTotal struct {
+	Currency string
+	Amount   decimal.Decimal
+}
+
+// digestPayment is an upcoming occurrence of a recurring transaction
+type digestPayment struct {
+	Name     string
+	DueDate  time.Time
+	Amount   decimal.Decimal
+	Currency string
+}
+
+// defaultDigestSettings returns the digest preferences of a user who set none, opted out.
+func defaultDigestSettings(userID int32) *domain.DigestSettings {
+	return &domain.DigestSettings{
+		UserID:     userID,
+		Frequency:  domain.DigestFrequencyWeekly,
+		Weekday:    time.Monday,
+		DayOfMonth: 1,
+	}
+}
+
+// digestInterval returns the length of the period a digest covers
+func digestInterval(frequency domain.DigestFrequency) (months, days int) {
+	if frequency == domain.DigestFrequencyMonthly {
+		return 1, 0
+	}
+	return 0, 7
+}
+
+// digestPeriod returns the period covered by the digest sent on the day of now, from since,
+// inclusive, to until, exclusive. It reports false when no digest is sent that day.
+func digestPeriod(settings *domain.DigestSettings, now time.Time) (since, until time.Time, ok bool) {
+	switch settings.Frequency {
+	case domain.DigestFrequencyWeekly:
+		ok = now.Weekday() == settings.Weekday
+	case domain.DigestFrequencyMonthly:
+		ok = now.Day() == settings.DayOfMonth
+	}
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	months, days := digestInterval(settings.Frequency)
+	until = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return until.AddDate(0, -months, -days), until, true
+}
+
+// GetDigestSettings gets the digest preferences of a user, opted out when they set none.
+func (s *Service) GetDigestSettings(ctx context.Context, userID int32) (*domain.DigestSettings, error) {
+	if s.digestRepo == nil {
+		return nil, ErrDigestRepositoryNotSet
+	}
+
+	settings, err := s.digestRepo.GetDigestSettings(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return defaultDigestSettings(userID), nil
+	}
+	return settings, err
+}
+
+// UpdateDigestSettings sets whether a user receives digests, how often and on which day
+func (s *Service) UpdateDigestSettings(ctx context.Context, settings domain.DigestSettings) (*domain.DigestSettings, error) {
+	if s.digestRepo == nil {
+		return nil, ErrDigestRepositoryNotSet
+	}
+
+	if !settings.Frequency.IsValid() {
+		return nil, fmt.Errorf("%w: unknown frequency %q", ErrInvalidDigestSettings, settings.Frequency)
+	}
+	if settings.Weekday < time.Sunday || settings.Weekday > time.Saturday {
+		return nil, fmt.Errorf("%w: weekday must be between 0 and 6", ErrInvalidDigestSettings)
+	}
+	if settings.DayOfMonth < 1 || settings.DayOfMonth > maxDigestDayOfMonth {
+		return nil, fmt.Errorf("%w: day of month must be between 1 and %d", ErrInvalidDigestSettings, maxDigestDayOfMonth)
+	}
+
+	return s.digestRepo.UpdateDigestSettings(ctx, settings)
+}
+
+// SendDigests emails the users who opted in to digests the report of the period that just ended,
+// when today is their digest day. Every digest is sent once, a failed one is retried by the next
+// run of the day. It returns the number of digests sent.
+func (s *Service) SendDigests(ctx context.Context) (int, error) {
+	if s.digestRepo == nil || s.userRepo == nil || s.accountRepo == nil || s.recurringTransactionRepo == nil {
+		return 0, ErrDigestRepositoryNotSet
+	}
+	if s.mailer == nil {
+		return 0, ErrMailerNotSet
+	}
+
+	settingsList, err := s.digestRepo.GetEnabledDigestSettings(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	sent := 0
+	for _, settings := range settingsList {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+
+		since, until, ok := digestPeriod(settings, now)
+		if !ok || (settings.LastPeriodEnd != nil && !settings.LastPeriodEnd.Before(until)) {
+			continue
+		}
+
+		ok, err := s.sendDigest(ctx, settings, since, until)
+		if err != nil {
+			slog.Error("failed to send digest",
+				"user_id", settings.UserID,
+				"error", err)
+			continue
+		}
+
+		if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// sendDigest claims the digest of a user for a period and sends it, releasing the claim when it
+// could not be sent. It reports false when the digest was not sent because the user is disabled
+// or another run claimed it.
+func (s *Service) sendDigest(ctx context.Context, settings *domain.DigestSettings, since, until time.Time) (bool, error) {
+	user, err := s.userRepo.GetUserByID(settings.UserID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Disabled {
+		return false, nil
+	}
+
+	recipient, err := s.digestRecipient(ctx, settings.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.digestRepo.ClaimDigestPeriod(ctx, settings.UserID, until); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	email, err := s.buildDigest(ctx, user, settings.Frequency, since, until)
+	if err == nil {
+		email.Recipient = recipient
+		err = s.mailer.Send(*email)
+	}
+	if err != nil {
+		if releaseErr := s.digestRepo.ReleaseDigestPeriod(context.WithoutCancel(ctx), settings.UserID, until, settings.LastPeriodEnd); releaseErr != nil {
+			slog.Error("failed to release digest period",
+				"user_id", settings.UserID,
+				"error", releaseErr)
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// digestRecipient returns the address digests of a user are sent to, the target of their email
+// channel, or the email they sign in with.
+func (s *Service) digestRecipient(ctx context.Context, userID int32) (string, error) {
+	if s.notificationRepo != nil {
+		preferences, err := s.notificationRepo.GetNotificationPreferences(ctx, userID)
+		if err != nil {
+			return "", err
+		}
+
+		for _, preference := range preferences {
+			if preference.Channel == domain.NotificationChannelEmail && preference.Enabled {
+				return preference.Target, nil
+			}
+		}
+	}
+
+	identities, err := s.userRepo.GetIdentitiesByUserID(userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get identities: %w", err)
+	}
+
+	index := slices.IndexFunc(identities, func(identity *domain.Identity) bool {
+		return identity.Provider == domain.IdentityProviderPassword
+	})
+	if index < 0 {
+		return "", errors.New("user has no email address")
+	}
+
+	return identities[index].Identifier, nil
+}
+
+// buildDigest renders the digest of a user for a period, from since, inclusive, to until,
+// exclusive. The recipient of the returned email is not set.
+func (s *Service) buildDigest(ctx context.Context, user *domain.User, frequency domain.DigestFrequency, since, until time.Time) (*domain.Email, error) {
+	spending, err := s.digestRepo.GetSpendingByCategory(ctx, user.ID, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.accountRepo.GetAccountsByUserID(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	transactions, err := s.recurringTransactionRepo.GetRecurringTransactionsByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring transactions: %w", err)
+	}
+
+	months, days := digestInterval(frequency)
+	report := digestReport{
+		Name:         cmp.Or(user.Nickname, user.Name),
+		Frequency:    frequency,
+		Since:        since,
+		Last:         until.AddDate(0, 0, -1),
+		Spending:     spending,
+		Totals:       digestTotals(spending),
+		UpcomingLast: until.AddDate(0, months, days-1),
+	}
+	report.Subject = fmt.Sprintf("Your %s digest from %s to %s",
+		frequency, report.Since.Format(time.DateOnly), report.Last.Format(time.DateOnly))
+
+	currencies := make(map[int32]string, len(accounts))
+	for _, account := range accounts {
+		currencies[account.ID] = account.Currency
+		if account.Status == domain.AccountStatusActive {
+			report.Accounts = append(report.Accounts, account)
+		}
+	}
+
+	upcomingUntil := until.AddDate(0, months, days)
+	for _, transaction := range transactions {
+		if transaction.Status != domain.RecurrenceStatusActive {
+			continue
+		}
+
+		occurrences, err := s.PreviewOccurrences(transaction, transaction.NextDue, upcomingUntil)
+		if err != nil {
+			slog.Warn("failed to preview occurrences for digest",
+				"transaction_id", transaction.ID,
+				"error", err)
+			continue
+		}
+
+		for _, occurrence := range occurrences {
+			if !occurrence.Before(upcomingUntil) {
+				break
+			}
+
+			report.Upcoming = append(report.Upcoming, digestPayment{
+				Name:     transaction.Name,
+				DueDate:  occurrence,
+				Amount:   transaction.Amount.Abs(),
+				Currency: currencies[transaction.AccountID],
+			})
+		}
+	}
+	slices.SortStableFunc(report.Upcoming, func(a, b digestPayment) int {
+		return a.DueDate.Compare(b.DueDate)
+	})
+
+	var text, html bytes.Buffer
+	if err := digestTextTemplate.Execute(&text, report); err != nil {
+		return nil, fmt.Errorf("failed to render digest: %w", err)
+	}
+	if err := digestHTMLTemplate.Execute(&html, report); err != nil {
+		return nil, fmt.Errorf("failed to render digest: %w", err)
+	}
+
+	return &domain.Email{
+		Subject: report.Subject,
+		Text:    strings.TrimSpace(text.String()),
+		HTML:    html.String(),
+	}, nil
+}
+
+// digestTotals sums spending by currency, in the order currencies first appear
+func digestTotals(spending []*domain.CategorySpending) []digestTotal {
+	var totals []digestTotal
+	for _, category := range spending {
+		index := slices.IndexFunc(totals, func(total digestTotal) bool {
+			return total.Currency == category.Currency
+		})
+		if index < 0 {
+			totals = append(totals, digestTotal{Currency: category.Currency})
+			index = len(totals) - 1
+		}
+		totals[index].Amount = totals[index].Amount.Add(category.Amount)
+	}
+
+	return totals
+}
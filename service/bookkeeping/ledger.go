@@ -8,12 +8,30 @@ import (
 	"github.com/omegaatt36/bookly/domain"
 )
 
+// ErrLedgerNotEditable is returned when the edit policy of an account no longer allows
+// editing a ledger in place; it has to be corrected by an adjustment instead
+var ErrLedgerNotEditable = errors.New("ledger can no longer be edited, adjust it instead")
+
+// ErrLedgerPending is returned when changing a pending ledger, which is confirmed or skipped from
+// its reminder instead
+var ErrLedgerPending = errors.New("ledger is pending confirmation")
+
 // CreateLedger creates a new ledger based on the provided CreateLedgerRequest.
 func (s *Service) CreateLedger(req domain.CreateLedgerRequest) (int32, error) {
-	if _, err := s.accountRepo.GetAccountByID(req.AccountID); err != nil {
+	account, err := s.accountRepo.GetAccountByID(req.AccountID)
+	if err != nil {
 		return 0, fmt.Errorf("account not found: %d, %w", req.AccountID, err)
 	}
 
+	if err := s.checkBooksLock(req.AccountID, &req.Actor, req.Date); err != nil {
+		return 0, err
+	}
+
+	req, err = s.applyPayeeRules(account.UserID, req)
+	if err != nil {
+		return 0, err
+	}
+
 	return s.ledgerRepo.CreateLedger(req)
 
 }
@@ -35,19 +53,71 @@ func (s *Service) UpdateLedger(req domain.UpdateLedgerRequest) error {
 		return err
 	}
 
-	if time.Since(ledger.UpdatedAt) > domain.EditableDuration {
-		return errors.New("ledger is too old to be edited")
+	if ledger.IsPending {
+		return ErrLedgerPending
+	}
+
+	account, err := s.accountRepo.GetAccountByID(ledger.AccountID)
+	if err != nil {
+		return err
+	}
+
+	if !account.EditPolicy.Editable(ledger, time.Now()) {
+		return ErrLedgerNotEditable
+	}
+
+	dates := []time.Time{ledger.Date}
+	if req.Date != nil {
+		dates = append(dates, *req.Date)
+	}
+
+	if err := s.checkBooksLock(ledger.AccountID, &req.Actor, dates...); err != nil {
+		return err
 	}
 
 	return s.ledgerRepo.UpdateLedger(req)
 }
 
 // VoidLedger voids a ledger by its ID.
-func (s *Service) VoidLedger(id int32) error {
-	return s.ledgerRepo.VoidLedger(id)
+func (s *Service) VoidLedger(id int32, actor domain.AuditActor) error {
+	ledger, err := s.ledgerRepo.GetLedgerByID(id)
+	if err != nil {
+		return err
+	}
+
+	if ledger.IsPending {
+		return ErrLedgerPending
+	}
+
+	if err := s.checkBooksLock(ledger.AccountID, &actor, ledger.Date); err != nil {
+		return err
+	}
+
+	return s.ledgerRepo.VoidLedger(id, actor)
 }
 
 // AdjustLedger adjusts a ledger by its original ID.
+// Both the original ledger and the adjusting entry have to fall into an open period.
 func (s *Service) AdjustLedger(originalID int32, adjustment domain.CreateLedgerRequest) error {
+	original, err := s.ledgerRepo.GetLedgerByID(originalID)
+	if err != nil {
+		return err
+	}
+
+	if original.IsPending {
+		return ErrLedgerPending
+	}
+
+	// The adjusting entry may be booked to another account than the original ledger
+	originalActor := adjustment.Actor
+	if err := s.checkBooksLock(original.AccountID, &originalActor, original.Date); err != nil {
+		return err
+	}
+
+	if err := s.checkBooksLock(adjustment.AccountID, &adjustment.Actor, adjustment.Date); err != nil {
+		return err
+	}
+	adjustment.Actor.OverrideBooksLock = adjustment.Actor.OverrideBooksLock || originalActor.OverrideBooksLock
+
 	return s.ledgerRepo.AdjustLedger(originalID, adjustment)
 }
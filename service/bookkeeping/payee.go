@@ -0,0 +1,203 @@
+package bookkeeping
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// ErrInvalidPayeeRule is returned when a payee rule cannot be used to match notes
+var ErrInvalidPayeeRule = errors.New("invalid payee rule")
+
+// CreatePayee creates a new payee
+func (s *Service) CreatePayee(req domain.CreatePayeeRequest) (int32, error) {
+	if req.Name == "" {
+		return 0, errors.New("payee name is required")
+	}
+
+	return s.payeeRepo.CreatePayee(req)
+}
+
+// GetPayeeByID retrieves a payee by its ID
+func (s *Service) GetPayeeByID(id int32) (*domain.Payee, error) {
+	return s.payeeRepo.GetPayeeByID(id)
+}
+
+// GetPayeesByUserID retrieves all payees of a user
+func (s *Service) GetPayeesByUserID(userID int32) ([]*domain.Payee, error) {
+	return s.payeeRepo.GetPayeesByUserID(userID)
+}
+
+// UpdatePayee updates an existing payee
+func (s *Service) UpdatePayee(req domain.UpdatePayeeRequest) error {
+	return s.payeeRepo.UpdatePayee(req)
+}
+
+// DeletePayee deletes a payee by its ID
+func (s *Service) DeletePayee(id int32) error {
+	return s.payeeRepo.DeletePayee(id)
+}
+
+// CreatePayeeRule creates a new payee rule
+func (s *Service) CreatePayeeRule(req domain.CreatePayeeRuleRequest) (int32, error) {
+	if err := validatePayeeRule(req.MatchType, req.Pattern); err != nil {
+		return 0, err
+	}
+
+	if req.PayeeID == nil && req.Category == "" && len(req.Tags) == 0 {
+		return 0, fmt.Errorf("%w: rule must set a payee, category or tags", ErrInvalidPayeeRule)
+	}
+
+	return s.payeeRepo.CreatePayeeRule(req)
+}
+
+// GetPayeeRuleByID retrieves a payee rule by its ID
+func (s *Service) GetPayeeRuleByID(id int32) (*domain.PayeeRule, error) {
+	return s.payeeRepo.GetPayeeRuleByID(id)
+}
+
+// GetPayeeRulesByUserID retrieves all payee rules of a user, highest priority first
+func (s *Service) GetPayeeRulesByUserID(userID int32) ([]*domain.PayeeRule, error) {
+	return s.payeeRepo.GetPayeeRulesByUserID(userID)
+}
+
+// UpdatePayeeRule updates an existing payee rule
+func (s *Service) UpdatePayeeRule(req domain.UpdatePayeeRuleRequest) error {
+	rule, err := s.payeeRepo.GetPayeeRuleByID(req.ID)
+	if err != nil {
+		return err
+	}
+
+	matchType := rule.MatchType
+	if req.MatchType != nil {
+		matchType = *req.MatchType
+	}
+	pattern := rule.Pattern
+	if req.Pattern != nil {
+		pattern = *req.Pattern
+	}
+
+	if err := validatePayeeRule(matchType, pattern); err != nil {
+		return err
+	}
+
+	return s.payeeRepo.UpdatePayeeRule(req)
+}
+
+// DeletePayeeRule deletes a payee rule by its ID
+func (s *Service) DeletePayeeRule(id int32) error {
+	return s.payeeRepo.DeletePayeeRule(id)
+}
+
+// ApplyPayeeRules re-applies the user's payee rules to the ledger history.
+// It returns the ledgers whose classification changes; with dryRun nothing is written.
+func (s *Service) ApplyPayeeRules(userID int32, dryRun bool) ([]domain.PayeeRuleMatch, error) {
+	rules, err := s.payeeRepo.GetPayeeRulesByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgers, err := s.ledgerRepo.GetLedgersByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]domain.PayeeRuleMatch, 0)
+	for _, ledger := range ledgers {
+		rule := matchPayeeRule(rules, ledger.Note)
+		if rule == nil {
+			continue
+		}
+
+		before := ledger.Classification()
+		after := rule.Apply(before)
+		if before.Equal(after) {
+			continue
+		}
+
+		matches = append(matches, domain.PayeeRuleMatch{
+			LedgerID: ledger.ID,
+			RuleID:   rule.ID,
+			Note:     ledger.Note,
+			Before:   before,
+			After:    after,
+		})
+	}
+
+	if dryRun {
+		return matches, nil
+	}
+
+	// Either the whole history is reclassified or none of it
+	if err := s.ledgerRepo.ClassifyLedgers(matches); err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// applyPayeeRules fills the payee, category and tags of a new ledger from the first
+// matching rule. Values given explicitly in the request take precedence.
+func (s *Service) applyPayeeRules(userID int32, req domain.CreateLedgerRequest) (domain.CreateLedgerRequest, error) {
+	if s.payeeRepo == nil {
+		return req, nil
+	}
+
+	rules, err := s.payeeRepo.GetPayeeRulesByUserID(userID)
+	if err != nil {
+		return req, err
+	}
+
+	rule := matchPayeeRule(rules, req.Note)
+	if rule == nil {
+		return req, nil
+	}
+
+	classification := rule.Apply(domain.LedgerClassification{})
+	if req.PayeeID == nil {
+		req.PayeeID = classification.PayeeID
+	}
+	if req.Category == "" {
+		req.Category = classification.Category
+	}
+	if len(req.Tags) == 0 {
+		req.Tags = classification.Tags
+	}
+
+	return req, nil
+}
+
+// matchPayeeRule returns the first rule matching the note. Rules must be ordered by priority.
+func matchPayeeRule(rules []*domain.PayeeRule, note string) *domain.PayeeRule {
+	if note == "" {
+		return nil
+	}
+
+	for _, rule := range rules {
+		if rule.Match(note) {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+func validatePayeeRule(matchType domain.PayeeRuleMatchType, pattern string) error {
+	if !matchType.IsValid() {
+		return fmt.Errorf("%w: %s is not a valid match type", ErrInvalidPayeeRule, matchType)
+	}
+
+	if pattern == "" {
+		return fmt.Errorf("%w: pattern is required", ErrInvalidPayeeRule)
+	}
+
+	if matchType == domain.PayeeRuleMatchTypeRegex {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPayeeRule, err)
+		}
+	}
+
+	return nil
+}
@@ -3,9 +3,14 @@ package bookkeeping
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/omegaatt36/bookly/domain"
 )
 
@@ -15,22 +20,41 @@ func (s *Service) CreateRecurringTransaction(ctx context.Context, request domain
 		return nil, ErrRecurringRepositoriesNotSet
 	}
 
-	transaction, err := s.recurringTransactionRepo.CreateRecurringTransaction(ctx, request)
+	nextDue, err := firstDueDate(&domain.RecurringTransaction{
+		StartDate:   request.StartDate,
+		EndDate:     request.EndDate,
+		RecurType:   request.RecurType,
+		Frequency:   request.Frequency,
+		DayOfWeek:   request.DayOfWeek,
+		DayOfMonth:  request.DayOfMonth,
+		MonthOfYear: request.MonthOfYear,
+		RRule:       request.RRule,
+	})
 	if err != nil {
 		return nil, err
 	}
+	request.NextDue = nextDue
 
-	// Create initial reminder if needed (3 days before first due date)
-	reminderDate := calculateReminderDate(transaction.NextDue)
-	if reminderDate.After(time.Now()) {
-		_, err = s.reminderRepo.CreateReminder(ctx, transaction.ID, reminderDate)
-		if err != nil {
-			slog.Warn("failed to create reminder for recurring transaction",
-				"transaction_id", transaction.ID,
-				"error", err)
-		}
+	if request.CatchUpPolicy == "" {
+		request.CatchUpPolicy = domain.CatchUpPolicyBookAll
 	}
 
+	if request.PostMode == "" {
+		request.PostMode = domain.PostModeAuto
+	}
+
+	request.ReminderOffsets, err = normalizeReminderOffsets(request.ReminderOffsets)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.recurringTransactionRepo.CreateRecurringTransaction(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	s.scheduleReminders(ctx, transaction)
+
 	return transaction, nil
 }
 
@@ -39,22 +63,199 @@ func (s *Service) GetRecurringTransaction(ctx context.Context, id int32) (*domai
 	return s.recurringTransactionRepo.GetRecurringTransactionByID(ctx, id)
 }
 
+// GetRecurringTransactionIncludingDeleted gets a recurring transaction by ID, even if it was deleted
+func (s *Service) GetRecurringTransactionIncludingDeleted(ctx context.Context, id int32) (*domain.RecurringTransaction, error) {
+	return s.recurringTransactionRepo.GetRecurringTransactionIncludingDeleted(ctx, id)
+}
+
 // GetRecurringTransactionsByUserID gets all recurring transactions for a user
 func (s *Service) GetRecurringTransactionsByUserID(ctx context.Context, userID int32) ([]*domain.RecurringTransaction, error) {
 	return s.recurringTransactionRepo.GetRecurringTransactionsByUserID(ctx, userID)
 }
 
+// GetRecurringTransactionsByWorkspaceID gets all recurring transactions owned by a workspace
+func (s *Service) GetRecurringTransactionsByWorkspaceID(ctx context.Context, workspaceID int32) ([]*domain.RecurringTransaction, error) {
+	return s.recurringTransactionRepo.GetRecurringTransactionsByWorkspaceID(ctx, workspaceID)
+}
+
 // UpdateRecurringTransaction updates a recurring transaction
 func (s *Service) UpdateRecurringTransaction(ctx context.Context, request domain.UpdateRecurringTransactionRequest) (*domain.RecurringTransaction, error) {
-	return s.recurringTransactionRepo.UpdateRecurringTransaction(ctx, request)
+	if request.RecurType != nil || request.Frequency != nil || request.DayOfWeek != nil ||
+		request.DayOfMonth != nil || request.MonthOfYear != nil || request.RRule != nil || request.EndDate != nil {
+		transaction, err := s.recurringTransactionRepo.GetRecurringTransactionByID(ctx, request.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Reschedule from the updated schedule, still anchored on the start date and moving on
+		// from the current next due date
+		schedule := *transaction
+		if request.RecurType != nil {
+			schedule.RecurType = *request.RecurType
+		}
+		if request.Frequency != nil {
+			schedule.Frequency = *request.Frequency
+		}
+		if request.DayOfWeek != nil {
+			schedule.DayOfWeek = request.DayOfWeek
+		}
+		if request.DayOfMonth != nil {
+			schedule.DayOfMonth = request.DayOfMonth
+		}
+		if request.MonthOfYear != nil {
+			schedule.MonthOfYear = request.MonthOfYear
+		}
+		if request.RRule != nil {
+			schedule.RRule = *request.RRule
+		}
+		if request.EndDate != nil {
+			schedule.EndDate = request.EndDate
+		}
+
+		nextDue, err := rescheduledDueDate(transaction, &schedule)
+		if err != nil {
+			return nil, err
+		}
+		request.NextDue = &nextDue
+	}
+
+	offsets, err := normalizeReminderOffsets(request.ReminderOffsets)
+	if err != nil {
+		return nil, err
+	}
+	request.ReminderOffsets = offsets
+
+	transaction, err := s.recurringTransactionRepo.UpdateRecurringTransaction(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reminders follow the new due date or lead times
+	if (request.NextDue != nil || request.ReminderOffsets != nil) && transaction.Status == domain.RecurrenceStatusActive {
+		s.scheduleReminders(ctx, transaction)
+	}
+
+	return transaction, nil
 }
 
 // DeleteRecurringTransaction deletes a recurring transaction
-func (s *Service) DeleteRecurringTransaction(ctx context.Context, id int32) error {
+func (s *Service) DeleteRecurringTransaction(ctx context.Context, id int32, actor domain.AuditActor) error {
 	if s.recurringTransactionRepo == nil {
 		return ErrRecurringRepositoriesNotSet
 	}
-	return s.recurringTransactionRepo.DeleteRecurringTransaction(ctx, id)
+	return s.recurringTransactionRepo.DeleteRecurringTransaction(ctx, id, actor)
+}
+
+// ErrRecurringTransactionNotActive is returned when changing the next occurrence of a recurring
+// transaction that is paused, completed or cancelled
+var ErrRecurringTransactionNotActive = errors.New("recurring transaction is not active")
+
+// ErrInvalidPostponement is returned when an occurrence is not postponed to a later date before the
+// following occurrence
+var ErrInvalidPostponement = errors.New("an occurrence can only be postponed to a later date before the following one")
+
+// GetRecurringTransactionHistory returns the processed occurrences of a recurring transaction,
+// newest first, with the ledgers booked for them
+func (s *Service) GetRecurringTransactionHistory(ctx context.Context, id int32) ([]*domain.RecurringTransactionExecution, error) {
+	executions, err := s.recurringTransactionRepo.GetRecurringTransactionExecutions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgers, err := s.ledgerRepo.GetLedgersByRecurringTransactionID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgersByID := make(map[int32]*domain.Ledger, len(ledgers))
+	for _, ledger := range ledgers {
+		ledgersByID[ledger.ID] = ledger
+	}
+
+	// Skipped drafts were deleted and keep no ledger
+	for _, execution := range executions {
+		if execution.LedgerID != nil {
+			execution.Ledger = ledgersByID[*execution.LedgerID]
+		}
+	}
+
+	return executions, nil
+}
+
+// SkipNextOccurrence skips the next occurrence of a recurring transaction without booking it,
+// leaving the rest of the series untouched.
+func (s *Service) SkipNextOccurrence(ctx context.Context, id int32, actor domain.AuditActor) (*domain.RecurringTransaction, error) {
+	transaction, err := s.recurringTransactionRepo.GetRecurringTransactionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.Status != domain.RecurrenceStatusActive {
+		return nil, ErrRecurringTransactionNotActive
+	}
+
+	req := domain.ExecuteRecurringOccurrenceRequest{
+		ID:           transaction.ID,
+		ScheduledFor: transaction.NextDue,
+		Status:       domain.RecurrenceStatusActive,
+		Actor:        actor,
+	}
+
+	nextDue, active := nextOccurrence(transaction, transaction.NextDue, false)
+	if active {
+		req.NextDue = nextDue
+	} else {
+		req.NextDue = transaction.NextDue
+		req.Status = domain.RecurrenceStatusCompleted
+	}
+
+	transaction, err = s.recurringTransactionRepo.ExecuteRecurringOccurrence(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if active {
+		s.scheduleReminders(ctx, transaction)
+	}
+
+	return transaction, nil
+}
+
+// PostponeNextOccurrence moves the next occurrence of a recurring transaction to a later day,
+// before the following occurrence, keeping its time of day. The rest of the series is untouched.
+func (s *Service) PostponeNextOccurrence(ctx context.Context, id int32, until time.Time, actor domain.AuditActor) (*domain.RecurringTransaction, error) {
+	transaction, err := s.recurringTransactionRepo.GetRecurringTransactionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.Status != domain.RecurrenceStatusActive {
+		return nil, ErrRecurringTransactionNotActive
+	}
+
+	due := transaction.NextDue
+	until = time.Date(until.Year(), until.Month(), until.Day(),
+		due.Hour(), due.Minute(), due.Second(), due.Nanosecond(), due.Location())
+	if !until.After(due) {
+		return nil, ErrInvalidPostponement
+	}
+
+	if following, ok := nextOccurrence(transaction, due, false); ok && !until.Before(following) {
+		return nil, ErrInvalidPostponement
+	}
+
+	transaction, err = s.recurringTransactionRepo.UpdateRecurringTransaction(ctx, domain.UpdateRecurringTransactionRequest{
+		ID:      id,
+		NextDue: &until,
+		Actor:   actor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.scheduleReminders(ctx, transaction)
+
+	return transaction, nil
 }
 
 // GetReminders gets reminders for a recurring transaction
@@ -77,10 +278,80 @@ func (s *Service) MarkReminderAsRead(ctx context.Context, id int32) (*domain.Rem
 	return s.reminderRepo.MarkReminderAsRead(ctx, id)
 }
 
-// ProcessDueTransactions processes all due recurring transactions
-func (s *Service) ProcessDueTransactions(ctx context.Context) error {
+// ErrNoPendingLedger is returned when confirming or skipping a reminder that has no pending ledger
+var ErrNoPendingLedger = errors.New("reminder has no pending ledger")
+
+// pendingLedger returns the pending ledger a reminder asks to confirm.
+func (s *Service) pendingLedger(reminder *domain.Reminder) (*domain.Ledger, error) {
+	if reminder.LedgerID == nil {
+		return nil, ErrNoPendingLedger
+	}
+
+	ledger, err := s.ledgerRepo.GetLedgerByID(*reminder.LedgerID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, ErrNoPendingLedger
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !ledger.IsPending {
+		return nil, ErrNoPendingLedger
+	}
+
+	return ledger, nil
+}
+
+// ConfirmReminder posts the pending ledger drafted for an occurrence of a recurring transaction,
+// with the amount the user confirmed when one is given, and marks its reminder as read.
+func (s *Service) ConfirmReminder(ctx context.Context, id int32, amount *decimal.Decimal, actor domain.AuditActor) (*domain.Reminder, error) {
+	reminder, err := s.reminderRepo.GetReminderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := s.pendingLedger(reminder)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBooksLock(ledger.AccountID, &actor, ledger.Date); err != nil {
+		return nil, err
+	}
+
+	if err := s.ledgerRepo.PostPendingLedger(ledger.ID, amount, actor); err != nil {
+		return nil, err
+	}
+
+	return s.reminderRepo.MarkReminderAsRead(ctx, id)
+}
+
+// SkipReminder discards the pending ledger drafted for an occurrence of a recurring transaction,
+// and marks its reminder as read.
+func (s *Service) SkipReminder(ctx context.Context, id int32, actor domain.AuditActor) (*domain.Reminder, error) {
+	reminder, err := s.reminderRepo.GetReminderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := s.pendingLedger(reminder)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ledgerRepo.DiscardPendingLedger(ledger.ID, actor); err != nil {
+		return nil, err
+	}
+
+	return s.reminderRepo.MarkReminderAsRead(ctx, id)
+}
+
+// ProcessDueTransactions processes all due recurring transactions. Occurrences missed while
+// transactions were not processed are caught up on their scheduled dates, following the catch-up
+// policy of each transaction. It returns the number of transactions processed.
+func (s *Service) ProcessDueTransactions(ctx context.Context) (int, error) {
 	if s.recurringTransactionRepo == nil || s.reminderRepo == nil || s.ledgerRepo == nil {
-		return ErrRecurringRepositoriesNotSet
+		return 0, ErrRecurringRepositoriesNotSet
 	}
 
 	now := time.Now()
@@ -88,131 +359,225 @@ func (s *Service) ProcessDueTransactions(ctx context.Context) error {
 	// Get all active and due transactions
 	dueTransactions, err := s.recurringTransactionRepo.GetActiveRecurringTransactionsDue(ctx, now)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	for _, transaction := range dueTransactions {
-		// Create ledger entry based on recurring transaction
-		ledgerReq := domain.CreateLedgerRequest{
-			AccountID: transaction.AccountID,
-			Date:      now,
-			Type:      transaction.Type,
-			Amount:    transaction.Amount,
-			Note:      transaction.Note + " (Recurring: " + transaction.Name + ")",
+	for i, transaction := range dueTransactions {
+		if err := ctx.Err(); err != nil {
+			// The transactions left are processed by the next run
+			return i, err
 		}
 
-		_, err := s.ledgerRepo.CreateLedger(ledgerReq)
+		s.processDueTransaction(ctx, transaction, now)
+	}
+
+	return len(dueTransactions), nil
+}
+
+// maxCatchUpOccurrences bounds the occurrences of a recurring transaction booked in one run under
+// the book_all policy. Longer backlogs are caught up over the following runs.
+const maxCatchUpOccurrences = 100
+
+// dueOccurrences lists the occurrences of a recurring transaction due by now to book in one run,
+// oldest first.
+func dueOccurrences(transaction *domain.RecurringTransaction, now time.Time) []time.Time {
+	due := []time.Time{transaction.NextDue}
+	for len(due) < maxCatchUpOccurrences {
+		next, ok := nextOccurrence(transaction, due[len(due)-1], false)
+		if !ok || next.After(now) {
+			break
+		}
+		due = append(due, next)
+	}
+
+	return due
+}
+
+func (s *Service) processDueTransaction(ctx context.Context, transaction *domain.RecurringTransaction, now time.Time) {
+	if transaction.CatchUpPolicy == domain.CatchUpPolicyBookAll {
+		s.bookOccurrences(ctx, transaction, dueOccurrences(transaction, now), now)
+		return
+	}
+
+	// The other policies apply to the whole missed range, which is skipped over in one step
+	missed, next, active := missedOccurrences(transaction, now)
+	if len(missed) == 1 {
+		s.bookOccurrences(ctx, transaction, missed, now)
+		return
+	}
+
+	latest := missed[len(missed)-1]
+	skipped := missed
+	req := domain.ExecuteRecurringOccurrenceRequest{
+		ID:           transaction.ID,
+		ScheduledFor: missed[0],
+		NextDue:      next,
+		Status:       domain.RecurrenceStatusActive,
+	}
+	switch {
+	case transaction.CatchUpPolicy == domain.CatchUpPolicyLatestOnly:
+		// Moved to the latest occurrence, which is booked next
+		skipped = missed[:len(missed)-1]
+		req.NextDue = latest
+	case !active:
+		req.NextDue = latest
+		req.Status = domain.RecurrenceStatusCompleted
+	}
+
+	executed, err := s.recurringTransactionRepo.ExecuteRecurringOccurrence(ctx, req)
+	if errors.Is(err, domain.ErrNotFound) {
+		slog.Info("recurring transaction occurrence already processed",
+			"transaction_id", transaction.ID,
+			"scheduled_for", req.ScheduledFor)
+		return
+	}
+	if err != nil {
+		slog.Error("failed to skip missed recurring transaction occurrences",
+			"transaction_id", transaction.ID,
+			"scheduled_for", req.ScheduledFor,
+			"error", err)
+		return
+	}
+
+	slog.Info("skipped missed recurring transaction occurrences",
+		"transaction_id", transaction.ID,
+		"policy", transaction.CatchUpPolicy,
+		"skipped", len(skipped))
+
+	if transaction.CatchUpPolicy == domain.CatchUpPolicySkip {
+		s.notifySkippedOccurrences(transaction, skipped)
+	}
+
+	switch {
+	case transaction.CatchUpPolicy == domain.CatchUpPolicyLatestOnly:
+		s.bookOccurrences(ctx, executed, []time.Time{latest}, now)
+	case active:
+		s.scheduleReminders(ctx, executed)
+	}
+}
+
+// bookOccurrences books due occurrences of a recurring transaction in order, moving its next due
+// date past each of them, then schedules the reminders of the next one.
+func (s *Service) bookOccurrences(ctx context.Context, transaction *domain.RecurringTransaction, due []time.Time, now time.Time) {
+	var (
+		executed *domain.RecurringTransaction
+		active   bool
+	)
+	for _, scheduledFor := range due {
+		var nextDue time.Time
+		nextDue, active = nextOccurrence(transaction, scheduledFor, false)
+
+		req := domain.ExecuteRecurringOccurrenceRequest{
+			ID:           transaction.ID,
+			ScheduledFor: scheduledFor,
+			NextDue:      nextDue,
+			Status:       domain.RecurrenceStatusActive,
+			LastExecuted: &now,
+			Ledger:       s.occurrenceLedger(transaction, scheduledFor),
+		}
+		if !active {
+			req.NextDue = scheduledFor
+			req.Status = domain.RecurrenceStatusCompleted
+		}
+
+		var err error
+		executed, err = s.recurringTransactionRepo.ExecuteRecurringOccurrence(ctx, req)
+		if errors.Is(err, domain.ErrNotFound) {
+			slog.Info("recurring transaction occurrence already processed",
+				"transaction_id", transaction.ID,
+				"scheduled_for", scheduledFor)
+			return
+		}
 		if err != nil {
-			slog.Error("failed to create ledger entry for recurring transaction",
+			slog.Error("failed to execute recurring transaction occurrence",
 				"transaction_id", transaction.ID,
+				"scheduled_for", scheduledFor,
 				"error", err)
-			continue
-		}
-
-		// Calculate next due date
-		nextDue := calculateNextDueDate(
-			transaction.NextDue,
-			transaction.RecurType,
-			transaction.Frequency,
-			transaction.DayOfWeek,
-			transaction.DayOfMonth,
-			transaction.MonthOfYear,
-		)
-
-		// Check if this was the last occurrence
-		if transaction.EndDate != nil && nextDue.After(*transaction.EndDate) {
-			// Mark as completed
-			completedStatus := domain.RecurrenceStatusCompleted
-			updateReq := domain.UpdateRecurringTransactionRequest{
-				ID:     transaction.ID,
-				Status: &completedStatus,
-			}
-			_, err := s.recurringTransactionRepo.UpdateRecurringTransaction(ctx, updateReq)
-			if err != nil {
-				slog.Error("failed to mark recurring transaction as completed",
-					"transaction_id", transaction.ID,
-					"error", err)
-			}
-		} else {
-			// Update last executed and next due date
-			_, err := s.recurringTransactionRepo.UpdateRecurringTransactionExecution(ctx, transaction.ID, now, nextDue)
-			if err != nil {
-				slog.Error("failed to update recurring transaction execution",
-					"transaction_id", transaction.ID,
-					"error", err)
-				continue
-			}
-
-			// Create next reminder
-			reminderDate := calculateReminderDate(nextDue)
-			_, err = s.reminderRepo.CreateReminder(ctx, transaction.ID, reminderDate)
-			if err != nil {
-				slog.Error("failed to create reminder for next execution",
-					"transaction_id", transaction.ID,
-					"error", err)
-			}
-		}
-	}
-
-	return nil
-}
-
-// GetUpcomingReminders gets upcoming reminders for a user within the next week
-func (s *Service) GetUpcomingReminders(ctx context.Context, userID int32) ([]*domain.Reminder, error) {
-
-	now := time.Now()
-	oneWeekLater := now.AddDate(0, 0, 7)
+			return
+		}
 
-	// Use repository that supports date range
-	if repo, ok := s.reminderRepo.(interface {
-		GetUpcomingReminders(ctx context.Context, userID int32, start, end time.Time) ([]*domain.Reminder, error)
-	}); ok {
-		return repo.GetUpcomingReminders(ctx, userID, now, oneWeekLater)
+		if !active {
+			break
+		}
 	}
 
-	// Fallback to standard method
-	return s.reminderRepo.GetActiveRemindersByUserID(ctx, userID, oneWeekLater)
+	if active {
+		s.scheduleReminders(ctx, executed)
+	}
 }
 
-// ErrRecurringRepositoriesNotSet is returned when trying to use recurring features without setting up repositories
-var ErrRecurringRepositoriesNotSet = errors.New("recurring repositories not set")
+// occurrenceLedger returns the ledger booked for an occurrence of a recurring transaction, dated
+// when it was scheduled, or nil when the occurrence falls in a closed period. The ledger is drafted
+// as pending when the transaction has to be confirmed.
+func (s *Service) occurrenceLedger(transaction *domain.RecurringTransaction, scheduledFor time.Time) *domain.CreateLedgerRequest {
+	ledgerReq := domain.CreateLedgerRequest{
+		AccountID: transaction.AccountID,
+		Date:      scheduledFor,
+		Type:      transaction.Type,
+		Amount:    transaction.Amount,
+		Note:      transaction.Note + " (Recurring: " + transaction.Name + ")",
+		IsPending: transaction.PostMode == domain.PostModeConfirm,
+
+		RecurringTransactionID: &transaction.ID,
+	}
 
-// Helper functions
-func calculateReminderDate(dueDate time.Time) time.Time {
-	// Default: remind 3 days before due date
-	return dueDate.AddDate(0, 0, -3)
-}
+	ledgerReq, err := s.applyPayeeRules(transaction.UserID, ledgerReq)
+	if err != nil {
+		slog.Error("failed to apply payee rules for recurring transaction",
+			"transaction_id", transaction.ID,
+			"error", err)
+	}
 
-func calculateNextDueDate(
-	lastDue time.Time,
-	recurType domain.RecurrenceType,
-	frequency int,
-	_ *int,
-	_ *int,
-	_ *int,
-) time.Time {
-	// Implementation in RecurringTransactionRepository
-	// This is a simplified version for future calculations
-	switch recurType {
-	case domain.RecurrenceTypeDaily:
-		return lastDue.AddDate(0, 0, frequency)
+	if err := s.checkBooksLock(ledgerReq.AccountID, &ledgerReq.Actor, ledgerReq.Date); err != nil {
+		slog.Error("skipped recurring transaction in closed period",
+			"transaction_id", transaction.ID,
+			"scheduled_for", scheduledFor,
+			"error", err)
+		return nil
+	}
 
-	case domain.RecurrenceTypeWeekly:
-		return lastDue.AddDate(0, 0, 7*frequency)
+	return &ledgerReq
+}
 
-	case domain.RecurrenceTypeBiweekly:
-		return lastDue.AddDate(0, 0, 14*frequency)
+// notifySkippedOccurrences tells the owner of a recurring transaction which missed occurrences
+// were not booked, so that they can book those that still apply.
+func (s *Service) notifySkippedOccurrences(transaction *domain.RecurringTransaction, skipped []time.Time) {
+	if s.notifier == nil || s.userRepo == nil {
+		return
+	}
 
-	case domain.RecurrenceTypeMonthly:
-		return lastDue.AddDate(0, frequency, 0)
+	identities, err := s.userRepo.GetIdentitiesByUserID(transaction.UserID)
+	if err != nil {
+		slog.Error("failed to get identities to notify skipped occurrences",
+			"transaction_id", transaction.ID,
+			"error", err)
+		return
+	}
 
-	case domain.RecurrenceTypeQuarterly:
-		return lastDue.AddDate(0, 3*frequency, 0)
+	index := slices.IndexFunc(identities, func(identity *domain.Identity) bool {
+		return identity.Provider == domain.IdentityProviderPassword
+	})
+	if index < 0 {
+		return
+	}
 
-	case domain.RecurrenceTypeYearly:
-		return lastDue.AddDate(frequency, 0, 0)
+	dates := make([]string, len(skipped))
+	for i, scheduledFor := range skipped {
+		dates[i] = scheduledFor.Format(time.DateOnly)
+	}
 
-	default:
-		return lastDue.AddDate(0, 1, 0)
+	if err := s.notifier.Notify(domain.Notification{
+		Recipient: identities[index].Identifier,
+		Subject:   fmt.Sprintf("Missed payments of %q were not booked", transaction.Name),
+		Body: fmt.Sprintf("Recurring transactions were not processed for a while, and %d payments of %q "+
+			"were skipped as configured:\n\n%s\n\nBook the payments that still apply manually.",
+			len(skipped), transaction.Name, strings.Join(dates, "\n")),
+	}); err != nil {
+		slog.Error("failed to notify skipped occurrences",
+			"transaction_id", transaction.ID,
+			"error", err)
 	}
 }
+
+// ErrRecurringRepositoriesNotSet is returned when trying to use recurring features without setting up repositories
+var ErrRecurringRepositoriesNotSet = errors.New("recurring repositories not set")
@@ -0,0 +1,161 @@
+package bookkeeping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// ErrInvalidReminderOffsets is returned when reminder offsets are out of range or repeated
+var ErrInvalidReminderOffsets = errors.New("invalid reminder offsets")
+
+// ErrReminderAlreadyRead is returned when snoozing a reminder that was already read
+var ErrReminderAlreadyRead = errors.New("reminder already read")
+
+// ErrInvalidSnooze is returned when a reminder is snoozed to a time that already passed
+var ErrInvalidSnooze = errors.New("a reminder can only be snoozed to a later time")
+
+const (
+	// maxReminderOffsets bounds the number of reminders created for an occurrence.
+	maxReminderOffsets = 5
+	// maxReminderOffsetDays bounds how many days before a due date a reminder is created.
+	maxReminderOffsetDays = 90
+)
+
+// normalizeReminderOffsets validates reminder offsets and orders them from the earliest reminder
+// to the latest. A nil slice stays nil.
+func normalizeReminderOffsets(offsets []int) ([]int, error) {
+	if offsets == nil {
+		return nil, nil
+	}
+
+	if len(offsets) > maxReminderOffsets {
+		return nil, fmt.Errorf("%w: at most %d reminders are allowed", ErrInvalidReminderOffsets, maxReminderOffsets)
+	}
+
+	normalized := slices.Clone(offsets)
+	slices.SortFunc(normalized, func(a, b int) int { return b - a })
+	for i, offset := range normalized {
+		if offset < 0 || offset > maxReminderOffsetDays {
+			return nil, fmt.Errorf("%w: days before must be between 0 and %d", ErrInvalidReminderOffsets, maxReminderOffsetDays)
+		}
+		if i > 0 && offset == normalized[i-1] {
+			return nil, fmt.Errorf("%w: %d days before is repeated", ErrInvalidReminderOffsets, offset)
+		}
+	}
+
+	return normalized, nil
+}
+
+// reminderOffsets returns the days before a due date the reminders of a recurring transaction are
+// created, falling back to the defaults of its owner.
+func (s *Service) reminderOffsets(ctx context.Context, transaction *domain.RecurringTransaction) []int {
+	if transaction.ReminderOffsets != nil {
+		return transaction.ReminderOffsets
+	}
+
+	settings, err := s.GetReminderSettings(ctx, transaction.UserID)
+	if err != nil {
+		slog.Warn("failed to get reminder settings, using the defaults",
+			"user_id", transaction.UserID,
+			"error", err)
+		return domain.DefaultReminderOffsets
+	}
+
+	return settings.DefaultOffsets
+}
+
+// scheduleReminders replaces the upcoming reminders of a recurring transaction with the ones of its
+// next due date. Reminders whose lead time already passed are due right away.
+func (s *Service) scheduleReminders(ctx context.Context, transaction *domain.RecurringTransaction) {
+	now := time.Now()
+	if err := s.reminderRepo.DeleteUpcomingReminders(ctx, transaction.ID, now); err != nil {
+		slog.Error("failed to delete upcoming reminders",
+			"transaction_id", transaction.ID,
+			"error", err)
+		return
+	}
+
+	var previous time.Time
+	for _, offset := range s.reminderOffsets(ctx, transaction) {
+		reminderDate := transaction.NextDue.AddDate(0, 0, -offset)
+		if reminderDate.Before(now) {
+			reminderDate = now
+		}
+
+		// Offsets are ordered, so reminders due right away follow each other
+		if reminderDate.Equal(previous) {
+			continue
+		}
+		previous = reminderDate
+
+		if _, err := s.reminderRepo.CreateReminder(ctx, transaction.ID, reminderDate); err != nil {
+			slog.Error("failed to create reminder for recurring transaction",
+				"transaction_id", transaction.ID,
+				"reminder_date", reminderDate,
+				"error", err)
+		}
+	}
+}
+
+// GetReminderSettings gets the reminder preferences of a user, the defaults when they set none.
+func (s *Service) GetReminderSettings(ctx context.Context, userID int32) (*domain.ReminderSettings, error) {
+	settings, err := s.reminderRepo.GetReminderSettings(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return &domain.ReminderSettings{
+			UserID:         userID,
+			DefaultOffsets: slices.Clone(domain.DefaultReminderOffsets),
+		}, nil
+	}
+	return settings, err
+}
+
+// UpdateReminderSettings sets the reminder preferences of a user. Empty default offsets turn off
+// the reminders of recurring transactions without their own offsets.
+func (s *Service) UpdateReminderSettings(ctx context.Context, settings domain.ReminderSettings) (*domain.ReminderSettings, error) {
+	offsets, err := normalizeReminderOffsets(settings.DefaultOffsets)
+	if err != nil {
+		return nil, err
+	}
+
+	if offsets == nil {
+		offsets = []int{}
+	}
+	settings.DefaultOffsets = offsets
+
+	return s.reminderRepo.UpdateReminderSettings(ctx, settings)
+}
+
+// SnoozeReminder moves an unread reminder to a later time
+func (s *Service) SnoozeReminder(ctx context.Context, id int32, until time.Time) (*domain.Reminder, error) {
+	if !until.After(time.Now()) {
+		return nil, ErrInvalidSnooze
+	}
+
+	reminder, err := s.reminderRepo.GetReminderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if reminder.IsRead {
+		return nil, ErrReminderAlreadyRead
+	}
+
+	reminder, err = s.reminderRepo.SnoozeReminder(ctx, id, until)
+	if errors.Is(err, domain.ErrNotFound) {
+		// Read since it was fetched
+		return nil, ErrReminderAlreadyRead
+	}
+	return reminder, err
+}
+
+// GetUpcomingReminders gets the unread reminders of a user due within the next week
+func (s *Service) GetUpcomingReminders(ctx context.Context, userID int32) ([]*domain.Reminder, error) {
+	now := time.Now()
+	return s.reminderRepo.GetUpcomingReminders(ctx, userID, now, now.AddDate(0, 0, 7))
+}
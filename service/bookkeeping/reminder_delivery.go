@@ -0,0 +1,349 @@
+package bookkeeping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// ErrInvalidNotificationTarget is returned when a target cannot be reached through its channel
+var ErrInvalidNotificationTarget = errors.New("invalid notification target")
+
+// ErrNotificationRepositoryNotSet is returned when using notification channels without their repository
+var ErrNotificationRepositoryNotSet = errors.New("notification repository not set")
+
+// ErrInvalidConfirmationToken is returned when a notification target confirmation token is unknown or expired
+var ErrInvalidConfirmationToken = errors.New("invalid or expired confirmation token")
+
+const (
+	// reminderDeliveryWindow bounds how long ago a reminder came due to still be delivered, so that
+	// enabling a channel does not flood it with old reminders.
+	reminderDeliveryWindow = 24 * time.Hour
+	// reminderDeliveryLease is how long a claimed delivery is held before another run retries it.
+	reminderDeliveryLease = 15 * time.Minute
+	// reminderDeliveryBatchSize bounds the deliveries sent by a run.
+	reminderDeliveryBatchSize = 50
+	// maxDeliveryBackoff bounds the wait between two attempts of a delivery.
+	maxDeliveryBackoff = 6 * time.Hour
+)
+
+// DeliveryRetry defines how failed reminder deliveries are retried
+type DeliveryRetry struct {
+	MaxAttempts int           // Attempts before a delivery is given up
+	Backoff     time.Duration // Wait before the first retry, doubled after every failed attempt
+}
+
+func (r DeliveryRetry) withDefaults() DeliveryRetry {
+	if r.MaxAttempts <= 0 {
+		r.MaxAttempts = 5
+	}
+	if r.Backoff <= 0 {
+		r.Backoff = time.Minute
+	}
+	return r
+}
+
+// backoff returns the wait before retrying a delivery that failed its attempt-th attempt
+func (r DeliveryRetry) backoff(attempt int) time.Duration {
+	wait := r.Backoff
+	for i := 1; i < attempt && wait < maxDeliveryBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxDeliveryBackoff)
+}
+
+// validateNotificationTarget checks that a target is an address of its channel
+func validateNotificationTarget(channel domain.NotificationChannel, target string) error {
+	switch channel {
+	case domain.NotificationChannelEmail:
+		address, err := mail.ParseAddress(target)
+		if err != nil || address.Address != target {
+			return fmt.Errorf("%w: %q is not an email address", ErrInvalidNotificationTarget, target)
+		}
+	case domain.NotificationChannelWebhook:
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: %q is not an http or https URL", ErrInvalidNotificationTarget, target)
+		}
+	case domain.NotificationChannelChat:
+		if target == "" || strings.ContainsAny(target, " \t\r\n") {
+			return fmt.Errorf("%w: %q is not a chat ID", ErrInvalidNotificationTarget, target)
+		}
+	default:
+		return fmt.Errorf("%w: unknown channel %q", ErrInvalidNotificationTarget, channel)
+	}
+
+	return nil
+}
+
+// GetNotificationPreferences gets the channels a user wants their reminders delivered through
+func (s *Service) GetNotificationPreferences(ctx context.Context, userID int32) ([]*domain.NotificationPreference, error) {
+	if s.notificationRepo == nil {
+		return nil, ErrNotificationRepositoryNotSet
+	}
+
+	return s.notificationRepo.GetNotificationPreferences(ctx, userID)
+}
+
+// UpdateNotificationPreference sets the target of a notification channel of a user. A new email
+// target receives reminders once confirmed through the link sent to it, unless it is a verified
+// email address of the user.
+func (s *Service) UpdateNotificationPreference(ctx context.Context, preference domain.NotificationPreference) (*domain.NotificationPreference, error) {
+	if s.notificationRepo == nil {
+		return nil, ErrNotificationRepositoryNotSet
+	}
+
+	preference.Target = strings.TrimSpace(preference.Target)
+	if err := validateNotificationTarget(preference.Channel, preference.Target); err != nil {
+		return nil, err
+	}
+
+	confirmedAt, err := s.notificationTargetConfirmedAt(ctx, preference)
+	if err != nil {
+		return nil, err
+	}
+
+	var token string
+	preference.ConfirmedAt = confirmedAt
+	if confirmedAt == nil {
+		var tokenHash string
+		token, tokenHash, err = newSecretToken()
+		if err != nil {
+			return nil, err
+		}
+
+		expiresAt := time.Now().Add(domain.NotificationConfirmationTTL)
+		preference.ConfirmationTokenHash = tokenHash
+		preference.ConfirmationExpiresAt = &expiresAt
+	}
+
+	updated, err := s.notificationRepo.UpsertNotificationPreference(ctx, preference)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		// The target stays unconfirmed if the email fails, setting it again sends a new link
+		if err := s.notifyNotificationConfirmation(updated, token); err != nil {
+			slog.Error("failed to send notification target confirmation",
+				"user_id", updated.UserID,
+				"channel", updated.Channel,
+				"error", err)
+		}
+	}
+
+	return updated, nil
+}
+
+// notificationTargetConfirmedAt returns when the target of a preference was confirmed, or nil if
+// an email target has yet to be confirmed by its owner
+func (s *Service) notificationTargetConfirmedAt(ctx context.Context, preference domain.NotificationPreference) (*time.Time, error) {
+	now := time.Now()
+	if preference.Channel != domain.NotificationChannelEmail {
+		return &now, nil
+	}
+
+	preferences, err := s.notificationRepo.GetNotificationPreferences(ctx, preference.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range preferences {
+		if existing.Channel == preference.Channel && existing.Target == preference.Target && existing.ConfirmedAt != nil {
+			return existing.ConfirmedAt, nil
+		}
+	}
+
+	if s.userRepo == nil {
+		return nil, nil
+	}
+
+	identities, err := s.userRepo.GetIdentitiesByUserID(preference.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identities: %w", err)
+	}
+	for _, identity := range identities {
+		if identity.VerifiedAt != nil && strings.EqualFold(identity.Identifier, preference.Target) {
+			return &now, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// notifyNotificationConfirmation emails the link confirming an email target to it
+func (s *Service) notifyNotificationConfirmation(preference *domain.NotificationPreference, token string) error {
+	if s.notifier == nil {
+		return nil
+	}
+
+	return s.notifier.Notify(domain.Notification{
+		Recipient: preference.Target,
+		Subject:   "Confirm your Bookly reminder address",
+		Body: fmt.Sprintf("Open the link below within %s to receive your Bookly reminders at this address:\n"+
+			"%s/page/confirm-notification-email?token=%s\n\n"+
+			"If you did not ask for Bookly reminders, you can ignore this message.",
+			domain.NotificationConfirmationTTL, s.webURL, token),
+	})
+}
+
+// ConfirmNotificationTarget confirms an email target using the token sent to it
+func (s *Service) ConfirmNotificationTarget(ctx context.Context, token string) (*domain.NotificationPreference, error) {
+	if s.notificationRepo == nil {
+		return nil, ErrNotificationRepositoryNotSet
+	}
+
+	preference, err := s.notificationRepo.ConfirmNotificationPreference(ctx, hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidConfirmationToken
+		}
+		return nil, err
+	}
+
+	return preference, nil
+}
+
+// DeleteNotificationPreference stops delivering the reminders of a user through a channel
+func (s *Service) DeleteNotificationPreference(ctx context.Context, userID int32, channel domain.NotificationChannel) error {
+	if s.notificationRepo == nil {
+		return ErrNotificationRepositoryNotSet
+	}
+
+	return s.notificationRepo.DeleteNotificationPreference(ctx, userID, channel)
+}
+
+// GetReminderDeliveries gets the deliveries of a reminder through the channels of its user
+func (s *Service) GetReminderDeliveries(ctx context.Context, reminderID int32) ([]*domain.ReminderDelivery, error) {
+	if s.notificationRepo == nil {
+		return nil, ErrNotificationRepositoryNotSet
+	}
+
+	return s.notificationRepo.GetReminderDeliveries(ctx, reminderID)
+}
+
+// DispatchDueReminders delivers the reminders that came due through the channels of their users.
+// Failed deliveries are retried with an exponential backoff until they run out of attempts. It
+// returns the number of delivery attempts.
+func (s *Service) DispatchDueReminders(ctx context.Context) (int, error) {
+	if s.notificationRepo == nil || s.reminderRepo == nil || s.recurringTransactionRepo == nil {
+		return 0, ErrNotificationRepositoryNotSet
+	}
+
+	now := time.Now()
+	queued, err := s.notificationRepo.QueueReminderDeliveries(ctx, now.Add(-reminderDeliveryWindow), now)
+	if err != nil {
+		return 0, err
+	}
+	if len(queued) > 0 {
+		slog.Info("queued reminder deliveries", "count", len(queued))
+	}
+
+	deliveries, err := s.notificationRepo.ClaimReminderDeliveries(ctx, now, now.Add(reminderDeliveryLease), reminderDeliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, delivery := range deliveries {
+		if err := ctx.Err(); err != nil {
+			// Claimed deliveries left are retried once their lease expires
+			return i, err
+		}
+
+		if err := s.notificationRepo.UpdateReminderDelivery(ctx, s.deliverReminder(ctx, delivery)); err != nil {
+			slog.Error("failed to record reminder delivery",
+				"delivery_id", delivery.ID,
+				"error", err)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// deliverReminder attempts a claimed delivery and returns its outcome
+func (s *Service) deliverReminder(ctx context.Context, delivery *domain.ReminderDelivery) domain.UpdateReminderDeliveryRequest {
+	now := time.Now()
+	outcome := domain.UpdateReminderDeliveryRequest{
+		ID:            delivery.ID,
+		NextAttemptAt: now,
+	}
+
+	notification, err := s.reminderNotification(ctx, delivery)
+	if err != nil {
+		outcome.Status = domain.ReminderDeliveryStatusCancelled
+		outcome.LastError = err.Error()
+		return outcome
+	}
+
+	notifier, ok := s.channelNotifiers[delivery.Channel]
+	if !ok {
+		outcome.Status = domain.ReminderDeliveryStatusFailed
+		outcome.LastError = fmt.Sprintf("channel %s is not available", delivery.Channel)
+		return outcome
+	}
+
+	if err := notifier.Notify(notification); err != nil {
+		slog.Warn("failed to deliver reminder",
+			"delivery_id", delivery.ID,
+			"channel", delivery.Channel,
+			"attempts", delivery.Attempts,
+			"error", err)
+
+		outcome.LastError = err.Error()
+		if delivery.Attempts >= s.deliveryRetry.MaxAttempts {
+			outcome.Status = domain.ReminderDeliveryStatusFailed
+		} else {
+			outcome.Status = domain.ReminderDeliveryStatusPending
+			outcome.NextAttemptAt = now.Add(s.deliveryRetry.backoff(delivery.Attempts))
+		}
+		return outcome
+	}
+
+	outcome.Status = domain.ReminderDeliveryStatusSent
+	outcome.DeliveredAt = &now
+	return outcome
+}
+
+// reminderNotification builds the notification of a delivery, or fails when its reminder no
+// longer has to be delivered
+func (s *Service) reminderNotification(ctx context.Context, delivery *domain.ReminderDelivery) (domain.Notification, error) {
+	reminder, err := s.reminderRepo.GetReminderByID(ctx, delivery.ReminderID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.Notification{}, errors.New("reminder was deleted")
+	}
+	if err != nil {
+		return domain.Notification{}, err
+	}
+
+	if reminder.IsRead {
+		return domain.Notification{}, errors.New("reminder was read")
+	}
+	if !reminder.ReminderDate.Equal(delivery.ReminderDate) {
+		return domain.Notification{}, errors.New("reminder was snoozed")
+	}
+
+	transaction, err := s.recurringTransactionRepo.GetRecurringTransactionByID(ctx, reminder.RecurringTransactionID)
+	if err != nil {
+		return domain.Notification{}, fmt.Errorf("failed to get recurring transaction: %w", err)
+	}
+
+	notification := domain.Notification{
+		Recipient: delivery.Target,
+		Subject:   fmt.Sprintf("%q is due on %s", transaction.Name, transaction.NextDue.Format(time.DateOnly)),
+		Body: fmt.Sprintf("The %s of %s for %q is due on %s.",
+			transaction.Type, transaction.Amount.Abs().String(), transaction.Name, transaction.NextDue.Format(time.DateOnly)),
+	}
+	if reminder.LedgerID != nil {
+		notification.Subject = fmt.Sprintf("Confirm %q", transaction.Name)
+		notification.Body = fmt.Sprintf("The %s of %s for %q was drafted as pending. Confirm or skip it from your reminders.",
+			transaction.Type, transaction.Amount.Abs().String(), transaction.Name)
+	}
+
+	return notification, nil
+}
@@ -0,0 +1,195 @@
+package bookkeeping
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/rrule"
+)
+
+// ErrInvalidRecurrenceRule is returned when the schedule of a recurring transaction cannot be used
+var ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+
+// maxPreviewOccurrences bounds the number of due dates returned by an occurrence preview.
+const maxPreviewOccurrences = 100
+
+var weekdayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// recurrenceRule returns the rule generating the due dates of a recurring transaction. Every
+// recurrence type is expressed as an RFC 5545 rule anchored on the start date, so that due dates
+// never drift from the schedule however late they are processed.
+func recurrenceRule(transaction *domain.RecurringTransaction) (*rrule.Rule, error) {
+	if transaction.RecurType == domain.RecurrenceTypeCustom && transaction.RRule != "" {
+		return rrule.Parse(transaction.RRule)
+	}
+
+	if transaction.RRule != "" {
+		return nil, errors.New("only custom recurrences take a rule")
+	}
+
+	interval := max(transaction.Frequency, 1)
+
+	dayOfMonth := transaction.StartDate.Day()
+	if transaction.DayOfMonth != nil {
+		dayOfMonth = *transaction.DayOfMonth
+	}
+
+	monthOfYear := int(transaction.StartDate.Month())
+	if transaction.MonthOfYear != nil {
+		monthOfYear = *transaction.MonthOfYear
+	}
+
+	var rule string
+	switch transaction.RecurType {
+	case domain.RecurrenceTypeDaily, domain.RecurrenceTypeCustom:
+		// Custom recurrences without a rule repeat every N days
+		rule = fmt.Sprintf("FREQ=DAILY;INTERVAL=%d", interval)
+	case domain.RecurrenceTypeWeekly:
+		rule = fmt.Sprintf("FREQ=WEEKLY;INTERVAL=%d", interval) + byWeekday(transaction.DayOfWeek)
+	case domain.RecurrenceTypeBiweekly:
+		rule = fmt.Sprintf("FREQ=WEEKLY;INTERVAL=%d", 2*interval) + byWeekday(transaction.DayOfWeek)
+	case domain.RecurrenceTypeQuarterly:
+		rule = fmt.Sprintf("FREQ=MONTHLY;INTERVAL=%d", 3*interval) + byMonthDay(dayOfMonth)
+	case domain.RecurrenceTypeYearly:
+		rule = fmt.Sprintf("FREQ=YEARLY;INTERVAL=%d;BYMONTH=%d", interval, monthOfYear) + byMonthDay(dayOfMonth)
+	default:
+		rule = fmt.Sprintf("FREQ=MONTHLY;INTERVAL=%d", interval) + byMonthDay(dayOfMonth)
+	}
+
+	return rrule.Parse(rule)
+}
+
+func byWeekday(dayOfWeek *int) string {
+	if dayOfWeek == nil || *dayOfWeek < 0 || *dayOfWeek >= len(weekdayCodes) {
+		return ""
+	}
+
+	return ";BYDAY=" + weekdayCodes[*dayOfWeek]
+}
+
+// byMonthDay selects a day of the month. Days past the end of a short month fall on its last
+// day, so that a schedule on the 31st still happens in February.
+func byMonthDay(day int) string {
+	if day <= 28 {
+		return fmt.Sprintf(";BYMONTHDAY=%d", max(day, 1))
+	}
+
+	days := make([]string, 0, day-27)
+	for d := 28; d <= min(day, 31); d++ {
+		days = append(days, fmt.Sprint(d))
+	}
+
+	return ";BYMONTHDAY=" + strings.Join(days, ",") + ";BYSETPOS=-1"
+}
+
+// nextOccurrence returns the first due date of a recurring transaction after t, or at t when
+// inclusive. It reports false when the schedule has ended.
+func nextOccurrence(transaction *domain.RecurringTransaction, t time.Time, inclusive bool) (time.Time, bool) {
+	rule, err := recurrenceRule(transaction)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	next, ok := rule.After(transaction.StartDate, t, inclusive)
+	if !ok || (transaction.EndDate != nil && next.After(*transaction.EndDate)) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
+
+// missedOccurrences returns the due dates of a recurring transaction from its next due date up to
+// now, both inclusive, walking the schedule once however long the range is, and the first due
+// date after now. It reports false when the schedule ends by now.
+func missedOccurrences(transaction *domain.RecurringTransaction, now time.Time) ([]time.Time, time.Time, bool) {
+	missed := []time.Time{transaction.NextDue}
+
+	rule, err := recurrenceRule(transaction)
+	if err != nil {
+		return missed, time.Time{}, false
+	}
+
+	for occurrence := range rule.Occurrences(transaction.StartDate) {
+		if transaction.EndDate != nil && occurrence.After(*transaction.EndDate) {
+			break
+		}
+
+		if !occurrence.After(transaction.NextDue) {
+			continue
+		}
+
+		if occurrence.After(now) {
+			return missed, occurrence, true
+		}
+
+		missed = append(missed, occurrence)
+	}
+
+	return missed, time.Time{}, false
+}
+
+// firstDueDate validates the schedule of a recurring transaction and returns its first due date
+// from now on.
+func firstDueDate(transaction *domain.RecurringTransaction) (time.Time, error) {
+	if _, err := recurrenceRule(transaction); err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidRecurrenceRule, err)
+	}
+
+	next, ok := nextOccurrence(transaction, time.Now(), true)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: the schedule has no upcoming occurrence", ErrInvalidRecurrenceRule)
+	}
+
+	return next, nil
+}
+
+// rescheduledDueDate validates the updated schedule of a recurring transaction and returns its
+// next due date, which moves on from the current one rather than from now: occurrences before it
+// were processed, and a missed or postponed one is still pending. The current next due date is
+// kept while the rule itself is unchanged.
+func rescheduledDueDate(transaction, schedule *domain.RecurringTransaction) (time.Time, error) {
+	rule, err := recurrenceRule(schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", ErrInvalidRecurrenceRule, err)
+	}
+
+	next, ok := transaction.NextDue, true
+	if current, err := recurrenceRule(transaction); err != nil || current.String() != rule.String() {
+		next, ok = nextOccurrence(schedule, transaction.NextDue, true)
+	}
+	if !ok || (schedule.EndDate != nil && next.After(*schedule.EndDate)) {
+		return time.Time{}, fmt.Errorf("%w: the schedule has no upcoming occurrence", ErrInvalidRecurrenceRule)
+	}
+
+	return next, nil
+}
+
+// PreviewOccurrences returns the due dates of a recurring transaction from one time up to another,
+// both inclusive.
+func (s *Service) PreviewOccurrences(transaction *domain.RecurringTransaction, from, to time.Time) ([]time.Time, error) {
+	rule, err := recurrenceRule(transaction)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRecurrenceRule, err)
+	}
+
+	occurrences := []time.Time{}
+	for occurrence := range rule.Occurrences(transaction.StartDate) {
+		if occurrence.After(to) || (transaction.EndDate != nil && occurrence.After(*transaction.EndDate)) {
+			break
+		}
+
+		if occurrence.Before(from) {
+			continue
+		}
+
+		occurrences = append(occurrences, occurrence)
+		if len(occurrences) == maxPreviewOccurrences {
+			break
+		}
+	}
+
+	return occurrences, nil
+}
@@ -11,6 +11,19 @@ type Service struct {
 	recurringTransactionRepo domain.RecurringTransactionRepository
 	reminderRepo             domain.ReminderRepository
 	bankAccountRepo          domain.BankAccountRepository
+	payeeRepo                domain.PayeeRepository
+	accountMemberRepo        domain.AccountMemberRepository
+	workspaceRepo            domain.WorkspaceRepository
+	auditLogRepo             domain.AuditLogRepository
+	booksLockRepo            domain.BooksLockRepository
+	userRepo                 domain.UserRepository
+	notifier                 domain.Notifier
+	notificationRepo         domain.NotificationRepository
+	channelNotifiers         map[domain.NotificationChannel]domain.Notifier
+	deliveryRetry            DeliveryRetry
+	digestRepo               domain.DigestRepository
+	mailer                   domain.Mailer
+	webURL                   string
 }
 
 // NewServiceRequest represents the request to create a new bookkeeping service
@@ -20,6 +33,19 @@ type NewServiceRequest struct {
 	RecurringTransactionRepo domain.RecurringTransactionRepository
 	ReminderRepo             domain.ReminderRepository
 	BankAccountRepo          domain.BankAccountRepository
+	PayeeRepo                domain.PayeeRepository
+	AccountMemberRepo        domain.AccountMemberRepository
+	WorkspaceRepo            domain.WorkspaceRepository
+	AuditLogRepo             domain.AuditLogRepository
+	BooksLockRepo            domain.BooksLockRepository
+	UserRepo                 domain.UserRepository
+	Notifier                 domain.Notifier // Tells users about recurring transactions needing attention
+	NotificationRepo         domain.NotificationRepository
+	ChannelNotifiers         map[domain.NotificationChannel]domain.Notifier // Deliver reminders, by channel
+	DeliveryRetry            DeliveryRetry                                  // Defaults apply to zero fields
+	DigestRepo               domain.DigestRepository
+	Mailer                   domain.Mailer // Sends digest emails
+	WebURL                   string        // Base URL of the web app, used in links sent to users
 }
 
 // NewService creates a new bookkeeping service
@@ -30,5 +56,18 @@ func NewService(req NewServiceRequest) *Service {
 		recurringTransactionRepo: req.RecurringTransactionRepo,
 		reminderRepo:             req.ReminderRepo,
 		bankAccountRepo:          req.BankAccountRepo,
+		payeeRepo:                req.PayeeRepo,
+		accountMemberRepo:        req.AccountMemberRepo,
+		workspaceRepo:            req.WorkspaceRepo,
+		auditLogRepo:             req.AuditLogRepo,
+		booksLockRepo:            req.BooksLockRepo,
+		userRepo:                 req.UserRepo,
+		notifier:                 req.Notifier,
+		notificationRepo:         req.NotificationRepo,
+		channelNotifiers:         req.ChannelNotifiers,
+		deliveryRetry:            req.DeliveryRetry.withDefaults(),
+		digestRepo:               req.DigestRepo,
+		mailer:                   req.Mailer,
+		webURL:                   req.WebURL,
 	}
 }
@@ -0,0 +1,149 @@
+package bookkeeping
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// ErrInvalidWorkspace is returned when a workspace or membership change is not allowed
+var ErrInvalidWorkspace = errors.New("invalid workspace")
+
+// CreateWorkspace creates a workspace owned by the requesting user.
+func (s *Service) CreateWorkspace(req domain.CreateWorkspaceRequest) (*domain.Workspace, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidWorkspace)
+	}
+
+	if !req.Type.IsValid() {
+		return nil, fmt.Errorf("%w: invalid workspace type %q", ErrInvalidWorkspace, req.Type)
+	}
+
+	return s.workspaceRepo.CreateWorkspace(req)
+}
+
+// GetWorkspaceAccess retrieves a workspace together with the role the user holds in it.
+// An empty role means the user is not a member.
+func (s *Service) GetWorkspaceAccess(workspaceID, userID int32) (*domain.Workspace, domain.WorkspaceRole, error) {
+	workspace, err := s.workspaceRepo.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	member, err := s.workspaceRepo.GetWorkspaceMember(workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return workspace, "", nil
+		}
+		return nil, "", err
+	}
+
+	return workspace, member.Role, nil
+}
+
+// GetWorkspacesByUserID retrieves the workspaces the user is a member of.
+func (s *Service) GetWorkspacesByUserID(userID int32) ([]*domain.MemberWorkspace, error) {
+	return s.workspaceRepo.GetWorkspacesByUserID(userID)
+}
+
+// UpdateWorkspace updates the name or type of a workspace.
+func (s *Service) UpdateWorkspace(req domain.UpdateWorkspaceRequest) (*domain.Workspace, error) {
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidWorkspace)
+	}
+
+	if req.Type != nil && !req.Type.IsValid() {
+		return nil, fmt.Errorf("%w: invalid workspace type %q", ErrInvalidWorkspace, *req.Type)
+	}
+
+	return s.workspaceRepo.UpdateWorkspace(req)
+}
+
+// DeleteWorkspace deletes a workspace. Accounts it owns are kept but become unreachable
+// through the workspace.
+func (s *Service) DeleteWorkspace(id int32) error {
+	return s.workspaceRepo.DeleteWorkspace(id)
+}
+
+// GetWorkspaceMembers retrieves all members of a workspace, including its owners.
+func (s *Service) GetWorkspaceMembers(workspaceID int32) ([]*domain.WorkspaceMember, error) {
+	return s.workspaceRepo.GetWorkspaceMembersByWorkspaceID(workspaceID)
+}
+
+// AddWorkspaceMember adds the user registered with the email to a workspace.
+func (s *Service) AddWorkspaceMember(workspaceID int32, email string, role domain.WorkspaceRole) (*domain.WorkspaceMember, error) {
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: invalid workspace role %q", ErrInvalidWorkspace, role)
+	}
+
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, fmt.Errorf("%w: email is required", ErrInvalidWorkspace)
+	}
+
+	member, err := s.workspaceRepo.AddWorkspaceMemberByEmail(workspaceID, email, role)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: no user registered with email %s", ErrInvalidWorkspace, email)
+		}
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// UpdateWorkspaceMemberRole changes the role of a workspace member.
+// A workspace always keeps at least one owner.
+func (s *Service) UpdateWorkspaceMemberRole(workspaceID, userID int32, role domain.WorkspaceRole) error {
+	if !role.IsValid() {
+		return fmt.Errorf("%w: invalid workspace role %q", ErrInvalidWorkspace, role)
+	}
+
+	member, err := s.workspaceRepo.GetWorkspaceMember(workspaceID, userID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == domain.WorkspaceRoleOwner && role != domain.WorkspaceRoleOwner {
+		if err := s.ensureAnotherWorkspaceOwner(workspaceID, userID); err != nil {
+			return err
+		}
+	}
+
+	return s.workspaceRepo.UpsertWorkspaceMember(workspaceID, userID, role)
+}
+
+// RemoveWorkspaceMember removes a user from a workspace.
+// The last owner cannot leave; the workspace must be deleted instead.
+func (s *Service) RemoveWorkspaceMember(workspaceID, userID int32) error {
+	member, err := s.workspaceRepo.GetWorkspaceMember(workspaceID, userID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == domain.WorkspaceRoleOwner {
+		if err := s.ensureAnotherWorkspaceOwner(workspaceID, userID); err != nil {
+			return err
+		}
+	}
+
+	return s.workspaceRepo.DeleteWorkspaceMember(workspaceID, userID)
+}
+
+func (s *Service) ensureAnotherWorkspaceOwner(workspaceID, userID int32) error {
+	members, err := s.workspaceRepo.GetWorkspaceMembersByWorkspaceID(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if member.UserID != userID && member.Role == domain.WorkspaceRoleOwner {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: workspace must keep at least one owner", ErrInvalidWorkspace)
+}
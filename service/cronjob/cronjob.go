@@ -0,0 +1,66 @@
+// Package cronjob runs the scheduled jobs of crond and records their runs.
+package cronjob
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// ErrJobAlreadyRegistered is returned when two jobs are registered with the same name
+var ErrJobAlreadyRegistered = errors.New("job already registered")
+
+// ErrUnknownJob is returned when running a job that was not registered
+var ErrUnknownJob = errors.New("unknown job")
+
+// Config defines when a job runs.
+type Config struct {
+	Schedule string        // Cron expression with five fields, in the local time of crond
+	Timeout  time.Duration // Cancels the context of a run taking longer
+	Enabled  bool          // Disabled jobs only run when triggered manually
+}
+
+// CliFlags returns the cli flags configuring the job named name, defaulting to the current
+// configuration.
+func (c *Config) CliFlags(name string) []cli.Flag {
+	env := envName(name)
+
+	var flags []cli.Flag
+	flags = append(flags, &cli.StringFlag{
+		Name:        "job-" + name + "-schedule",
+		Usage:       "cron expression of the " + name + " job",
+		EnvVars:     []string{"JOB_" + env + "_SCHEDULE"},
+		Value:       c.Schedule,
+		Destination: &c.Schedule,
+	})
+	flags = append(flags, &cli.DurationFlag{
+		Name:        "job-" + name + "-timeout",
+		EnvVars:     []string{"JOB_" + env + "_TIMEOUT"},
+		Value:       c.Timeout,
+		Destination: &c.Timeout,
+	})
+	flags = append(flags, &cli.BoolFlag{
+		Name:        "job-" + name + "-enabled",
+		EnvVars:     []string{"JOB_" + env + "_ENABLED"},
+		Value:       c.Enabled,
+		Destination: &c.Enabled,
+	})
+
+	return flags
+}
+
+// envName turns a job name like "process-recurring" into "PROCESS_RECURRING".
+func envName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// Job defines a job run by crond.
+type Job struct {
+	Name   string
+	Config Config
+	// Run does the work of the job and returns how many items it processed
+	Run func(ctx context.Context) (int, error)
+}
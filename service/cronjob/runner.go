@@ -0,0 +1,217 @@
+package cronjob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// triggerPollInterval is how often crond looks for jobs triggered manually.
+const triggerPollInterval = 15 * time.Second
+
+type registeredJob struct {
+	Job
+
+	// mu keeps scheduled and manual runs of the job from overlapping
+	mu sync.Mutex
+}
+
+// Runner schedules registered jobs and records every run of them.
+type Runner struct {
+	repo domain.CronJobRepository
+
+	jobs      []*registeredJob
+	scheduler gocron.Scheduler
+}
+
+// NewRunner creates a new runner.
+func NewRunner(repo domain.CronJobRepository) *Runner {
+	return &Runner{
+		repo: repo,
+	}
+}
+
+// Register adds a job to the runner.
+func (r *Runner) Register(job Job) error {
+	if r.job(job.Name) != nil {
+		return fmt.Errorf("%w: %s", ErrJobAlreadyRegistered, job.Name)
+	}
+
+	r.jobs = append(r.jobs, &registeredJob{Job: job})
+	return nil
+}
+
+func (r *Runner) job(name string) *registeredJob {
+	for _, job := range r.jobs {
+		if job.Name == name {
+			return job
+		}
+	}
+	return nil
+}
+
+// Start records the configuration of the registered jobs, schedules the enabled ones and starts
+// polling for the jobs triggered manually.
+func (r *Runner) Start(ctx context.Context) error {
+	scheduler, err := gocron.NewScheduler()
+	if err != nil {
+		return fmt.Errorf("failed to create scheduler: %w", err)
+	}
+
+	if err := r.schedule(ctx, scheduler); err != nil {
+		_ = scheduler.Shutdown()
+		return err
+	}
+
+	r.scheduler = scheduler
+	scheduler.Start()
+
+	return nil
+}
+
+func (r *Runner) schedule(ctx context.Context, scheduler gocron.Scheduler) error {
+	for _, job := range r.jobs {
+		if _, err := r.repo.RegisterCronJob(ctx, domain.CronJob{
+			Name:     job.Name,
+			Schedule: job.Config.Schedule,
+			Timeout:  job.Config.Timeout,
+			Enabled:  job.Config.Enabled,
+		}); err != nil {
+			return err
+		}
+
+		if !job.Config.Enabled {
+			slog.Info("Job disabled", "job", job.Name)
+			continue
+		}
+
+		if _, err := scheduler.NewJob(
+			gocron.CronJob(job.Config.Schedule, false),
+			gocron.NewTask(func() {
+				_, _ = r.Run(ctx, job.Name, domain.CronJobTriggerSchedule)
+			}),
+			gocron.WithName(job.Name),
+			gocron.WithSingletonMode(gocron.LimitModeReschedule), // Skip a run while the previous one is going
+		); err != nil {
+			return fmt.Errorf("failed to schedule job %s: %w", job.Name, err)
+		}
+		slog.Info("Job scheduled", "job", job.Name, "schedule", job.Config.Schedule, "timeout", job.Config.Timeout)
+	}
+
+	if _, err := scheduler.NewJob(
+		gocron.DurationJob(triggerPollInterval),
+		gocron.NewTask(func() {
+			if err := r.RunTriggered(ctx); err != nil {
+				slog.Error("Failed to run triggered jobs", "error", err)
+			}
+		}),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	); err != nil {
+		return fmt.Errorf("failed to schedule trigger polling: %w", err)
+	}
+
+	return nil
+}
+
+// Shutdown stops scheduling jobs and waits for the running ones.
+func (r *Runner) Shutdown() error {
+	if r.scheduler == nil {
+		return nil
+	}
+	return r.scheduler.Shutdown()
+}
+
+// RunTriggered runs the registered jobs an admin asked to run, one replica of crond running each
+// request.
+func (r *Runner) RunTriggered(ctx context.Context) error {
+	for _, job := range r.jobs {
+		claimed, err := r.repo.ClaimCronJobTrigger(ctx, job.Name)
+		if err != nil {
+			return err
+		}
+
+		if claimed {
+			_, _ = r.Run(ctx, job.Name, domain.CronJobTriggerManual)
+		}
+	}
+
+	return nil
+}
+
+// Run runs a registered job within its timeout and records the run. The returned error is the
+// one of recording the run, the outcome of the job being in the run.
+func (r *Runner) Run(ctx context.Context, name string, trigger domain.CronJobTrigger) (*domain.CronJobRun, error) {
+	job := r.job(name)
+	if job == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJob, name)
+	}
+
+	job.mu.Lock()
+	defer job.mu.Unlock()
+
+	run, err := r.repo.CreateCronJobRun(ctx, job.Name, trigger, time.Now())
+	if err != nil {
+		slog.Error("Failed to record job run", "job", job.Name, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Running job", "job", job.Name, "trigger", trigger)
+
+	items, err := job.run(ctx)
+
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.ItemsProcessed = items
+	run.Status = domain.CronJobRunStatusSucceeded
+	if err != nil {
+		run.Status = domain.CronJobRunStatusFailed
+		run.Error = err.Error()
+		slog.Error("Job failed", "job", job.Name, "items_processed", items, "error", err)
+	} else {
+		slog.Info("Job succeeded", "job", job.Name, "items_processed", items,
+			"duration", finishedAt.Sub(run.StartedAt))
+	}
+
+	// The run is recorded even when the job used up the context
+	if err := r.repo.FinishCronJobRun(context.WithoutCancel(ctx), domain.FinishCronJobRunRequest{
+		ID:             run.ID,
+		Status:         run.Status,
+		FinishedAt:     finishedAt,
+		ItemsProcessed: run.ItemsProcessed,
+		Error:          run.Error,
+	}); err != nil {
+		slog.Error("Failed to record job run outcome", "job", job.Name, "error", err)
+		return run, err
+	}
+
+	return run, nil
+}
+
+// run calls the job within its timeout, turning a panic into an error.
+func (j *registeredJob) run(ctx context.Context) (items int, err error) {
+	if j.Config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.Config.Timeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+
+	items, err = j.Run(ctx)
+	if err == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", j.Config.Timeout)
+	}
+
+	return items, err
+}
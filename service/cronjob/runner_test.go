@@ -0,0 +1,272 @@
+package cronjob_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/cronjob"
+)
+
+// memoryRepository keeps cron jobs and their runs in memory
+type memoryRepository struct {
+	mu   sync.Mutex
+	jobs map[string]*domain.CronJob
+	runs []*domain.CronJobRun
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{jobs: make(map[string]*domain.CronJob)}
+}
+
+func (r *memoryRepository) RegisterCronJob(_ context.Context, job domain.CronJob) (*domain.CronJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.jobs[job.Name] = &job
+	return &job, nil
+}
+
+func (r *memoryRepository) GetCronJobs(context.Context) ([]*domain.CronJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var jobs []*domain.CronJob
+	for _, job := range r.jobs {
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (r *memoryRepository) GetCronJobByName(_ context.Context, name string) (*domain.CronJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[name]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return job, nil
+}
+
+func (r *memoryRepository) RequestCronJobTrigger(_ context.Context, name string) (*domain.CronJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[name]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	now := time.Now()
+	job.TriggerRequestedAt = &now
+	return job, nil
+}
+
+func (r *memoryRepository) ClaimCronJobTrigger(_ context.Context, name string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[name]
+	if !ok || job.TriggerRequestedAt == nil {
+		return false, nil
+	}
+	job.TriggerRequestedAt = nil
+	return true, nil
+}
+
+func (r *memoryRepository) CreateCronJobRun(_ context.Context, jobName string, trigger domain.CronJobTrigger, startedAt time.Time) (*domain.CronJobRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run := &domain.CronJobRun{
+		ID:        int32(len(r.runs) + 1),
+		JobName:   jobName,
+		Trigger:   trigger,
+		Status:    domain.CronJobRunStatusRunning,
+		StartedAt: startedAt,
+	}
+	r.runs = append(r.runs, run)
+
+	stored := *run
+	return &stored, nil
+}
+
+func (r *memoryRepository) FinishCronJobRun(_ context.Context, req domain.FinishCronJobRunRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run := r.runs[req.ID-1]
+	run.Status = req.Status
+	run.FinishedAt = &req.FinishedAt
+	run.ItemsProcessed = req.ItemsProcessed
+	run.Error = req.Error
+	return nil
+}
+
+func (r *memoryRepository) GetCronJobRuns(_ context.Context, jobName string, limit int) ([]*domain.CronJobRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var runs []*domain.CronJobRun
+	for i := len(r.runs) - 1; i >= 0 && len(runs) < limit; i-- {
+		if r.runs[i].JobName == jobName {
+			runs = append(runs, r.runs[i])
+		}
+	}
+	return runs, nil
+}
+
+type testRunnerSuite struct {
+	suite.Suite
+
+	repo   *memoryRepository
+	runner *cronjob.Runner
+}
+
+func TestRunnerSuite(t *testing.T) {
+	suite.Run(t, new(testRunnerSuite))
+}
+
+func (s *testRunnerSuite) SetupTest() {
+	s.repo = newMemoryRepository()
+	s.runner = cronjob.NewRunner(s.repo)
+}
+
+func (s *testRunnerSuite) TearDownTest() {
+	s.NoError(s.runner.Shutdown())
+}
+
+func (s *testRunnerSuite) TestRunRecordsOutcome() {
+	s.NoError(s.runner.Register(cronjob.Job{
+		Name: "succeeds",
+		Run:  func(context.Context) (int, error) { return 3, nil },
+	}))
+	s.NoError(s.runner.Register(cronjob.Job{
+		Name: "fails",
+		Run:  func(context.Context) (int, error) { return 1, errors.New("database is down") },
+	}))
+	s.NoError(s.runner.Register(cronjob.Job{
+		Name: "panics",
+		Run:  func(context.Context) (int, error) { panic("nil map") },
+	}))
+	s.ErrorIs(s.runner.Register(cronjob.Job{Name: "fails"}), cronjob.ErrJobAlreadyRegistered)
+
+	run, err := s.runner.Run(s.T().Context(), "succeeds", domain.CronJobTriggerSchedule)
+	s.NoError(err)
+	s.Equal(domain.CronJobRunStatusSucceeded, run.Status)
+	s.Equal(3, run.ItemsProcessed)
+	s.NotNil(run.FinishedAt)
+
+	run, err = s.runner.Run(s.T().Context(), "fails", domain.CronJobTriggerSchedule)
+	s.NoError(err)
+	s.Equal(domain.CronJobRunStatusFailed, run.Status)
+	s.Equal(1, run.ItemsProcessed)
+	s.Equal("database is down", run.Error)
+
+	run, err = s.runner.Run(s.T().Context(), "panics", domain.CronJobTriggerManual)
+	s.NoError(err)
+	s.Equal(domain.CronJobRunStatusFailed, run.Status)
+	s.Equal("panic: nil map", run.Error)
+
+	_, err = s.runner.Run(s.T().Context(), "unknown", domain.CronJobTriggerManual)
+	s.ErrorIs(err, cronjob.ErrUnknownJob)
+
+	// Runs are recorded in the repository
+	runs, err := s.repo.GetCronJobRuns(s.T().Context(), "fails", 10)
+	s.NoError(err)
+	s.Len(runs, 1)
+	s.Equal(domain.CronJobRunStatusFailed, runs[0].Status)
+	s.Equal(domain.CronJobTriggerSchedule, runs[0].Trigger)
+}
+
+func (s *testRunnerSuite) TestRunTimesOut() {
+	s.NoError(s.runner.Register(cronjob.Job{
+		Name:   "slow",
+		Config: cronjob.Config{Timeout: 10 * time.Millisecond},
+		Run: func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			// Work done so far is still reported
+			return 2, nil
+		},
+	}))
+
+	run, err := s.runner.Run(s.T().Context(), "slow", domain.CronJobTriggerSchedule)
+	s.NoError(err)
+	s.Equal(domain.CronJobRunStatusFailed, run.Status)
+	s.Equal(2, run.ItemsProcessed)
+	s.Contains(run.Error, "timed out")
+}
+
+func (s *testRunnerSuite) TestStartRegistersJobs() {
+	s.NoError(s.runner.Register(cronjob.Job{
+		Name:   "hourly",
+		Config: cronjob.Config{Schedule: "0 * * * *", Timeout: time.Minute, Enabled: true},
+		Run:    func(context.Context) (int, error) { return 0, nil },
+	}))
+	s.NoError(s.runner.Register(cronjob.Job{
+		Name:   "disabled",
+		Config: cronjob.Config{Schedule: "not a schedule"},
+		Run:    func(context.Context) (int, error) { return 0, nil },
+	}))
+
+	s.NoError(s.runner.Start(s.T().Context()))
+
+	job, err := s.repo.GetCronJobByName(s.T().Context(), "hourly")
+	s.NoError(err)
+	s.Equal("0 * * * *", job.Schedule)
+	s.Equal(time.Minute, job.Timeout)
+	s.True(job.Enabled)
+
+	job, err = s.repo.GetCronJobByName(s.T().Context(), "disabled")
+	s.NoError(err)
+	s.False(job.Enabled)
+}
+
+func (s *testRunnerSuite) TestStartRejectsInvalidSchedule() {
+	s.NoError(s.runner.Register(cronjob.Job{
+		Name:   "broken",
+		Config: cronjob.Config{Schedule: "every hour", Enabled: true},
+		Run:    func(context.Context) (int, error) { return 0, nil },
+	}))
+
+	s.Error(s.runner.Start(s.T().Context()))
+}
+
+func (s *testRunnerSuite) TestRunTriggered() {
+	var calls int
+	s.NoError(s.runner.Register(cronjob.Job{
+		Name: "manual",
+		Run: func(context.Context) (int, error) {
+			calls++
+			return 0, nil
+		},
+	}))
+	_, err := s.repo.RegisterCronJob(s.T().Context(), domain.CronJob{Name: "manual"})
+	s.NoError(err)
+
+	// Nothing runs until an admin triggers the job
+	s.NoError(s.runner.RunTriggered(s.T().Context()))
+	s.Zero(calls)
+
+	service := cronjob.NewService(s.repo)
+	_, err = service.TriggerJob(s.T().Context(), "manual")
+	s.NoError(err)
+	_, err = service.TriggerJob(s.T().Context(), "unknown")
+	s.ErrorIs(err, domain.ErrNotFound)
+
+	s.NoError(s.runner.RunTriggered(s.T().Context()))
+	s.NoError(s.runner.RunTriggered(s.T().Context()))
+	s.Equal(1, calls)
+
+	statuses, err := service.GetJobs(s.T().Context())
+	s.NoError(err)
+	s.Len(statuses, 1)
+	s.Nil(statuses[0].Job.TriggerRequestedAt)
+	s.Equal(domain.CronJobTriggerManual, statuses[0].LastRun.Trigger)
+	s.Equal(domain.CronJobRunStatusSucceeded, statuses[0].LastRun.Status)
+}
@@ -0,0 +1,62 @@
+package cronjob
+
+import (
+	"context"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// JobStatus represents a cron job along with its latest run
+type JobStatus struct {
+	Job     *domain.CronJob
+	LastRun *domain.CronJobRun // Nil when the job never ran
+}
+
+// Service lets admins inspect and trigger the jobs of crond.
+type Service struct {
+	repo domain.CronJobRepository
+}
+
+// NewService creates a new cron job service.
+func NewService(repo domain.CronJobRepository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// GetJobs gets the jobs registered by crond with their latest run
+func (s *Service) GetJobs(ctx context.Context) ([]JobStatus, error) {
+	jobs, err := s.repo.GetCronJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]JobStatus, len(jobs))
+	for i, job := range jobs {
+		runs, err := s.repo.GetCronJobRuns(ctx, job.Name, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses[i] = JobStatus{Job: job}
+		if len(runs) > 0 {
+			statuses[i].LastRun = runs[0]
+		}
+	}
+
+	return statuses, nil
+}
+
+// GetJobRuns gets the latest runs of a job, the latest first
+func (s *Service) GetJobRuns(ctx context.Context, name string, limit int) ([]*domain.CronJobRun, error) {
+	if _, err := s.repo.GetCronJobByName(ctx, name); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetCronJobRuns(ctx, name, limit)
+}
+
+// TriggerJob asks crond to run a job as soon as it polls for triggered jobs
+func (s *Service) TriggerJob(ctx context.Context, name string) (*domain.CronJob, error) {
+	return s.repo.RequestCronJobTrigger(ctx, name)
+}
@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+var _ domain.Notifier = (*ChatNotifier)(nil)
+
+// ChatOption defines the chat bot notifications are sent by.
+type ChatOption struct {
+	APIURL   string // Base URL of the Telegram Bot API compatible server
+	BotToken string
+	Timeout  time.Duration
+}
+
+// ChatNotifier sends notifications as chat messages through a Telegram Bot API compatible server,
+// the recipient being the ID of the chat.
+type ChatNotifier struct {
+	opt    ChatOption
+	client *http.Client
+}
+
+// NewChatNotifier creates a new chat notifier.
+func NewChatNotifier(opt ChatOption) *ChatNotifier {
+	return &ChatNotifier{
+		opt:    opt,
+		client: &http.Client{Timeout: opt.Timeout},
+	}
+}
+
+type sendMessageRequest struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+type sendMessageResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+// Notify implements the domain.Notifier interface.
+func (n *ChatNotifier) Notify(notification domain.Notification) error {
+	payload, err := json.Marshal(sendMessageRequest{
+		ChatID: notification.Recipient,
+		Text:   notificationText(notification),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode chat message: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimSuffix(n.opt.APIURL, "/"), n.opt.BotToken)
+	resp, err := n.client.Post(endpoint, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		// The URL holds the bot token, keep it out of the error
+		return fmt.Errorf("failed to send chat message: %w", unwrapURLError(err))
+	}
+	defer resp.Body.Close()
+
+	var result sendMessageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode chat response with status %d: %w", resp.StatusCode, err)
+	}
+
+	if !result.OK {
+		return fmt.Errorf("chat message rejected with status %d: %s", resp.StatusCode, result.Description)
+	}
+
+	return nil
+}
+
+// unwrapURLError strips the URL a request failed for from an error.
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
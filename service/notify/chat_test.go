@@ -0,0 +1,59 @@
+package notify_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/notify"
+)
+
+type testChatNotifierSuite struct {
+	suite.Suite
+}
+
+func TestChatNotifierSuite(t *testing.T) {
+	suite.Run(t, new(testChatNotifierSuite))
+}
+
+func (s *testChatNotifierSuite) TestNotifySendsMessage() {
+	var message map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/bottoken/sendMessage", r.URL.Path)
+		s.NoError(json.NewDecoder(r.Body).Decode(&message))
+		_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+	}))
+	defer server.Close()
+
+	notifier := notify.NewChatNotifier(notify.ChatOption{APIURL: server.URL + "/", BotToken: "token"})
+	s.NoError(notifier.Notify(domain.Notification{Recipient: "42", Subject: "Rent", Body: "due tomorrow"}))
+
+	s.Equal("42", message["chat_id"])
+	s.Equal("Rent\n\ndue tomorrow", message["text"])
+}
+
+func (s *testChatNotifierSuite) TestNotifyFailsWhenRejected() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"ok":false,"description":"Bad Request: chat not found"}`))
+	}))
+	defer server.Close()
+
+	notifier := notify.NewChatNotifier(notify.ChatOption{APIURL: server.URL, BotToken: "token"})
+	err := notifier.Notify(domain.Notification{Recipient: "42", Subject: "Rent"})
+	s.ErrorContains(err, "chat not found")
+}
+
+func (s *testChatNotifierSuite) TestNotifyErrorHidesToken() {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	notifier := notify.NewChatNotifier(notify.ChatOption{APIURL: server.URL, BotToken: "token"})
+	err := notifier.Notify(domain.Notification{Recipient: "42", Subject: "Rent"})
+	s.Error(err)
+	s.NotContains(err.Error(), "token")
+}
@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+var (
+	_ domain.Notifier = (*FileNotifier)(nil)
+	_ domain.Mailer   = (*FileNotifier)(nil)
+)
+
+// FileNotifier appends notifications and emails to a file, for local development and tests.
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+
+	getNow func() time.Time
+}
+
+// NewFileNotifier creates a new file notifier writing to the given path.
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{
+		path:   path,
+		getNow: time.Now,
+	}
+}
+
+// Notify implements the domain.Notifier interface.
+func (n *FileNotifier) Notify(notification domain.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open notification file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := fmt.Fprintf(file, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		n.getNow().Format(time.RFC3339), notification.Recipient, notification.Subject, notification.Body); err != nil {
+		return fmt.Errorf("failed to write notification: %w", err)
+	}
+
+	return nil
+}
+
+// Send implements the domain.Mailer interface. Only the text version of the email is written.
+func (n *FileNotifier) Send(email domain.Email) error {
+	return n.Notify(domain.Notification{
+		Recipient: email.Recipient,
+		Subject:   email.Subject,
+		Body:      email.Text,
+	})
+}
@@ -0,0 +1,40 @@
+package notify_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/notify"
+)
+
+type testFileNotifierSuite struct {
+	suite.Suite
+}
+
+func TestFileNotifierSuite(t *testing.T) {
+	suite.Run(t, new(testFileNotifierSuite))
+}
+
+func (s *testFileNotifierSuite) TestNotifyAppends() {
+	path := filepath.Join(s.T().TempDir(), "notifications.log")
+	notifier := notify.NewFileNotifier(path)
+
+	s.NoError(notifier.Notify(domain.Notification{Recipient: "a@example.com", Subject: "first", Body: "hello"}))
+	s.NoError(notifier.Notify(domain.Notification{Recipient: "b@example.com", Subject: "second", Body: "world"}))
+
+	content, err := os.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), "To: a@example.com\nSubject: first\n\nhello")
+	s.Contains(string(content), "To: b@example.com\nSubject: second\n\nworld")
+}
+
+func (s *testFileNotifierSuite) TestUnknownSink() {
+	opt := notify.Option{Sink: "pigeon"}
+
+	_, err := opt.NewNotifier()
+	s.Error(err)
+}
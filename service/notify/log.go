@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"log/slog"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+var (
+	_ domain.Notifier = (*LogNotifier)(nil)
+	_ domain.Mailer   = (*LogNotifier)(nil)
+)
+
+// LogNotifier writes notifications and emails to the application log, for local development.
+type LogNotifier struct{}
+
+// NewLogNotifier creates a new log notifier.
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+// Notify implements the domain.Notifier interface.
+func (n *LogNotifier) Notify(notification domain.Notification) error {
+	slog.Info("notification",
+		slog.String("recipient", notification.Recipient),
+		slog.String("subject", notification.Subject),
+		slog.String("body", notification.Body),
+	)
+
+	return nil
+}
+
+// Send implements the domain.Mailer interface. Only the text version of the email is logged.
+func (n *LogNotifier) Send(email domain.Email) error {
+	slog.Info("email",
+		slog.String("recipient", email.Recipient),
+		slog.String("subject", email.Subject),
+		slog.String("text", email.Text),
+		slog.Int("html_length", len(email.HTML)),
+	)
+
+	return nil
+}
@@ -0,0 +1,157 @@
+// Package notify delivers notifications to users through pluggable sinks.
+package notify
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+const (
+	// SinkLog writes notifications to the application log
+	SinkLog = "log"
+	// SinkFile appends notifications to a file
+	SinkFile = "file"
+	// SinkSMTP sends notifications as emails through an SMTP server
+	SinkSMTP = "smtp"
+)
+
+// Option defines the notification sink to use.
+type Option struct {
+	Sink     string
+	FilePath string
+	SMTP     SMTPOption
+	Webhook  WebhookOption
+	Chat     ChatOption
+}
+
+// CliFlags returns cli flag list.
+func (opt *Option) CliFlags() []cli.Flag {
+	var flags []cli.Flag
+	flags = append(flags, &cli.StringFlag{
+		Name:        "notifier-sink",
+		Usage:       "log, file, smtp",
+		EnvVars:     []string{"NOTIFIER_SINK"},
+		Value:       SinkLog,
+		Destination: &opt.Sink,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "notifier-file-path",
+		Usage:       "file notifications are appended to when the file sink is used",
+		EnvVars:     []string{"NOTIFIER_FILE_PATH"},
+		Value:       "notifications.log",
+		Destination: &opt.FilePath,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "smtp-host",
+		EnvVars:     []string{"SMTP_HOST"},
+		Value:       "localhost",
+		Destination: &opt.SMTP.Host,
+	})
+	flags = append(flags, &cli.IntFlag{
+		Name:        "smtp-port",
+		EnvVars:     []string{"SMTP_PORT"},
+		Value:       587,
+		Destination: &opt.SMTP.Port,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "smtp-username",
+		EnvVars:     []string{"SMTP_USERNAME"},
+		Destination: &opt.SMTP.Username,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "smtp-password",
+		EnvVars:     []string{"SMTP_PASSWORD"},
+		Destination: &opt.SMTP.Password,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "smtp-from",
+		Usage:       "sender address of emails",
+		EnvVars:     []string{"SMTP_FROM"},
+		Value:       "bookly@localhost",
+		Destination: &opt.SMTP.From,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "notifier-webhook-secret",
+		Usage:       "secret signing the payloads posted to webhooks",
+		EnvVars:     []string{"NOTIFIER_WEBHOOK_SECRET"},
+		Destination: &opt.Webhook.Secret,
+	})
+	flags = append(flags, &cli.DurationFlag{
+		Name:        "notifier-webhook-timeout",
+		EnvVars:     []string{"NOTIFIER_WEBHOOK_TIMEOUT"},
+		Value:       10 * time.Second,
+		Destination: &opt.Webhook.Timeout,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "notifier-chat-api-url",
+		Usage:       "Telegram Bot API compatible server chat messages are sent through",
+		EnvVars:     []string{"NOTIFIER_CHAT_API_URL"},
+		Value:       "https://api.telegram.org",
+		Destination: &opt.Chat.APIURL,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "notifier-chat-bot-token",
+		Usage:       "token of the chat bot, the chat channel is disabled without it",
+		EnvVars:     []string{"NOTIFIER_CHAT_BOT_TOKEN"},
+		Destination: &opt.Chat.BotToken,
+	})
+	flags = append(flags, &cli.DurationFlag{
+		Name:        "notifier-chat-timeout",
+		EnvVars:     []string{"NOTIFIER_CHAT_TIMEOUT"},
+		Value:       10 * time.Second,
+		Destination: &opt.Chat.Timeout,
+	})
+
+	return flags
+}
+
+// NewNotifier creates the notifier of the configured sink.
+func (opt *Option) NewNotifier() (domain.Notifier, error) {
+	switch opt.Sink {
+	case SinkLog, "":
+		return NewLogNotifier(), nil
+	case SinkFile:
+		return NewFileNotifier(opt.FilePath), nil
+	case SinkSMTP:
+		return NewSMTPNotifier(opt.SMTP), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier sink: %s", opt.Sink)
+	}
+}
+
+// NewMailer creates the mailer of the configured sink.
+func (opt *Option) NewMailer() (domain.Mailer, error) {
+	switch opt.Sink {
+	case SinkLog, "":
+		return NewLogNotifier(), nil
+	case SinkFile:
+		return NewFileNotifier(opt.FilePath), nil
+	case SinkSMTP:
+		return NewSMTPNotifier(opt.SMTP), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier sink: %s", opt.Sink)
+	}
+}
+
+// NewChannelNotifiers creates the notifiers of the channels reminders are delivered through. Emails
+// go through the configured sink, and the chat channel is only available with a bot token.
+func (opt *Option) NewChannelNotifiers() (map[domain.NotificationChannel]domain.Notifier, error) {
+	email, err := opt.NewNotifier()
+	if err != nil {
+		return nil, err
+	}
+
+	notifiers := map[domain.NotificationChannel]domain.Notifier{
+		domain.NotificationChannelEmail:   email,
+		domain.NotificationChannelWebhook: NewWebhookNotifier(opt.Webhook),
+	}
+	if opt.Chat.BotToken != "" {
+		notifiers[domain.NotificationChannelChat] = NewChatNotifier(opt.Chat)
+	}
+
+	return notifiers, nil
+}
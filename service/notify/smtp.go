@@ -0,0 +1,145 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+var (
+	_ domain.Notifier = (*SMTPNotifier)(nil)
+	_ domain.Mailer   = (*SMTPNotifier)(nil)
+)
+
+// SMTPOption defines the SMTP server notifications are sent through.
+type SMTPOption struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPNotifier sends notifications as plain text emails, and emails with an HTML version as
+// multipart emails.
+type SMTPNotifier struct {
+	opt SMTPOption
+
+	getNow func() time.Time
+}
+
+// NewSMTPNotifier creates a new SMTP notifier.
+func NewSMTPNotifier(opt SMTPOption) *SMTPNotifier {
+	return &SMTPNotifier{
+		opt:    opt,
+		getNow: time.Now,
+	}
+}
+
+// Notify implements the domain.Notifier interface.
+func (n *SMTPNotifier) Notify(notification domain.Notification) error {
+	return n.send(notification.Recipient, n.message(notification))
+}
+
+// Send implements the domain.Mailer interface. Emails with an HTML version are sent as
+// multipart/alternative, so that clients show the version they support.
+func (n *SMTPNotifier) Send(email domain.Email) error {
+	if email.HTML == "" {
+		return n.Notify(domain.Notification{
+			Recipient: email.Recipient,
+			Subject:   email.Subject,
+			Body:      email.Text,
+		})
+	}
+
+	message, err := n.multipartMessage(email)
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	return n.send(email.Recipient, message)
+}
+
+func (n *SMTPNotifier) send(recipient string, message []byte) error {
+	var auth smtp.Auth
+	if n.opt.Username != "" {
+		auth = smtp.PlainAuth("", n.opt.Username, n.opt.Password, n.opt.Host)
+	}
+
+	addr := net.JoinHostPort(n.opt.Host, strconv.Itoa(n.opt.Port))
+	if err := smtp.SendMail(addr, auth, n.opt.From, []string{recipient}, message); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func (n *SMTPNotifier) writeHeader(buf *bytes.Buffer, recipient, subject string) {
+	fmt.Fprintf(buf, "From: %s\r\n", n.opt.From)
+	fmt.Fprintf(buf, "To: %s\r\n", recipient)
+	fmt.Fprintf(buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(buf, "Date: %s\r\n", n.getNow().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+}
+
+func (n *SMTPNotifier) message(notification domain.Notification) []byte {
+	var buf bytes.Buffer
+	n.writeHeader(&buf, notification.Recipient, notification.Subject)
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(notification.Body)
+	buf.WriteString("\r\n")
+
+	return buf.Bytes()
+}
+
+func (n *SMTPNotifier) multipartMessage(email domain.Email) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{contentType: "text/plain; charset=utf-8", content: email.Text},
+		{contentType: "text/html; charset=utf-8", content: email.HTML},
+	} {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Quoted-printable keeps the lines of long HTML within the limits of SMTP
+		encoder := quotedprintable.NewWriter(partWriter)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	n.writeHeader(&buf, email.Recipient, email.Subject)
+	fmt.Fprintf(&buf, "Content-Type: %s\r\n", mime.FormatMediaType("multipart/alternative", map[string]string{
+		"boundary": writer.Boundary(),
+	}))
+	buf.WriteString("\r\n")
+	buf.Write(body.Bytes())
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,136 @@
+package notify_test
+
+import (
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/notify"
+)
+
+type testSMTPNotifierSuite struct {
+	suite.Suite
+}
+
+func TestSMTPNotifierSuite(t *testing.T) {
+	suite.Run(t, new(testSMTPNotifierSuite))
+}
+
+// fakeSMTPServer accepts a single email and sends its envelope and data to the returned channel.
+func fakeSMTPServer(listener net.Listener) <-chan []string {
+	received := make(chan []string, 1)
+	go func() {
+		defer close(received)
+
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		text := textproto.NewConn(conn)
+		var lines []string
+		_ = text.PrintfLine("220 localhost fake")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch command {
+			case "EHLO", "HELO":
+				_ = text.PrintfLine("250 localhost")
+			case "MAIL", "RCPT":
+				lines = append(lines, line)
+				_ = text.PrintfLine("250 OK")
+			case "DATA":
+				_ = text.PrintfLine("354 send data")
+				data, err := text.ReadDotLines()
+				if err != nil {
+					return
+				}
+				lines = append(lines, data...)
+				_ = text.PrintfLine("250 OK")
+			case "QUIT":
+				_ = text.PrintfLine("221 bye")
+				received <- lines
+				return
+			default:
+				_ = text.PrintfLine("250 OK")
+			}
+		}
+	}()
+	return received
+}
+
+func (s *testSMTPNotifierSuite) TestNotifySendsEmail() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer listener.Close()
+
+	received := fakeSMTPServer(listener)
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	s.Require().NoError(err)
+	portNumber, err := strconv.Atoi(port)
+	s.Require().NoError(err)
+
+	notifier := notify.NewSMTPNotifier(notify.SMTPOption{Host: host, Port: portNumber, From: "bookly@localhost"})
+	s.NoError(notifier.Notify(domain.Notification{Recipient: "a@example.com", Subject: "Rent", Body: "due tomorrow"}))
+
+	lines := <-received
+	s.Contains(lines, "MAIL FROM:<bookly@localhost>")
+	s.Contains(lines, "RCPT TO:<a@example.com>")
+	s.Contains(lines, "Subject: Rent")
+	s.Contains(lines, "due tomorrow")
+}
+
+func (s *testSMTPNotifierSuite) TestSendMultipartEmail() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer listener.Close()
+
+	received := fakeSMTPServer(listener)
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	s.Require().NoError(err)
+	portNumber, err := strconv.Atoi(port)
+	s.Require().NoError(err)
+
+	mailer := notify.NewSMTPNotifier(notify.SMTPOption{Host: host, Port: portNumber, From: "bookly@localhost"})
+	s.NoError(mailer.Send(domain.Email{
+		Recipient: "a@example.com",
+		Subject:   "Weekly digest",
+		Text:      "You spent 30 on food",
+		HTML:      "<p>You spent 30 on food</p>",
+	}))
+
+	lines := <-received
+	s.Contains(lines, "RCPT TO:<a@example.com>")
+	s.Contains(lines, "Subject: Weekly digest")
+	s.Contains(lines, "Content-Type: text/plain; charset=utf-8")
+	s.Contains(lines, "Content-Type: text/html; charset=utf-8")
+	s.Contains(lines, "You spent 30 on food")
+	s.Contains(lines, "<p>You spent 30 on food</p>")
+
+	var multipartHeader bool
+	for _, line := range lines {
+		multipartHeader = multipartHeader || strings.HasPrefix(line, "Content-Type: multipart/alternative; boundary=")
+	}
+	s.True(multipartHeader)
+}
+
+func (s *testSMTPNotifierSuite) TestNotifyFailsWhenUnreachable() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	addr := listener.Addr().(*net.TCPAddr)
+	listener.Close()
+
+	notifier := notify.NewSMTPNotifier(notify.SMTPOption{Host: "127.0.0.1", Port: addr.Port, From: "bookly@localhost"})
+	s.Error(notifier.Notify(domain.Notification{Recipient: "a@example.com", Subject: "Rent"}))
+}
@@ -0,0 +1,140 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+var _ domain.Notifier = (*WebhookNotifier)(nil)
+
+// SignatureHeader carries the HMAC-SHA256 of a webhook payload when a secret is configured
+const SignatureHeader = "X-Bookly-Signature"
+
+// ErrWebhookAddressNotAllowed is returned when a webhook resolves to an address of the host or
+// of a private network.
+var ErrWebhookAddressNotAllowed = errors.New("webhook address not allowed")
+
+// WebhookOption defines how notifications are posted to webhooks.
+type WebhookOption struct {
+	Secret  string // Signs payloads when set
+	Timeout time.Duration
+	// AllowPrivateNetworks lets webhooks reach loopback, private and link-local addresses. Webhook
+	// URLs are chosen by users, so this is only meant for tests.
+	AllowPrivateNetworks bool
+}
+
+// webhookPayload is posted to webhooks. The text field makes it a valid Slack incoming webhook
+// message.
+type webhookPayload struct {
+	Text    string `json:"text"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// WebhookNotifier posts notifications as JSON to the URL of their recipient.
+type WebhookNotifier struct {
+	opt    WebhookOption
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new webhook notifier.
+func NewWebhookNotifier(opt WebhookOption) *WebhookNotifier {
+	return &WebhookNotifier{
+		opt:    opt,
+		client: &http.Client{Timeout: opt.Timeout, Transport: webhookTransport(opt)},
+	}
+}
+
+// webhookTransport dials webhooks directly, checking every address they resolve to. The check
+// runs on the dialed address, so it also covers redirects and DNS answers changing after a
+// target was saved.
+func webhookTransport(opt WebhookOption) *http.Transport {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !opt.AllowPrivateNetworks {
+		dialer.Control = refusePrivateAddress
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+// refusePrivateAddress fails dialing an address that is not publicly routable.
+func refusePrivateAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrWebhookAddressNotAllowed, address)
+	}
+
+	ip := addrPort.Addr().Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%w: %s", ErrWebhookAddressNotAllowed, ip)
+	}
+
+	return nil
+}
+
+// Notify implements the domain.Notifier interface.
+func (n *WebhookNotifier) Notify(notification domain.Notification) error {
+	payload, err := json.Marshal(webhookPayload{
+		Text:    notificationText(notification),
+		Subject: notification.Subject,
+		Body:    notification.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, notification.Recipient, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.opt.Secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(n.opt.Secret, payload))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of a payload, receivers compare it with the
+// signature header to verify a webhook.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// notificationText renders a notification as a single chat message.
+func notificationText(notification domain.Notification) string {
+	if notification.Body == "" {
+		return notification.Subject
+	}
+	return notification.Subject + "\n\n" + notification.Body
+}
@@ -0,0 +1,90 @@
+package notify_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/notify"
+)
+
+type testWebhookNotifierSuite struct {
+	suite.Suite
+}
+
+func TestWebhookNotifierSuite(t *testing.T) {
+	suite.Run(t, new(testWebhookNotifierSuite))
+}
+
+func (s *testWebhookNotifierSuite) TestNotifySignsPayload() {
+	var (
+		payload   map[string]string
+		signature string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		s.NoError(err)
+		s.NoError(json.Unmarshal(body, &payload))
+		s.Equal("application/json", r.Header.Get("Content-Type"))
+		s.Equal("sha256="+notify.Sign("secret", body), r.Header.Get(notify.SignatureHeader))
+		signature = r.Header.Get(notify.SignatureHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := notify.NewWebhookNotifier(notify.WebhookOption{Secret: "secret", AllowPrivateNetworks: true})
+	s.NoError(notifier.Notify(domain.Notification{Recipient: server.URL, Subject: "Rent", Body: "due tomorrow"}))
+
+	s.NotEmpty(signature)
+	s.Equal("Rent", payload["subject"])
+	s.Equal("due tomorrow", payload["body"])
+	s.Equal("Rent\n\ndue tomorrow", payload["text"])
+}
+
+func (s *testWebhookNotifierSuite) TestNotifyWithoutSecret() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Empty(r.Header.Get(notify.SignatureHeader))
+	}))
+	defer server.Close()
+
+	notifier := notify.NewWebhookNotifier(notify.WebhookOption{AllowPrivateNetworks: true})
+	s.NoError(notifier.Notify(domain.Notification{Recipient: server.URL, Subject: "Rent"}))
+}
+
+func (s *testWebhookNotifierSuite) TestNotifyFailsOnErrorStatus() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notifier := notify.NewWebhookNotifier(notify.WebhookOption{AllowPrivateNetworks: true})
+	err := notifier.Notify(domain.Notification{Recipient: server.URL, Subject: "Rent"})
+	s.ErrorContains(err, "502")
+}
+
+func (s *testWebhookNotifierSuite) TestNotifyRefusesPrivateAddresses() {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	notifier := notify.NewWebhookNotifier(notify.WebhookOption{})
+	for _, recipient := range []string{
+		server.URL,
+		strings.Replace(server.URL, "127.0.0.1", "localhost", 1),
+		"http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.1/hook",
+		"http://[::1]/hook",
+	} {
+		err := notifier.Notify(domain.Notification{Recipient: recipient, Subject: "Rent"})
+		s.ErrorIs(err, notify.ErrWebhookAddressNotAllowed, recipient)
+	}
+	s.Zero(calls)
+}
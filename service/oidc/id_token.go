@@ -0,0 +1,209 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// clockSkew is the leeway given to the expiry and issue time of ID tokens
+const clockSkew = time.Minute
+
+var errInvalidIDToken = errors.New("invalid id token")
+
+// keySet maps the key IDs of the provider to their public keys.
+type keySet map[string]crypto.PublicKey
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	decode := base64.RawURLEncoding.DecodeString
+
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve: %s", k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", k.Kty)
+	}
+}
+
+// fetchKeys downloads the signing keys of the provider.
+func (p *Provider) fetchKeys(jwksURI string) (keySet, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(jwksURI, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to fetch oidc signing keys: %w", err)
+	}
+
+	keys := make(keySet, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// getKey returns the signing key with the given ID. The keys are fetched again when
+// the ID is unknown, since providers rotate their keys.
+func (p *Provider) getKey(jwksURI, kid string) (crypto.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	keys, err := p.fetchKeys(jwksURI)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	// Providers with a single key may leave out the key ID
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown oidc signing key: %q", kid)
+}
+
+// verifyIDToken checks the signature, issuer, audience, lifetime and nonce of an ID token.
+func (p *Provider) verifyIDToken(discovery *discoveryDocument, rawIDToken, nonce string) (*domain.OIDCClaims, error) {
+	parser := jwt.Parser{
+		ValidMethods:         []string{"RS256", "ES256"},
+		SkipClaimsValidation: true, // checked below, against getNow and with leeway
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.getKey(discovery.JWKSURI, kid)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidIDToken, err.Error())
+	}
+
+	if issuer, _ := claims["iss"].(string); issuer != discovery.Issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", errInvalidIDToken, issuer)
+	}
+
+	audiences := claimStrings(claims["aud"])
+	if !slices.Contains(audiences, p.opt.ClientID) {
+		return nil, fmt.Errorf("%w: not issued for this client", errInvalidIDToken)
+	}
+	if azp, ok := claims["azp"].(string); ok && azp != p.opt.ClientID {
+		return nil, fmt.Errorf("%w: authorized party is %q", errInvalidIDToken, azp)
+	}
+
+	now := p.getNow()
+	exp, ok := claims["exp"].(float64)
+	if !ok || now.After(time.Unix(int64(exp), 0).Add(clockSkew)) {
+		return nil, fmt.Errorf("%w: expired", errInvalidIDToken)
+	}
+	if iat, ok := claims["iat"].(float64); ok && time.Unix(int64(iat), 0).After(now.Add(clockSkew)) {
+		return nil, fmt.Errorf("%w: issued in the future", errInvalidIDToken)
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", errInvalidIDToken)
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", errInvalidIDToken)
+	}
+
+	email, _ := claims["email"].(string)
+	emailVerified, _ := claims["email_verified"].(bool)
+	name, _ := claims["name"].(string)
+
+	return &domain.OIDCClaims{
+		Issuer:        discovery.Issuer,
+		Subject:       subject,
+		Email:         email,
+		EmailVerified: emailVerified,
+		Name:          name,
+	}, nil
+}
+
+// claimStrings reads a claim that is either a string or an array of strings.
+func claimStrings(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, value := range v {
+			if s, ok := value.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,233 @@
+// Package oidc signs users in with an external OpenID Connect provider, using the
+// authorization code flow with PKCE.
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+var _ domain.OIDCProvider = (*Provider)(nil)
+
+// Option defines the OpenID Connect provider to sign in with.
+type Option struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       string
+}
+
+// CliFlags returns cli flag list.
+func (opt *Option) CliFlags() []cli.Flag {
+	var flags []cli.Flag
+	flags = append(flags, &cli.StringFlag{
+		Name:        "oidc-issuer",
+		Usage:       "issuer URL of the OpenID Connect provider, OIDC login is disabled when empty",
+		EnvVars:     []string{"OIDC_ISSUER"},
+		Destination: &opt.Issuer,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "oidc-client-id",
+		EnvVars:     []string{"OIDC_CLIENT_ID"},
+		Destination: &opt.ClientID,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "oidc-client-secret",
+		Usage:       "leave empty for public clients",
+		EnvVars:     []string{"OIDC_CLIENT_SECRET"},
+		Destination: &opt.ClientSecret,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "oidc-redirect-url",
+		Usage:       "callback URL registered at the provider, defaults to the web app callback",
+		EnvVars:     []string{"OIDC_REDIRECT_URL"},
+		Destination: &opt.RedirectURL,
+	})
+	flags = append(flags, &cli.StringFlag{
+		Name:        "oidc-scopes",
+		EnvVars:     []string{"OIDC_SCOPES"},
+		Value:       "openid email profile",
+		Destination: &opt.Scopes,
+	})
+
+	return flags
+}
+
+// Enabled reports whether an OpenID Connect provider is configured.
+func (opt *Option) Enabled() bool {
+	return opt.Issuer != ""
+}
+
+// discoveryDocument holds the fields of the provider metadata the flow needs.
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider is an OpenID Connect provider configured through discovery.
+type Provider struct {
+	opt    Option
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *discoveryDocument
+	keys      keySet
+
+	getNow func() time.Time
+}
+
+// NewProvider creates a new provider. The provider metadata is discovered on first use.
+func NewProvider(opt Option) *Provider {
+	opt.Issuer = strings.TrimSuffix(opt.Issuer, "/")
+	if opt.Scopes == "" {
+		opt.Scopes = "openid email profile"
+	}
+
+	return &Provider{
+		opt:    opt,
+		client: &http.Client{Timeout: 10 * time.Second},
+		getNow: time.Now,
+	}
+}
+
+// getDiscovery fetches the provider metadata once and caches it.
+func (p *Provider) getDiscovery() (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var discovery discoveryDocument
+	if err := p.getJSON(p.opt.Issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, fmt.Errorf("failed to discover oidc provider: %w", err)
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != p.opt.Issuer {
+		return nil, fmt.Errorf("oidc provider reports issuer %q instead of %q", discovery.Issuer, p.opt.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("oidc provider metadata is incomplete")
+	}
+
+	p.discovery = &discovery
+	return p.discovery, nil
+}
+
+func (p *Provider) getJSON(url string, v any) error {
+	resp, err := p.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// CodeChallenge derives the S256 PKCE code challenge of a code verifier.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthCodeURL implements the domain.OIDCProvider interface.
+func (p *Provider) AuthCodeURL(state, nonce, codeChallenge string) (string, error) {
+	discovery, err := p.getDiscovery()
+	if err != nil {
+		return "", err
+	}
+
+	values := url.Values{}
+	values.Set("response_type", "code")
+	values.Set("client_id", p.opt.ClientID)
+	values.Set("redirect_uri", p.opt.RedirectURL)
+	values.Set("scope", p.opt.Scopes)
+	values.Set("state", state)
+	values.Set("nonce", nonce)
+	values.Set("code_challenge", codeChallenge)
+	values.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return discovery.AuthorizationEndpoint + separator + values.Encode(), nil
+}
+
+// Exchange implements the domain.OIDCProvider interface.
+func (p *Provider) Exchange(code, codeVerifier, nonce string) (*domain.OIDCClaims, error) {
+	discovery, err := p.getDiscovery()
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.opt.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	if p.opt.ClientSecret == "" {
+		form.Set("client_id", p.opt.ClientID)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.opt.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.opt.ClientID), url.QueryEscape(p.opt.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeem authorization code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	var tokenResp struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc provider rejected the authorization code: %s %s", tokenResp.Error, tokenResp.ErrorDescription)
+	}
+	if tokenResp.IDToken == "" {
+		return nil, errors.New("oidc provider returned no id token")
+	}
+
+	return p.verifyIDToken(discovery, tokenResp.IDToken, nonce)
+}
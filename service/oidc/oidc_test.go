@@ -0,0 +1,117 @@
+package oidc_test
+
+import (
+	"crypto/rand"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/omegaatt36/bookly/service/oidc"
+	"github.com/omegaatt36/bookly/service/oidc/oidctest"
+)
+
+const redirectURL = "http://web.test/auth/oidc/callback"
+
+type testProviderSuite struct {
+	suite.Suite
+
+	issuer   *oidctest.Issuer
+	provider *oidc.Provider
+}
+
+func (s *testProviderSuite) SetupTest() {
+	s.issuer = oidctest.NewIssuer("bookly", "client-secret")
+	s.provider = oidc.NewProvider(oidc.Option{
+		Issuer:       s.issuer.URL,
+		ClientID:     "bookly",
+		ClientSecret: "client-secret",
+		RedirectURL:  redirectURL,
+	})
+}
+
+func (s *testProviderSuite) TearDownTest() {
+	s.issuer.Close()
+}
+
+func TestProviderSuite(t *testing.T) {
+	suite.Run(t, new(testProviderSuite))
+}
+
+// authorize runs the authorization step and returns the code along with the PKCE verifier
+func (s *testProviderSuite) authorize(nonce string) (code, codeVerifier string) {
+	codeVerifier = rand.Text()
+
+	authURL, err := s.provider.AuthCodeURL("state", nonce, oidc.CodeChallenge(codeVerifier))
+	s.Require().NoError(err)
+
+	parsed, err := url.Parse(authURL)
+	s.Require().NoError(err)
+	s.Equal(redirectURL, parsed.Query().Get("redirect_uri"))
+	s.Equal("S256", parsed.Query().Get("code_challenge_method"))
+	s.Equal("openid email profile", parsed.Query().Get("scope"))
+
+	code, state, err := s.issuer.Authorize(authURL)
+	s.Require().NoError(err)
+	s.Equal("state", state)
+
+	return code, codeVerifier
+}
+
+func (s *testProviderSuite) TestExchange() {
+	code, codeVerifier := s.authorize("nonce")
+
+	claims, err := s.provider.Exchange(code, codeVerifier, "nonce")
+	s.Require().NoError(err)
+	s.Equal(s.issuer.URL, claims.Issuer)
+	s.Equal("test-subject", claims.Subject)
+	s.Equal("oidc@example.com", claims.Email)
+	s.True(claims.EmailVerified)
+	s.Equal("OIDC User", claims.Name)
+	s.Equal(s.issuer.URL+"|test-subject", claims.Identifier())
+
+	// Codes are single use
+	_, err = s.provider.Exchange(code, codeVerifier, "nonce")
+	s.Error(err)
+}
+
+func (s *testProviderSuite) TestExchangeRequiresCodeVerifier() {
+	code, _ := s.authorize("nonce")
+
+	_, err := s.provider.Exchange(code, rand.Text(), "nonce")
+	s.Error(err)
+}
+
+func (s *testProviderSuite) TestExchangeRequiresNonce() {
+	code, codeVerifier := s.authorize("nonce")
+
+	_, err := s.provider.Exchange(code, codeVerifier, "other-nonce")
+	s.ErrorContains(err, "nonce")
+}
+
+func (s *testProviderSuite) TestExchangeRejectsInvalidIDTokens() {
+	for name, modify := range map[string]func(jwt.MapClaims){
+		"audience": func(claims jwt.MapClaims) { claims["aud"] = "another-client" },
+		"issuer":   func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" },
+		"expired":  func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"subject":  func(claims jwt.MapClaims) { delete(claims, "sub") },
+	} {
+		s.issuer.ModifyClaims = modify
+
+		code, codeVerifier := s.authorize("nonce")
+		_, err := s.provider.Exchange(code, codeVerifier, "nonce")
+		s.Error(err, name)
+	}
+}
+
+func (s *testProviderSuite) TestDiscoveryRequiresMatchingIssuer() {
+	provider := oidc.NewProvider(oidc.Option{
+		Issuer:   s.issuer.URL + "/other",
+		ClientID: "bookly",
+	})
+
+	_, err := provider.AuthCodeURL("state", "nonce", "challenge")
+	s.Error(err)
+}
@@ -0,0 +1,277 @@
+package rrule
+
+import (
+	"iter"
+	"slices"
+	"time"
+)
+
+// maxIdleYears bounds the search for occurrences of rules that can never match, such as
+// February 30th. Any pattern of the Gregorian calendar repeats within 400 years.
+const maxIdleYears = 400
+
+// Occurrences iterates over the occurrences of the rule starting at dtstart, in chronological
+// order. Occurrences keep the time of day and location of dtstart. Unlike RFC 5545, dtstart is
+// only an occurrence when it matches the rule, so that a schedule such as "the last Friday of
+// every month" never falls on an arbitrary start date. Excluded dates still count towards COUNT.
+func (r *Rule) Occurrences(dtstart time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		e := r.expand(dtstart)
+		loc := dtstart.Location()
+
+		count := 0
+		lastYear := e.start.Year()
+		for period := 0; ; period++ {
+			days := r.candidates(e, period)
+			if len(days) == 0 {
+				if r.periodStart(e, period).Year()-lastYear > maxIdleYears {
+					return
+				}
+				continue
+			}
+
+			for _, day := range days {
+				t := time.Date(day.Year(), day.Month(), day.Day(),
+					dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), loc)
+				if t.Before(dtstart) {
+					continue
+				}
+
+				if r.until != nil && !r.until.covers(t) {
+					return
+				}
+
+				lastYear = day.Year()
+				count++
+				if !r.excluded(t) && !yield(t) {
+					return
+				}
+
+				if r.count > 0 && count >= r.count {
+					return
+				}
+			}
+		}
+	}
+}
+
+// After returns the first occurrence after t, or at t when inclusive.
+// It reports false when the rule has no such occurrence.
+func (r *Rule) After(dtstart, t time.Time, inclusive bool) (time.Time, bool) {
+	for occurrence := range r.Occurrences(dtstart) {
+		if occurrence.After(t) || (inclusive && occurrence.Equal(t)) {
+			return occurrence, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (r *Rule) excluded(t time.Time) bool {
+	for _, date := range r.exDates {
+		if date.matches(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// expansion holds the parts of a rule completed with the defaults RFC 5545 derives from
+// the start of the recurrence. Days are civil dates at midnight UTC.
+type expansion struct {
+	start      time.Time
+	weekStart  time.Time
+	byMonth    []int
+	byMonthDay []int
+	byDay      []WeekdayNum
+}
+
+func (r *Rule) expand(dtstart time.Time) *expansion {
+	start := time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), 0, 0, 0, 0, time.UTC)
+	e := expansion{
+		start:      start,
+		weekStart:  start.AddDate(0, 0, -((int(start.Weekday()) - int(r.weekStart) + 7) % 7)),
+		byMonth:    r.byMonth,
+		byMonthDay: r.byMonthDay,
+		byDay:      r.byDay,
+	}
+
+	switch r.freq {
+	case Weekly:
+		if len(e.byDay) == 0 {
+			e.byDay = []WeekdayNum{{Weekday: start.Weekday()}}
+		}
+	case Monthly:
+		if len(e.byDay) == 0 && len(e.byMonthDay) == 0 {
+			e.byMonthDay = []int{start.Day()}
+		}
+	case Yearly:
+		if len(e.byDay) == 0 && len(e.byMonthDay) == 0 {
+			e.byMonthDay = []int{start.Day()}
+			if len(e.byMonth) == 0 {
+				e.byMonth = []int{int(start.Month())}
+			}
+		}
+	}
+
+	return &e
+}
+
+// periodStart returns the first day of a period of the rule, counted from the start.
+func (r *Rule) periodStart(e *expansion, period int) time.Time {
+	step := period * r.interval
+
+	switch r.freq {
+	case Daily:
+		return e.start.AddDate(0, 0, step)
+	case Weekly:
+		return e.weekStart.AddDate(0, 0, 7*step)
+	case Monthly:
+		return time.Date(e.start.Year(), e.start.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(e.start.Year()+step, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// candidates returns the days of a period matching the rule, in order.
+func (r *Rule) candidates(e *expansion, period int) []time.Time {
+	first := r.periodStart(e, period)
+
+	var days []time.Time
+	switch r.freq {
+	case Daily:
+		if e.matchesMonth(first) && e.matchesMonthDay(first) && e.matchesWeekday(first) {
+			days = append(days, first)
+		}
+	case Weekly:
+		for i := range 7 {
+			day := first.AddDate(0, 0, i)
+			if e.matchesMonth(day) && e.matchesWeekday(day) {
+				days = append(days, day)
+			}
+		}
+	case Monthly:
+		if e.matchesMonth(first) {
+			days = e.daysIn(first, first.AddDate(0, 1, 0))
+		}
+	case Yearly:
+		switch {
+		case len(e.byMonth) > 0:
+			for _, month := range e.byMonth {
+				first := time.Date(first.Year(), time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+				days = append(days, e.daysIn(first, first.AddDate(0, 1, 0))...)
+			}
+		case len(e.byMonthDay) > 0:
+			for month := range 12 {
+				first := first.AddDate(0, month, 0)
+				days = append(days, e.daysIn(first, first.AddDate(0, 1, 0))...)
+			}
+		default:
+			// BYDAY alone counts its ordinals within the whole year
+			days = e.daysIn(first, first.AddDate(1, 0, 0))
+		}
+	}
+
+	return r.applySetPos(days)
+}
+
+// daysIn returns the days from first up to end matching BYMONTHDAY and BYDAY.
+// BYDAY ordinals count within that range.
+func (e *expansion) daysIn(first, end time.Time) []time.Time {
+	var days []time.Time
+	for day := first; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if len(e.byMonthDay) > 0 && !e.matchesMonthDay(day) {
+			continue
+		}
+
+		if len(e.byDay) > 0 && !e.matchesByDay(day, first, end) {
+			continue
+		}
+
+		days = append(days, day)
+	}
+
+	return days
+}
+
+func (e *expansion) matchesMonth(day time.Time) bool {
+	return len(e.byMonth) == 0 || slices.Contains(e.byMonth, int(day.Month()))
+}
+
+func (e *expansion) matchesMonthDay(day time.Time) bool {
+	if len(e.byMonthDay) == 0 {
+		return true
+	}
+
+	daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, monthDay := range e.byMonthDay {
+		if monthDay == day.Day() || (monthDay < 0 && daysInMonth+1+monthDay == day.Day()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *expansion) matchesWeekday(day time.Time) bool {
+	if len(e.byDay) == 0 {
+		return true
+	}
+
+	for _, weekday := range e.byDay {
+		if weekday.Weekday == day.Weekday() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *expansion) matchesByDay(day, first, end time.Time) bool {
+	for _, weekday := range e.byDay {
+		if weekday.Weekday != day.Weekday() {
+			continue
+		}
+
+		switch {
+		case weekday.N == 0:
+			return true
+		case weekday.N > 0 && daysBetween(first, day)/7+1 == weekday.N:
+			return true
+		case weekday.N < 0 && (daysBetween(day, end)-1)/7+1 == -weekday.N:
+			return true
+		}
+	}
+
+	return false
+}
+
+// applySetPos keeps the days of a period at the BYSETPOS positions.
+func (r *Rule) applySetPos(days []time.Time) []time.Time {
+	if len(r.bySetPos) == 0 || len(days) == 0 {
+		return days
+	}
+
+	var selected []time.Time
+	for _, pos := range r.bySetPos {
+		index := pos - 1
+		if pos < 0 {
+			index = len(days) + pos
+		}
+
+		if index >= 0 && index < len(days) && !slices.ContainsFunc(selected, days[index].Equal) {
+			selected = append(selected, days[index])
+		}
+	}
+
+	slices.SortFunc(selected, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+
+	return selected
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from) / (24 * time.Hour))
+}
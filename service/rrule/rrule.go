@@ -0,0 +1,419 @@
+// Package rrule parses iCalendar recurrence rules (RFC 5545, section 3.3.10) and iterates
+// their occurrences. It supports the daily, weekly, monthly and yearly frequencies with the
+// BYMONTH, BYMONTHDAY, BYDAY, BYSETPOS and WKST parts, COUNT, UNTIL and excluded dates.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRule is returned for rules that cannot be parsed.
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// Frequency is the FREQ part of a rule.
+type Frequency int
+
+// Supported frequencies. Rules repeating more often than daily are rejected.
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+// String implements the Stringer interface.
+func (f Frequency) String() string {
+	return frequencyNames[f]
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// WeekdayNum is an entry of the BYDAY part, such as FR or -1FR. N selects the nth such
+// weekday of the month or year, counting from the end when negative; zero selects all of them.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// String implements the Stringer interface.
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayNames[w.Weekday]
+	}
+
+	return strconv.Itoa(w.N) + weekdayNames[w.Weekday]
+}
+
+type valueKind int
+
+const (
+	valueDate valueKind = iota
+	valueFloating
+	valueUTC
+)
+
+// dateValue is a DATE or DATE-TIME value of UNTIL or EXDATE. Dates and floating date-times
+// are interpreted in the location of the start of the recurrence; their wall clock is kept in UTC.
+type dateValue struct {
+	t    time.Time
+	kind valueKind
+}
+
+func parseDateValue(s string) (dateValue, error) {
+	for _, layout := range []struct {
+		layout string
+		kind   valueKind
+	}{
+		{"20060102", valueDate},
+		{"20060102T150405", valueFloating},
+		{"20060102T150405Z", valueUTC},
+	} {
+		if len(s) != len(layout.layout) {
+			continue
+		}
+
+		t, err := time.Parse(layout.layout, s)
+		if err != nil {
+			return dateValue{}, fmt.Errorf("%w: bad date %q", ErrInvalidRule, s)
+		}
+
+		return dateValue{t: t, kind: layout.kind}, nil
+	}
+
+	return dateValue{}, fmt.Errorf("%w: bad date %q", ErrInvalidRule, s)
+}
+
+func (v dateValue) String() string {
+	switch v.kind {
+	case valueDate:
+		return v.t.Format("20060102")
+	case valueFloating:
+		return v.t.Format("20060102T150405")
+	default:
+		return v.t.Format("20060102T150405Z")
+	}
+}
+
+// in resolves the value in the location of the start of the recurrence.
+func (v dateValue) in(loc *time.Location) time.Time {
+	if v.kind == valueUTC {
+		return v.t
+	}
+
+	return time.Date(v.t.Year(), v.t.Month(), v.t.Day(), v.t.Hour(), v.t.Minute(), v.t.Second(), 0, loc)
+}
+
+// covers reports whether an occurrence is on or before the value. Dates cover the whole day.
+func (v dateValue) covers(t time.Time) bool {
+	if v.kind == valueDate {
+		return t.Before(v.in(t.Location()).AddDate(0, 0, 1))
+	}
+
+	return !t.After(v.in(t.Location()))
+}
+
+// matches reports whether an occurrence is the value. Dates match any occurrence on that day.
+func (v dateValue) matches(t time.Time) bool {
+	if v.kind == valueDate {
+		y, m, d := t.Date()
+		return y == v.t.Year() && m == v.t.Month() && d == v.t.Day()
+	}
+
+	return t.Equal(v.in(t.Location()))
+}
+
+// Rule is a recurrence rule along with the dates excluded from it.
+type Rule struct {
+	freq       Frequency
+	interval   int
+	count      int
+	until      *dateValue
+	byMonth    []int
+	byMonthDay []int
+	byDay      []WeekdayNum
+	bySetPos   []int
+	weekStart  time.Weekday
+	exDates    []dateValue
+}
+
+// Parse parses a recurrence rule. It accepts either a bare RRULE value such as
+// "FREQ=MONTHLY;BYDAY=-1FR", or content lines such as "RRULE:FREQ=MONTHLY" followed by
+// "EXDATE:20250131,20250228". The start of the recurrence is not part of the rule.
+func Parse(s string) (*Rule, error) {
+	var (
+		rule   *Rule
+		err    error
+		exDate []dateValue
+	)
+
+	for _, line := range strings.FieldsFunc(s, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			name, value = "RRULE", line
+		}
+
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+		switch name {
+		case "RRULE":
+			if rule != nil {
+				return nil, fmt.Errorf("%w: more than one RRULE", ErrInvalidRule)
+			}
+			if rule, err = parseRule(value); err != nil {
+				return nil, err
+			}
+		case "EXDATE":
+			for _, date := range strings.Split(value, ",") {
+				v, err := parseDateValue(strings.TrimSpace(date))
+				if err != nil {
+					return nil, err
+				}
+				exDate = append(exDate, v)
+			}
+		default:
+			return nil, fmt.Errorf("%w: unsupported property %s", ErrInvalidRule, name)
+		}
+	}
+
+	if rule == nil {
+		return nil, fmt.Errorf("%w: missing RRULE", ErrInvalidRule)
+	}
+
+	rule.exDates = exDate
+	return rule, nil
+}
+
+func parseRule(s string) (*Rule, error) {
+	rule := Rule{interval: 1, weekStart: time.Monday}
+
+	seen := make(map[string]bool)
+	hasFreq := false
+	for _, part := range strings.Split(strings.ToUpper(s), ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found || value == "" {
+			return nil, fmt.Errorf("%w: bad part %q", ErrInvalidRule, part)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %s given more than once", ErrInvalidRule, name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			hasFreq = true
+			rule.freq, err = parseFrequency(value)
+		case "INTERVAL":
+			rule.interval, err = parseInt(name, value, 1, 0)
+		case "COUNT":
+			rule.count, err = parseInt(name, value, 1, 0)
+		case "UNTIL":
+			var until dateValue
+			until, err = parseDateValue(value)
+			rule.until = &until
+		case "BYMONTH":
+			rule.byMonth, err = parseIntList(name, value, 1, 12, false)
+		case "BYMONTHDAY":
+			rule.byMonthDay, err = parseIntList(name, value, 1, 31, true)
+		case "BYSETPOS":
+			rule.bySetPos, err = parseIntList(name, value, 1, 366, true)
+		case "BYDAY":
+			rule.byDay, err = parseWeekdayList(value)
+		case "WKST":
+			var weekStart WeekdayNum
+			weekStart, err = parseWeekday(value)
+			if err == nil && weekStart.N != 0 {
+				err = fmt.Errorf("%w: bad WKST %q", ErrInvalidRule, value)
+			}
+			rule.weekStart = weekStart.Weekday
+		case "BYSECOND", "BYMINUTE", "BYHOUR", "BYYEARDAY", "BYWEEKNO":
+			err = fmt.Errorf("%w: %s is not supported", ErrInvalidRule, name)
+		default:
+			err = fmt.Errorf("%w: unknown part %s", ErrInvalidRule, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !hasFreq {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+
+	if rule.count > 0 && rule.until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot both be set", ErrInvalidRule)
+	}
+
+	if rule.freq != Monthly && rule.freq != Yearly {
+		for _, day := range rule.byDay {
+			if day.N != 0 {
+				return nil, fmt.Errorf("%w: BYDAY ordinals need a MONTHLY or YEARLY frequency", ErrInvalidRule)
+			}
+		}
+	}
+
+	if rule.freq == Weekly && len(rule.byMonthDay) > 0 {
+		return nil, fmt.Errorf("%w: BYMONTHDAY cannot be used with a WEEKLY frequency", ErrInvalidRule)
+	}
+
+	if len(rule.bySetPos) > 0 && len(rule.byMonth) == 0 && len(rule.byMonthDay) == 0 && len(rule.byDay) == 0 {
+		return nil, fmt.Errorf("%w: BYSETPOS needs another BY part", ErrInvalidRule)
+	}
+
+	slices.Sort(rule.byMonth)
+	return &rule, nil
+}
+
+func parseFrequency(s string) (Frequency, error) {
+	for freq, name := range frequencyNames {
+		if name == s {
+			return freq, nil
+		}
+	}
+
+	switch s {
+	case "SECONDLY", "MINUTELY", "HOURLY":
+		return 0, fmt.Errorf("%w: FREQ=%s is not supported", ErrInvalidRule, s)
+	}
+
+	return 0, fmt.Errorf("%w: bad FREQ %q", ErrInvalidRule, s)
+}
+
+// parseInt parses a positive integer no greater than max, or unbounded when max is zero.
+func parseInt(name, s string, min, max int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < min || (max > 0 && n > max) {
+		return 0, fmt.Errorf("%w: bad %s %q", ErrInvalidRule, name, s)
+	}
+
+	return n, nil
+}
+
+// parseIntList parses a list of integers between min and max, or their negatives when allowed.
+func parseIntList(name, s string, min, max int, negative bool) ([]int, error) {
+	var list []int
+	for _, item := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(item)
+		if err == nil && negative && n < 0 {
+			n = -n
+			if n >= min && n <= max {
+				list = append(list, -n)
+				continue
+			}
+		}
+
+		if err != nil || n < min || n > max {
+			return nil, fmt.Errorf("%w: bad %s %q", ErrInvalidRule, name, item)
+		}
+		list = append(list, n)
+	}
+
+	return list, nil
+}
+
+func parseWeekdayList(s string) ([]WeekdayNum, error) {
+	var list []WeekdayNum
+	for _, item := range strings.Split(s, ",") {
+		day, err := parseWeekday(item)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, day)
+	}
+
+	return list, nil
+}
+
+func parseWeekday(s string) (WeekdayNum, error) {
+	if len(s) < 2 {
+		return WeekdayNum{}, fmt.Errorf("%w: bad weekday %q", ErrInvalidRule, s)
+	}
+
+	index := slices.Index(weekdayNames[:], s[len(s)-2:])
+	if index < 0 {
+		return WeekdayNum{}, fmt.Errorf("%w: bad weekday %q", ErrInvalidRule, s)
+	}
+
+	day := WeekdayNum{Weekday: time.Weekday(index)}
+	if ordinal := s[:len(s)-2]; ordinal != "" {
+		n, err := strconv.Atoi(ordinal)
+		if err != nil || n == 0 || n < -53 || n > 53 {
+			return WeekdayNum{}, fmt.Errorf("%w: bad weekday %q", ErrInvalidRule, s)
+		}
+		day.N = n
+	}
+
+	return day, nil
+}
+
+// String returns the rule in the form accepted by Parse. Rules without excluded dates are
+// returned as a bare RRULE value.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.freq.String()}
+	if r.interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.interval))
+	}
+	if r.count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.count))
+	}
+	if r.until != nil {
+		parts = append(parts, "UNTIL="+r.until.String())
+	}
+	if len(r.byMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.byMonth))
+	}
+	if len(r.byMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.byMonthDay))
+	}
+	if len(r.byDay) > 0 {
+		days := make([]string, len(r.byDay))
+		for i, day := range r.byDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.bySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.bySetPos))
+	}
+	if r.weekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.weekStart])
+	}
+
+	rule := strings.Join(parts, ";")
+	if len(r.exDates) == 0 {
+		return rule
+	}
+
+	exDates := make([]string, len(r.exDates))
+	for i, date := range r.exDates {
+		exDates[i] = date.String()
+	}
+
+	return "RRULE:" + rule + "\nEXDATE:" + strings.Join(exDates, ",")
+}
+
+func joinInts(list []int) string {
+	s := make([]string, len(list))
+	for i, n := range list {
+		s[i] = strconv.Itoa(n)
+	}
+
+	return strings.Join(s, ",")
+}
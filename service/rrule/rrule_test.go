@@ -0,0 +1,208 @@
+package rrule_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/omegaatt36/bookly/service/rrule"
+)
+
+type testRuleSuite struct {
+	suite.Suite
+}
+
+func TestRuleSuite(t *testing.T) {
+	suite.Run(t, new(testRuleSuite))
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+}
+
+// take returns at most n occurrences of a rule
+func (s *testRuleSuite) take(rule string, dtstart time.Time, n int) []time.Time {
+	r, err := rrule.Parse(rule)
+	s.Require().NoError(err, rule)
+
+	var occurrences []time.Time
+	for occurrence := range r.Occurrences(dtstart) {
+		occurrences = append(occurrences, occurrence)
+		if len(occurrences) == n {
+			break
+		}
+	}
+
+	return occurrences
+}
+
+func (s *testRuleSuite) TestOccurrences() {
+	for _, tc := range []struct {
+		rule    string
+		dtstart time.Time
+		want    []time.Time
+		// unbounded rules are cut after the wanted occurrences
+		unbounded bool
+	}{
+		{
+			// Last Friday of every month, starting on a day that does not match
+			rule:      "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart:   date(2025, time.January, 3),
+			unbounded: true,
+			want:      []time.Time{date(2025, time.January, 31), date(2025, time.February, 28), date(2025, time.March, 28), date(2025, time.April, 25)},
+		},
+		{
+			// Last weekday of every month
+			rule:      "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			dtstart:   date(2025, time.January, 1),
+			unbounded: true,
+			want:      []time.Time{date(2025, time.January, 31), date(2025, time.February, 28), date(2025, time.March, 31), date(2025, time.April, 30)},
+		},
+		{
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=4",
+			dtstart: date(2025, time.January, 7),
+			want:    []time.Time{date(2025, time.January, 7), date(2025, time.January, 9), date(2025, time.January, 21), date(2025, time.January, 23)},
+		},
+		{
+			// Months without a 31st are skipped
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3",
+			dtstart: date(2025, time.January, 1),
+			want:    []time.Time{date(2025, time.January, 31), date(2025, time.March, 31), date(2025, time.May, 31)},
+		},
+		{
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			dtstart: date(2024, time.January, 15),
+			want:    []time.Time{date(2024, time.January, 31), date(2024, time.February, 29), date(2024, time.March, 31)},
+		},
+		{
+			rule:    "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29;COUNT=2",
+			dtstart: date(2024, time.January, 1),
+			want:    []time.Time{date(2024, time.February, 29), date(2028, time.February, 29)},
+		},
+		{
+			// Defaults are taken from the start
+			rule:    "FREQ=YEARLY;COUNT=2",
+			dtstart: date(2025, time.March, 10),
+			want:    []time.Time{date(2025, time.March, 10), date(2026, time.March, 10)},
+		},
+		{
+			// RFC 5545: every other month on the first and last Sunday of the month for 10 occurrences
+			rule:    "FREQ=MONTHLY;INTERVAL=2;COUNT=10;BYDAY=1SU,-1SU",
+			dtstart: date(1997, time.September, 7),
+			want: []time.Time{
+				date(1997, time.September, 7), date(1997, time.September, 28),
+				date(1997, time.November, 2), date(1997, time.November, 30),
+				date(1998, time.January, 4), date(1998, time.January, 25),
+				date(1998, time.March, 1), date(1998, time.March, 29),
+				date(1998, time.May, 3), date(1998, time.May, 31),
+			},
+		},
+		{
+			// RFC 5545: every 20th Monday of the year
+			rule:    "FREQ=YEARLY;BYDAY=20MO;COUNT=3",
+			dtstart: date(1997, time.May, 19),
+			want:    []time.Time{date(1997, time.May, 19), date(1998, time.May, 18), date(1999, time.May, 17)},
+		},
+		{
+			// RFC 5545: every Friday the 13th
+			rule:    "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13;COUNT=3",
+			dtstart: date(1997, time.September, 2),
+			want:    []time.Time{date(1998, time.February, 13), date(1998, time.March, 13), date(1998, time.November, 13)},
+		},
+		{
+			rule:    "FREQ=DAILY;UNTIL=20250103",
+			dtstart: date(2025, time.January, 1),
+			want:    []time.Time{date(2025, time.January, 1), date(2025, time.January, 2), date(2025, time.January, 3)},
+		},
+		{
+			// Excluded dates count towards COUNT
+			rule:    "RRULE:FREQ=MONTHLY;COUNT=3\nEXDATE:20250215",
+			dtstart: date(2025, time.January, 15),
+			want:    []time.Time{date(2025, time.January, 15), date(2025, time.March, 15)},
+		},
+		{
+			// February 30th never happens
+			rule:    "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			dtstart: date(2025, time.January, 1),
+			want:    nil,
+		},
+	} {
+		n := 20
+		if tc.unbounded {
+			n = len(tc.want)
+		}
+		s.Equal(tc.want, s.take(tc.rule, tc.dtstart, n), tc.rule)
+	}
+}
+
+func (s *testRuleSuite) TestOccurrencesKeepLocation() {
+	taipei := time.FixedZone("Asia/Taipei", 8*60*60)
+	dtstart := time.Date(2025, time.January, 31, 23, 30, 0, 0, taipei)
+
+	occurrences := s.take("FREQ=MONTHLY;BYMONTHDAY=-1;UNTIL=20250228", dtstart, 5)
+	s.Equal([]time.Time{dtstart, time.Date(2025, time.February, 28, 23, 30, 0, 0, taipei)}, occurrences)
+}
+
+func (s *testRuleSuite) TestAfter() {
+	r, err := rrule.Parse("FREQ=MONTHLY;BYDAY=-1FR")
+	s.Require().NoError(err)
+
+	dtstart := date(2025, time.January, 1)
+
+	next, ok := r.After(dtstart, date(2025, time.January, 31), false)
+	s.True(ok)
+	s.Equal(date(2025, time.February, 28), next)
+
+	next, ok = r.After(dtstart, date(2025, time.January, 31), true)
+	s.True(ok)
+	s.Equal(date(2025, time.January, 31), next)
+
+	r, err = rrule.Parse("FREQ=DAILY;COUNT=2")
+	s.Require().NoError(err)
+
+	_, ok = r.After(dtstart, date(2025, time.January, 2), false)
+	s.False(ok)
+}
+
+func (s *testRuleSuite) TestString() {
+	for rule, want := range map[string]string{
+		"FREQ=MONTHLY;BYDAY=-1FR":                                   "FREQ=MONTHLY;BYDAY=-1FR",
+		"freq=weekly;byday=mo,fr;interval=2;wkst=su":                "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;WKST=SU",
+		"FREQ=YEARLY;BYMONTH=12,6;UNTIL=20301231T000000Z":           "FREQ=YEARLY;UNTIL=20301231T000000Z;BYMONTH=6,12",
+		"RRULE:FREQ=DAILY;COUNT=5\nEXDATE:20250102,20250103T090000": "RRULE:FREQ=DAILY;COUNT=5\nEXDATE:20250102,20250103T090000",
+	} {
+		r, err := rrule.Parse(rule)
+		s.Require().NoError(err, rule)
+		s.Equal(want, r.String())
+
+		again, err := rrule.Parse(r.String())
+		s.Require().NoError(err)
+		s.Equal(want, again.String())
+	}
+}
+
+func (s *testRuleSuite) TestParseErrors() {
+	for _, rule := range []string{
+		"",
+		"BYDAY=MO",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20250101",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=YEARLY;BYWEEKNO=20",
+		"FREQ=DAILY;FOO=1",
+		"DTSTART:20250101\nRRULE:FREQ=DAILY",
+		"RRULE:FREQ=DAILY\nEXDATE:2025-01-01",
+	} {
+		_, err := rrule.Parse(rule)
+		s.True(errors.Is(err, rrule.ErrInvalidRule), rule)
+	}
+}
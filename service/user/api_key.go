@@ -0,0 +1,105 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/auth"
+)
+
+// maxAPIKeyNameLen is how long the name of an API key can be
+const maxAPIKeyNameLen = 100
+
+var (
+	// ErrInvalidAPIKeyScope is returned when an API key is requested without scopes or with unknown ones
+	ErrInvalidAPIKeyScope = errors.New("invalid api key scope")
+	// ErrInvalidAPIKeyName is returned when an API key is requested with an empty or too long name
+	ErrInvalidAPIKeyName = errors.New("invalid api key name")
+	// ErrInvalidAPIKeyExpiry is returned when an API key is requested to expire in the past
+	ErrInvalidAPIKeyExpiry = errors.New("api key must expire in the future")
+)
+
+// CreateAPIKeyRequest defines the request to create a personal API key
+type CreateAPIKeyRequest struct {
+	UserID    int32
+	Name      string
+	Scopes    []domain.APIKeyScope
+	ExpiresAt *time.Time
+}
+
+// NewAPIKey holds a created API key along with the key itself, which cannot be retrieved later
+type NewAPIKey struct {
+	APIKey *domain.APIKey
+	Key    string
+}
+
+// CreateAPIKey creates a personal API key for scripts and integrations to act as the user.
+func (s *Service) CreateAPIKey(req CreateAPIKeyRequest) (*NewAPIKey, error) {
+	if s.apiKeyRepo == nil {
+		return nil, errors.New("api key repository not initialized")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxAPIKeyNameLen {
+		return nil, ErrInvalidAPIKeyName
+	}
+
+	if len(req.Scopes) == 0 {
+		return nil, ErrInvalidAPIKeyScope
+	}
+
+	scopes := make([]domain.APIKeyScope, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if !scope.IsValid() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidAPIKeyScope, scope)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(s.getNow()) {
+		return nil, ErrInvalidAPIKeyExpiry
+	}
+
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := s.apiKeyRepo.CreateAPIKey(domain.CreateAPIKeyRequest{
+		UserID:    req.UserID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hash,
+		Scopes:    scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &NewAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+// GetAPIKeys lists the API keys of the user that have not been revoked.
+func (s *Service) GetAPIKeys(userID int32) ([]*domain.APIKey, error) {
+	if s.apiKeyRepo == nil {
+		return nil, errors.New("api key repository not initialized")
+	}
+
+	return s.apiKeyRepo.GetAPIKeysByUserID(userID)
+}
+
+// RevokeAPIKey revokes one of the user's API keys.
+func (s *Service) RevokeAPIKey(userID, apiKeyID int32) error {
+	if s.apiKeyRepo == nil {
+		return errors.New("api key repository not initialized")
+	}
+
+	return s.apiKeyRepo.RevokeAPIKey(apiKeyID, userID)
+}
@@ -3,10 +3,19 @@ package user
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/omegaatt36/bookly/domain"
 )
 
+var (
+	// ErrUserDisabled is returned when a disabled user tries to log in
+	ErrUserDisabled = errors.New("user is disabled")
+	// ErrInvalidCredentials is returned when the identifier or the credential does not match
+	ErrInvalidCredentials = errors.New("invalid identifier or credentials")
+)
+
 // RegisterRequest defines the request to register a new user
 type RegisterRequest struct {
 	Name       string
@@ -16,14 +25,21 @@ type RegisterRequest struct {
 	Credential string
 }
 
-// Register registers a new user
+// Register registers a new user. Users registered by an operator are trusted,
+// so their identity is verified right away.
 func (s *Service) Register(req RegisterRequest) error {
+	now := s.getNow()
+	_, err := s.register(req, &now)
+	return err
+}
+
+func (s *Service) register(req RegisterRequest, verifiedAt *time.Time) (int32, error) {
 	if s.mAuthenticator == nil {
-		return errors.New("authentication provider not initialized")
+		return 0, errors.New("authentication provider not initialized")
 	}
 
 	if s.mAuthenticator[req.Provider] == nil {
-		return fmt.Errorf("authentication provider not found: %s", req.Provider)
+		return 0, fmt.Errorf("authentication provider not found: %s", req.Provider)
 	}
 
 	userID, err := s.userRepo.CreateUser(domain.CreateUserRequest{
@@ -31,14 +47,14 @@ func (s *Service) Register(req RegisterRequest) error {
 		Nickname: req.Nickname,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
+		return 0, fmt.Errorf("failed to create user: %w", err)
 	}
 
 	var credentials string
 	if req.Provider == domain.IdentityProviderPassword {
 		credentials, err = s.mAuthenticator[req.Provider].HashPassword(req.Credential)
 		if err != nil {
-			return fmt.Errorf("failed to hash password: %w", err)
+			return 0, fmt.Errorf("failed to hash password: %w", err)
 		}
 	}
 
@@ -46,13 +62,14 @@ func (s *Service) Register(req RegisterRequest) error {
 		Provider:   req.Provider,
 		Identifier: req.Identifier,
 		Credential: credentials,
+		VerifiedAt: verifiedAt,
 	}
 
 	if err := s.userRepo.AddIdentity(userID, authProvider); err != nil {
-		return fmt.Errorf("failed to add identity: %w", err)
+		return 0, fmt.Errorf("failed to add identity: %w", err)
 	}
 
-	return nil
+	return userID, nil
 }
 
 // LoginRequest defines the request to login a user
@@ -60,36 +77,153 @@ type LoginRequest struct {
 	Provider   domain.IdentityProvider
 	Identifier string
 	Credential string
+	Metadata   SessionMetadata
+}
+
+// LoginResult holds the tokens of the session a login started, or the challenge to answer
+// with a second factor when the user enabled two-factor authentication.
+type LoginResult struct {
+	Tokens    *domain.AuthTokens
+	Challenge *LoginChallenge
 }
 
-// Login authenticates a user and returns a token
-func (s *Service) Login(req LoginRequest) (string, error) {
+// Login authenticates a user, and starts a session unless a second factor is required.
+// Identifiers are locked for exponentially longer after repeated wrong credentials.
+func (s *Service) Login(req LoginRequest) (*LoginResult, error) {
 	if s.mAuthenticator == nil {
-		return "", errors.New("authentication provider not initialized")
+		return nil, errors.New("authentication provider not initialized")
 	}
 
 	authenticator, ok := s.mAuthenticator[req.Provider]
 	if !ok {
-		return "", fmt.Errorf("authentication provider not found: %s", req.Provider)
+		return nil, fmt.Errorf("authentication provider not found: %s", req.Provider)
 	}
 
 	user, identity, err := s.userRepo.GetUserByIdentity(req.Provider, req.Identifier)
-	if err != nil {
-		return "", errors.New("invalid identifier or credentials")
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+
+	var userID *int32
+	if user != nil {
+		userID = &user.ID
+	}
+
+	if err := s.checkLoginLock(req.Provider, req.Identifier); err != nil {
+		if errors.Is(err, ErrTooManyLoginAttempts) {
+			s.recordAuthEvent(domain.AuthEventTypeLoginLocked, userID, req.Provider, req.Identifier, req.Metadata)
+		}
+		return nil, err
+	}
+
+	if user == nil {
+		// Take as long as for an existing user, so that timing does not tell which identifiers exist
+		s.verifyDummyCredential(authenticator, req.Provider, req.Credential)
+
+		if err := s.recordFailedLogin(req, nil); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCredentials
 	}
 
 	valid, err := authenticator.VerifyCredential(req.Credential, identity)
 	if err != nil {
-		return "", fmt.Errorf("credential verification failed: %w", err)
+		return nil, fmt.Errorf("credential verification failed: %w", err)
 	}
 	if !valid {
-		return "", errors.New("invalid identifier or credentials")
+		if err := s.recordFailedLogin(req, userID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCredentials
+	}
+
+	if user.Disabled {
+		return nil, ErrUserDisabled
+	}
+
+	if req.Provider == domain.IdentityProviderPassword && identity.VerifiedAt == nil {
+		return nil, ErrEmailNotVerified
+	}
+
+	// Upgrade legacy or outdated password hashes while the plain password is at hand
+	if req.Provider == domain.IdentityProviderPassword && authenticator.NeedsRehash(identity.Credential) {
+		if err := s.updateCredential(authenticator, identity, req.Credential); err != nil {
+			slog.Warn("failed to rehash credential",
+				slog.Int("user_id", int(user.ID)), slog.String("error", err.Error()))
+		}
+	}
+
+	return s.finishLogin(user, req.Provider, req.Identifier, req.Metadata)
+}
+
+// finishLogin starts a session for an authenticated user, or a two-factor challenge
+// when the user enabled two-factor authentication. The failures of the identifier are
+// only forgotten once a session is started.
+func (s *Service) finishLogin(user *domain.User, provider domain.IdentityProvider, identifier string, metadata SessionMetadata) (*LoginResult, error) {
+	requiresTwoFactor, err := s.requiresTwoFactor(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if requiresTwoFactor {
+		challenge, err := s.startTwoFactorChallenge(user, provider, identifier)
+		if err != nil {
+			return nil, err
+		}
+
+		return &LoginResult{Challenge: challenge}, nil
+	}
+
+	tokens, err := s.startSession(user, provider, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordSuccessfulLogin(provider, identifier)
+
+	return &LoginResult{Tokens: tokens}, nil
+}
+
+// verifyDummyCredential verifies a credential against the hash of a made up password, to spend
+// the time verifying a real one would.
+func (s *Service) verifyDummyCredential(authenticator domain.Authenticator, provider domain.IdentityProvider, credential string) {
+	if provider != domain.IdentityProviderPassword {
+		return
 	}
 
-	token, err := authenticator.GenerateToken(domain.GenerateTokenRequest{UserID: user.ID})
+	s.dummyCredentialOnce.Do(func() {
+		hash, err := authenticator.HashPassword("bookly-dummy-password")
+		if err != nil {
+			slog.Warn("failed to hash dummy credential", slog.String("error", err.Error()))
+			return
+		}
+		s.dummyCredential = hash
+	})
+	if s.dummyCredential == "" {
+		return
+	}
+
+	_, _ = authenticator.VerifyCredential(credential, &domain.Identity{
+		Provider:   provider,
+		Credential: s.dummyCredential,
+	})
+}
+
+// updateCredential hashes a new credential and stores it on the identity.
+func (s *Service) updateCredential(authenticator domain.Authenticator, identity *domain.Identity, credential string) error {
+	hash, err := authenticator.HashPassword(credential)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate token: %w", err)
+		return fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	return token, nil
+	return s.userRepo.UpdateIdentityCredential(identity.Provider, identity.Identifier, hash)
+}
+
+// Logout revokes the session the request was authenticated with
+func (s *Service) Logout(sessionID int32) error {
+	if err := s.sessionRepo.RevokeSession(sessionID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	return nil
 }
@@ -0,0 +1,122 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// ErrTooManyLoginAttempts is matched by LoginLockedError
+var ErrTooManyLoginAttempts = errors.New("too many failed login attempts")
+
+// LoginLockedError is returned when logins with an identifier are refused after too many failures
+type LoginLockedError struct {
+	Until time.Time
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("%s, try again after %s", ErrTooManyLoginAttempts, e.Until.Format(time.RFC3339))
+}
+
+// Is makes errors.Is(err, ErrTooManyLoginAttempts) match any lockout
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrTooManyLoginAttempts
+}
+
+// checkLoginLock refuses logins with an identifier that is locked after too many failures.
+func (s *Service) checkLoginLock(provider domain.IdentityProvider, identifier string) error {
+	if s.loginAttemptRepo == nil {
+		return nil
+	}
+
+	attempt, err := s.loginAttemptRepo.GetLoginAttempt(provider, identifier)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if attempt.IsLocked(s.getNow()) {
+		return &LoginLockedError{Until: *attempt.LockedUntil}
+	}
+
+	return nil
+}
+
+// recordFailedLogin counts a failed login towards the lockout of the identifier, locking it
+// for exponentially longer once the free attempts are used up.
+func (s *Service) recordFailedLogin(req LoginRequest, userID *int32) error {
+	s.recordAuthEvent(domain.AuthEventTypeLoginFailed, userID, req.Provider, req.Identifier, req.Metadata)
+
+	if s.loginAttemptRepo == nil {
+		return nil
+	}
+
+	now := s.getNow()
+	attempt, err := s.loginAttemptRepo.RecordFailedLogin(domain.RecordFailedLoginRequest{
+		Provider:    req.Provider,
+		Identifier:  req.Identifier,
+		FailedAt:    now,
+		ResetBefore: now.Add(-domain.LoginAttemptWindow),
+	})
+	if err != nil {
+		return err
+	}
+
+	lockout := domain.LoginLockout(attempt.FailedCount)
+	if lockout == 0 {
+		return nil
+	}
+
+	return s.loginAttemptRepo.LockLogin(req.Provider, req.Identifier, now.Add(lockout))
+}
+
+// recordSuccessfulLogin forgets the failures of an identifier once it started a session.
+func (s *Service) recordSuccessfulLogin(provider domain.IdentityProvider, identifier string) {
+	if err := s.userRepo.UpdateIdentityLastUsed(provider, identifier); err != nil {
+		slog.Warn("failed to update identity last used", slog.String("error", err.Error()))
+	}
+
+	if s.loginAttemptRepo == nil {
+		return
+	}
+
+	if err := s.loginAttemptRepo.ClearLoginAttempts(provider, identifier); err != nil {
+		slog.Warn("failed to clear login attempts", slog.String("error", err.Error()))
+	}
+}
+
+// recordAuthEvent records an authentication event. Failing to record it does not fail the login.
+func (s *Service) recordAuthEvent(eventType domain.AuthEventType, userID *int32, provider domain.IdentityProvider, identifier string, metadata SessionMetadata) {
+	if s.authEventRepo == nil {
+		return
+	}
+
+	if err := s.authEventRepo.CreateAuthEvent(domain.CreateAuthEventRequest{
+		UserID:     userID,
+		Type:       eventType,
+		Provider:   provider,
+		Identifier: identifier,
+		IPAddress:  metadata.IPAddress,
+		UserAgent:  metadata.UserAgent,
+	}); err != nil {
+		slog.Warn("failed to record auth event",
+			slog.String("type", eventType.String()), slog.String("error", err.Error()))
+	}
+}
+
+// maxAuthEvents is how many of the latest authentication events are listed
+const maxAuthEvents = 50
+
+// GetAuthEvents lists the latest logins and login attempts of the user.
+func (s *Service) GetAuthEvents(userID int32) ([]*domain.AuthEvent, error) {
+	if s.authEventRepo == nil {
+		return nil, errors.New("auth event repository not initialized")
+	}
+
+	return s.authEventRepo.GetAuthEventsByUserID(userID, maxAuthEvents)
+}
@@ -0,0 +1,200 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/oidc"
+)
+
+var (
+	// ErrInvalidOIDCState is returned when an OIDC callback carries an unknown, used or expired state
+	ErrInvalidOIDCState = errors.New("invalid or expired oidc login attempt")
+	// ErrOIDCLoginFailed is returned when the OIDC provider does not confirm the external identity
+	ErrOIDCLoginFailed = errors.New("failed to verify oidc login")
+	// ErrOIDCIdentityNotLinked is returned when nobody linked the external identity and it cannot sign up
+	ErrOIDCIdentityNotLinked = errors.New("external identity is not linked to an account")
+	// ErrOIDCIdentityTaken is returned when linking an external identity that belongs to another user
+	ErrOIDCIdentityTaken = errors.New("external identity is linked to another account")
+)
+
+// StartOIDCRequest defines the request to send a user to the OIDC provider
+type StartOIDCRequest struct {
+	// LinkUserID is the user linking the external identity, zero to log in with it
+	LinkUserID int32
+}
+
+// OIDCAuthorization is where to send the user to the OIDC provider. The state has to be kept
+// in the browser of the user, the callback is only accepted from it.
+type OIDCAuthorization struct {
+	URL   string
+	State string
+}
+
+// StartOIDC records an authorization request and returns the URL of the OIDC provider to
+// send the user to. The state, nonce and PKCE verifier tie the callback to this request.
+func (s *Service) StartOIDC(req StartOIDCRequest) (*OIDCAuthorization, error) {
+	if s.oidcProvider == nil || s.oidcAuthRequestRepo == nil {
+		return nil, errors.New("oidc login not configured")
+	}
+
+	state, stateHash, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, _, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	codeVerifier, _, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	var linkUserID *int32
+	if req.LinkUserID != 0 {
+		linkUserID = &req.LinkUserID
+	}
+
+	if _, err := s.oidcAuthRequestRepo.CreateOIDCAuthRequest(domain.CreateOIDCAuthRequestRequest{
+		StateHash:    stateHash,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		LinkUserID:   linkUserID,
+		ExpiresAt:    s.getNow().Add(domain.OIDCAuthRequestTTL),
+	}); err != nil {
+		return nil, err
+	}
+
+	authorizationURL, err := s.oidcProvider.AuthCodeURL(state, nonce, oidc.CodeChallenge(codeVerifier))
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCAuthorization{URL: authorizationURL, State: state}, nil
+}
+
+// CompleteOIDCRequest defines the request of a user coming back from the OIDC provider
+type CompleteOIDCRequest struct {
+	State string
+	Code  string
+	// UserID is the signed-in user completing a link, zero to log in
+	UserID   int32
+	Metadata SessionMetadata
+}
+
+// OIDCResult is the outcome of an OIDC callback: the external identity was either
+// linked to the user who started it, or used to log in.
+type OIDCResult struct {
+	Linked bool
+	Login  *LoginResult
+}
+
+// CompleteOIDC redeems the authorization code of an OIDC callback, then links the external
+// identity or logs its owner in. Unknown identities sign up as new users when allowed, unless
+// their email address already has an account, whose owner has to link the identity first.
+// A link is only completed by the user who started it, and a login only by nobody signed in.
+func (s *Service) CompleteOIDC(req CompleteOIDCRequest) (*OIDCResult, error) {
+	if s.oidcProvider == nil || s.oidcAuthRequestRepo == nil {
+		return nil, errors.New("oidc login not configured")
+	}
+
+	authRequest, err := s.oidcAuthRequestRepo.UseOIDCAuthRequest(hashSecretToken(req.State))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidOIDCState
+		}
+		return nil, err
+	}
+
+	if (authRequest.LinkUserID == nil && req.UserID != 0) ||
+		(authRequest.LinkUserID != nil && *authRequest.LinkUserID != req.UserID) {
+		return nil, ErrInvalidOIDCState
+	}
+
+	claims, err := s.oidcProvider.Exchange(req.Code, authRequest.CodeVerifier, authRequest.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrOIDCLoginFailed, err)
+	}
+
+	if authRequest.LinkUserID != nil {
+		if err := s.linkOIDCIdentity(*authRequest.LinkUserID, claims); err != nil {
+			return nil, err
+		}
+		return &OIDCResult{Linked: true}, nil
+	}
+
+	user, _, err := s.userRepo.GetUserByIdentity(domain.IdentityProviderOIDC, claims.Identifier())
+	if errors.Is(err, domain.ErrNotFound) {
+		user, err = s.signUpOIDC(claims)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Disabled {
+		return nil, ErrUserDisabled
+	}
+
+	login, err := s.finishLogin(user, domain.IdentityProviderOIDC, claims.Identifier(), req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCResult{Login: login}, nil
+}
+
+func (s *Service) linkOIDCIdentity(userID int32, claims *domain.OIDCClaims) error {
+	owner, _, err := s.userRepo.GetUserByIdentity(domain.IdentityProviderOIDC, claims.Identifier())
+	if err == nil {
+		if owner.ID != userID {
+			return ErrOIDCIdentityTaken
+		}
+		return nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	now := s.getNow()
+	return s.userRepo.AddIdentity(userID, domain.Identity{
+		Provider:   domain.IdentityProviderOIDC,
+		Identifier: claims.Identifier(),
+		VerifiedAt: &now,
+	})
+}
+
+func (s *Service) signUpOIDC(claims *domain.OIDCClaims) (*domain.User, error) {
+	if !s.oidcAllowSignUp {
+		return nil, ErrOIDCIdentityNotLinked
+	}
+
+	// Signing up would leave the existing account of the address unreachable through this identity
+	if claims.Email != "" {
+		if _, _, err := s.userRepo.GetUserByIdentity(domain.IdentityProviderPassword, claims.Email); err == nil {
+			return nil, ErrOIDCIdentityNotLinked
+		}
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = claims.Email
+	}
+	if name == "" {
+		name = claims.Subject
+	}
+
+	userID, err := s.userRepo.CreateUser(domain.CreateUserRequest{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	if err := s.linkOIDCIdentity(userID, claims); err != nil {
+		return nil, fmt.Errorf("failed to add identity: %w", err)
+	}
+
+	return s.userRepo.GetUserByID(userID)
+}
@@ -0,0 +1,175 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"unicode/utf8"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+var (
+	// ErrIncorrectPassword is returned when the current password does not match on a password change
+	ErrIncorrectPassword = errors.New("current password is incorrect")
+	// ErrInvalidResetToken is returned when a password reset token is unknown, used or expired
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+	// ErrPasswordTooShort is returned when a new password is shorter than MinPasswordLength
+	ErrPasswordTooShort = fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+)
+
+// MinPasswordLength is the minimum number of characters of a password
+const MinPasswordLength = 8
+
+// ValidatePassword checks that a new password is acceptable.
+func ValidatePassword(password string) error {
+	if utf8.RuneCountInString(password) < MinPasswordLength {
+		return ErrPasswordTooShort
+	}
+
+	return nil
+}
+
+// ChangePasswordRequest defines the request to change the password of a user
+type ChangePasswordRequest struct {
+	UserID          int32
+	SessionID       int32 // the session making the change, which stays signed in
+	CurrentPassword string
+	NewPassword     string
+}
+
+// ChangePassword replaces the password of a user after checking the current one,
+// and signs out every other session.
+func (s *Service) ChangePassword(req ChangePasswordRequest) error {
+	if err := ValidatePassword(req.NewPassword); err != nil {
+		return err
+	}
+
+	authenticator, ok := s.mAuthenticator[domain.IdentityProviderPassword]
+	if !ok {
+		return fmt.Errorf("authentication provider not found: %s", domain.IdentityProviderPassword)
+	}
+
+	identity, err := s.getPasswordIdentity(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	valid, err := authenticator.VerifyCredential(req.CurrentPassword, identity)
+	if err != nil {
+		return fmt.Errorf("credential verification failed: %w", err)
+	}
+	if !valid {
+		return ErrIncorrectPassword
+	}
+
+	if err := s.updateCredential(authenticator, identity, req.NewPassword); err != nil {
+		return err
+	}
+
+	if s.sessionRepo != nil {
+		return s.sessionRepo.RevokeSessionsByUserID(req.UserID, req.SessionID)
+	}
+
+	return nil
+}
+
+// getPasswordIdentity returns the password identity of a user.
+func (s *Service) getPasswordIdentity(userID int32) (*domain.Identity, error) {
+	identities, err := s.userRepo.GetIdentitiesByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identity := range identities {
+		if identity.Provider == domain.IdentityProviderPassword {
+			return identity, nil
+		}
+	}
+
+	return nil, domain.ErrNotFound
+}
+
+// RequestPasswordReset sends a single-use password reset link to the owner of a password
+// identity. Unknown identifiers are ignored, so the response does not reveal who has an account.
+func (s *Service) RequestPasswordReset(identifier string) error {
+	if s.passwordResetRepo == nil || s.notifier == nil {
+		return errors.New("password reset not configured")
+	}
+
+	user, identity, err := s.userRepo.GetUserByIdentity(domain.IdentityProviderPassword, identifier)
+	if err != nil {
+		slog.Debug("password reset requested for unknown identity", slog.String("error", err.Error()))
+		return nil
+	}
+
+	if user.Disabled {
+		return nil
+	}
+
+	token, tokenHash, err := newSecretToken()
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.passwordResetRepo.CreatePasswordResetToken(domain.CreatePasswordResetTokenRequest{
+		UserID:     user.ID,
+		Identifier: identity.Identifier,
+		TokenHash:  tokenHash,
+		ExpiresAt:  s.getNow().Add(domain.PasswordResetTokenTTL),
+	}); err != nil {
+		return err
+	}
+
+	return s.notifier.Notify(domain.Notification{
+		Recipient: identity.Identifier,
+		Subject:   "Reset your Bookly password",
+		Body: fmt.Sprintf("A password reset was requested for your Bookly account.\n\n"+
+			"Open the link below within %s to choose a new password:\n%s/page/password/reset?token=%s\n\n"+
+			"If you did not request it, you can ignore this message.",
+			domain.PasswordResetTokenTTL, s.webURL, token),
+	})
+}
+
+// ResetPassword sets a new password using a reset token. The token can only be used once,
+// and every session of the user is signed out.
+func (s *Service) ResetPassword(token, newPassword string) error {
+	if err := ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	if s.passwordResetRepo == nil {
+		return errors.New("password reset not configured")
+	}
+
+	authenticator, ok := s.mAuthenticator[domain.IdentityProviderPassword]
+	if !ok {
+		return fmt.Errorf("authentication provider not found: %s", domain.IdentityProviderPassword)
+	}
+
+	resetToken, err := s.passwordResetRepo.UsePasswordResetToken(hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	if err := s.updateCredential(authenticator, &domain.Identity{
+		Provider:   domain.IdentityProviderPassword,
+		Identifier: resetToken.Identifier,
+	}, newPassword); err != nil {
+		return err
+	}
+
+	if err := s.passwordResetRepo.InvalidatePasswordResetTokensByUserID(resetToken.UserID); err != nil {
+		return err
+	}
+
+	// Following the emailed link proves control of the address
+	if err := s.verifyIdentity(resetToken.Identifier); err != nil {
+		return err
+	}
+
+	return s.revokeAllSessions(resetToken.UserID)
+}
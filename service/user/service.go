@@ -1,13 +1,36 @@
 package user
 
 import (
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/omegaatt36/bookly/domain"
 )
 
 // Service represents a user service
 type Service struct {
 	userRepo       domain.UserRepository
+	sessionRepo    domain.SessionRepository
 	mAuthenticator map[domain.IdentityProvider]domain.Authenticator
+
+	passwordResetRepo     domain.PasswordResetTokenRepository
+	emailVerificationRepo domain.EmailVerificationTokenRepository
+	twoFactorRepo         domain.TwoFactorRepository
+	oidcProvider          domain.OIDCProvider
+	oidcAuthRequestRepo   domain.OIDCAuthRequestRepository
+	oidcAllowSignUp       bool
+	apiKeyRepo            domain.APIKeyRepository
+	loginAttemptRepo      domain.LoginAttemptRepository
+	authEventRepo         domain.AuthEventRepository
+	notifier              domain.Notifier
+	webURL                string // base URL of the web app, used in links sent to users
+
+	// dummyCredential is verified against for unknown identifiers, see verifyDummyCredential
+	dummyCredential     string
+	dummyCredentialOnce sync.Once
+
+	getNow func() time.Time
 }
 
 // NewService creates a new user service
@@ -15,6 +38,7 @@ func NewService(userRepo domain.UserRepository) *Service {
 	return &Service{
 		userRepo:       userRepo,
 		mAuthenticator: make(map[domain.IdentityProvider]domain.Authenticator),
+		getNow:         time.Now,
 	}
 }
 
@@ -22,3 +46,56 @@ func NewService(userRepo domain.UserRepository) *Service {
 func (s *Service) RegisterAuthenticator(identityProvider domain.IdentityProvider, authenticator domain.Authenticator) {
 	s.mAuthenticator[identityProvider] = authenticator
 }
+
+// RegisterSessionRepository registers the repository storing login sessions.
+func (s *Service) RegisterSessionRepository(sessionRepo domain.SessionRepository) {
+	s.sessionRepo = sessionRepo
+}
+
+// RegisterNotifier registers the notifier delivering messages to users, and the base URL
+// of the web app the links in those messages point to.
+func (s *Service) RegisterNotifier(notifier domain.Notifier, webURL string) {
+	s.notifier = notifier
+	s.webURL = strings.TrimSuffix(webURL, "/")
+}
+
+// RegisterPasswordResetRepository registers the repository storing password reset tokens.
+func (s *Service) RegisterPasswordResetRepository(passwordResetRepo domain.PasswordResetTokenRepository) {
+	s.passwordResetRepo = passwordResetRepo
+}
+
+// RegisterEmailVerificationRepository registers the repository storing email verification tokens.
+func (s *Service) RegisterEmailVerificationRepository(emailVerificationRepo domain.EmailVerificationTokenRepository) {
+	s.emailVerificationRepo = emailVerificationRepo
+}
+
+// RegisterTwoFactorRepository registers the repository storing TOTP secrets, recovery codes
+// and login challenges.
+func (s *Service) RegisterTwoFactorRepository(twoFactorRepo domain.TwoFactorRepository) {
+	s.twoFactorRepo = twoFactorRepo
+}
+
+// RegisterOIDCProvider registers the OpenID Connect provider users log in with or link, and
+// the repository storing its authorization requests. Unknown external identities sign up as
+// new users only when allowSignUp is set.
+func (s *Service) RegisterOIDCProvider(provider domain.OIDCProvider, authRequestRepo domain.OIDCAuthRequestRepository, allowSignUp bool) {
+	s.oidcProvider = provider
+	s.oidcAuthRequestRepo = authRequestRepo
+	s.oidcAllowSignUp = allowSignUp
+}
+
+// RegisterAPIKeyRepository registers the repository storing personal API keys.
+func (s *Service) RegisterAPIKeyRepository(apiKeyRepo domain.APIKeyRepository) {
+	s.apiKeyRepo = apiKeyRepo
+}
+
+// RegisterLoginAttemptRepository registers the repository tracking failed logins, enabling
+// the lockout of identifiers after repeated wrong credentials.
+func (s *Service) RegisterLoginAttemptRepository(loginAttemptRepo domain.LoginAttemptRepository) {
+	s.loginAttemptRepo = loginAttemptRepo
+}
+
+// RegisterAuthEventRepository registers the repository recording logins and login attempts.
+func (s *Service) RegisterAuthEventRepository(authEventRepo domain.AuthEventRepository) {
+	s.authEventRepo = authEventRepo
+}
@@ -0,0 +1,164 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired, revoked or reused
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+// SessionMetadata describes the device a session is started from
+type SessionMetadata struct {
+	UserAgent string
+	IPAddress string
+}
+
+// startSession creates a session for the user and issues its first token pair.
+func (s *Service) startSession(user *domain.User, provider domain.IdentityProvider, metadata SessionMetadata) (*domain.AuthTokens, error) {
+	if s.sessionRepo == nil {
+		return nil, errors.New("session repository not initialized")
+	}
+
+	refreshToken, refreshTokenHash, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessionRepo.CreateSession(domain.CreateSessionRequest{
+		UserID:           user.ID,
+		Provider:         provider,
+		RefreshTokenHash: refreshTokenHash,
+		UserAgent:        metadata.UserAgent,
+		IPAddress:        metadata.IPAddress,
+		ExpiresAt:        s.getNow().Add(domain.SessionTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	s.recordAuthEvent(domain.AuthEventTypeLoginSucceeded, &user.ID, provider, "", metadata)
+
+	return s.issueTokens(user, session, refreshToken)
+}
+
+func (s *Service) issueTokens(user *domain.User, session *domain.Session, refreshToken string) (*domain.AuthTokens, error) {
+	authenticator, ok := s.mAuthenticator[session.Provider]
+	if !ok {
+		return nil, fmt.Errorf("authentication provider not found: %s", session.Provider)
+	}
+
+	issuedAt := s.getNow()
+	accessToken, err := authenticator.GenerateToken(domain.GenerateTokenRequest{
+		UserID:    user.ID,
+		Role:      user.Role,
+		SessionID: session.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return &domain.AuthTokens{
+		SessionID:    session.ID,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    issuedAt.Add(domain.AccessTokenTTL),
+	}, nil
+}
+
+// RefreshSession exchanges a refresh token for a new token pair. The refresh token is
+// rotated; presenting an already rotated token revokes the whole session, since it means
+// the token has been copied.
+func (s *Service) RefreshSession(refreshToken string) (*domain.AuthTokens, error) {
+	if s.sessionRepo == nil {
+		return nil, errors.New("session repository not initialized")
+	}
+
+	hash := hashSecretToken(refreshToken)
+	session, err := s.sessionRepo.GetSessionByRefreshTokenHash(hash)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if session.RefreshTokenHash != hash {
+		if err := s.sessionRepo.RevokeSession(session.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if !session.IsActive(s.getNow()) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.GetUserByID(session.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if user.Disabled {
+		return nil, ErrUserDisabled
+	}
+
+	newToken, newTokenHash, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err = s.sessionRepo.RotateSessionRefreshToken(domain.RotateSessionRequest{
+		ID:                      session.ID,
+		CurrentRefreshTokenHash: hash,
+		RefreshTokenHash:        newTokenHash,
+		ExpiresAt:               s.getNow().Add(domain.SessionTTL),
+	})
+	if err != nil {
+		// Another request rotated the token first
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	return s.issueTokens(user, session, newToken)
+}
+
+// GetActiveSessions retrieves the sessions of a user that have not been revoked or expired.
+func (s *Service) GetActiveSessions(userID int32) ([]*domain.Session, error) {
+	return s.sessionRepo.GetActiveSessionsByUserID(userID)
+}
+
+// RevokeSession revokes one of the user's sessions, signing that device out.
+func (s *Service) RevokeSession(userID, sessionID int32) error {
+	session, err := s.sessionRepo.GetSessionByID(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session.UserID != userID {
+		return domain.ErrNotFound
+	}
+
+	return s.sessionRepo.RevokeSession(sessionID)
+}
+
+// RevokeOtherSessions revokes every session of the user except the current one.
+func (s *Service) RevokeOtherSessions(userID, currentSessionID int32) error {
+	return s.sessionRepo.RevokeSessionsByUserID(userID, currentSessionID)
+}
+
+// revokeAllSessions signs a user out everywhere, ignoring a missing session repository.
+func (s *Service) revokeAllSessions(userID int32) error {
+	if s.sessionRepo == nil {
+		return nil
+	}
+
+	return s.sessionRepo.RevokeSessionsByUserID(userID, 0)
+}
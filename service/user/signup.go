@@ -0,0 +1,123 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/omegaatt36/bookly/domain"
+)
+
+var (
+	// ErrEmailNotVerified is returned when a user signs in before verifying their email address
+	ErrEmailNotVerified = errors.New("email address is not verified")
+	// ErrEmailTaken is returned when signing up with an email address that already has an account
+	ErrEmailTaken = errors.New("email address is already registered")
+	// ErrInvalidVerificationToken is returned when an email verification token is unknown, used or expired
+	ErrInvalidVerificationToken = errors.New("invalid or expired email verification token")
+)
+
+// SignUpRequest defines the request of a user registering themselves
+type SignUpRequest struct {
+	Email    string
+	Password string
+}
+
+// SignUp registers a new user with a password identity, which cannot be used to
+// log in until the email address is verified through the link sent to it.
+func (s *Service) SignUp(req SignUpRequest) error {
+	if s.emailVerificationRepo == nil || s.notifier == nil {
+		return errors.New("email verification not configured")
+	}
+
+	if err := ValidatePassword(req.Password); err != nil {
+		return err
+	}
+
+	if _, _, err := s.userRepo.GetUserByIdentity(domain.IdentityProviderPassword, req.Email); err == nil {
+		return ErrEmailTaken
+	}
+
+	userID, err := s.register(RegisterRequest{
+		Name:       req.Email,
+		Provider:   domain.IdentityProviderPassword,
+		Identifier: req.Email,
+		Credential: req.Password,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.sendVerificationEmail(userID, req.Email)
+}
+
+func (s *Service) sendVerificationEmail(userID int32, email string) error {
+	token, tokenHash, err := newSecretToken()
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.emailVerificationRepo.CreateEmailVerificationToken(domain.CreateEmailVerificationTokenRequest{
+		UserID:     userID,
+		Identifier: email,
+		TokenHash:  tokenHash,
+		ExpiresAt:  s.getNow().Add(domain.EmailVerificationTokenTTL),
+	}); err != nil {
+		return err
+	}
+
+	return s.notifier.Notify(domain.Notification{
+		Recipient: email,
+		Subject:   "Verify your Bookly email address",
+		Body: fmt.Sprintf("Welcome to Bookly!\n\n"+
+			"Open the link below within %s to verify your email address:\n%s/page/verify-email?token=%s\n\n"+
+			"If you did not sign up, you can ignore this message.",
+			domain.EmailVerificationTokenTTL, s.webURL, token),
+	})
+}
+
+// VerifyEmail marks the email address of an identity as verified using a verification token.
+func (s *Service) VerifyEmail(token string) error {
+	if s.emailVerificationRepo == nil {
+		return errors.New("email verification not configured")
+	}
+
+	verificationToken, err := s.emailVerificationRepo.UseEmailVerificationToken(hashSecretToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return ErrInvalidVerificationToken
+		}
+		return err
+	}
+
+	return s.verifyIdentity(verificationToken.Identifier)
+}
+
+// verifyIdentity marks a password identity as verified, ignoring identities that already are.
+func (s *Service) verifyIdentity(identifier string) error {
+	if err := s.userRepo.VerifyIdentity(domain.IdentityProviderPassword, identifier); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// ResendVerificationEmail sends a new verification link to an unverified email address.
+// Unknown and verified addresses are ignored, so the response does not reveal who has an account.
+func (s *Service) ResendVerificationEmail(email string) error {
+	if s.emailVerificationRepo == nil || s.notifier == nil {
+		return errors.New("email verification not configured")
+	}
+
+	user, identity, err := s.userRepo.GetUserByIdentity(domain.IdentityProviderPassword, email)
+	if err != nil {
+		slog.Debug("verification requested for unknown identity", slog.String("error", err.Error()))
+		return nil
+	}
+
+	if user.Disabled || identity.VerifiedAt != nil {
+		return nil
+	}
+
+	return s.sendVerificationEmail(user.ID, identity.Identifier)
+}
@@ -0,0 +1,397 @@
+package user
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/omegaatt36/bookly/domain"
+	"github.com/omegaatt36/bookly/service/auth"
+)
+
+var (
+	// ErrInvalidTwoFactorCode is returned when a TOTP or recovery code does not match
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+	// ErrInvalidTwoFactorChallenge is returned when a login challenge is unknown, used, expired or had too many attempts
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
+	// ErrTwoFactorEnabled is returned when enrolling while two-factor authentication is already enabled
+	ErrTwoFactorEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrTwoFactorNotEnabled is returned when managing two-factor authentication that is not enabled
+	ErrTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+)
+
+// totpIssuer is the name authenticator apps list the secret under
+const totpIssuer = "Bookly"
+
+// LoginChallenge is handed out instead of session tokens when the password of a user with
+// two-factor authentication is verified. It is exchanged for tokens along with a code.
+type LoginChallenge struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// TOTPEnrollment holds a new TOTP secret, to be added to an authenticator app
+type TOTPEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// TwoFactorStatus describes the two-factor authentication of a user
+type TwoFactorStatus struct {
+	Enabled                bool
+	RecoveryCodesRemaining int
+}
+
+// getConfirmedTOTPSecret returns the TOTP secret of a user with two-factor authentication enabled.
+func (s *Service) getConfirmedTOTPSecret(userID int32) (*domain.TOTPSecret, error) {
+	secret, err := s.twoFactorRepo.GetTOTPSecretByUserID(userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrTwoFactorNotEnabled
+		}
+		return nil, err
+	}
+
+	if secret.ConfirmedAt == nil {
+		return nil, ErrTwoFactorNotEnabled
+	}
+
+	return secret, nil
+}
+
+// GetTwoFactorStatus returns whether a user enabled two-factor authentication.
+func (s *Service) GetTwoFactorStatus(userID int32) (*TwoFactorStatus, error) {
+	if s.twoFactorRepo == nil {
+		return nil, errors.New("two-factor authentication not configured")
+	}
+
+	if _, err := s.getConfirmedTOTPSecret(userID); err != nil {
+		if errors.Is(err, ErrTwoFactorNotEnabled) {
+			return &TwoFactorStatus{}, nil
+		}
+		return nil, err
+	}
+
+	remaining, err := s.twoFactorRepo.CountUnusedRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorStatus{
+		Enabled:                true,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+// EnrollTOTP generates a new TOTP secret for a user. Two-factor authentication is only
+// enabled once the secret is confirmed with a first code, see ConfirmTOTP.
+func (s *Service) EnrollTOTP(userID int32) (*TOTPEnrollment, error) {
+	if s.twoFactorRepo == nil {
+		return nil, errors.New("two-factor authentication not configured")
+	}
+
+	if _, err := s.getConfirmedTOTPSecret(userID); err == nil {
+		return nil, ErrTwoFactorEnabled
+	} else if !errors.Is(err, ErrTwoFactorNotEnabled) {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountName := user.Name
+	if identity, err := s.getPasswordIdentity(userID); err == nil {
+		accountName = identity.Identifier
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.twoFactorRepo.UpsertTOTPSecret(userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(totpIssuer, accountName, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables two-factor authentication with the first code of the enrolled
+// secret, and returns the recovery codes. They are only shown this once.
+func (s *Service) ConfirmTOTP(userID int32, code string) ([]string, error) {
+	if s.twoFactorRepo == nil {
+		return nil, errors.New("two-factor authentication not configured")
+	}
+
+	secret, err := s.twoFactorRepo.GetTOTPSecretByUserID(userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidTwoFactorCode
+		}
+		return nil, err
+	}
+
+	if secret.ConfirmedAt != nil {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	if ok, err := s.verifyTOTP(secret, code); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	if err := s.twoFactorRepo.ConfirmTOTPSecret(userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrTwoFactorEnabled
+		}
+		return nil, err
+	}
+
+	return s.generateRecoveryCodes(userID)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a user after checking a current code.
+func (s *Service) RegenerateRecoveryCodes(userID int32, code string) ([]string, error) {
+	if s.twoFactorRepo == nil {
+		return nil, errors.New("two-factor authentication not configured")
+	}
+
+	secret, err := s.getConfirmedTOTPSecret(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok, err := s.verifySecondFactor(secret, code); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	return s.generateRecoveryCodes(userID)
+}
+
+// DisableTwoFactor turns off two-factor authentication after checking a current code.
+func (s *Service) DisableTwoFactor(userID int32, code string) error {
+	if s.twoFactorRepo == nil {
+		return errors.New("two-factor authentication not configured")
+	}
+
+	secret, err := s.getConfirmedTOTPSecret(userID)
+	if err != nil {
+		return err
+	}
+
+	if ok, err := s.verifySecondFactor(secret, code); err != nil {
+		return err
+	} else if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	return s.twoFactorRepo.DisableTwoFactor(userID)
+}
+
+// requiresTwoFactor reports whether a login of the user needs a second factor.
+func (s *Service) requiresTwoFactor(userID int32) (bool, error) {
+	if s.twoFactorRepo == nil {
+		return false, nil
+	}
+
+	if _, err := s.getConfirmedTOTPSecret(userID); err != nil {
+		if errors.Is(err, ErrTwoFactorNotEnabled) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// startTwoFactorChallenge creates the challenge a login answers with a second factor.
+func (s *Service) startTwoFactorChallenge(user *domain.User, provider domain.IdentityProvider, identifier string) (*LoginChallenge, error) {
+	token, tokenHash, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := s.twoFactorRepo.CreateTwoFactorChallenge(domain.CreateTwoFactorChallengeRequest{
+		UserID:     user.ID,
+		Provider:   provider,
+		Identifier: identifier,
+		TokenHash:  tokenHash,
+		ExpiresAt:  s.getNow().Add(domain.TwoFactorChallengeTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginChallenge{
+		Token:     token,
+		ExpiresAt: challenge.ExpiresAt,
+	}, nil
+}
+
+// CompleteLoginRequest defines the request to answer a login challenge with a second factor
+type CompleteLoginRequest struct {
+	ChallengeToken string
+	Code           string // a TOTP code or a recovery code
+	Metadata       SessionMetadata
+}
+
+// CompleteLogin exchanges a login challenge and a TOTP or recovery code for session tokens.
+// A challenge is rejected after too many codes, so the user has to start over with the password,
+// and wrong codes count towards the lockout of the identifier the user logged in with.
+func (s *Service) CompleteLogin(req CompleteLoginRequest) (*domain.AuthTokens, error) {
+	if s.twoFactorRepo == nil {
+		return nil, errors.New("two-factor authentication not configured")
+	}
+
+	challenge, err := s.twoFactorRepo.GetTwoFactorChallengeByTokenHash(hashSecretToken(req.ChallengeToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+
+	if err := s.checkLoginLock(challenge.Provider, challenge.Identifier); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetUserByID(challenge.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+
+	if user.Disabled {
+		return nil, ErrUserDisabled
+	}
+
+	secret, err := s.getConfirmedTOTPSecret(user.ID)
+	if err != nil {
+		// Two-factor authentication was turned off since the challenge started
+		if errors.Is(err, ErrTwoFactorNotEnabled) {
+			return nil, ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+
+	// Count the attempt before checking the code, so concurrent guesses cannot exceed the limit
+	if err := s.twoFactorRepo.IncrementTwoFactorChallengeAttempts(challenge.ID, domain.TwoFactorChallengeMaxAttempts); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+
+	ok, err := s.verifySecondFactor(secret, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := s.recordFailedLogin(LoginRequest{
+			Provider:   challenge.Provider,
+			Identifier: challenge.Identifier,
+			Metadata:   req.Metadata,
+		}, &user.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	if err := s.twoFactorRepo.UseTwoFactorChallenge(challenge.ID); err != nil {
+		// Another request answered the challenge first
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+
+	tokens, err := s.startSession(user, challenge.Provider, req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordSuccessfulLogin(challenge.Provider, challenge.Identifier)
+
+	return tokens, nil
+}
+
+// verifySecondFactor checks a TOTP code, or else a recovery code, which is used up on success.
+func (s *Service) verifySecondFactor(secret *domain.TOTPSecret, code string) (bool, error) {
+	if ok, err := s.verifyTOTP(secret, code); err != nil || ok {
+		return ok, err
+	}
+
+	if err := s.twoFactorRepo.UseRecoveryCode(secret.UserID, hashSecretToken(normalizeRecoveryCode(code))); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// verifyTOTP checks a TOTP code and records its time step, so it cannot be used twice.
+func (s *Service) verifyTOTP(secret *domain.TOTPSecret, code string) (bool, error) {
+	step, ok := auth.ValidateTOTP(secret.Secret, code, s.getNow())
+	if !ok {
+		return false, nil
+	}
+
+	if err := s.twoFactorRepo.UpdateTOTPLastUsedStep(secret.UserID, step); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// recoveryCodeAlphabet is the lowercase base32 alphabet, it has no 0 or 1 to confuse with o or l
+const recoveryCodeAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+
+// generateRecoveryCodes replaces the recovery codes of a user with new ones, formatted
+// like "abcde-23456". Only their hashes are stored.
+func (s *Service) generateRecoveryCodes(userID int32) ([]string, error) {
+	codes := make([]string, domain.RecoveryCodeCount)
+	hashes := make([]string, domain.RecoveryCodeCount)
+
+	for i := range codes {
+		bs := make([]byte, 10)
+		if _, err := rand.Read(bs); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		for j, b := range bs {
+			bs[j] = recoveryCodeAlphabet[b%32]
+		}
+
+		codes[i] = string(bs[:5]) + "-" + string(bs[5:])
+		hashes[i] = hashSecretToken(normalizeRecoveryCode(codes[i]))
+	}
+
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
@@ -34,3 +34,34 @@ func (s *Service) UpdateUser(req domain.UpdateUserRequest) error {
 func (s *Service) DeactivateUserByID(id int32) error {
 	return s.userRepo.DeactivateUserByID(id)
 }
+
+// DisableUserByID disables a user, preventing further logins and signing out their sessions.
+func (s *Service) DisableUserByID(id int32) error {
+	disabled := true
+	if err := s.userRepo.UpdateUser(domain.UpdateUserRequest{
+		ID:       id,
+		Disabled: &disabled,
+	}); err != nil {
+		return err
+	}
+
+	return s.revokeAllSessions(id)
+}
+
+// EnableUserByID enables a previously disabled user.
+func (s *Service) EnableUserByID(id int32) error {
+	disabled := false
+	return s.userRepo.UpdateUser(domain.UpdateUserRequest{
+		ID:       id,
+		Disabled: &disabled,
+	})
+}
+
+// DeleteUserByID soft deletes a user and signs out their sessions.
+func (s *Service) DeleteUserByID(id int32) error {
+	if err := s.userRepo.DeleteUser(id); err != nil {
+		return err
+	}
+
+	return s.revokeAllSessions(id)
+}